@@ -3,23 +3,40 @@ package bootstrap
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 
 	"lanchonete/infra/database"
+	"lanchonete/infra/database/repositories"
 	"lanchonete/internal/domain/repository"
 )
 
 type App struct {
-	Env               *Env
-	DB                *sql.DB
-	PedidoRepository  repository.PedidoRepository
-	ProdutoRepository repository.ProdutoRepository
+	Env                     *Env
+	DB                      *sql.DB
+	PedidoRepository        repository.PedidoRepository
+	ProdutoRepository       repository.ProdutoRepository
+	ModificadorRepository   repository.ModificadorRepository
+	CupomRepository         repository.CupomRepository
+	VersaoProdutoRepository repository.VersaoProdutoRepository
+	CategoriaRepository     repository.CategoriaRepository
+	ImagemProdutoRepository repository.ImagemProdutoRepository
+	// Histórico de status dos pedidos
+	HistoricoPedidoRepository repository.HistoricoPedidoRepository
+	// Outbox transacional: os eventos são gravados na transação da alteração e publicados pelo relay
+	Transacao        repository.Transacao
+	OutboxRepository repository.OutboxRepository
 }
 
 func NewApp(ctx context.Context) (*App, error) {
 	// Load environment variables
 	env := NewEnv()
 
+	// Sem MySQL: os repositórios guardam os dados em memória e não há esquema para migrar
+	if env.DBDriver == "memory" {
+		return newAppMemoria(ctx, env)
+	}
+
 	db, err := database.NewMySQLConnection(
 		env.DBUser,
 		env.DBPass,
@@ -32,13 +49,32 @@ func NewApp(ctx context.Context) (*App, error) {
 		log.Fatalf("erro ao conectar ao MySQL: %v", err)
 	}
 
+	// Atualiza o esquema; a trava do migrador faz as outras réplicas esperarem a primeira terminar
+	if env.DBAutoMigrate {
+		migrador, err := newMigrador(db)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := migrador.Subir(ctx); err != nil {
+			return nil, fmt.Errorf("erro ao migrar o banco: %w", err)
+		}
+	}
+
 	// Initialize repositories
 	_, pedidoRepo, produtoRepo, _, _ := NewRepositories(db)
 
 	return &App{
-		Env:               env,
-		DB:                db,
-		PedidoRepository:  pedidoRepo,
-		ProdutoRepository: produtoRepo,
+		Env:                       env,
+		DB:                        db,
+		PedidoRepository:          pedidoRepo,
+		ProdutoRepository:         produtoRepo,
+		ModificadorRepository:     repositories.NewModificadorMysqlRepository(db),
+		CupomRepository:           repositories.NewCupomMysqlRepository(db),
+		VersaoProdutoRepository:   repositories.NewVersaoProdutoMysqlRepository(db),
+		CategoriaRepository:       repositories.NewCategoriaMysqlRepository(db),
+		ImagemProdutoRepository:   repositories.NewImagemProdutoMysqlRepository(db),
+		HistoricoPedidoRepository: repositories.NewHistoricoPedidoMysqlRepository(db),
+		Transacao:                 repositories.NewTransacaoMysql(db),
+		OutboxRepository:          repositories.NewOutboxMysqlRepository(db),
 	}, nil
 }
@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -18,6 +19,29 @@ type Env struct {
 	ProdutoQueueURL   string
 	PedidoQueueURL    string
 	PagamentoQueueURL string
+	// Armazenamento das imagens dos produtos: "local" (padrão) ou "s3"
+	ImageStorage string
+	ImageDir     string
+	ImageBaseURL string
+	S3Bucket     string
+	S3Endpoint   string
+	S3PublicURL  string
+	// Serviço de clientes; sem URL, os clientes ficam em memória
+	ClienteURL     string
+	ClienteTimeout time.Duration
+	// Regras de validação dos pedidos; zero ou vazio desativa a regra
+	PedidoMaximoItens int
+	PedidoValorMaximo string // ex: "300.00"
+	PedidoLimites     string // unidades por produto, ex: "12:2,15:1"
+	PedidoAvulsas     string // categorias que podem formar um pedido sozinhas, ex: "Bebida"
+	// Relay da outbox: intervalo entre rodadas, tentativas por evento e idade de um evento travado
+	OutboxIntervalo  time.Duration
+	OutboxTentativas int
+	OutboxAlerta     time.Duration
+	// Aplica as migrações pendentes do esquema ao iniciar; desative para migrar só pelo subcomando migrate
+	DBAutoMigrate bool
+	// Onde os dados ficam: "mysql" (padrão) ou "memory", que dispensa o banco e perde tudo ao reiniciar
+	DBDriver string
 }
 
 func NewEnv() *Env {
@@ -27,6 +51,15 @@ func NewEnv() *Env {
 	}
 
 	viper.AutomaticEnv()
+	viper.SetDefault("IMAGE_STORAGE", "local")
+	viper.SetDefault("IMAGE_DIR", "imagens")
+	viper.SetDefault("IMAGE_BASE_URL", "http://localhost:8080/imagens")
+	viper.SetDefault("CLIENTE_SERVICE_TIMEOUT", "3s")
+	viper.SetDefault("OUTBOX_INTERVALO", "1s")
+	viper.SetDefault("OUTBOX_MAX_TENTATIVAS", 10)
+	viper.SetDefault("OUTBOX_ALERTA", "5m")
+	viper.SetDefault("DB_AUTO_MIGRATE", true)
+	viper.SetDefault("DB_DRIVER", "mysql")
 
 	return &Env{
 		ServerAddress:     viper.GetString("SERVER_ADDRESS"),
@@ -39,5 +72,22 @@ func NewEnv() *Env {
 		ProdutoQueueURL:   viper.GetString("PRODUTO_QUEUE_URL"),
 		PedidoQueueURL:    viper.GetString("PEDIDO_QUEUE_URL"),
 		PagamentoQueueURL: viper.GetString("PAGAMENTO_QUEUE_URL"),
+		ImageStorage:      viper.GetString("IMAGE_STORAGE"),
+		ImageDir:          viper.GetString("IMAGE_DIR"),
+		ImageBaseURL:      viper.GetString("IMAGE_BASE_URL"),
+		S3Bucket:          viper.GetString("S3_BUCKET"),
+		S3Endpoint:        viper.GetString("S3_ENDPOINT"),
+		S3PublicURL:       viper.GetString("S3_PUBLIC_URL"),
+		ClienteURL:        viper.GetString("CLIENTE_SERVICE_URL"),
+		ClienteTimeout:    viper.GetDuration("CLIENTE_SERVICE_TIMEOUT"),
+		PedidoMaximoItens: viper.GetInt("PEDIDO_MAXIMO_ITENS"),
+		PedidoValorMaximo: viper.GetString("PEDIDO_VALOR_MAXIMO"),
+		PedidoLimites:     viper.GetString("PEDIDO_LIMITES_PRODUTO"),
+		PedidoAvulsas:     viper.GetString("PEDIDO_CATEGORIAS_AVULSAS"),
+		OutboxIntervalo:   viper.GetDuration("OUTBOX_INTERVALO"),
+		OutboxTentativas:  viper.GetInt("OUTBOX_MAX_TENTATIVAS"),
+		OutboxAlerta:      viper.GetDuration("OUTBOX_ALERTA"),
+		DBAutoMigrate:     viper.GetBool("DB_AUTO_MIGRATE"),
+		DBDriver:          viper.GetString("DB_DRIVER"),
 	}
 }
@@ -0,0 +1,131 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"lanchonete/infra/database/memoria"
+	"lanchonete/internal/domain/entities"
+)
+
+// categoriasPadrao são as categorias que as migrações criam no MySQL, com os tempos de preparo padrão
+var categoriasPadrao = []entities.Categoria{
+	{Nome: "Lanche", Slug: "lanche", Ordem: 1, Ativa: true, ObrigatoriaNoPedido: true, TempoPreparoMinutos: 10},
+	{Nome: "Acompanhamento", Slug: "acompanhamento", Ordem: 2, Ativa: true, TempoPreparoMinutos: 6},
+	{Nome: "Bebida", Slug: "bebida", Ordem: 3, Ativa: true, TempoPreparoMinutos: 1},
+	{Nome: "Sobremesa", Slug: "sobremesa", Ordem: 4, Ativa: true, TempoPreparoMinutos: 3},
+	{Nome: "Combo", Slug: "combo", Ordem: 5, Ativa: true},
+}
+
+// produtosPadrao é o cardápio de exemplo que as migrações 001 e 020 gravam no MySQL, sem o combo
+var produtosPadrao = []entities.Produto{
+	{Nome: "X-Salada", Categoria: entities.Lanche, Descricao: "Lanche com tomate, alface, hambúrguer e maionese", Preco: entities.Centavos(2250), TempoPreparoMinutos: 10,
+		InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoOvo}}},
+	{Nome: "Coca-cola", Categoria: entities.Bebida, Descricao: "Refrigerante gelado de cola", Preco: entities.Centavos(600), TempoPreparoMinutos: 1,
+		InformacoesDieteticas: entities.InformacoesDieteticas{TagsDieta: []entities.TagDieta{entities.TagSemGluten, entities.TagVegano}}},
+	{Nome: "Batata-frita", Categoria: entities.Acompanhamento, Descricao: "Porção de batata-frita palito crocante", Preco: entities.Centavos(1800), TempoPreparoMinutos: 6,
+		InformacoesDieteticas: entities.InformacoesDieteticas{TagsDieta: []entities.TagDieta{entities.TagSemGluten, entities.TagVegano}}},
+	{Nome: "Mousse de chocolate", Categoria: entities.Sobremesa, Descricao: "Chocolate cremoso ao leite", Preco: entities.Centavos(1250), TempoPreparoMinutos: 3,
+		InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoLactose, entities.AlergenoOvo}, TagsDieta: []entities.TagDieta{entities.TagSemGluten, entities.TagVegetariano}}},
+	{Nome: "X-Frango", Categoria: entities.Lanche, Descricao: "Lanche com frango desfiado e bacon", Preco: entities.Centavos(2600), TempoPreparoMinutos: 12,
+		InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten}}},
+	{Nome: "X-Tudo", Categoria: entities.Lanche, Descricao: "Calabresa, Bacon, 2 ovos, maionese e queijo", Preco: entities.Centavos(2850), TempoPreparoMinutos: 15,
+		InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoLactose, entities.AlergenoOvo}}},
+	{Nome: "Cachorro-quente", Categoria: entities.Lanche, Descricao: "2 salsichas, purê, milho  ervilha", Preco: entities.Centavos(1800), TempoPreparoMinutos: 7,
+		InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoLactose}}},
+	{Nome: "Cachorrão especial", Categoria: entities.Lanche, Descricao: "2 salsichas, calabresa, bacon, purê, milho e ervilha", Preco: entities.Centavos(2200), TempoPreparoMinutos: 9,
+		InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoLactose}}},
+	{Nome: "Fanta", Categoria: entities.Bebida, Descricao: "Refrigerante sabor laranja gelado", Preco: entities.Centavos(550), TempoPreparoMinutos: 1,
+		InformacoesDieteticas: entities.InformacoesDieteticas{TagsDieta: []entities.TagDieta{entities.TagSemGluten, entities.TagVegano}}},
+	{Nome: "Sprite", Categoria: entities.Bebida, Descricao: "Refrigerante sabor limão gelado", Preco: entities.Centavos(550), TempoPreparoMinutos: 1,
+		InformacoesDieteticas: entities.InformacoesDieteticas{TagsDieta: []entities.TagDieta{entities.TagSemGluten, entities.TagVegano}}},
+}
+
+// semearCardapio grava no banco em memória o mesmo cardápio de exemplo das migrações: as categorias,
+// os produtos com suas versões, o combo X-Salada e os modificadores do X-Salada
+func semearCardapio(ctx context.Context, banco *memoria.Banco) error {
+	categoriaRepo := memoria.NewCategoriaMemoriaRepository(banco)
+	for _, categoria := range categoriasPadrao {
+		if err := categoriaRepo.AdicionarCategoria(ctx, &categoria); err != nil {
+			return fmt.Errorf("erro ao criar a categoria %s: %w", categoria.Nome, err)
+		}
+	}
+
+	produtoRepo := memoria.NewProdutoMemoriaRepository(banco)
+	versaoRepo := memoria.NewVersaoProdutoMemoriaRepository(banco)
+	agora := time.Now()
+	adicionar := func(produto *entities.Produto) error {
+		if err := produtoRepo.AdicionarProduto(ctx, produto); err != nil {
+			return fmt.Errorf("erro ao criar o produto %s: %w", produto.Nome, err)
+		}
+		versao, err := entities.VersaoProdutoNew(*produto, "sistema", agora)
+		if err != nil {
+			return err
+		}
+		return versaoRepo.RegistrarVersao(ctx, versao)
+	}
+
+	produtos := make([]*entities.Produto, len(produtosPadrao))
+	for i := range produtosPadrao {
+		produto := produtosPadrao[i]
+		if err := adicionar(&produto); err != nil {
+			return err
+		}
+		produtos[i] = &produto
+	}
+	xSalada, batata := produtos[0], produtos[2]
+
+	combo, err := entities.ComboNew("Combo X-Salada", "X-Salada, batata-frita e um refrigerante à escolha", entities.Centavos(4200), []entities.ComponenteCombo{
+		{Produto: xSalada, Quantidade: 1},
+		{Produto: batata, Quantidade: 1},
+		{Categoria: entities.Bebida, Quantidade: 1},
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao montar o combo de exemplo: %w", err)
+	}
+	if err := adicionar(combo); err != nil {
+		return err
+	}
+
+	modificadorRepo := memoria.NewModificadorMemoriaRepository(banco)
+	grupos := []entities.GrupoModificador{
+		{ProdutoID: xSalada.ID, Nome: "Adicionais", MinSelecoes: 0, MaxSelecoes: 2, Opcoes: []entities.Modificador{
+			{Nome: "Bacon extra", Preco: entities.Centavos(400)},
+			{Nome: "Queijo extra", Preco: entities.Centavos(300)},
+		}},
+		{ProdutoID: xSalada.ID, Nome: "Remover", MinSelecoes: 0, MaxSelecoes: 1, Opcoes: []entities.Modificador{
+			{Nome: "Sem cebola", Preco: entities.Centavos(0)},
+		}},
+	}
+	for _, grupo := range grupos {
+		if err := modificadorRepo.AdicionarGrupo(ctx, &grupo); err != nil {
+			return fmt.Errorf("erro ao criar o grupo %s: %w", grupo.Nome, err)
+		}
+	}
+	return nil
+}
+
+// newAppMemoria monta a aplicação com os repositórios em memória, para rodar localmente sem MySQL.
+// O cardápio começa com o mesmo exemplo das migrações e os dados se perdem quando o processo termina.
+func newAppMemoria(ctx context.Context, env *Env) (*App, error) {
+	banco := memoria.NewBanco()
+
+	if err := semearCardapio(ctx, banco); err != nil {
+		return nil, err
+	}
+
+	return &App{
+		Env:                       env,
+		PedidoRepository:          memoria.NewPedidoMemoriaRepository(banco),
+		ProdutoRepository:         memoria.NewProdutoMemoriaRepository(banco),
+		ModificadorRepository:     memoria.NewModificadorMemoriaRepository(banco),
+		CupomRepository:           memoria.NewCupomMemoriaRepository(banco),
+		VersaoProdutoRepository:   memoria.NewVersaoProdutoMemoriaRepository(banco),
+		CategoriaRepository:       memoria.NewCategoriaMemoriaRepository(banco),
+		ImagemProdutoRepository:   memoria.NewImagemProdutoMemoriaRepository(banco),
+		HistoricoPedidoRepository: memoria.NewHistoricoPedidoMemoriaRepository(banco),
+		Transacao:                 memoria.NewTransacaoMemoria(banco),
+		OutboxRepository:          memoria.NewOutboxMemoriaRepository(banco),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"strconv"
+
+	esquema "lanchonete/db"
+	"lanchonete/infra/database"
+)
+
+func newMigrador(db *sql.DB) (*database.Migrador, error) {
+	arquivos, err := fs.Sub(esquema.Migracoes, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler as migrações: %w", err)
+	}
+	return database.NewMigrador(db, arquivos, esquema.MarcosLegado)
+}
+
+// Migrar executa o subcomando migrate:
+//
+//	migrate [up]      aplica as migrações pendentes
+//	migrate down [n]  desfaz as últimas n migrações (padrão 1)
+//	migrate version   mostra a versão do esquema
+func Migrar(ctx context.Context, args []string) error {
+	env := NewEnv()
+	db, err := database.NewMySQLConnection(env.DBUser, env.DBPass, env.DBHost, env.DBPort, env.DBName)
+	if err != nil {
+		return fmt.Errorf("erro ao conectar ao MySQL: %w", err)
+	}
+	defer db.Close()
+
+	migrador, err := newMigrador(db)
+	if err != nil {
+		return err
+	}
+
+	comando := "up"
+	if len(args) > 0 {
+		comando = args[0]
+	}
+	switch comando {
+	case "up":
+		aplicadas, err := migrador.Subir(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("%d migração(ões) aplicada(s)", aplicadas)
+	case "down":
+		passos := 1
+		if len(args) > 1 {
+			if passos, err = strconv.Atoi(args[1]); err != nil || passos < 1 {
+				return fmt.Errorf("número de migrações inválido: %q", args[1])
+			}
+		}
+		desfeitas, err := migrador.Descer(ctx, passos)
+		if err != nil {
+			return err
+		}
+		log.Printf("%d migração(ões) desfeita(s)", desfeitas)
+	case "version":
+		versao, err := migrador.Versao(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("versão do esquema: %d\n", versao)
+	default:
+		return fmt.Errorf("comando desconhecido %q; use up, down [n] ou version", comando)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package db guarda as migrações do esquema, embutidas no binário para que cada versão do serviço leve
+// junto o esquema de que precisa.
+package db
+
+import "embed"
+
+// Migracoes são os arquivos NNN_nome.up.sql e NNN_nome.down.sql da pasta migrations
+//
+//go:embed migrations/*.sql
+var Migracoes embed.FS
+
+// Marco é uma tabela, ou uma coluna quando Coluna não é vazia, criada pela migração Versao
+type Marco struct {
+	Versao int
+	Tabela string
+	Coluna string
+}
+
+// MarcosLegado reconhecem até que versão chegou um banco criado pelo antigo db/init.sql, que não tem a
+// tabela SchemaMigracao: cada versão do init.sql trazia as alterações das migrações anteriores a ela. O
+// primeiro marco é o esquema base; sem a tabela dele o banco é novo. A migração 2 só troca tipos de
+// coluna e não tem marco, mas pode ser repetida.
+var MarcosLegado = []Marco{
+	{Versao: 1, Tabela: "Pedido", Coluna: "statusPagamento"},
+	{Versao: 3, Tabela: "GrupoModificador"},
+	{Versao: 4, Tabela: "ComboComponente"},
+	{Versao: 5, Tabela: "Cupom"},
+	{Versao: 6, Tabela: "Produto", Coluna: "tempoPreparoMinutos"},
+	{Versao: 7, Tabela: "Produto", Coluna: "esgotado"},
+	{Versao: 8, Tabela: "Produto", Coluna: "archived_at"},
+	{Versao: 9, Tabela: "Pedido_Produto", Coluna: "nomeProduto"},
+	{Versao: 10, Tabela: "ProdutoVersao"},
+	{Versao: 11, Tabela: "Pedido", Coluna: "atorCancelamento"},
+	{Versao: 12, Tabela: "Categoria"},
+	{Versao: 13, Tabela: "ProdutoImagem"},
+	{Versao: 14, Tabela: "Produto", Coluna: "alergenos"},
+	{Versao: 15, Tabela: "Pedido_Historico"},
+	{Versao: 16, Tabela: "Pedido", Coluna: "clienteCpf"},
+	{Versao: 17, Tabela: "Pedido", Coluna: "codigoRetirada"},
+	{Versao: 18, Tabela: "Outbox"},
+	{Versao: 19, Tabela: "Pedido", Coluna: "criadoEm"},
+}
@@ -0,0 +1,9 @@
+-- Remove as tabelas do esquema base, na ordem inversa das chaves estrangeiras
+
+DROP TABLE IF EXISTS `FilaPedidos`;
+DROP TABLE IF EXISTS `Acompanhamento`;
+DROP TABLE IF EXISTS `cliente`;
+DROP TABLE IF EXISTS `Pagamento`;
+DROP TABLE IF EXISTS `Pedido_Produto`;
+DROP TABLE IF EXISTS `Pedido`;
+DROP TABLE IF EXISTS `Produto`;
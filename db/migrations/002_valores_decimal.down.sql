@@ -0,0 +1,2 @@
+ALTER TABLE `Pedido` MODIFY `totalPedido` FLOAT NOT NULL DEFAULT 0;
+ALTER TABLE `Produto` MODIFY `precoProduto` FLOAT NOT NULL;
@@ -0,0 +1,4 @@
+-- Converte preços e totais de FLOAT para DECIMAL(10,2), eliminando erros de arredondamento
+
+ALTER TABLE `Produto` MODIFY `precoProduto` DECIMAL(10,2) NOT NULL;
+ALTER TABLE `Pedido` MODIFY `totalPedido` DECIMAL(10,2) NOT NULL DEFAULT 0;
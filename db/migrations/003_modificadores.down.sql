@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `Pedido_Produto_Modificador`;
+DROP TABLE IF EXISTS `Modificador`;
+DROP TABLE IF EXISTS `GrupoModificador`;
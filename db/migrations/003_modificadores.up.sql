@@ -0,0 +1,34 @@
+-- Grupos de modificadores por produto e modificadores escolhidos em cada item do pedido
+
+CREATE TABLE IF NOT EXISTS `GrupoModificador` (
+  `idGrupo` INT NOT NULL AUTO_INCREMENT,
+  `idProduto` INT NOT NULL,
+  `nomeGrupo` VARCHAR(45) NOT NULL,
+  `minSelecoes` INT NOT NULL DEFAULT 0,
+  `maxSelecoes` INT NOT NULL DEFAULT 1,
+  PRIMARY KEY (`idGrupo`),
+  KEY `idx_grupo_produto` (`idProduto`),
+  CONSTRAINT `fk_grupo_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `Modificador` (
+  `idModificador` INT NOT NULL AUTO_INCREMENT,
+  `idGrupo` INT NOT NULL,
+  `nomeModificador` VARCHAR(45) NOT NULL,
+  `precoModificador` DECIMAL(10,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`idModificador`),
+  KEY `idx_modificador_grupo` (`idGrupo`),
+  CONSTRAINT `fk_modificador_grupo` FOREIGN KEY (`idGrupo`) REFERENCES `GrupoModificador` (`idGrupo`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `Pedido_Produto_Modificador` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `idPedidoProduto` INT NOT NULL,
+  `idModificador` INT DEFAULT NULL,
+  `nomeModificador` VARCHAR(45) NOT NULL,
+  `precoModificador` DECIMAL(10,2) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  KEY `idx_item_pedido` (`idPedidoProduto`),
+  CONSTRAINT `fk_item_pedido` FOREIGN KEY (`idPedidoProduto`) REFERENCES `Pedido_Produto` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_item_modificador` FOREIGN KEY (`idModificador`) REFERENCES `Modificador` (`idModificador`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
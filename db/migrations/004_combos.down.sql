@@ -0,0 +1,6 @@
+-- Falha se ainda houver produtos da categoria Combo, que o ENUM anterior não aceita
+
+DROP TABLE IF EXISTS `Pedido_Produto_Componente`;
+DROP TABLE IF EXISTS `ComboComponente`;
+
+ALTER TABLE `Produto` MODIFY `categoriaProduto` enum('Lanche','Bebida','Acompanhamento','Sobremesa') DEFAULT NULL;
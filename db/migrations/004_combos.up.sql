@@ -0,0 +1,26 @@
+-- Combos: produtos da categoria Combo compostos por outros produtos ou por escolhas de categoria
+
+ALTER TABLE `Produto` MODIFY `categoriaProduto` enum('Lanche','Bebida','Acompanhamento','Sobremesa','Combo') DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS `ComboComponente` (
+  `idComponente` INT NOT NULL AUTO_INCREMENT,
+  `idCombo` INT NOT NULL,
+  `idProduto` INT DEFAULT NULL,
+  `categoria` enum('Lanche','Bebida','Acompanhamento','Sobremesa') DEFAULT NULL,
+  `quantidade` INT NOT NULL DEFAULT 1,
+  PRIMARY KEY (`idComponente`),
+  KEY `idx_combo` (`idCombo`),
+  CONSTRAINT `fk_combo` FOREIGN KEY (`idCombo`) REFERENCES `Produto` (`idProduto`) ON DELETE CASCADE,
+  CONSTRAINT `fk_combo_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `Pedido_Produto_Componente` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `idPedidoProduto` INT NOT NULL,
+  `idProduto` INT NOT NULL,
+  `quantidade` INT NOT NULL DEFAULT 1,
+  PRIMARY KEY (`id`),
+  KEY `idx_componente_item` (`idPedidoProduto`),
+  CONSTRAINT `fk_componente_item` FOREIGN KEY (`idPedidoProduto`) REFERENCES `Pedido_Produto` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_componente_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,6 @@
+ALTER TABLE `Pedido`
+  DROP COLUMN `cupom`,
+  DROP COLUMN `descontoPedido`,
+  DROP COLUMN `subtotalPedido`;
+
+DROP TABLE IF EXISTS `Cupom`;
@@ -0,0 +1,27 @@
+-- Cupons de desconto e detalhamento de subtotal, desconto e cupom aplicado no pedido
+
+CREATE TABLE IF NOT EXISTS `Cupom` (
+  `idCupom` INT NOT NULL AUTO_INCREMENT,
+  `codigo` VARCHAR(30) NOT NULL,
+  `tipo` VARCHAR(20) NOT NULL,
+  `percentual` INT NOT NULL DEFAULT 0,
+  `valor` DECIMAL(10,2) NOT NULL DEFAULT 0,
+  `compre` INT NOT NULL DEFAULT 0,
+  `ganhe` INT NOT NULL DEFAULT 0,
+  `categoria` VARCHAR(20) DEFAULT NULL,
+  `valorMinimo` DECIMAL(10,2) NOT NULL DEFAULT 0,
+  `validoDe` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `validoAte` DATETIME DEFAULT NULL,
+  `limiteUsos` INT NOT NULL DEFAULT 0,
+  `usos` INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (`idCupom`),
+  UNIQUE KEY `codigo_UNIQUE` (`codigo`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+ALTER TABLE `Pedido`
+  ADD COLUMN `subtotalPedido` DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER `clienteNome`,
+  ADD COLUMN `descontoPedido` DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER `subtotalPedido`,
+  ADD COLUMN `cupom` VARCHAR(30) DEFAULT NULL AFTER `totalPedido`;
+
+-- Pedidos existentes não tinham desconto
+UPDATE `Pedido` SET `subtotalPedido` = `totalPedido`;
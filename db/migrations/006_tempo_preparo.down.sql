@@ -0,0 +1,2 @@
+ALTER TABLE `Pedido` DROP COLUMN `previsaoPronto`;
+ALTER TABLE `Produto` DROP COLUMN `tempoPreparoMinutos`;
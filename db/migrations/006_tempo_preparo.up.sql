@@ -0,0 +1,13 @@
+-- Tempo de preparo por produto e previsão de pronto do pedido, recalculada conforme a fila da cozinha
+
+ALTER TABLE `Produto` ADD COLUMN `tempoPreparoMinutos` INT NOT NULL DEFAULT 0 AFTER `categoriaProduto`;
+
+UPDATE `Produto` SET `tempoPreparoMinutos` = CASE `categoriaProduto`
+  WHEN 'Lanche' THEN 10
+  WHEN 'Acompanhamento' THEN 6
+  WHEN 'Bebida' THEN 1
+  WHEN 'Sobremesa' THEN 3
+  ELSE 0
+END;
+
+ALTER TABLE `Pedido` ADD COLUMN `previsaoPronto` DATETIME DEFAULT NULL AFTER `tempoEstimado`;
@@ -0,0 +1,3 @@
+ALTER TABLE `Produto`
+  DROP COLUMN `estoque`,
+  DROP COLUMN `esgotado`;
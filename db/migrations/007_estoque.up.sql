@@ -0,0 +1,5 @@
+-- Disponibilidade manual e estoque opcional por produto
+
+ALTER TABLE `Produto`
+  ADD COLUMN `esgotado` BOOLEAN NOT NULL DEFAULT FALSE AFTER `tempoPreparoMinutos`,
+  ADD COLUMN `estoque` INT DEFAULT NULL AFTER `esgotado`;
@@ -0,0 +1,7 @@
+-- Os produtos arquivados voltam a ser produtos comuns do cardápio
+
+ALTER TABLE `Pedido_Produto` DROP FOREIGN KEY `fk_produto`;
+ALTER TABLE `Pedido_Produto`
+  ADD CONSTRAINT `fk_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`) ON DELETE CASCADE;
+
+ALTER TABLE `Produto` DROP COLUMN `archived_at`;
@@ -0,0 +1,8 @@
+-- Produtos passam a ser arquivados em vez de apagados; o histórico de pedidos nunca perde itens
+
+ALTER TABLE `Produto`
+  ADD COLUMN `archived_at` DATETIME DEFAULT NULL AFTER `estoque`;
+
+ALTER TABLE `Pedido_Produto` DROP FOREIGN KEY `fk_produto`;
+ALTER TABLE `Pedido_Produto`
+  ADD CONSTRAINT `fk_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`) ON DELETE RESTRICT;
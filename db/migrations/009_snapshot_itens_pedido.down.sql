@@ -0,0 +1,8 @@
+ALTER TABLE `Pedido_Produto_Componente`
+  DROP COLUMN `categoriaProduto`,
+  DROP COLUMN `nomeProduto`;
+
+ALTER TABLE `Pedido_Produto`
+  DROP COLUMN `precoProduto`,
+  DROP COLUMN `categoriaProduto`,
+  DROP COLUMN `nomeProduto`;
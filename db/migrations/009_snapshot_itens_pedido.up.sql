@@ -0,0 +1,17 @@
+-- Linhas de pedido guardam nome, categoria e preço do produto no momento da compra
+
+ALTER TABLE `Pedido_Produto`
+  ADD COLUMN `nomeProduto` VARCHAR(45) NOT NULL DEFAULT '' AFTER `idProduto`,
+  ADD COLUMN `categoriaProduto` VARCHAR(20) NOT NULL DEFAULT '' AFTER `nomeProduto`,
+  ADD COLUMN `precoProduto` DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER `categoriaProduto`;
+
+ALTER TABLE `Pedido_Produto_Componente`
+  ADD COLUMN `nomeProduto` VARCHAR(45) NOT NULL DEFAULT '' AFTER `idProduto`,
+  ADD COLUMN `categoriaProduto` VARCHAR(20) NOT NULL DEFAULT '' AFTER `nomeProduto`;
+
+-- Pedidos antigos recebem os valores atuais do catálogo, o melhor dado disponível
+UPDATE `Pedido_Produto` pp JOIN `Produto` p ON p.idProduto = pp.idProduto
+  SET pp.nomeProduto = p.nomeProduto, pp.categoriaProduto = p.categoriaProduto, pp.precoProduto = p.precoProduto;
+
+UPDATE `Pedido_Produto_Componente` ppc JOIN `Produto` p ON p.idProduto = ppc.idProduto
+  SET ppc.nomeProduto = p.nomeProduto, ppc.categoriaProduto = p.categoriaProduto;
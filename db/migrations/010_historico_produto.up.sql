@@ -0,0 +1,20 @@
+-- Histórico de versões dos produtos do catálogo
+
+CREATE TABLE IF NOT EXISTS `ProdutoVersao` (
+  `idVersao` INT NOT NULL AUTO_INCREMENT,
+  `idProduto` INT NOT NULL,
+  `nomeProduto` VARCHAR(45) NOT NULL,
+  `categoriaProduto` VARCHAR(20) NOT NULL,
+  `descricaoProduto` VARCHAR(125) NOT NULL,
+  `precoProduto` DECIMAL(10,2) NOT NULL,
+  `tempoPreparoMinutos` INT NOT NULL DEFAULT 0,
+  `autor` VARCHAR(60) NOT NULL,
+  `vigenteDesde` DATETIME NOT NULL,
+  PRIMARY KEY (`idVersao`),
+  KEY `idx_versao_produto` (`idProduto`, `vigenteDesde`),
+  CONSTRAINT `fk_versao_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- O estado atual de cada produto vira a primeira versão; não há como saber o que valia antes desta migração
+INSERT INTO `ProdutoVersao` (idProduto, nomeProduto, categoriaProduto, descricaoProduto, precoProduto, tempoPreparoMinutos, autor, vigenteDesde)
+  SELECT idProduto, nomeProduto, categoriaProduto, descricaoProduto, precoProduto, tempoPreparoMinutos, 'migracao', NOW() FROM `Produto`;
@@ -0,0 +1,5 @@
+ALTER TABLE `Pedido`
+  DROP COLUMN `reembolsoNecessario`,
+  DROP COLUMN `canceladoEm`,
+  DROP COLUMN `motivoCancelamento`,
+  DROP COLUMN `atorCancelamento`;
@@ -0,0 +1,7 @@
+-- Cancelamento de pedido com responsável, motivo e indicação de reembolso
+
+ALTER TABLE `Pedido`
+  ADD COLUMN `atorCancelamento` VARCHAR(20) DEFAULT NULL AFTER `personalizacao`,
+  ADD COLUMN `motivoCancelamento` VARCHAR(255) DEFAULT NULL AFTER `atorCancelamento`,
+  ADD COLUMN `canceladoEm` DATETIME DEFAULT NULL AFTER `motivoCancelamento`,
+  ADD COLUMN `reembolsoNecessario` BOOLEAN NOT NULL DEFAULT FALSE AFTER `canceladoEm`;
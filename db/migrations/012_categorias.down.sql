@@ -0,0 +1,21 @@
+-- As categorias voltam a ser o ENUM das colunas; falha se algum produto, combo ou cupom usar uma categoria
+-- cadastrada pela loja, que o ENUM não aceita. As chaves estrangeiras deixam para trás os índices que o
+-- MySQL criou para elas, removidos em seguida.
+
+ALTER TABLE `Pedido_Produto_Componente` MODIFY COLUMN `categoriaProduto` VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE `Pedido_Produto` MODIFY COLUMN `categoriaProduto` VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE `ProdutoVersao` MODIFY COLUMN `categoriaProduto` VARCHAR(20) NOT NULL;
+
+ALTER TABLE `Cupom` DROP FOREIGN KEY `fk_cupom_categoria`;
+ALTER TABLE `Cupom` DROP INDEX `fk_cupom_categoria`;
+ALTER TABLE `Cupom` MODIFY COLUMN `categoria` VARCHAR(20) DEFAULT NULL;
+
+ALTER TABLE `ComboComponente` DROP FOREIGN KEY `fk_combo_categoria`;
+ALTER TABLE `ComboComponente` DROP INDEX `fk_combo_categoria`;
+ALTER TABLE `ComboComponente` MODIFY COLUMN `categoria` enum('Lanche','Bebida','Acompanhamento','Sobremesa') DEFAULT NULL;
+
+ALTER TABLE `Produto` DROP FOREIGN KEY `fk_produto_categoria`;
+ALTER TABLE `Produto` DROP INDEX `fk_produto_categoria`;
+ALTER TABLE `Produto` MODIFY COLUMN `categoriaProduto` enum('Lanche','Bebida','Acompanhamento','Sobremesa','Combo') DEFAULT NULL;
+
+DROP TABLE IF EXISTS `Categoria`;
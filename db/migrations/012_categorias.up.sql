@@ -0,0 +1,38 @@
+-- Categorias passam a ser cadastradas pela loja em vez de fixas no ENUM das colunas
+
+CREATE TABLE IF NOT EXISTS `Categoria` (
+  `idCategoria` INT NOT NULL AUTO_INCREMENT,
+  `nome` VARCHAR(30) NOT NULL,
+  `slug` VARCHAR(40) NOT NULL,
+  `ordem` INT NOT NULL DEFAULT 0,
+  `ativa` BOOLEAN NOT NULL DEFAULT TRUE,
+  `obrigatoriaNoPedido` BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (`idCategoria`),
+  UNIQUE KEY `nome_UNIQUE` (`nome`),
+  UNIQUE KEY `slug_UNIQUE` (`slug`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- As categorias do antigo ENUM; a regra "todo pedido tem um lanche" vira o indicador obrigatoriaNoPedido
+INSERT IGNORE INTO `Categoria` (nome, slug, ordem, ativa, obrigatoriaNoPedido) VALUES
+  ('Lanche', 'lanche', 1, TRUE, TRUE),
+  ('Acompanhamento', 'acompanhamento', 2, TRUE, FALSE),
+  ('Bebida', 'bebida', 3, TRUE, FALSE),
+  ('Sobremesa', 'sobremesa', 4, TRUE, FALSE),
+  ('Combo', 'combo', 5, TRUE, FALSE);
+
+ALTER TABLE `Produto` MODIFY COLUMN `categoriaProduto` VARCHAR(30) NOT NULL;
+ALTER TABLE `Produto`
+  ADD CONSTRAINT `fk_produto_categoria` FOREIGN KEY (`categoriaProduto`) REFERENCES `Categoria` (`nome`) ON UPDATE CASCADE;
+
+ALTER TABLE `ComboComponente` MODIFY COLUMN `categoria` VARCHAR(30) DEFAULT NULL;
+ALTER TABLE `ComboComponente`
+  ADD CONSTRAINT `fk_combo_categoria` FOREIGN KEY (`categoria`) REFERENCES `Categoria` (`nome`) ON UPDATE CASCADE;
+
+ALTER TABLE `Cupom` MODIFY COLUMN `categoria` VARCHAR(30) DEFAULT NULL;
+ALTER TABLE `Cupom`
+  ADD CONSTRAINT `fk_cupom_categoria` FOREIGN KEY (`categoria`) REFERENCES `Categoria` (`nome`) ON UPDATE CASCADE;
+
+-- Cópias do nome da categoria no histórico e nos pedidos não seguem a renomeação, mas precisam caber
+ALTER TABLE `ProdutoVersao` MODIFY COLUMN `categoriaProduto` VARCHAR(30) NOT NULL;
+ALTER TABLE `Pedido_Produto` MODIFY COLUMN `categoriaProduto` VARCHAR(30) NOT NULL;
+ALTER TABLE `Pedido_Produto_Componente` MODIFY COLUMN `categoriaProduto` VARCHAR(30) NOT NULL;
@@ -0,0 +1,14 @@
+-- Imagens dos produtos: chave no armazenamento e URL de cada variante gerada no envio
+
+CREATE TABLE IF NOT EXISTS `ProdutoImagem` (
+  `idImagem` INT NOT NULL AUTO_INCREMENT,
+  `idProduto` INT NOT NULL,
+  `variante` VARCHAR(20) NOT NULL,
+  `chave` VARCHAR(255) NOT NULL,
+  `url` VARCHAR(512) NOT NULL,
+  `largura` INT NOT NULL,
+  `altura` INT NOT NULL,
+  PRIMARY KEY (`idImagem`),
+  UNIQUE KEY `uk_imagem_variante` (`idProduto`, `variante`),
+  CONSTRAINT `fk_imagem_produto` FOREIGN KEY (`idProduto`) REFERENCES `Produto` (`idProduto`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
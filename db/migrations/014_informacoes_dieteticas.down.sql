@@ -0,0 +1,4 @@
+ALTER TABLE `Produto`
+  DROP COLUMN `informacaoNutricional`,
+  DROP COLUMN `tagsDieta`,
+  DROP COLUMN `alergenos`;
@@ -0,0 +1,6 @@
+-- Alérgenos, tags de dieta e tabela nutricional dos produtos
+
+ALTER TABLE `Produto`
+  ADD COLUMN `alergenos` VARCHAR(255) NOT NULL DEFAULT '' AFTER `archived_at`,
+  ADD COLUMN `tagsDieta` VARCHAR(100) NOT NULL DEFAULT '' AFTER `alergenos`,
+  ADD COLUMN `informacaoNutricional` JSON DEFAULT NULL AFTER `tagsDieta`;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `Pedido_Historico`;
@@ -0,0 +1,20 @@
+-- Histórico de status dos pedidos
+
+CREATE TABLE IF NOT EXISTS `Pedido_Historico` (
+  `idAlteracao` INT NOT NULL AUTO_INCREMENT,
+  `idPedido` INT NOT NULL,
+  `campo` VARCHAR(20) NOT NULL,
+  `statusAnterior` VARCHAR(50) DEFAULT NULL,
+  `statusNovo` VARCHAR(50) NOT NULL,
+  `origem` VARCHAR(20) NOT NULL,
+  `alteradoEm` DATETIME NOT NULL,
+  PRIMARY KEY (`idAlteracao`),
+  KEY `idx_historico_pedido` (`idPedido`, `alteradoEm`),
+  CONSTRAINT `fk_historico_pedido` FOREIGN KEY (`idPedido`) REFERENCES `Pedido` (`idPedido`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- O status atual de cada pedido vira o início do histórico; não há como saber quando os status anteriores mudaram
+INSERT INTO `Pedido_Historico` (idPedido, campo, statusAnterior, statusNovo, origem, alteradoEm)
+  SELECT idPedido, 'status', NULL, status, 'migracao', COALESCE(ultimaAtualizacao, NOW()) FROM `Pedido`;
+INSERT INTO `Pedido_Historico` (idPedido, campo, statusAnterior, statusNovo, origem, alteradoEm)
+  SELECT idPedido, 'pagamento', NULL, statusPagamento, 'migracao', COALESCE(ultimaAtualizacao, NOW()) FROM `Pedido`;
@@ -0,0 +1,5 @@
+ALTER TABLE `Pedido`
+  DROP KEY `idx_pedido_cliente_id`,
+  DROP KEY `idx_pedido_cliente_cpf`,
+  DROP COLUMN `clienteIdExterno`,
+  DROP COLUMN `clienteCpf`;
@@ -0,0 +1,7 @@
+-- Identificação opcional do cliente no pedido, pelo CPF ou pelo identificador no serviço de clientes
+
+ALTER TABLE `Pedido`
+  ADD COLUMN `clienteCpf` CHAR(11) DEFAULT NULL AFTER `clienteNome`,
+  ADD COLUMN `clienteIdExterno` VARCHAR(64) DEFAULT NULL AFTER `clienteCpf`,
+  ADD KEY `idx_pedido_cliente_cpf` (`clienteCpf`),
+  ADD KEY `idx_pedido_cliente_id` (`clienteIdExterno`);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS `SequenciaRetirada`;
+
+ALTER TABLE `Pedido`
+  DROP KEY `uk_pedido_codigo_retirada`,
+  DROP COLUMN `dataRetirada`,
+  DROP COLUMN `codigoRetirada`;
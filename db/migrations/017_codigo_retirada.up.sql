@@ -0,0 +1,13 @@
+-- Código de retirada diário dos pedidos, como A-017, chamado no balcão no lugar do número do pedido
+
+ALTER TABLE `Pedido`
+  ADD COLUMN `codigoRetirada` CHAR(5) DEFAULT NULL AFTER `clienteIdExterno`,
+  ADD COLUMN `dataRetirada` DATE DEFAULT NULL AFTER `codigoRetirada`,
+  ADD UNIQUE KEY `uk_pedido_codigo_retirada` (`dataRetirada`, `codigoRetirada`);
+
+-- Pedidos anteriores ficam sem código; a sequência começa no próximo pedido
+CREATE TABLE IF NOT EXISTS `SequenciaRetirada` (
+  `dia` DATE NOT NULL,
+  `ultimo` INT NOT NULL,
+  PRIMARY KEY (`dia`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,2 @@
+-- Os eventos ainda não publicados se perdem
+DROP TABLE IF EXISTS `Outbox`;
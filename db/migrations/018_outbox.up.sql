@@ -0,0 +1,16 @@
+-- Outbox transacional: eventos gravados na mesma transação da alteração e publicados depois pelo relay
+
+CREATE TABLE IF NOT EXISTS `Outbox` (
+  `idEvento` BIGINT NOT NULL AUTO_INCREMENT,
+  `fila` VARCHAR(20) NOT NULL,
+  `tipo` VARCHAR(50) NOT NULL,
+  `payload` JSON NOT NULL,
+  `status` VARCHAR(20) NOT NULL DEFAULT 'pendente',
+  `tentativas` INT NOT NULL DEFAULT 0,
+  `ultimoErro` VARCHAR(500) DEFAULT NULL,
+  `criadoEm` DATETIME(3) NOT NULL,
+  `proximaTentativa` DATETIME(3) NOT NULL,
+  `publicadoEm` DATETIME(3) DEFAULT NULL,
+  PRIMARY KEY (`idEvento`),
+  KEY `idx_outbox_pendentes` (`status`, `proximaTentativa`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
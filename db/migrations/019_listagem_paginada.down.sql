@@ -0,0 +1,8 @@
+ALTER TABLE `Produto`
+  DROP KEY `idx_produto_preco`,
+  DROP KEY `idx_produto_nome`;
+
+ALTER TABLE `Pedido`
+  DROP KEY `idx_pedido_total`,
+  DROP KEY `idx_pedido_criado_em`,
+  DROP COLUMN `criadoEm`;
@@ -0,0 +1,20 @@
+-- Data de criação do pedido, usada nos filtros por período e na ordenação da listagem paginada
+
+ALTER TABLE `Pedido`
+  ADD COLUMN `criadoEm` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER `previsaoPronto`;
+
+-- Pedidos anteriores herdam a data da primeira entrada do histórico ou, sem histórico, da última atualização
+UPDATE `Pedido` p
+  SET p.`criadoEm` = COALESCE(
+    (SELECT MIN(h.`alteradoEm`) FROM `Pedido_Historico` h WHERE h.`idPedido` = p.`idPedido`),
+    p.`ultimaAtualizacao`,
+    p.`criadoEm`
+  );
+
+ALTER TABLE `Pedido`
+  ADD KEY `idx_pedido_criado_em` (`criadoEm`, `idPedido`),
+  ADD KEY `idx_pedido_total` (`totalPedido`, `idPedido`);
+
+ALTER TABLE `Produto`
+  ADD KEY `idx_produto_nome` (`nomeProduto`, `idProduto`),
+  ADD KEY `idx_produto_preco` (`precoProduto`, `idProduto`);
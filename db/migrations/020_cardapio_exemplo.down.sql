@@ -0,0 +1,12 @@
+-- Remove o combo e os modificadores de exemplo; falha se algum pedido já usou o combo. Os tempos de preparo
+-- e as informações dietéticas dos produtos ficam como estão.
+
+DELETE g FROM `GrupoModificador` g
+JOIN `Produto` p ON p.`idProduto` = g.`idProduto`
+WHERE p.`nomeProduto` = 'X-Salada' AND g.`nomeGrupo` IN ('Adicionais', 'Remover');
+
+DELETE v FROM `ProdutoVersao` v
+JOIN `Produto` p ON p.`idProduto` = v.`idProduto`
+WHERE p.`nomeProduto` = 'Combo X-Salada' AND p.`categoriaProduto` = 'Combo';
+
+DELETE FROM `Produto` WHERE `nomeProduto` = 'Combo X-Salada' AND `categoriaProduto` = 'Combo';
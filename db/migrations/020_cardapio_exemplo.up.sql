@@ -0,0 +1,75 @@
+-- Completa o cardápio de exemplo do esquema base com o que o antigo init.sql passou a trazer: os tempos de
+-- preparo e as informações dietéticas dos produtos, o combo X-Salada e os modificadores do X-Salada.
+-- Só vale para bancos novos; num banco que já recebeu pedidos ou já tem combos ou modificadores, o
+-- cardápio é o da loja e fica como está.
+
+SET @cardapio_exemplo = NOT EXISTS (SELECT 1 FROM `Pedido`)
+  AND NOT EXISTS (SELECT 1 FROM `ComboComponente`)
+  AND NOT EXISTS (SELECT 1 FROM `GrupoModificador`);
+
+UPDATE `Produto` SET
+  `tempoPreparoMinutos` = CASE `nomeProduto`
+    WHEN 'X-Frango' THEN 12
+    WHEN 'X-Tudo' THEN 15
+    WHEN 'Cachorro-quente' THEN 7
+    WHEN 'Cachorrão especial' THEN 9
+    ELSE `tempoPreparoMinutos`
+  END,
+  `alergenos` = CASE `nomeProduto`
+    WHEN 'X-Salada' THEN 'gluten,ovo'
+    WHEN 'Mousse de chocolate' THEN 'lactose,ovo'
+    WHEN 'X-Frango' THEN 'gluten'
+    WHEN 'X-Tudo' THEN 'gluten,lactose,ovo'
+    WHEN 'Cachorro-quente' THEN 'gluten,lactose'
+    WHEN 'Cachorrão especial' THEN 'gluten,lactose'
+    ELSE `alergenos`
+  END,
+  `tagsDieta` = CASE `nomeProduto`
+    WHEN 'Coca-cola' THEN 'sem-gluten,vegano'
+    WHEN 'Batata-frita' THEN 'sem-gluten,vegano'
+    WHEN 'Mousse de chocolate' THEN 'sem-gluten,vegetariano'
+    WHEN 'Fanta' THEN 'sem-gluten,vegano'
+    WHEN 'Sprite' THEN 'sem-gluten,vegano'
+    ELSE `tagsDieta`
+  END
+WHERE @cardapio_exemplo AND `idProduto` BETWEEN 1 AND 10;
+
+-- A primeira versão de cada produto, gravada pela migração do histórico, acompanha os novos tempos
+UPDATE `ProdutoVersao` v
+JOIN `Produto` p ON p.`idProduto` = v.`idProduto`
+SET v.`tempoPreparoMinutos` = p.`tempoPreparoMinutos`
+WHERE @cardapio_exemplo AND v.`autor` = 'migracao';
+
+INSERT INTO `Produto` (nomeProduto, descricaoProduto, precoProduto, categoriaProduto, tempoPreparoMinutos)
+  SELECT 'Combo X-Salada', 'X-Salada, batata-frita e um refrigerante à escolha', 42, 'Combo', 0 FROM DUAL
+  WHERE @cardapio_exemplo;
+
+INSERT INTO `ProdutoVersao` (idProduto, nomeProduto, categoriaProduto, descricaoProduto, precoProduto, tempoPreparoMinutos, autor, vigenteDesde)
+  SELECT idProduto, nomeProduto, categoriaProduto, descricaoProduto, precoProduto, tempoPreparoMinutos, 'sistema', NOW() FROM `Produto`
+  WHERE @cardapio_exemplo AND `nomeProduto` = 'Combo X-Salada';
+
+INSERT INTO `ComboComponente` (idCombo, idProduto, categoria, quantidade)
+  SELECT c.`idProduto`, p.`idProduto`, NULL, 1 FROM `Produto` c
+  JOIN `Produto` p ON p.`nomeProduto` IN ('X-Salada', 'Batata-frita')
+  WHERE @cardapio_exemplo AND c.`nomeProduto` = 'Combo X-Salada'
+  ORDER BY p.`idProduto`;
+
+INSERT INTO `ComboComponente` (idCombo, idProduto, categoria, quantidade)
+  SELECT `idProduto`, NULL, 'Bebida', 1 FROM `Produto`
+  WHERE @cardapio_exemplo AND `nomeProduto` = 'Combo X-Salada';
+
+INSERT INTO `GrupoModificador` (idProduto, nomeGrupo, minSelecoes, maxSelecoes)
+  SELECT `idProduto`, 'Adicionais', 0, 2 FROM `Produto` WHERE @cardapio_exemplo AND `nomeProduto` = 'X-Salada'
+  UNION ALL
+  SELECT `idProduto`, 'Remover', 0, 1 FROM `Produto` WHERE @cardapio_exemplo AND `nomeProduto` = 'X-Salada';
+
+INSERT INTO `Modificador` (idGrupo, nomeModificador, precoModificador)
+  SELECT g.`idGrupo`, m.nome, m.preco FROM `GrupoModificador` g
+  JOIN `Produto` p ON p.`idProduto` = g.`idProduto` AND p.`nomeProduto` = 'X-Salada'
+  JOIN (
+    SELECT 'Adicionais' AS grupo, 'Bacon extra' AS nome, 4.00 AS preco
+    UNION ALL SELECT 'Adicionais', 'Queijo extra', 3.00
+    UNION ALL SELECT 'Remover', 'Sem cebola', 0
+  ) m ON m.grupo = g.`nomeGrupo`
+  WHERE @cardapio_exemplo
+  ORDER BY g.`idGrupo`;
@@ -0,0 +1,39 @@
+-- Recria as tabelas legadas, vazias
+
+CREATE TABLE `Pagamento` (
+  `idPagamento` int NOT NULL AUTO_INCREMENT,
+  `dataCriacao` datetime NOT NULL,
+  `Status` enum('Pendente','Recebido','Em Preparação','Pronto','Finalizado') NOT NULL DEFAULT 'Pendente',
+  `idPedido` int NOT NULL,
+  PRIMARY KEY (`idPagamento`),
+  UNIQUE KEY `idPagamento_UNIQUE` (`idPagamento`),
+  KEY `idPedido_idx` (`idPedido`),
+  CONSTRAINT `idPedido` FOREIGN KEY (`idPedido`) REFERENCES `Pedido` (`idPedido`) ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `cliente` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `cpf` varchar(11) NOT NULL,
+  `nome` varchar(100) NOT NULL,
+  `email` varchar(100) DEFAULT NULL,
+  `telefone` varchar(20) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `cpf` (`cpf`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `Acompanhamento` (
+  `idAcompanhamento` INT AUTO_INCREMENT PRIMARY KEY,
+  `tempoEstimado` TIME NOT NULL DEFAULT '00:15:00',
+  `ultimaAtualizacao` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE `FilaPedidos` (
+  `id` INT AUTO_INCREMENT PRIMARY KEY,
+  `idAcompanhamento` INT NOT NULL,
+  `idPedido` INT NOT NULL,
+  `ordem` INT NOT NULL,
+  FOREIGN KEY (`idAcompanhamento`) REFERENCES `Acompanhamento` (`idAcompanhamento`) ON DELETE CASCADE,
+  FOREIGN KEY (`idPedido`) REFERENCES `Pedido` (`idPedido`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,7 @@
+-- Tabelas dos módulos de cliente, acompanhamento e pagamento, que saíram deste serviço e não são mais usadas.
+-- Vêm do esquema base do antigo db/init.sql.
+
+DROP TABLE IF EXISTS `FilaPedidos`;
+DROP TABLE IF EXISTS `Acompanhamento`;
+DROP TABLE IF EXISTS `Pagamento`;
+DROP TABLE IF EXISTS `cliente`;
@@ -0,0 +1 @@
+ALTER TABLE `Pedido_Produto_Componente` DROP COLUMN `precoProduto`;
@@ -0,0 +1,8 @@
+-- O preço dos produtos que compõem um combo também é copiado no momento do pedido. Os pedidos antigos
+-- recebem o preço atual do catálogo, a melhor aproximação disponível.
+
+ALTER TABLE `Pedido_Produto_Componente` ADD COLUMN `precoProduto` DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER `categoriaProduto`;
+
+UPDATE `Pedido_Produto_Componente` ppc
+JOIN `Produto` p ON p.`idProduto` = ppc.`idProduto`
+SET ppc.`precoProduto` = p.`precoProduto`;
@@ -0,0 +1,2 @@
+ALTER TABLE `Pedido_Produto_Componente` DROP COLUMN `quantidadeReservada`;
+ALTER TABLE `Pedido_Produto` DROP COLUMN `quantidadeReservada`;
@@ -0,0 +1,19 @@
+-- Quantas unidades de cada linha do pedido saíram do estoque na compra; o cancelamento devolve exatamente
+-- isso, mesmo que o controle de estoque do produto tenha sido ligado ou desligado depois. Para os pedidos
+-- antigos, ainda não cancelados, vale a regra anterior: reservado se o produto controla o estoque hoje.
+
+ALTER TABLE `Pedido_Produto` ADD COLUMN `quantidadeReservada` INT NOT NULL DEFAULT 0 AFTER `quantidade`;
+ALTER TABLE `Pedido_Produto_Componente` ADD COLUMN `quantidadeReservada` INT NOT NULL DEFAULT 0 AFTER `quantidade`;
+
+UPDATE `Pedido_Produto` pp
+JOIN `Pedido` pe ON pe.`idPedido` = pp.`idPedido`
+JOIN `Produto` p ON p.`idProduto` = pp.`idProduto`
+SET pp.`quantidadeReservada` = pp.`quantidade`
+WHERE p.`estoque` IS NOT NULL AND pe.`status` <> 'Cancelado';
+
+UPDATE `Pedido_Produto_Componente` ppc
+JOIN `Pedido_Produto` pp ON pp.`id` = ppc.`idPedidoProduto`
+JOIN `Pedido` pe ON pe.`idPedido` = pp.`idPedido`
+JOIN `Produto` p ON p.`idProduto` = ppc.`idProduto`
+SET ppc.`quantidadeReservada` = ppc.`quantidade` * pp.`quantidade`
+WHERE p.`estoque` IS NOT NULL AND pe.`status` <> 'Cancelado';
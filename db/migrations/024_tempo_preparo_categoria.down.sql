@@ -0,0 +1 @@
+ALTER TABLE `Categoria` DROP COLUMN `tempoPreparoMinutos`;
@@ -0,0 +1,17 @@
+-- O tempo de preparo padrão passa a ser cadastrado na categoria, em vez de fixo no código. As categorias
+-- existentes recebem os tempos que o código usava, e os produtos gravados sem tempo recebem o da categoria.
+
+ALTER TABLE `Categoria` ADD COLUMN `tempoPreparoMinutos` INT NOT NULL DEFAULT 0 AFTER `obrigatoriaNoPedido`;
+
+UPDATE `Categoria` SET `tempoPreparoMinutos` = CASE `nome`
+  WHEN 'Lanche' THEN 10
+  WHEN 'Acompanhamento' THEN 6
+  WHEN 'Bebida' THEN 1
+  WHEN 'Sobremesa' THEN 3
+  ELSE 0
+END;
+
+UPDATE `Produto` p
+JOIN `Categoria` cat ON cat.`nome` = p.`categoriaProduto`
+SET p.`tempoPreparoMinutos` = cat.`tempoPreparoMinutos`
+WHERE p.`tempoPreparoMinutos` = 0;
@@ -0,0 +1,75 @@
+// infra/cliente/http_cliente_gateway.go
+package cliente
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+)
+
+// HTTPClienteGateway consulta o serviço de clientes pela API HTTP:
+// GET {baseURL}/clientes/cpf/{cpf} ou GET {baseURL}/clientes/{id}
+type HTTPClienteGateway struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPClienteGateway(baseURL string, timeout time.Duration) (*HTTPClienteGateway, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("a URL do serviço de clientes não pode ser vazia")
+	}
+	return &HTTPClienteGateway{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// clienteResponse é o corpo devolvido pelo serviço de clientes
+type clienteResponse struct {
+	ID   json.Number `json:"id"`
+	CPF  string      `json:"cpf"`
+	Nome string      `json:"nome"`
+}
+
+func (g *HTTPClienteGateway) BuscarCliente(ctx context.Context, ref entities.ReferenciaCliente) (*entities.Cliente, error) {
+	endereco := g.baseURL + "/clientes/" + url.PathEscape(ref.IDExterno)
+	if ref.CPF != "" {
+		endereco = g.baseURL + "/clientes/cpf/" + url.PathEscape(ref.CPF)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endereco, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar a consulta do cliente: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entities.ErrServicoClientesIndisponivel, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, entities.ErrClienteNaoEncontrado
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: resposta %d", entities.ErrServicoClientesIndisponivel, resp.StatusCode)
+	}
+
+	var corpo clienteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&corpo); err != nil {
+		return nil, fmt.Errorf("%w: resposta inválida: %w", entities.ErrServicoClientesIndisponivel, err)
+	}
+
+	return &entities.Cliente{
+		IDExterno: corpo.ID.String(),
+		CPF:       corpo.CPF,
+		Nome:      corpo.Nome,
+	}, nil
+}
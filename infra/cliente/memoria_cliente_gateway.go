@@ -0,0 +1,47 @@
+// infra/cliente/memoria_cliente_gateway.go
+package cliente
+
+import (
+	"context"
+	"sync"
+
+	"lanchonete/internal/domain/entities"
+)
+
+// MemoriaClienteGateway guarda os clientes em memória, para desenvolvimento e testes sem o serviço de clientes
+type MemoriaClienteGateway struct {
+	mu       sync.RWMutex
+	clientes []entities.Cliente
+}
+
+func NewMemoriaClienteGateway(clientes ...entities.Cliente) *MemoriaClienteGateway {
+	g := &MemoriaClienteGateway{}
+	for _, c := range clientes {
+		g.Adicionar(c)
+	}
+	return g
+}
+
+// Adicionar cadastra o cliente; o CPF é guardado só com os dígitos
+func (g *MemoriaClienteGateway) Adicionar(cliente entities.Cliente) {
+	if cpf, err := entities.NormalizarCPF(cliente.CPF); err == nil {
+		cliente.CPF = cpf
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.clientes = append(g.clientes, cliente)
+}
+
+func (g *MemoriaClienteGateway) BuscarCliente(ctx context.Context, ref entities.ReferenciaCliente) (*entities.Cliente, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, c := range g.clientes {
+		if (ref.CPF != "" && c.CPF == ref.CPF) || (ref.CPF == "" && ref.IDExterno != "" && c.IDExterno == ref.IDExterno) {
+			cliente := c
+			return &cliente, nil
+		}
+	}
+	return nil, entities.ErrClienteNaoEncontrado
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"lanchonete/internal/domain/entities"
 	"lanchonete/usecases"
 	"log"
 
@@ -31,11 +32,11 @@ func (c *SQSConsumer) StartConsumingPagamento(queueURL string, useCase usecases.
 		var envelope struct {
 			EventType string `json:"event_type"`
 			Data      struct {
-				IDPagamento int     `json:"id_pagamento"`
-				IDPedido    string  `json:"id_pedido"` // vem como string no JSON
-				Valor       float64 `json:"valor"`
-				Status      string  `json:"status"`
-				DataCriacao string  `json:"data_criacao"`
+				IDPagamento int            `json:"id_pagamento"`
+				IDPedido    string         `json:"id_pedido"` // vem como string no JSON
+				Valor       entities.Money `json:"valor"`
+				Status      string         `json:"status"`
+				DataCriacao string         `json:"data_criacao"`
 			} `json:"data"`
 		}
 
@@ -51,10 +52,11 @@ func (c *SQSConsumer) StartConsumingPagamento(queueURL string, useCase usecases.
 			return
 		}
 
-		log.Printf("📥 Evento '%s' recebido: pedidoID=%d status=%s", envelope.EventType, pedidoID, envelope.Data.Status)
+		log.Printf("📥 Evento '%s' recebido: pedidoID=%d status=%s valor=%s", envelope.EventType, pedidoID, envelope.Data.Status, envelope.Data.Valor)
 
-		// Executa o use-case
-		err := useCase.Run(context.Background(), pedidoID, envelope.Data.Status)
+		// Executa o use-case; o histórico do pedido registra que a mudança veio da fila
+		ctx := usecases.ComOrigem(context.Background(), entities.OrigemConsumidorSQS)
+		err := useCase.Run(ctx, pedidoID, envelope.Data.Status)
 		if err != nil {
 			log.Printf("❌ Erro ao atualizar status do pagamento: %v", err)
 		} else {
@@ -0,0 +1,133 @@
+// Package conformidade reúne os testes que toda implementação dos repositórios precisa passar. A mesma
+// suíte roda contra o MySQL e contra os repositórios em memória, garantindo que as duas se comportem
+// igual, inclusive nos erros.
+package conformidade
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// Repositorios são as implementações testadas, todas sobre o mesmo armazenamento
+type Repositorios struct {
+	Categorias    repository.CategoriaRepository
+	Produtos      repository.ProdutoRepository
+	Pedidos       repository.PedidoRepository
+	Cupons        repository.CupomRepository
+	Modificadores repository.ModificadorRepository
+}
+
+// Fabrica devolve os repositórios sobre um armazenamento vazio, sem categorias nem produtos. Cada
+// subteste chama a fábrica de novo, então os testes não dependem uns dos outros.
+type Fabrica func(t *testing.T) Repositorios
+
+// cenario é um armazenamento vazio com as categorias usadas pela suíte
+type cenario struct {
+	Repositorios
+	t     *testing.T
+	ctx   context.Context
+	agora time.Time
+}
+
+func novoCenario(t *testing.T, fabrica Fabrica) *cenario {
+	s := &cenario{
+		Repositorios: fabrica(t),
+		t:            t,
+		ctx:          context.Background(),
+		// Sem frações de segundo, que as colunas DATETIME não guardam
+		agora: time.Now().Truncate(time.Second),
+	}
+	for ordem, nome := range []string{"Lanche", "Bebida"} {
+		categoria, err := entities.CategoriaNew(nome, ordem+1, true, false)
+		if err != nil {
+			t.Fatalf("erro ao montar a categoria %s: %v", nome, err)
+		}
+		if err := s.Categorias.AdicionarCategoria(s.ctx, categoria); err != nil {
+			t.Fatalf("erro ao criar a categoria %s: %v", nome, err)
+		}
+	}
+	return s
+}
+
+// produto cadastra um produto; estoque negativo deixa o estoque sem controle
+func (s *cenario) produto(nome string, categoria entities.CatProduto, preco int64, estoque int) *entities.Produto {
+	produto := &entities.Produto{
+		Nome:                nome,
+		Categoria:           categoria,
+		Descricao:           "Descrição de " + nome,
+		Preco:               entities.Centavos(preco),
+		TempoPreparoMinutos: 5,
+	}
+	if estoque >= 0 {
+		produto.Estoque = &estoque
+	}
+	if err := s.Produtos.AdicionarProduto(s.ctx, produto); err != nil {
+		s.t.Fatalf("erro ao cadastrar o produto %s: %v", nome, err)
+	}
+	return produto
+}
+
+// buscarProduto relê o produto do armazenamento
+func (s *cenario) buscarProduto(id int) *entities.Produto {
+	produto, err := s.Produtos.BuscarProdutoPorId(s.ctx, id)
+	if err != nil {
+		s.t.Fatalf("erro ao buscar o produto %d: %v", id, err)
+	}
+	return produto
+}
+
+// novoPedido monta, sem gravar, um pedido de uma unidade de cada produto, feito no instante do cenário
+func (s *cenario) novoPedido(cliente string, produtos ...*entities.Produto) *entities.Pedido {
+	itens := make([]entities.ItemPedido, 0, len(produtos))
+	for _, produto := range produtos {
+		itens = append(itens, entities.ItemPedido{Produto: *s.buscarProduto(produto.ID), Quantidade: 1})
+	}
+	pedido, err := entities.PedidoNew(cliente, itens, nil)
+	if err != nil {
+		s.t.Fatalf("erro ao montar o pedido: %v", err)
+	}
+	pedido.CriadoEm = s.agora
+	pedido.UltimaAtualizacao = s.agora
+	return pedido
+}
+
+// pedido grava um pedido de uma unidade de cada produto
+func (s *cenario) pedido(cliente string, produtos ...*entities.Produto) *entities.Pedido {
+	pedido := s.novoPedido(cliente, produtos...)
+	if err := s.Pedidos.CriarPedido(s.ctx, pedido); err != nil {
+		s.t.Fatalf("erro ao criar o pedido: %v", err)
+	}
+	return pedido
+}
+
+// buscarPedido relê o pedido do armazenamento
+func (s *cenario) buscarPedido(id int) *entities.Pedido {
+	pedido, err := s.Pedidos.BuscarPedido(s.ctx, id)
+	if err != nil {
+		s.t.Fatalf("erro ao buscar o pedido %d: %v", id, err)
+	}
+	return pedido
+}
+
+// consultarTudo percorre todas as páginas da consulta e devolve os IDs na ordem da listagem
+func consultarTudo[T any](t *testing.T, ids func([]T) []int, consultar func(cursor *entities.Cursor) (*entities.Pagina[T], error)) []int {
+	var todos []int
+	var cursor *entities.Cursor
+	for {
+		pagina, err := consultar(cursor)
+		if err != nil {
+			t.Fatalf("erro ao consultar: %v", err)
+		}
+		todos = append(todos, ids(pagina.Itens)...)
+		if pagina.ProximoCursor == "" {
+			return todos
+		}
+		if cursor, err = entities.CursorParse(pagina.ProximoCursor); err != nil {
+			t.Fatalf("cursor inválido: %v", err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package conformidade
+
+import (
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestarModificadorRepository confere o cadastro, a listagem e a remoção dos grupos de modificadores
+func TestarModificadorRepository(t *testing.T, fabrica Fabrica) {
+	t.Run("lista os grupos do produto, inclusive os sem opções", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		lanche := s.produto("X-Salada", "Lanche", 2250, -1)
+		outro := s.produto("X-Egg", "Lanche", 2400, -1)
+
+		adicionais := &entities.GrupoModificador{
+			ProdutoID:   lanche.ID,
+			Nome:        "Adicionais",
+			MaxSelecoes: 2,
+			Opcoes: []entities.Modificador{
+				{Nome: "Bacon extra", Preco: entities.Centavos(400)},
+				{Nome: "Queijo extra", Preco: entities.Centavos(300)},
+			},
+		}
+		vazio := &entities.GrupoModificador{ProdutoID: lanche.ID, Nome: "Molhos", MaxSelecoes: 1}
+		assert.NoError(t, s.Modificadores.AdicionarGrupo(s.ctx, adicionais))
+		assert.NoError(t, s.Modificadores.AdicionarGrupo(s.ctx, vazio))
+		assert.NoError(t, s.Modificadores.AdicionarGrupo(s.ctx, &entities.GrupoModificador{ProdutoID: outro.ID, Nome: "Remover", MaxSelecoes: 1}))
+
+		grupos, err := s.Modificadores.ListarGruposPorProduto(s.ctx, lanche.ID)
+		assert.NoError(t, err)
+		if assert.Len(t, grupos, 2) {
+			assert.Equal(t, "Adicionais", grupos[0].Nome)
+			assert.Equal(t, []string{"Bacon extra", "Queijo extra"}, nomesOpcoes(grupos[0].Opcoes))
+			assert.Equal(t, int64(400), grupos[0].Opcoes[0].Preco.Centavos)
+			assert.Equal(t, grupos[0].ID, grupos[0].Opcoes[0].GrupoID)
+
+			assert.Equal(t, vazio.ID, grupos[1].ID)
+			assert.Equal(t, "Molhos", grupos[1].Nome)
+			assert.Equal(t, 1, grupos[1].MaxSelecoes)
+			assert.Empty(t, grupos[1].Opcoes)
+			assert.NotNil(t, grupos[1].Opcoes, "a lista de opções vazia é serializada como []")
+		}
+
+		// O produto buscado traz os mesmos grupos
+		assert.Len(t, s.buscarProduto(lanche.ID).Modificadores, 2)
+	})
+
+	t.Run("remove o grupo", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		lanche := s.produto("X-Salada", "Lanche", 2250, -1)
+		grupo := &entities.GrupoModificador{ProdutoID: lanche.ID, Nome: "Molhos", MaxSelecoes: 1}
+		assert.NoError(t, s.Modificadores.AdicionarGrupo(s.ctx, grupo))
+
+		assert.NoError(t, s.Modificadores.RemoverGrupo(s.ctx, grupo.ID))
+		assert.Error(t, s.Modificadores.RemoverGrupo(s.ctx, grupo.ID), "grupo já removido")
+
+		grupos, err := s.Modificadores.ListarGruposPorProduto(s.ctx, lanche.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, grupos)
+	})
+}
+
+func nomesOpcoes(opcoes []entities.Modificador) []string {
+	var nomes []string
+	for _, opcao := range opcoes {
+		nomes = append(nomes, opcao.Nome)
+	}
+	return nomes
+}
@@ -0,0 +1,445 @@
+package conformidade
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestarPedidoRepository confere a gravação dos pedidos com a baixa do estoque e o uso dos cupons, as
+// mudanças de status, o cancelamento e as consultas de pedidos
+func TestarPedidoRepository(t *testing.T, fabrica Fabrica) {
+	t.Run("cria e busca o pedido com os itens", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		lanche := s.produto("X-Salada", "Lanche", 2250, -1)
+		bebida := s.produto("Suco", "Bebida", 700, -1)
+		grupo := &entities.GrupoModificador{
+			ProdutoID:   lanche.ID,
+			Nome:        "Adicionais",
+			MaxSelecoes: 2,
+			Opcoes:      []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Centavos(400)}},
+		}
+		if err := s.Modificadores.AdicionarGrupo(s.ctx, grupo); err != nil {
+			t.Fatalf("erro ao criar os modificadores: %v", err)
+		}
+
+		pedido := s.novoPedido("Maria", bebida)
+		item, err := entities.ItemPedidoNew(*s.buscarProduto(lanche.ID), 2, []entities.Modificador{{ID: grupo.Opcoes[0].ID}}, nil)
+		if err != nil {
+			t.Fatalf("erro ao montar o item: %v", err)
+		}
+		pedido.Itens = append(pedido.Itens, *item)
+		if pedido.Subtotal, err = pedido.Subtotal.Add(item.Subtotal); err != nil {
+			t.Fatalf("erro ao somar o item: %v", err)
+		}
+		pedido.Total = pedido.Subtotal
+		pedido.Cliente = &entities.ReferenciaCliente{CPF: "52998224725"}
+		assert.NoError(t, s.Pedidos.CriarPedido(s.ctx, pedido))
+		assert.Positive(t, pedido.ID)
+		assert.Equal(t, "A-001", pedido.CodigoRetirada)
+
+		salvo := s.buscarPedido(pedido.ID)
+		assert.Equal(t, "Maria", salvo.ClienteNome)
+		assert.Equal(t, &entities.ReferenciaCliente{CPF: "52998224725"}, salvo.Cliente)
+		assert.Equal(t, "A-001", salvo.CodigoRetirada)
+		assert.Equal(t, entities.Pendente, salvo.Status)
+		assert.Equal(t, entities.PagamentoPendente, salvo.StatusPagamento)
+		assert.Equal(t, int64(700+2*2650), salvo.Total.Centavos)
+		assert.True(t, s.agora.Equal(salvo.CriadoEm))
+		assert.True(t, s.agora.Equal(salvo.UltimaAtualizacao))
+		if assert.Len(t, salvo.Itens, 2) {
+			assert.Equal(t, "Suco", salvo.Itens[0].Produto.Nome)
+			assert.Empty(t, salvo.Itens[0].Modificadores)
+			assert.Equal(t, "X-Salada", salvo.Itens[1].Produto.Nome)
+			assert.Equal(t, 2, salvo.Itens[1].Quantidade)
+			assert.Equal(t, int64(2650), salvo.Itens[1].PrecoUnitario.Centavos)
+			assert.Equal(t, int64(5300), salvo.Itens[1].Subtotal.Centavos)
+			if assert.Len(t, salvo.Itens[1].Modificadores, 1) {
+				assert.Equal(t, "Bacon extra", salvo.Itens[1].Modificadores[0].Nome)
+				assert.Equal(t, int64(400), salvo.Itens[1].Modificadores[0].Preco.Centavos)
+			}
+		}
+
+		// O código de retirada segue a sequência do dia
+		assert.Equal(t, "A-002", s.pedido("João", bebida).CodigoRetirada)
+		_, err = s.Pedidos.BuscarPedido(s.ctx, 999)
+		assert.Error(t, err)
+	})
+
+	t.Run("o item guarda o nome, a categoria e o preço da compra", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Suco", "Bebida", 700, -1)
+		pedido := s.pedido("Maria", produto)
+
+		produto.Nome = "Suco de laranja"
+		produto.Categoria = "Lanche"
+		produto.Preco = entities.Centavos(900)
+		produto.Descricao = "Suco natural"
+		assert.NoError(t, s.Produtos.EditarProduto(s.ctx, produto))
+
+		item := s.buscarPedido(pedido.ID).Itens[0]
+		assert.Equal(t, "Suco", item.Produto.Nome)
+		assert.Equal(t, entities.CatProduto("Bebida"), item.Produto.Categoria)
+		assert.Equal(t, int64(700), item.Produto.Preco.Centavos)
+		assert.Equal(t, int64(700), item.Subtotal.Centavos)
+		// A descrição não faz parte da cópia e vem do cardápio atual
+		assert.Equal(t, "Suco natural", item.Produto.Descricao)
+	})
+
+	t.Run("o item guarda a cópia dos modificadores e dos componentes do combo", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		lanche := s.produto("X-Salada", "Lanche", 2250, -1)
+		suco := s.produto("Suco", "Bebida", 700, -1)
+		grupo := &entities.GrupoModificador{
+			ProdutoID:   lanche.ID,
+			Nome:        "Adicionais",
+			MaxSelecoes: 1,
+			Opcoes:      []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Centavos(400)}},
+		}
+		if err := s.Modificadores.AdicionarGrupo(s.ctx, grupo); err != nil {
+			t.Fatalf("erro ao criar os modificadores: %v", err)
+		}
+
+		pedido := s.novoPedido("Maria")
+		item, err := entities.ItemPedidoNew(*s.buscarProduto(lanche.ID), 1, []entities.Modificador{{ID: grupo.Opcoes[0].ID}}, nil)
+		if err != nil {
+			t.Fatalf("erro ao montar o item: %v", err)
+		}
+		item.Componentes = []entities.ItemCombo{{Produto: *s.buscarProduto(suco.ID), Quantidade: 1}}
+		pedido.Itens = append(pedido.Itens, *item)
+		pedido.Subtotal, pedido.Total = item.Subtotal, item.Subtotal
+		assert.NoError(t, s.Pedidos.CriarPedido(s.ctx, pedido))
+
+		// O cardápio muda depois da compra
+		alterado := s.buscarProduto(suco.ID)
+		alterado.Preco = entities.Centavos(900)
+		alterado.Categoria = "Lanche"
+		assert.NoError(t, s.Produtos.EditarProduto(s.ctx, alterado))
+		assert.NoError(t, s.Modificadores.RemoverGrupo(s.ctx, grupo.ID))
+
+		salvo := s.buscarPedido(pedido.ID)
+		if !assert.Len(t, salvo.Itens, 1) {
+			return
+		}
+		assert.Equal(t, int64(2650), salvo.Itens[0].PrecoUnitario.Centavos)
+		if assert.Len(t, salvo.Itens[0].Modificadores, 1) {
+			assert.Equal(t, "Bacon extra", salvo.Itens[0].Modificadores[0].Nome)
+			assert.Equal(t, int64(400), salvo.Itens[0].Modificadores[0].Preco.Centavos)
+		}
+		if assert.Len(t, salvo.Itens[0].Componentes, 1) {
+			componente := salvo.Itens[0].Componentes[0].Produto
+			assert.Equal(t, "Suco", componente.Nome)
+			assert.Equal(t, entities.CatProduto("Bebida"), componente.Categoria)
+			assert.Equal(t, int64(700), componente.Preco.Centavos)
+		}
+	})
+
+	t.Run("baixa o estoque e recusa produtos indisponíveis", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		controlado := s.produto("Brownie", "Lanche", 900, 1)
+		livre := s.produto("Suco", "Bebida", 700, -1)
+
+		pedido := s.pedido("Maria", controlado, livre)
+		assert.Equal(t, 0, *s.buscarProduto(controlado.ID).Estoque)
+		assert.Nil(t, s.buscarProduto(livre.ID).Estoque)
+		assert.Equal(t, 0, *pedido.Itens[0].Produto.Estoque, "o pedido recebe o estoque restante")
+
+		// PedidoNew já recusaria o produto esgotado; o repositório confere de novo ao gravar
+		recusado := s.novoPedido("João", livre)
+		recusado.Itens = append(recusado.Itens, pedido.Itens[0])
+		var indisponiveis *entities.ProdutosIndisponiveisError
+		if assert.ErrorAs(t, s.Pedidos.CriarPedido(s.ctx, recusado), &indisponiveis) {
+			assert.Equal(t, []string{"Brownie"}, indisponiveis.Produtos)
+		}
+		assert.Zero(t, recusado.ID)
+
+		inexistente := s.novoPedido("João", livre)
+		inexistente.Itens[0].Produto.ID = 999
+		assert.ErrorIs(t, s.Pedidos.CriarPedido(s.ctx, inexistente), sql.ErrNoRows)
+
+		// Os pedidos recusados não deixam rastro nem consomem códigos de retirada
+		pagina, err := s.Pedidos.ConsultarPedidos(s.ctx, consultaPedidos(t, entities.ConsultaPedidos{}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pagina.Total)
+		assert.Equal(t, "A-002", s.pedido("João", livre).CodigoRetirada)
+	})
+
+	t.Run("conta o uso do cupom até o limite", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Suco", "Bebida", 700, -1)
+		cupom := &entities.Cupom{
+			Codigo:     "BEMVINDO",
+			Tipo:       entities.DescontoPercentual,
+			Percentual: 10,
+			ValidoDe:   s.agora.Add(-time.Hour),
+			LimiteUsos: 1,
+		}
+		if err := s.Cupons.AdicionarCupom(s.ctx, cupom); err != nil {
+			t.Fatalf("erro ao criar o cupom: %v", err)
+		}
+
+		codigo := "bemvindo"
+		pedido := s.novoPedido("Maria", produto)
+		pedido.Cupom = &codigo
+		assert.NoError(t, s.Pedidos.CriarPedido(s.ctx, pedido))
+		salvo, err := s.Cupons.BuscarCupomPorCodigo(s.ctx, "BEMVINDO")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, salvo.Usos)
+
+		esgotado := s.novoPedido("João", produto)
+		esgotado.Cupom = &codigo
+		assert.ErrorIs(t, s.Pedidos.CriarPedido(s.ctx, esgotado), entities.ErrCupomEsgotado)
+
+		inexistente := s.novoPedido("João", produto)
+		outro := "NATAL10"
+		inexistente.Cupom = &outro
+		assert.ErrorIs(t, s.Pedidos.CriarPedido(s.ctx, inexistente), entities.ErrCupomEsgotado)
+	})
+
+	t.Run("atualiza os status do pedido", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		pedido := s.pedido("Maria", s.produto("Suco", "Bebida", 700, -1))
+		depois := s.agora.Add(time.Minute)
+
+		assert.NoError(t, s.Pedidos.AtualizarStatusPagamento(s.ctx, pedido.ID, string(entities.PagamentoPago), depois))
+		assert.NoError(t, s.Pedidos.AtualizarStatusPedido(s.ctx, pedido.ID, string(entities.Pendente), string(entities.Recebido), depois))
+		salvo := s.buscarPedido(pedido.ID)
+		assert.Equal(t, entities.Recebido, salvo.Status)
+		assert.Equal(t, entities.PagamentoPago, salvo.StatusPagamento)
+		assert.True(t, depois.Equal(salvo.UltimaAtualizacao))
+
+		// Quem leu o pedido ainda pendente não sobrescreve a mudança feita nesse meio tempo
+		assert.ErrorIs(t, s.Pedidos.AtualizarStatusPedido(s.ctx, pedido.ID, string(entities.Pendente), string(entities.Recebido), depois), entities.ErrConflitoStatus)
+		assert.ErrorIs(t, s.Pedidos.AtualizarStatusPedido(s.ctx, pedido.ID, string(entities.Pendente), string(entities.Cancelado), depois), entities.ErrConflitoStatus)
+		assert.Equal(t, entities.Recebido, s.buscarPedido(pedido.ID).Status)
+
+		// Gravar o mesmo status de pagamento no mesmo instante não altera nada e é tratado como pedido não encontrado
+		assert.Error(t, s.Pedidos.AtualizarStatusPagamento(s.ctx, pedido.ID, string(entities.PagamentoPago), depois))
+		assert.ErrorIs(t, s.Pedidos.AtualizarStatusPedido(s.ctx, 999, string(entities.Pendente), string(entities.Recebido), depois), entities.ErrPedidoNaoEncontrado)
+		assert.Error(t, s.Pedidos.AtualizarStatusPagamento(s.ctx, 999, string(entities.PagamentoPago), depois))
+	})
+
+	t.Run("cancela o pedido e devolve o estoque", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Brownie", "Lanche", 900, 3)
+		pedido := s.pedido("Maria", produto)
+		previsao := s.agora.Add(10 * time.Minute)
+		pedido.PrevisaoPronto = &previsao
+		assert.NoError(t, s.Pedidos.AtualizarPrevisoes(s.ctx, []*entities.Pedido{pedido}))
+		assert.Equal(t, 2, *s.buscarProduto(produto.ID).Estoque)
+		assert.True(t, s.agora.Equal(s.buscarPedido(pedido.ID).UltimaAtualizacao), "a previsão não conta como atualização do pedido")
+
+		assert.Error(t, s.Pedidos.CancelarPedido(s.ctx, pedido), "pedido sem cancelamento")
+		if err := pedido.Cancelar(entities.CanceladoPelaLoja, "Falta de ingrediente", s.agora.Add(time.Minute)); err != nil {
+			t.Fatalf("erro ao cancelar o pedido: %v", err)
+		}
+		assert.NoError(t, s.Pedidos.CancelarPedido(s.ctx, pedido))
+		assert.Equal(t, 3, *s.buscarProduto(produto.ID).Estoque)
+
+		salvo := s.buscarPedido(pedido.ID)
+		assert.Equal(t, entities.Cancelado, salvo.Status)
+		assert.Equal(t, entities.PagamentoCancelado, salvo.StatusPagamento)
+		assert.Nil(t, salvo.PrevisaoPronto)
+		assert.True(t, s.agora.Add(time.Minute).Equal(salvo.UltimaAtualizacao))
+		if assert.NotNil(t, salvo.Cancelamento) {
+			assert.Equal(t, entities.CanceladoPelaLoja, salvo.Cancelamento.Ator)
+			assert.Equal(t, "Falta de ingrediente", salvo.Cancelamento.Motivo)
+			assert.True(t, s.agora.Add(time.Minute).Equal(salvo.Cancelamento.CanceladoEm))
+		}
+
+		// Cancelar de novo não devolve o estoque outra vez
+		assert.ErrorIs(t, s.Pedidos.CancelarPedido(s.ctx, pedido), entities.ErrConflitoStatus)
+		assert.Equal(t, 3, *s.buscarProduto(produto.ID).Estoque)
+
+		inexistente := *pedido
+		inexistente.ID = 999
+		assert.ErrorIs(t, s.Pedidos.CancelarPedido(s.ctx, &inexistente), entities.ErrPedidoNaoEncontrado)
+	})
+
+	t.Run("o cancelamento devolve só o que o pedido reservou e o uso do cupom", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		brownie := s.produto("Brownie", "Lanche", 900, 5)
+		suco := s.produto("Suco", "Bebida", 700, -1)
+		cupom := &entities.Cupom{
+			Codigo:     "VOLTE10",
+			Tipo:       entities.DescontoPercentual,
+			Percentual: 10,
+			ValidoDe:   s.agora.Add(-time.Hour),
+		}
+		if err := s.Cupons.AdicionarCupom(s.ctx, cupom); err != nil {
+			t.Fatalf("erro ao criar o cupom: %v", err)
+		}
+
+		// Uma unidade de brownie na linha e duas como componente de um combo pedido duas vezes
+		pedido := s.novoPedido("Maria", brownie, suco)
+		pedido.Itens[1].Quantidade = 2
+		pedido.Itens[1].Componentes = []entities.ItemCombo{{Produto: *s.buscarProduto(brownie.ID), Quantidade: 1}}
+		codigo := cupom.Codigo
+		pedido.Cupom = &codigo
+		assert.NoError(t, s.Pedidos.CriarPedido(s.ctx, pedido))
+		assert.Equal(t, 2, *s.buscarProduto(brownie.ID).Estoque)
+		usado, _ := s.Cupons.BuscarCupomPorCodigo(s.ctx, "VOLTE10")
+		assert.Equal(t, 1, usado.Usos)
+
+		// O suco passa a controlar o estoque depois da compra: nada dele foi reservado
+		zero := 0
+		assert.NoError(t, s.Produtos.DefinirEstoque(s.ctx, suco.ID, &zero))
+
+		if err := pedido.Cancelar(entities.CanceladoPelaLoja, "Falta de ingrediente", s.agora); err != nil {
+			t.Fatalf("erro ao cancelar o pedido: %v", err)
+		}
+		assert.NoError(t, s.Pedidos.CancelarPedido(s.ctx, pedido))
+		assert.Equal(t, 5, *s.buscarProduto(brownie.ID).Estoque)
+		assert.Equal(t, 0, *s.buscarProduto(suco.ID).Estoque)
+		devolvido, _ := s.Cupons.BuscarCupomPorCodigo(s.ctx, "VOLTE10")
+		assert.Equal(t, 0, devolvido.Usos)
+	})
+
+	t.Run("busca pelo código de retirada do dia", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		pedido := s.pedido("Maria", s.produto("Suco", "Bebida", 700, -1))
+
+		encontrado, err := s.Pedidos.BuscarPedidoPorCodigo(s.ctx, "a-001", s.agora)
+		if assert.NoError(t, err) {
+			assert.Equal(t, pedido.ID, encontrado.ID)
+			assert.Len(t, encontrado.Itens, 1)
+		}
+		_, err = s.Pedidos.BuscarPedidoPorCodigo(s.ctx, "A-001", s.agora.AddDate(0, 0, -1))
+		assert.Error(t, err, "código de outro dia")
+		_, err = s.Pedidos.BuscarPedidoPorCodigo(s.ctx, "A-002", s.agora)
+		assert.Error(t, err, "código não usado")
+	})
+
+	t.Run("lista a fila da cozinha por ordem de chegada", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Suco", "Bebida", 700, -1)
+		s.pedido("Ana", produto)
+		recebido := s.pedido("Bia", produto)
+		emPreparacao := s.pedido("Caio", produto)
+		pronto := s.pedido("Duda", produto)
+		for pedido, status := range map[int]entities.StatusPedido{
+			recebido.ID:     entities.Recebido,
+			emPreparacao.ID: entities.EmPreparacao,
+			pronto.ID:       entities.Pronto,
+		} {
+			assert.NoError(t, s.Pedidos.AtualizarStatusPedido(s.ctx, pedido, string(entities.Pendente), string(status), s.agora.Add(time.Minute)))
+		}
+		previsao := s.agora.Add(20 * time.Minute)
+		recebido.PrevisaoPronto = &previsao
+		assert.NoError(t, s.Pedidos.AtualizarPrevisoes(s.ctx, []*entities.Pedido{recebido}))
+
+		fila, err := s.Pedidos.ListarFilaCozinha(s.ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, fila, 2) {
+			assert.Equal(t, recebido.ID, fila[0].ID)
+			assert.Equal(t, entities.Recebido, fila[0].Status)
+			assert.Equal(t, recebido.TempoEstimado, fila[0].TempoEstimado)
+			if assert.NotNil(t, fila[0].PrevisaoPronto) {
+				assert.True(t, previsao.Equal(*fila[0].PrevisaoPronto))
+			}
+			assert.Equal(t, emPreparacao.ID, fila[1].ID)
+			assert.Nil(t, fila[1].PrevisaoPronto)
+		}
+	})
+
+	t.Run("consulta os pedidos do cliente em páginas", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Suco", "Bebida", 700, -1)
+		porCPF := s.novoPedido("Maria", produto)
+		porCPF.Cliente = &entities.ReferenciaCliente{CPF: "52998224725"}
+		porID := s.novoPedido("Maria", produto)
+		porID.Cliente = &entities.ReferenciaCliente{IDExterno: "cliente-42"}
+		for _, pedido := range []*entities.Pedido{porCPF, porID} {
+			if err := s.Pedidos.CriarPedido(s.ctx, pedido); err != nil {
+				t.Fatalf("erro ao criar o pedido: %v", err)
+			}
+		}
+		s.pedido("Maria", produto)
+
+		consulta := consultaPedidos(t, entities.ConsultaPedidos{
+			Cliente: &entities.ReferenciaCliente{CPF: "52998224725", IDExterno: "cliente-42"},
+			Limite:  1,
+		})
+		primeira, err := s.Pedidos.ConsultarPedidos(s.ctx, consulta)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, primeira.Total)
+		assert.NotEmpty(t, primeira.ProximoCursor)
+		if assert.Len(t, primeira.Itens, 1) {
+			assert.Len(t, primeira.Itens[0].Itens, 1)
+		}
+		ids := consultarTudo(t, idsPedidos, func(cursor *entities.Cursor) (*entities.Pagina[*entities.Pedido], error) {
+			consulta.Cursor = cursor
+			return s.Pedidos.ConsultarPedidos(s.ctx, consulta)
+		})
+		assert.Equal(t, []int{porCPF.ID, porID.ID}, ids)
+
+		outro, err := s.Pedidos.ConsultarPedidos(s.ctx, consultaPedidos(t, entities.ConsultaPedidos{Cliente: &entities.ReferenciaCliente{CPF: "11144477735"}}))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, outro.Total)
+		assert.Empty(t, outro.Itens)
+	})
+
+	t.Run("consulta os pedidos filtrados e paginados", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		suco := s.produto("Suco", "Bebida", 700, -1)
+		lanche := s.produto("X-Egg", "Lanche", 2400, -1)
+		jose := s.pedido("José", suco, lanche)
+		ana := s.pedido("Ana", suco)
+		josefa := s.pedido("Josefa", lanche)
+		s.pedido("Bia", suco)
+		assert.NoError(t, s.Pedidos.AtualizarStatusPedido(s.ctx, ana.ID, string(entities.Pendente), string(entities.Recebido), s.agora.Add(time.Minute)))
+
+		// O nome é comparado sem diferenciar maiúsculas nem acentos
+		consulta := consultaPedidos(t, entities.ConsultaPedidos{
+			ClienteNome: "jose",
+			Ordem:       entities.Ordenacao{Campo: entities.OrdemPedidoTotal, Decrescente: true},
+			Limite:      1,
+		})
+		ids := consultarTudo(t, idsPedidos, func(cursor *entities.Cursor) (*entities.Pagina[*entities.Pedido], error) {
+			consulta.Cursor = cursor
+			return s.Pedidos.ConsultarPedidos(s.ctx, consulta)
+		})
+		assert.Equal(t, []int{jose.ID, josefa.ID}, ids)
+
+		pagina, err := s.Pedidos.ConsultarPedidos(s.ctx, consultaPedidos(t, entities.ConsultaPedidos{Status: entities.Pendente, Limite: 2}))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, pagina.Total)
+		if assert.Len(t, pagina.Itens, 2) {
+			assert.Equal(t, jose.ID, pagina.Itens[0].ID)
+			assert.Len(t, pagina.Itens[0].Itens, 2)
+		}
+
+		// Sem filtros, os pedidos de mesmo instante saem na ordem de criação
+		todos := consultaPedidos(t, entities.ConsultaPedidos{Limite: 3})
+		ids = consultarTudo(t, idsPedidos, func(cursor *entities.Cursor) (*entities.Pagina[*entities.Pedido], error) {
+			todos.Cursor = cursor
+			return s.Pedidos.ConsultarPedidos(s.ctx, todos)
+		})
+		assert.Len(t, ids, 4)
+		assert.IsIncreasing(t, ids)
+
+		_, err = s.Pedidos.ConsultarPedidos(s.ctx, entities.ConsultaPedidos{Ordem: entities.Ordenacao{Campo: "cliente"}, Limite: 1})
+		assert.True(t, errors.Is(err, entities.ErrOrdemInvalida))
+	})
+}
+
+// consultaPedidos completa a consulta com a ordenação e o limite padrão
+func consultaPedidos(t *testing.T, consulta entities.ConsultaPedidos) entities.ConsultaPedidos {
+	if err := consulta.Validar(); err != nil {
+		t.Fatalf("consulta inválida: %v", err)
+	}
+	return consulta
+}
+
+func idsPedidos(pedidos []*entities.Pedido) []int {
+	var ids []int
+	for _, pedido := range pedidos {
+		ids = append(ids, pedido.ID)
+	}
+	return ids
+}
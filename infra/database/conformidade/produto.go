@@ -0,0 +1,274 @@
+package conformidade
+
+import (
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestarProdutoRepository confere o cadastro, a edição, o arquivamento, o estoque e as listagens do cardápio
+func TestarProdutoRepository(t *testing.T, fabrica Fabrica) {
+	t.Run("adiciona e busca pelo ID", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := &entities.Produto{
+			Nome:                  "X-Salada",
+			Categoria:             "Lanche",
+			Descricao:             "Lanche com tomate e alface",
+			Preco:                 entities.Centavos(2250),
+			TempoPreparoMinutos:   10,
+			InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoOvo}},
+		}
+		assert.NoError(t, s.Produtos.AdicionarProduto(s.ctx, produto))
+		assert.Positive(t, produto.ID)
+
+		salvo := s.buscarProduto(produto.ID)
+		assert.Equal(t, "X-Salada", salvo.Nome)
+		assert.Equal(t, entities.CatProduto("Lanche"), salvo.Categoria)
+		assert.Equal(t, "Lanche com tomate e alface", salvo.Descricao)
+		assert.Equal(t, int64(2250), salvo.Preco.Centavos)
+		assert.Equal(t, 10, salvo.TempoPreparoMinutos)
+		assert.Equal(t, []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoOvo}, salvo.Alergenos)
+		assert.Nil(t, salvo.Estoque)
+		assert.Nil(t, salvo.ArquivadoEm)
+		assert.False(t, salvo.Esgotado)
+	})
+
+	t.Run("produto inexistente não é encontrado", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		_, err := s.Produtos.BuscarProdutoPorId(s.ctx, 999)
+		assert.Error(t, err)
+	})
+
+	t.Run("recusa produto de categoria não cadastrada", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := &entities.Produto{Nome: "Pastel", Categoria: "Salgado", Preco: entities.Centavos(800)}
+		assert.Error(t, s.Produtos.AdicionarProduto(s.ctx, produto))
+
+		pagina, err := s.Produtos.ConsultarProdutos(s.ctx, consultaProdutos(t, entities.ConsultaProdutos{}))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, pagina.Total)
+	})
+
+	t.Run("edita o produto pelo ID, inclusive o nome", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Coca-cola", "Bebida", 600, -1)
+		outro := s.produto("Guaraná", "Bebida", 550, -1)
+
+		produto.Nome = "Coca-cola Zero"
+		produto.Preco = entities.Centavos(650)
+		produto.Descricao = "Lata 350ml"
+		assert.NoError(t, s.Produtos.EditarProduto(s.ctx, produto))
+
+		salvo := s.buscarProduto(produto.ID)
+		assert.Equal(t, "Coca-cola Zero", salvo.Nome)
+		assert.Equal(t, int64(650), salvo.Preco.Centavos)
+		assert.Equal(t, "Lata 350ml", salvo.Descricao)
+		assert.Equal(t, "Guaraná", s.buscarProduto(outro.ID).Nome)
+
+		// Regravar os mesmos valores não é erro, mas um produto inexistente é
+		assert.NoError(t, s.Produtos.EditarProduto(s.ctx, produto), "edição sem alterações")
+		inexistente := *produto
+		inexistente.ID = 999
+		assert.ErrorIs(t, s.Produtos.EditarProduto(s.ctx, &inexistente), entities.ErrProdutoNaoEncontrado)
+	})
+
+	t.Run("arquiva e restaura o produto", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("Fanta", "Bebida", 550, -1)
+		s.produto("Sprite", "Bebida", 550, -1)
+
+		arquivadoEm := s.agora.Add(-time.Hour)
+		produto.ArquivadoEm = &arquivadoEm
+		assert.NoError(t, s.Produtos.ArquivarProduto(s.ctx, produto))
+		assert.ErrorIs(t, s.Produtos.ArquivarProduto(s.ctx, produto), entities.ErrProdutoArquivado)
+
+		salvo := s.buscarProduto(produto.ID)
+		if assert.NotNil(t, salvo.ArquivadoEm) {
+			assert.True(t, arquivadoEm.Equal(*salvo.ArquivadoEm))
+		}
+		bebidas, err := s.Produtos.ListarPorCategoria(s.ctx, "Bebida")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Sprite"}, nomes(bebidas))
+		pagina, err := s.Produtos.ConsultarProdutos(s.ctx, consultaProdutos(t, entities.ConsultaProdutos{IncluirIndisponiveis: true}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Sprite"}, nomes(pagina.Itens))
+
+		assert.NoError(t, s.Produtos.RestaurarProduto(s.ctx, produto))
+		assert.ErrorIs(t, s.Produtos.RestaurarProduto(s.ctx, produto), entities.ErrProdutoNaoArquivado)
+		assert.Nil(t, s.buscarProduto(produto.ID).ArquivadoEm)
+
+		inexistente := entities.Produto{ID: produto.ID + 100, ArquivadoEm: &arquivadoEm}
+		assert.ErrorIs(t, s.Produtos.ArquivarProduto(s.ctx, &inexistente), entities.ErrProdutoNaoEncontrado)
+		assert.ErrorIs(t, s.Produtos.RestaurarProduto(s.ctx, &inexistente), entities.ErrProdutoNaoEncontrado)
+	})
+
+	t.Run("atualiza o estoque e a disponibilidade em colunas separadas", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		produto := s.produto("X-Bacon", "Lanche", 2800, 5)
+		assert.Equal(t, 5, *s.buscarProduto(produto.ID).Estoque)
+
+		assert.NoError(t, s.Produtos.AtualizarDisponibilidade(s.ctx, produto.ID, true))
+		salvo := s.buscarProduto(produto.ID)
+		assert.True(t, salvo.Esgotado)
+		assert.Equal(t, 5, *salvo.Estoque, "marcar esgotado não pode regravar o estoque")
+
+		estoque := 3
+		assert.NoError(t, s.Produtos.DefinirEstoque(s.ctx, produto.ID, &estoque))
+		salvo = s.buscarProduto(produto.ID)
+		assert.True(t, salvo.Esgotado, "ajustar o estoque não pode regravar a disponibilidade")
+		assert.Equal(t, 3, *salvo.Estoque)
+
+		assert.NoError(t, s.Produtos.DefinirEstoque(s.ctx, produto.ID, nil))
+		assert.Nil(t, s.buscarProduto(produto.ID).Estoque)
+
+		// Regravar o mesmo valor não é erro, mas um produto inexistente é
+		assert.NoError(t, s.Produtos.AtualizarDisponibilidade(s.ctx, produto.ID, true))
+		assert.ErrorIs(t, s.Produtos.AtualizarDisponibilidade(s.ctx, 999, true), entities.ErrProdutoNaoEncontrado)
+		assert.ErrorIs(t, s.Produtos.DefinirEstoque(s.ctx, 999, nil), entities.ErrProdutoNaoEncontrado)
+	})
+
+	t.Run("lista por categoria na ordem de cadastro", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		s.produto("Suco", "Bebida", 700, -1)
+		s.produto("X-Egg", "Lanche", 2400, -1)
+		s.produto("Água", "Bebida", 400, -1)
+
+		// A categoria é comparada sem diferenciar maiúsculas
+		bebidas, err := s.Produtos.ListarPorCategoria(s.ctx, "bebida")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Suco", "Água"}, nomes(bebidas))
+
+		vazia, err := s.Produtos.ListarPorCategoria(s.ctx, "Sobremesa")
+		assert.NoError(t, err)
+		assert.Empty(t, vazia)
+	})
+
+	t.Run("lista cada combo com os próprios componentes", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		categoria, err := entities.CategoriaNew(string(entities.Combo), 3, true, false)
+		if err != nil {
+			t.Fatalf("erro ao montar a categoria Combo: %v", err)
+		}
+		if err := s.Categorias.AdicionarCategoria(s.ctx, categoria); err != nil {
+			t.Fatalf("erro ao criar a categoria Combo: %v", err)
+		}
+		lanche := s.produto("X-Salada", "Lanche", 2250, -1)
+		suco := s.produto("Suco", "Bebida", 700, -1)
+		for _, combo := range []*entities.Produto{
+			{Nome: "Combo Salada", Categoria: entities.Combo, Preco: entities.Centavos(2700), Componentes: []entities.ComponenteCombo{
+				{Produto: lanche, Quantidade: 1},
+				{Produto: suco, Quantidade: 1},
+			}},
+			{Nome: "Combo Bebida", Categoria: entities.Combo, Preco: entities.Centavos(1200), Componentes: []entities.ComponenteCombo{
+				{Categoria: "Bebida", Quantidade: 2},
+			}},
+		} {
+			if err := s.Produtos.AdicionarProduto(s.ctx, combo); err != nil {
+				t.Fatalf("erro ao criar o combo %s: %v", combo.Nome, err)
+			}
+		}
+
+		combos, err := s.Produtos.ListarPorCategoria(s.ctx, string(entities.Combo))
+
+		assert.NoError(t, err)
+		if assert.Len(t, combos, 2) {
+			if assert.Len(t, combos[0].Componentes, 2) {
+				assert.Equal(t, "X-Salada", combos[0].Componentes[0].Produto.Nome)
+				assert.Equal(t, "Suco", combos[0].Componentes[1].Produto.Nome)
+			}
+			if assert.Len(t, combos[1].Componentes, 1) {
+				assert.Nil(t, combos[1].Componentes[0].Produto)
+				assert.Equal(t, entities.CatProduto("Bebida"), combos[1].Componentes[0].Categoria)
+				assert.Equal(t, 2, combos[1].Componentes[0].Quantidade)
+			}
+		}
+		bebidas, err := s.Produtos.ListarPorCategoria(s.ctx, "Bebida")
+		assert.NoError(t, err)
+		if assert.Len(t, bebidas, 1) {
+			assert.Nil(t, bebidas[0].Componentes, "só os combos têm componentes")
+		}
+	})
+
+	t.Run("consulta o cardápio filtrado e paginado", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		agua := s.produto("Água", "Bebida", 400, -1)
+		suco := s.produto("Suco", "Bebida", 700, -1)
+		cha := s.produto("Chá", "Bebida", 400, -1)
+		s.produto("X-Egg", "Lanche", 2400, -1)
+		esgotado := s.produto("Refrigerante", "Bebida", 600, 0)
+
+		consulta := consultaProdutos(t, entities.ConsultaProdutos{
+			Categoria: "Bebida",
+			Ordem:     entities.Ordenacao{Campo: entities.OrdemProdutoPreco, Decrescente: true},
+			Limite:    2,
+		})
+		primeira, err := s.Produtos.ConsultarProdutos(s.ctx, consulta)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, primeira.Total)
+		assert.Len(t, primeira.Itens, 2)
+		assert.NotEmpty(t, primeira.ProximoCursor)
+
+		// Preços iguais são desempatados pelo ID, na mesma direção da ordenação
+		ids := consultarTudo(t, idsProdutos, func(cursor *entities.Cursor) (*entities.Pagina[*entities.Produto], error) {
+			consulta.Cursor = cursor
+			return s.Produtos.ConsultarProdutos(s.ctx, consulta)
+		})
+		assert.Equal(t, []int{suco.ID, cha.ID, agua.ID}, ids)
+
+		consulta = consultaProdutos(t, entities.ConsultaProdutos{Categoria: "Bebida", IncluirIndisponiveis: true})
+		todas, err := s.Produtos.ConsultarProdutos(s.ctx, consulta)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, todas.Total)
+		assert.Equal(t, []int{agua.ID, cha.ID, esgotado.ID, suco.ID}, idsProdutos(todas.Itens))
+	})
+
+	t.Run("esconde do cardápio os produtos de categoria inativa", func(t *testing.T) {
+		s := novoCenario(t, fabrica)
+		sobremesa, err := entities.CategoriaNew("Sobremesa", 3, false, false)
+		assert.NoError(t, err)
+		assert.NoError(t, sobremesa.DefinirTempoPreparo(3))
+		assert.NoError(t, s.Categorias.AdicionarCategoria(s.ctx, sobremesa))
+		salva, err := s.Categorias.BuscarCategoriaPorId(s.ctx, sobremesa.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, salva.TempoPreparoMinutos)
+
+		lanche := s.produto("X-Egg", "Lanche", 2400, -1)
+		pudim := s.produto("Pudim", "Sobremesa", 900, -1)
+
+		cardapio, err := s.Produtos.ConsultarProdutos(s.ctx, consultaProdutos(t, entities.ConsultaProdutos{}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cardapio.Total)
+		assert.Equal(t, []int{lanche.ID}, idsProdutos(cardapio.Itens))
+
+		todos, err := s.Produtos.ConsultarProdutos(s.ctx, consultaProdutos(t, entities.ConsultaProdutos{IncluirIndisponiveis: true}))
+		assert.NoError(t, err)
+		assert.Equal(t, []int{pudim.ID, lanche.ID}, idsProdutos(todos.Itens))
+	})
+}
+
+// consultaProdutos completa a consulta com a ordenação e o limite padrão
+func consultaProdutos(t *testing.T, consulta entities.ConsultaProdutos) entities.ConsultaProdutos {
+	if err := consulta.Validar(); err != nil {
+		t.Fatalf("consulta inválida: %v", err)
+	}
+	return consulta
+}
+
+func nomes(produtos []*entities.Produto) []string {
+	var nomes []string
+	for _, produto := range produtos {
+		nomes = append(nomes, produto.Nome)
+	}
+	return nomes
+}
+
+func idsProdutos(produtos []*entities.Produto) []int {
+	var ids []int
+	for _, produto := range produtos {
+		ids = append(ids, produto.ID)
+	}
+	return ids
+}
@@ -0,0 +1,146 @@
+// Package memoria implementa os repositórios guardando os dados em memória, para rodar o serviço
+// localmente sem MySQL e para testes. Os repositórios reproduzem o comportamento das implementações
+// MySQL, inclusive os erros, e as duas passam pela mesma suíte de conformidade.
+package memoria
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// Banco guarda as tabelas compartilhadas pelos repositórios em memória. Como no MySQL, um repositório
+// enxerga o que os outros gravaram: os produtos trazem os grupos de modificadores e as imagens, e os
+// pedidos baixam o estoque dos produtos e contam o uso dos cupons.
+type Banco struct {
+	mu sync.Mutex
+	tabelas
+}
+
+func NewBanco() *Banco {
+	return &Banco{tabelas: tabelas{
+		produtos:   map[int]produtoLinha{},
+		imagens:    map[int][]entities.ImagemProduto{},
+		pedidos:    map[int]pedidoLinha{},
+		sequencias: map[string]int{},
+		ultimoID:   map[string]int{},
+	}}
+}
+
+// tabelas guarda as linhas como valores. Uma alteração substitui a linha inteira, sem mexer no que
+// ela referencia, então copiar os mapas e as fatias basta para guardar o estado antes de uma transação.
+type tabelas struct {
+	categorias  []entities.Categoria
+	produtos    map[int]produtoLinha
+	componentes []componenteLinha
+	grupos      []entities.GrupoModificador
+	imagens     map[int][]entities.ImagemProduto
+	versoes     []entities.VersaoProduto
+	cupons      []entities.Cupom
+	pedidos     map[int]pedidoLinha
+	itens       []itemLinha
+	sequencias  map[string]int
+	historico   []entities.AlteracaoPedido
+	eventos     []entities.EventoOutbox
+	// Último ID gerado por tabela, como o AUTO_INCREMENT, que não volta atrás quando a transação é desfeita
+	ultimoID map[string]int
+}
+
+func (t tabelas) copiar() tabelas {
+	return tabelas{
+		categorias:  slices.Clone(t.categorias),
+		produtos:    maps.Clone(t.produtos),
+		componentes: slices.Clone(t.componentes),
+		grupos:      slices.Clone(t.grupos),
+		imagens:     maps.Clone(t.imagens),
+		versoes:     slices.Clone(t.versoes),
+		cupons:      slices.Clone(t.cupons),
+		pedidos:     maps.Clone(t.pedidos),
+		itens:       slices.Clone(t.itens),
+		sequencias:  maps.Clone(t.sequencias),
+		historico:   slices.Clone(t.historico),
+		eventos:     slices.Clone(t.eventos),
+		ultimoID:    t.ultimoID,
+	}
+}
+
+// proximoID gera o ID da próxima linha da tabela
+func (t *tabelas) proximoID(tabela string) int {
+	t.ultimoID[tabela]++
+	return t.ultimoID[tabela]
+}
+
+type chaveTransacao struct{}
+
+// travar dá ao chamador acesso exclusivo às tabelas e devolve a função que o libera. Dentro de
+// Transacao.Executar a trava já pertence à transação.
+func (b *Banco) travar(c context.Context) func() {
+	if tx, ok := c.Value(chaveTransacao{}).(*Banco); ok && tx == b {
+		return func() {}
+	}
+	b.mu.Lock()
+	return b.mu.Unlock
+}
+
+type transacaoMemoria struct {
+	banco *Banco
+}
+
+func NewTransacaoMemoria(banco *Banco) repository.Transacao {
+	return &transacaoMemoria{banco: banco}
+}
+
+// Executar segura as tabelas até fn terminar e, se fn falhar, volta ao estado de antes. As transações
+// ficam em fila, como se todas fossem serializáveis.
+func (t *transacaoMemoria) Executar(c context.Context, fn func(c context.Context) error) error {
+	if tx, ok := c.Value(chaveTransacao{}).(*Banco); ok && tx == t.banco {
+		return fn(c)
+	}
+
+	t.banco.mu.Lock()
+	defer t.banco.mu.Unlock()
+
+	antes := t.banco.tabelas.copiar()
+	if err := fn(context.WithValue(c, chaveTransacao{}, t.banco)); err != nil {
+		t.banco.tabelas = antes
+		return err
+	}
+	return nil
+}
+
+// datetime reproduz uma coluna DATETIME: precisão de segundos, lida em UTC
+func datetime(t time.Time) time.Time {
+	return t.Round(time.Second).UTC()
+}
+
+// datetime3 reproduz uma coluna DATETIME(3), com milissegundos
+func datetime3(t time.Time) time.Time {
+	return t.Round(time.Millisecond).UTC()
+}
+
+func datetimeOuNulo(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	valor := datetime(*t)
+	return &valor
+}
+
+// decimal reproduz uma coluna DECIMAL(10,2), que guarda os centavos e volta na moeda padrão
+func decimal(valor entities.Money) entities.Money {
+	return entities.Centavos(valor.Centavos)
+}
+
+// copia devolve um ponteiro para uma cópia do valor, para que a linha guardada não mude junto com a entidade
+func copia[T any](valor *T) *T {
+	if valor == nil {
+		return nil
+	}
+	novo := *valor
+	return &novo
+}
@@ -0,0 +1,74 @@
+package memoria
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransacaoMemoria_DesfazQuandoFalha(t *testing.T) {
+	banco := NewBanco()
+	categorias := NewCategoriaMemoriaRepository(banco)
+	ctx := context.Background()
+
+	falha := errors.New("falha")
+	err := NewTransacaoMemoria(banco).Executar(ctx, func(c context.Context) error {
+		if err := categorias.AdicionarCategoria(c, &entities.Categoria{Nome: "Lanche", Slug: "lanche", Ativa: true}); err != nil {
+			return err
+		}
+		return falha
+	})
+	assert.ErrorIs(t, err, falha)
+
+	lista, err := categorias.ListarCategorias(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, lista)
+
+	// Como o AUTO_INCREMENT, o ID usado pela transação desfeita não volta a ser gerado
+	categoria := &entities.Categoria{Nome: "Bebida", Slug: "bebida", Ativa: true}
+	assert.NoError(t, categorias.AdicionarCategoria(ctx, categoria))
+	assert.Equal(t, 2, categoria.ID)
+}
+
+func TestPedidoMemoriaRepository_CriarPedidoConcorrente(t *testing.T) {
+	banco := NewBanco()
+	ctx := context.Background()
+	assert.NoError(t, NewCategoriaMemoriaRepository(banco).AdicionarCategoria(ctx, &entities.Categoria{Nome: "Lanche", Slug: "lanche", Ativa: true}))
+	estoque := 5
+	produto := &entities.Produto{Nome: "Brownie", Categoria: "Lanche", Preco: entities.Centavos(900), Estoque: &estoque}
+	produtos := NewProdutoMemoriaRepository(banco)
+	assert.NoError(t, produtos.AdicionarProduto(ctx, produto))
+
+	// Vinte pedidos disputam as cinco unidades: só cinco são gravados e o estoque nunca fica negativo
+	pedidos := NewPedidoMemoriaRepository(banco)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	gravados := 0
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pedido := &entities.Pedido{
+				Itens:             []entities.ItemPedido{{Produto: *produto, Quantidade: 1}},
+				UltimaAtualizacao: time.Now(),
+			}
+			if err := pedidos.CriarPedido(ctx, pedido); err == nil {
+				mu.Lock()
+				gravados++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 5, gravados)
+	salvo, err := produtos.BuscarProdutoPorId(ctx, produto.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, *salvo.Estoque)
+}
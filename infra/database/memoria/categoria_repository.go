@@ -0,0 +1,165 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type categoriaMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewCategoriaMemoriaRepository(banco *Banco) repository.CategoriaRepository {
+	return &categoriaMemoriaRepository{banco: banco}
+}
+
+func (cr *categoriaMemoriaRepository) AdicionarCategoria(c context.Context, categoria *entities.Categoria) error {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	if err := t.verificarCategoriaUnica(*categoria); err != nil {
+		return fmt.Errorf("erro ao inserir categoria: %w", err)
+	}
+	categoria.ID = t.proximoID("Categoria")
+	t.categorias = append(t.categorias, *categoria)
+	return nil
+}
+
+func (cr *categoriaMemoriaRepository) BuscarCategoriaPorId(c context.Context, id int) (*entities.Categoria, error) {
+	return cr.buscarCategoria(c, func(categoria entities.Categoria) bool { return categoria.ID == id })
+}
+
+func (cr *categoriaMemoriaRepository) BuscarCategoriaPorNome(c context.Context, nome string) (*entities.Categoria, error) {
+	// Diferencia maiúsculas e minúsculas, como o BINARY da consulta MySQL
+	return cr.buscarCategoria(c, func(categoria entities.Categoria) bool { return string(categoria.Nome) == nome })
+}
+
+func (cr *categoriaMemoriaRepository) BuscarCategoriaPorSlug(c context.Context, slug string) (*entities.Categoria, error) {
+	return cr.buscarCategoria(c, func(categoria entities.Categoria) bool { return compararTexto(categoria.Slug, slug) == 0 })
+}
+
+func (cr *categoriaMemoriaRepository) buscarCategoria(c context.Context, encontrada func(entities.Categoria) bool) (*entities.Categoria, error) {
+	defer cr.banco.travar(c)()
+
+	for _, categoria := range cr.banco.categorias {
+		if encontrada(categoria) {
+			return &categoria, nil
+		}
+	}
+	return nil, entities.ErrCategoriaNaoEncontrada
+}
+
+func (cr *categoriaMemoriaRepository) ListarCategorias(c context.Context) ([]*entities.Categoria, error) {
+	defer cr.banco.travar(c)()
+
+	var categorias []*entities.Categoria
+	for _, categoria := range cr.banco.categorias {
+		categorias = append(categorias, &categoria)
+	}
+	slices.SortStableFunc(categorias, func(a, b *entities.Categoria) int {
+		return cmp.Or(cmp.Compare(a.Ordem, b.Ordem), compararTexto(string(a.Nome), string(b.Nome)))
+	})
+	return categorias, nil
+}
+
+// EditarCategoria leva o novo nome aos produtos, combos e cupons, como o ON UPDATE CASCADE das chaves estrangeiras
+func (cr *categoriaMemoriaRepository) EditarCategoria(c context.Context, categoria *entities.Categoria) error {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	i := slices.IndexFunc(t.categorias, func(existente entities.Categoria) bool { return existente.ID == categoria.ID })
+	if i < 0 {
+		return entities.ErrCategoriaNaoEncontrada
+	}
+	if err := t.verificarCategoriaUnica(*categoria); err != nil {
+		return fmt.Errorf("erro ao atualizar categoria: %w", err)
+	}
+
+	anterior := t.categorias[i].Nome
+	t.categorias[i] = *categoria
+	if anterior == categoria.Nome {
+		return nil
+	}
+	for id, produto := range t.produtos {
+		if produto.categoria == anterior {
+			produto.categoria = categoria.Nome
+			t.produtos[id] = produto
+		}
+	}
+	for i := range t.componentes {
+		if t.componentes[i].categoria == anterior {
+			t.componentes[i].categoria = categoria.Nome
+		}
+	}
+	for i := range t.cupons {
+		if t.cupons[i].Categoria == anterior {
+			t.cupons[i].Categoria = categoria.Nome
+		}
+	}
+	return nil
+}
+
+func (cr *categoriaMemoriaRepository) RemoverCategoria(c context.Context, id int) error {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	i := slices.IndexFunc(t.categorias, func(categoria entities.Categoria) bool { return categoria.ID == id })
+	if i < 0 {
+		return entities.ErrCategoriaNaoEncontrada
+	}
+	if t.categoriaEmUso(t.categorias[i].Nome) {
+		return entities.ErrCategoriaEmUso
+	}
+	t.categorias = slices.Delete(t.categorias, i, i+1)
+	return nil
+}
+
+// verificarCategoriaUnica recusa nome ou slug já usados por outra categoria, como os índices únicos da tabela
+func (t *tabelas) verificarCategoriaUnica(categoria entities.Categoria) error {
+	for _, existente := range t.categorias {
+		if existente.ID == categoria.ID {
+			continue
+		}
+		if compararTexto(string(existente.Nome), string(categoria.Nome)) == 0 || compararTexto(existente.Slug, categoria.Slug) == 0 {
+			return fmt.Errorf("já existe a categoria %q", existente.Nome)
+		}
+	}
+	return nil
+}
+
+// existeCategoria confere a chave estrangeira das tabelas que referenciam a categoria pelo nome
+func (t *tabelas) existeCategoria(nome entities.CatProduto) bool {
+	return slices.ContainsFunc(t.categorias, func(categoria entities.Categoria) bool {
+		return compararTexto(string(categoria.Nome), string(nome)) == 0
+	})
+}
+
+func (t *tabelas) categoriaAtiva(nome entities.CatProduto) bool {
+	return slices.ContainsFunc(t.categorias, func(categoria entities.Categoria) bool {
+		return categoria.Ativa && compararTexto(string(categoria.Nome), string(nome)) == 0
+	})
+}
+
+func (t *tabelas) categoriaEmUso(nome entities.CatProduto) bool {
+	for _, produto := range t.produtos {
+		if compararTexto(string(produto.categoria), string(nome)) == 0 {
+			return true
+		}
+	}
+	for _, componente := range t.componentes {
+		if compararTexto(string(componente.categoria), string(nome)) == 0 {
+			return true
+		}
+	}
+	for _, cupom := range t.cupons {
+		if compararTexto(string(cupom.Categoria), string(nome)) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package memoria
+
+import (
+	"testing"
+
+	"lanchonete/infra/database/conformidade"
+)
+
+func novosRepositorios(t *testing.T) conformidade.Repositorios {
+	banco := NewBanco()
+	return conformidade.Repositorios{
+		Categorias:    NewCategoriaMemoriaRepository(banco),
+		Produtos:      NewProdutoMemoriaRepository(banco),
+		Pedidos:       NewPedidoMemoriaRepository(banco),
+		Cupons:        NewCupomMemoriaRepository(banco),
+		Modificadores: NewModificadorMemoriaRepository(banco),
+	}
+}
+
+func TestProdutoMemoriaRepository_Conformidade(t *testing.T) {
+	conformidade.TestarProdutoRepository(t, novosRepositorios)
+}
+
+func TestPedidoMemoriaRepository_Conformidade(t *testing.T) {
+	conformidade.TestarPedidoRepository(t, novosRepositorios)
+}
+
+func TestModificadorMemoriaRepository_Conformidade(t *testing.T) {
+	conformidade.TestarModificadorRepository(t, novosRepositorios)
+}
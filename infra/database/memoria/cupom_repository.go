@@ -0,0 +1,107 @@
+package memoria
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type cupomMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewCupomMemoriaRepository(banco *Banco) repository.CupomRepository {
+	return &cupomMemoriaRepository{banco: banco}
+}
+
+func (cr *cupomMemoriaRepository) AdicionarCupom(c context.Context, cupom *entities.Cupom) error {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	if t.indiceCupom(cupom.Codigo) >= 0 {
+		return fmt.Errorf("erro ao inserir cupom: já existe o cupom %s", cupom.Codigo)
+	}
+	if cupom.Categoria != "" && !t.existeCategoria(cupom.Categoria) {
+		return fmt.Errorf("erro ao inserir cupom: a categoria %q não existe", cupom.Categoria)
+	}
+
+	cupom.ID = t.proximoID("Cupom")
+	linha := cupomPersistido(*cupom)
+	linha.Usos = 0
+	t.cupons = append(t.cupons, linha)
+	return nil
+}
+
+func (cr *cupomMemoriaRepository) BuscarCupomPorCodigo(c context.Context, codigo string) (*entities.Cupom, error) {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	i := t.indiceCupom(codigo)
+	if i < 0 {
+		return nil, entities.ErrCupomNaoEncontrado
+	}
+	cupom := cupomPersistido(t.cupons[i])
+	return &cupom, nil
+}
+
+func (cr *cupomMemoriaRepository) ListarCupons(c context.Context) ([]*entities.Cupom, error) {
+	defer cr.banco.travar(c)()
+
+	var cupons []*entities.Cupom
+	for _, linha := range cr.banco.cupons {
+		cupom := cupomPersistido(linha)
+		cupons = append(cupons, &cupom)
+	}
+	slices.SortStableFunc(cupons, func(a, b *entities.Cupom) int { return compararTexto(a.Codigo, b.Codigo) })
+	return cupons, nil
+}
+
+// EditarCupom altera as regras do cupom; o código e a contagem de usos não mudam
+func (cr *cupomMemoriaRepository) EditarCupom(c context.Context, cupom *entities.Cupom) error {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	i := t.indiceCupom(cupom.Codigo)
+	if i < 0 {
+		return entities.ErrCupomNaoEncontrado
+	}
+	if cupom.Categoria != "" && !t.existeCategoria(cupom.Categoria) {
+		return fmt.Errorf("erro ao atualizar cupom: a categoria %q não existe", cupom.Categoria)
+	}
+
+	linha := cupomPersistido(*cupom)
+	linha.ID = t.cupons[i].ID
+	linha.Codigo = t.cupons[i].Codigo
+	linha.Usos = t.cupons[i].Usos
+	t.cupons[i] = linha
+	return nil
+}
+
+func (cr *cupomMemoriaRepository) RemoverCupom(c context.Context, codigo string) error {
+	defer cr.banco.travar(c)()
+	t := &cr.banco.tabelas
+
+	i := t.indiceCupom(codigo)
+	if i < 0 {
+		return entities.ErrCupomNaoEncontrado
+	}
+	t.cupons = slices.Delete(t.cupons, i, i+1)
+	return nil
+}
+
+// indiceCupom encontra o cupom pelo código, sem diferenciar maiúsculas, como o índice único da tabela
+func (t *tabelas) indiceCupom(codigo string) int {
+	return slices.IndexFunc(t.cupons, func(cupom entities.Cupom) bool { return compararTexto(cupom.Codigo, codigo) == 0 })
+}
+
+// cupomPersistido copia o cupom com os valores como as colunas os guardam
+func cupomPersistido(cupom entities.Cupom) entities.Cupom {
+	cupom.Valor = decimal(cupom.Valor)
+	cupom.ValorMinimo = decimal(cupom.ValorMinimo)
+	cupom.ValidoDe = datetime(cupom.ValidoDe)
+	cupom.ValidoAte = datetimeOuNulo(cupom.ValidoAte)
+	return cupom
+}
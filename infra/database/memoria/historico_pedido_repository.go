@@ -0,0 +1,49 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type historicoPedidoMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewHistoricoPedidoMemoriaRepository(banco *Banco) repository.HistoricoPedidoRepository {
+	return &historicoPedidoMemoriaRepository{banco: banco}
+}
+
+func (hr *historicoPedidoMemoriaRepository) RegistrarAlteracao(c context.Context, alteracao *entities.AlteracaoPedido) error {
+	defer hr.banco.travar(c)()
+	t := &hr.banco.tabelas
+
+	if _, ok := t.pedidos[alteracao.PedidoID]; !ok {
+		return fmt.Errorf("erro ao registrar alteração do pedido: o pedido %d não existe", alteracao.PedidoID)
+	}
+
+	alteracao.ID = t.proximoID("Pedido_Historico")
+	linha := *alteracao
+	linha.AlteradoEm = datetime(linha.AlteradoEm)
+	t.historico = append(t.historico, linha)
+	return nil
+}
+
+func (hr *historicoPedidoMemoriaRepository) ListarHistorico(c context.Context, pedidoID int) ([]entities.AlteracaoPedido, error) {
+	defer hr.banco.travar(c)()
+
+	historico := []entities.AlteracaoPedido{}
+	for _, alteracao := range hr.banco.historico {
+		if alteracao.PedidoID == pedidoID {
+			historico = append(historico, alteracao)
+		}
+	}
+	slices.SortFunc(historico, func(a, b entities.AlteracaoPedido) int {
+		return cmp.Or(a.AlteradoEm.Compare(b.AlteradoEm), cmp.Compare(a.ID, b.ID))
+	})
+	return historico, nil
+}
@@ -0,0 +1,55 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type imagemProdutoMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewImagemProdutoMemoriaRepository(banco *Banco) repository.ImagemProdutoRepository {
+	return &imagemProdutoMemoriaRepository{banco: banco}
+}
+
+func (ir *imagemProdutoMemoriaRepository) ListarImagens(c context.Context, produtoID int) ([]entities.ImagemProduto, error) {
+	defer ir.banco.travar(c)()
+
+	imagens := ir.banco.imagens[produtoID]
+	if len(imagens) == 0 {
+		return nil, nil
+	}
+	return slices.Clone(imagens), nil
+}
+
+func (ir *imagemProdutoMemoriaRepository) SalvarImagens(c context.Context, produtoID int, imagens []entities.ImagemProduto) error {
+	defer ir.banco.travar(c)()
+	t := &ir.banco.tabelas
+
+	if _, ok := t.produtos[produtoID]; !ok {
+		return fmt.Errorf("erro ao inserir imagem do produto: o produto %d não existe", produtoID)
+	}
+	for i, imagem := range imagens {
+		if slices.ContainsFunc(imagens[:i], func(outra entities.ImagemProduto) bool { return outra.Variante == imagem.Variante }) {
+			return fmt.Errorf("erro ao inserir imagem do produto: variante %s repetida", imagem.Variante)
+		}
+	}
+
+	// Guardadas na ordem em que a consulta MySQL as devolve: da maior para a menor
+	salvas := slices.Clone(imagens)
+	slices.SortStableFunc(salvas, func(a, b entities.ImagemProduto) int { return cmp.Compare(b.Largura, a.Largura) })
+	t.imagens[produtoID] = salvas
+	return nil
+}
+
+func (ir *imagemProdutoMemoriaRepository) RemoverImagens(c context.Context, produtoID int) error {
+	defer ir.banco.travar(c)()
+	delete(ir.banco.imagens, produtoID)
+	return nil
+}
@@ -0,0 +1,97 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type modificadorMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewModificadorMemoriaRepository(banco *Banco) repository.ModificadorRepository {
+	return &modificadorMemoriaRepository{banco: banco}
+}
+
+func (mr *modificadorMemoriaRepository) AdicionarGrupo(c context.Context, grupo *entities.GrupoModificador) error {
+	defer mr.banco.travar(c)()
+	t := &mr.banco.tabelas
+
+	if _, ok := t.produtos[grupo.ProdutoID]; !ok {
+		return fmt.Errorf("erro ao inserir grupo de modificadores: o produto %d não existe", grupo.ProdutoID)
+	}
+
+	grupo.ID = t.proximoID("GrupoModificador")
+	for i := range grupo.Opcoes {
+		grupo.Opcoes[i].ID = t.proximoID("Modificador")
+		grupo.Opcoes[i].GrupoID = grupo.ID
+	}
+
+	linha := *grupo
+	linha.Opcoes = make([]entities.Modificador, len(grupo.Opcoes))
+	for i, opcao := range grupo.Opcoes {
+		opcao.Preco = decimal(opcao.Preco)
+		linha.Opcoes[i] = opcao
+	}
+	t.grupos = append(t.grupos, linha)
+	return nil
+}
+
+func (mr *modificadorMemoriaRepository) ListarGruposPorProduto(c context.Context, produtoID int) ([]entities.GrupoModificador, error) {
+	defer mr.banco.travar(c)()
+	return mr.banco.gruposDoProduto(produtoID), nil
+}
+
+// RemoverGrupo apaga o grupo e suas opções; os pedidos que usaram uma opção ficam sem o ID dela,
+// como o ON DELETE SET NULL de Pedido_Produto_Modificador
+func (mr *modificadorMemoriaRepository) RemoverGrupo(c context.Context, grupoID int) error {
+	defer mr.banco.travar(c)()
+	t := &mr.banco.tabelas
+
+	i := slices.IndexFunc(t.grupos, func(grupo entities.GrupoModificador) bool { return grupo.ID == grupoID })
+	if i < 0 {
+		return fmt.Errorf("grupo de modificadores não encontrado")
+	}
+	t.removerGrupo(i)
+	return nil
+}
+
+// removerGrupo apaga o grupo no índice informado e solta as opções dele dos itens dos pedidos
+func (t *tabelas) removerGrupo(i int) {
+	removida := func(m entities.Modificador) bool {
+		return slices.ContainsFunc(t.grupos[i].Opcoes, func(opcao entities.Modificador) bool { return opcao.ID == m.ID })
+	}
+	for j, item := range t.itens {
+		if !slices.ContainsFunc(item.modificadores, removida) {
+			continue
+		}
+		modificadores := slices.Clone(item.modificadores)
+		for k := range modificadores {
+			if removida(modificadores[k]) {
+				modificadores[k].ID = 0
+			}
+		}
+		t.itens[j].modificadores = modificadores
+	}
+	t.grupos = slices.Delete(t.grupos, i, i+1)
+}
+
+// gruposDoProduto devolve os grupos do produto, inclusive os sem opções, pela ordem de cadastro, como o
+// LEFT JOIN da consulta MySQL
+func (t *tabelas) gruposDoProduto(produtoID int) []entities.GrupoModificador {
+	grupos := []entities.GrupoModificador{}
+	for _, grupo := range t.grupos {
+		if grupo.ProdutoID != produtoID {
+			continue
+		}
+		grupo.Opcoes = append([]entities.Modificador{}, grupo.Opcoes...)
+		slices.SortFunc(grupo.Opcoes, func(a, b entities.Modificador) int { return cmp.Compare(a.ID, b.ID) })
+		grupos = append(grupos, grupo)
+	}
+	return grupos
+}
@@ -0,0 +1,113 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// tamanhoMaximoErro é o tamanho da coluna ultimoErro
+const tamanhoMaximoErro = 500
+
+type outboxMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewOutboxMemoriaRepository(banco *Banco) repository.OutboxRepository {
+	return &outboxMemoriaRepository{banco: banco}
+}
+
+func (or *outboxMemoriaRepository) RegistrarEvento(c context.Context, evento *entities.EventoOutbox) error {
+	defer or.banco.travar(c)()
+	t := &or.banco.tabelas
+
+	evento.ID = int64(t.proximoID("Outbox"))
+	linha := eventoPersistido(*evento)
+	linha.UltimoErro = ""
+	linha.PublicadoEm = nil
+	t.eventos = append(t.eventos, linha)
+	return nil
+}
+
+// ListarPendentes não precisa do SKIP LOCKED: dentro de Transacao.Executar o relay segura o banco inteiro
+func (or *outboxMemoriaRepository) ListarPendentes(c context.Context, agora time.Time, limite int) ([]*entities.EventoOutbox, error) {
+	eventos := or.listarEventos(c, func(evento entities.EventoOutbox) bool {
+		return evento.Status == entities.EventoPendente && !evento.ProximaTentativa.After(agora)
+	})
+	if len(eventos) > limite {
+		eventos = eventos[:limite]
+	}
+	return eventos, nil
+}
+
+func (or *outboxMemoriaRepository) ListarNaoPublicados(c context.Context) ([]*entities.EventoOutbox, error) {
+	return or.listarEventos(c, func(evento entities.EventoOutbox) bool { return evento.Status != entities.EventoPublicado }), nil
+}
+
+func (or *outboxMemoriaRepository) AtualizarEvento(c context.Context, evento *entities.EventoOutbox) error {
+	defer or.banco.travar(c)()
+	t := &or.banco.tabelas
+
+	i := slices.IndexFunc(t.eventos, func(existente entities.EventoOutbox) bool { return existente.ID == evento.ID })
+	if i < 0 {
+		return fmt.Errorf("evento não encontrado")
+	}
+
+	linha := t.eventos[i]
+	atualizado := eventoPersistido(*evento)
+	linha.Status = atualizado.Status
+	linha.Tentativas = atualizado.Tentativas
+	linha.UltimoErro = atualizado.UltimoErro
+	linha.ProximaTentativa = atualizado.ProximaTentativa
+	linha.PublicadoEm = atualizado.PublicadoEm
+	// O MySQL não conta a linha quando nada muda, e a implementação MySQL trata isso como evento não encontrado
+	if reflect.DeepEqual(linha, t.eventos[i]) {
+		return fmt.Errorf("evento não encontrado")
+	}
+	t.eventos[i] = linha
+	return nil
+}
+
+func (or *outboxMemoriaRepository) BuscarEvento(c context.Context, id int64) (*entities.EventoOutbox, error) {
+	eventos := or.listarEventos(c, func(evento entities.EventoOutbox) bool { return evento.ID == id })
+	if len(eventos) == 0 {
+		return nil, fmt.Errorf("evento não encontrado")
+	}
+	return eventos[0], nil
+}
+
+// listarEventos devolve cópias dos eventos escolhidos, na ordem em que foram registrados
+func (or *outboxMemoriaRepository) listarEventos(c context.Context, escolhido func(entities.EventoOutbox) bool) []*entities.EventoOutbox {
+	defer or.banco.travar(c)()
+
+	eventos := []*entities.EventoOutbox{}
+	for _, linha := range or.banco.eventos {
+		if escolhido(linha) {
+			evento := eventoPersistido(linha)
+			eventos = append(eventos, &evento)
+		}
+	}
+	slices.SortFunc(eventos, func(a, b *entities.EventoOutbox) int { return cmp.Compare(a.ID, b.ID) })
+	return eventos
+}
+
+// eventoPersistido copia o evento como as colunas o guardam: datas com milissegundos e o erro truncado
+func eventoPersistido(evento entities.EventoOutbox) entities.EventoOutbox {
+	evento.Payload = slices.Clone(evento.Payload)
+	if len(evento.UltimoErro) > tamanhoMaximoErro {
+		evento.UltimoErro = evento.UltimoErro[:tamanhoMaximoErro]
+	}
+	evento.CriadoEm = datetime3(evento.CriadoEm)
+	evento.ProximaTentativa = datetime3(evento.ProximaTentativa)
+	if evento.PublicadoEm != nil {
+		publicadoEm := datetime3(*evento.PublicadoEm)
+		evento.PublicadoEm = &publicadoEm
+	}
+	return evento
+}
@@ -0,0 +1,57 @@
+package memoria
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+)
+
+// paginar faz em memória o keyset das listagens MySQL: ordena pelo valor do campo e pelo ID, no
+// sentido da ordenação, descarta os itens até o cursor e monta a página com PaginaNew. O total conta
+// todos os itens recebidos, que já passaram pelos filtros.
+func paginar[T any](itens []T, ordem entities.Ordenacao, valorCursor interface{}, cursor *entities.Cursor, limite int,
+	chave func(T) (interface{}, int), cursorDe func(T) entities.Cursor) *entities.Pagina[T] {
+	sentido := 1
+	if ordem.Decrescente {
+		sentido = -1
+	}
+	comparar := func(valorA interface{}, idA int, valorB interface{}, idB int) int {
+		if c := compararValores(valorA, valorB); c != 0 {
+			return c * sentido
+		}
+		return cmp.Compare(idA, idB) * sentido
+	}
+
+	slices.SortFunc(itens, func(a, b T) int {
+		valorA, idA := chave(a)
+		valorB, idB := chave(b)
+		return comparar(valorA, idA, valorB, idB)
+	})
+
+	total := len(itens)
+	if cursor != nil {
+		itens = slices.DeleteFunc(itens, func(item T) bool {
+			valor, id := chave(item)
+			return comparar(valor, id, valorCursor, cursor.ID) <= 0
+		})
+	}
+	if len(itens) > limite+1 {
+		itens = itens[:limite+1]
+	}
+	return entities.PaginaNew(itens, limite, total, cursorDe)
+}
+
+// compararValores compara os valores de um campo de ordenação como o MySQL compara as colunas
+func compararValores(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		return compararTexto(a, b.(string))
+	case entities.Money:
+		return cmp.Compare(a.Centavos, b.(entities.Money).Centavos)
+	case time.Time:
+		return datetime(a).Compare(datetime(b.(time.Time)))
+	}
+	return 0
+}
@@ -0,0 +1,532 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// pedidoLinha é uma linha da tabela Pedido
+type pedidoLinha struct {
+	id                int
+	clienteNome       string
+	cliente           *entities.ReferenciaCliente
+	codigoRetirada    string
+	dataRetirada      string
+	subtotal          entities.Money
+	desconto          entities.Money
+	total             entities.Money
+	cupom             *string
+	tempoEstimado     time.Duration
+	previsaoPronto    *time.Time
+	criadoEm          time.Time
+	ultimaAtualizacao time.Time
+	status            entities.StatusPedido
+	statusPagamento   entities.StatusPagamento
+	personalizacao    *string
+	cancelamento      *entities.Cancelamento
+}
+
+// itemLinha é uma linha de Pedido_Produto com os modificadores e os componentes de combo gravados para ela.
+// Nome, categoria e preço são a cópia feita no momento da compra.
+type itemLinha struct {
+	id            int
+	pedidoID      int
+	produtoID     int
+	nome          string
+	categoria     entities.CatProduto
+	preco         entities.Money
+	quantidade    int
+	reservado     int // Unidades tiradas do estoque na compra, devolvidas no cancelamento
+	modificadores []entities.Modificador
+	componentes   []componenteItemLinha
+}
+
+// componenteItemLinha é uma linha de Pedido_Produto_Componente; nome, categoria e preço também são a cópia
+// feita no momento da compra
+type componenteItemLinha struct {
+	produtoID  int
+	nome       string
+	categoria  entities.CatProduto
+	preco      entities.Money
+	quantidade int
+	reservado  int
+}
+
+type pedidoMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewPedidoMemoriaRepository(banco *Banco) repository.PedidoRepository {
+	return &pedidoMemoriaRepository{banco: banco}
+}
+
+// CriarPedido confere o estoque, o cupom e o código de retirada antes de gravar qualquer coisa, então
+// um pedido recusado não deixa rastro, como a transação desfeita no MySQL
+func (pr *pedidoMemoriaRepository) CriarPedido(c context.Context, pedido *entities.Pedido) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	consumo, err := t.verificarEstoque(pedido)
+	if err != nil {
+		return err
+	}
+
+	cupom := -1
+	if pedido.Cupom != nil {
+		cupom = t.indiceCupom(*pedido.Cupom)
+		if cupom < 0 || (t.cupons[cupom].LimiteUsos > 0 && t.cupons[cupom].Usos >= t.cupons[cupom].LimiteUsos) {
+			return entities.ErrCupomEsgotado
+		}
+	}
+
+	for _, item := range pedido.Itens {
+		for _, mod := range item.Modificadores {
+			if mod.ID != 0 && !t.existeModificador(mod.ID) {
+				return fmt.Errorf("erro ao inserir modificador do item: o modificador %d não existe", mod.ID)
+			}
+		}
+	}
+
+	dia := diaRetirada(pedido.UltimaAtualizacao)
+	codigo, err := entities.CodigoRetiradaNew(t.sequencias[dia] + 1)
+	if err != nil {
+		return err
+	}
+
+	// Daqui em diante nada falha: baixar o estoque, contar o uso do cupom e gravar o pedido
+	controlados := map[int]bool{}
+	for _, item := range consumo {
+		if !item.Produto.ControlaEstoque() {
+			continue
+		}
+		controlados[item.Produto.ID] = true
+		linha := t.produtos[item.Produto.ID]
+		restante := *linha.estoque - item.Quantidade
+		linha.estoque = &restante
+		t.produtos[item.Produto.ID] = linha
+		pedido.AtualizarEstoque(item.Produto.ID, restante)
+	}
+	if cupom >= 0 {
+		t.cupons[cupom].Usos++
+	}
+	t.sequencias[dia]++
+
+	pedido.ID = t.proximoID("Pedido")
+	pedido.CodigoRetirada = codigo
+	t.pedidos[pedido.ID] = pedidoLinha{
+		id:                pedido.ID,
+		clienteNome:       pedido.ClienteNome,
+		cliente:           clientePersistido(pedido.Cliente),
+		codigoRetirada:    codigo,
+		dataRetirada:      dia,
+		subtotal:          decimal(pedido.Subtotal),
+		desconto:          decimal(pedido.Desconto),
+		total:             decimal(pedido.Total),
+		cupom:             copia(pedido.Cupom),
+		tempoEstimado:     tempoPersistido(pedido.TempoEstimado),
+		previsaoPronto:    datetimeOuNulo(pedido.PrevisaoPronto),
+		criadoEm:          datetime(pedido.CriadoEm),
+		ultimaAtualizacao: datetime(pedido.UltimaAtualizacao),
+		status:            pedido.Status,
+		statusPagamento:   pedido.StatusPagamento,
+		personalizacao:    copia(pedido.Personalizacao),
+	}
+
+	for _, item := range pedido.Itens {
+		linha := itemLinha{
+			id:         t.proximoID("Pedido_Produto"),
+			pedidoID:   pedido.ID,
+			produtoID:  item.Produto.ID,
+			nome:       item.Produto.Nome,
+			categoria:  item.Produto.Categoria,
+			preco:      decimal(item.Produto.Preco),
+			quantidade: item.Quantidade,
+			reservado:  reservado(controlados, item.Produto.ID, item.Quantidade),
+		}
+		for _, mod := range item.Modificadores {
+			linha.modificadores = append(linha.modificadores, entities.Modificador{ID: mod.ID, Nome: mod.Nome, Preco: decimal(mod.Preco)})
+		}
+		for _, componente := range item.Componentes {
+			linha.componentes = append(linha.componentes, componenteItemLinha{
+				produtoID:  componente.Produto.ID,
+				nome:       componente.Produto.Nome,
+				categoria:  componente.Produto.Categoria,
+				preco:      decimal(componente.Produto.Preco),
+				quantidade: componente.Quantidade,
+				reservado:  reservado(controlados, componente.Produto.ID, componente.Quantidade*item.Quantidade),
+			})
+		}
+		t.itens = append(t.itens, linha)
+	}
+	return nil
+}
+
+// verificarEstoque confere se os produtos do pedido, inclusive os que compõem combos, estão disponíveis
+// nas quantidades pedidas, lendo o estoque atual de cada um
+func (t *tabelas) verificarEstoque(pedido *entities.Pedido) ([]entities.ConsumoProduto, error) {
+	consumo := entities.ConsumoDeProdutos(pedido.Itens)
+	slices.SortFunc(consumo, func(a, b entities.ConsumoProduto) int { return cmp.Compare(a.Produto.ID, b.Produto.ID) })
+
+	var indisponiveis []string
+	for i := range consumo {
+		produto := &consumo[i].Produto
+		linha, ok := t.produtos[produto.ID]
+		if !ok {
+			return nil, fmt.Errorf("erro ao verificar estoque do produto %d: %w", produto.ID, sql.ErrNoRows)
+		}
+
+		produto.Esgotado = linha.esgotado
+		produto.Estoque = copia(linha.estoque)
+		produto.ArquivadoEm = copia(linha.arquivadoEm)
+		if !produto.Disponivel(consumo[i].Quantidade) {
+			indisponiveis = append(indisponiveis, produto.Nome)
+		}
+	}
+	if len(indisponiveis) > 0 {
+		return nil, &entities.ProdutosIndisponiveisError{Produtos: indisponiveis}
+	}
+	return consumo, nil
+}
+
+func (t *tabelas) existeModificador(id int) bool {
+	for _, grupo := range t.grupos {
+		if slices.ContainsFunc(grupo.Opcoes, func(opcao entities.Modificador) bool { return opcao.ID == id }) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pr *pedidoMemoriaRepository) BuscarPedido(c context.Context, pedidoID int) (*entities.Pedido, error) {
+	return pr.buscarUmPedido(c, func(linha pedidoLinha) bool { return linha.id == pedidoID })
+}
+
+func (pr *pedidoMemoriaRepository) BuscarPedidoPorCodigo(c context.Context, codigo string, dia time.Time) (*entities.Pedido, error) {
+	data := diaRetirada(dia)
+	return pr.buscarUmPedido(c, func(linha pedidoLinha) bool {
+		return linha.dataRetirada == data && compararTexto(linha.codigoRetirada, codigo) == 0
+	})
+}
+
+func (pr *pedidoMemoriaRepository) buscarUmPedido(c context.Context, encontrado func(pedidoLinha) bool) (*entities.Pedido, error) {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	for _, linha := range t.pedidos {
+		if encontrado(linha) {
+			pedido := linha.entidade()
+			if err := t.carregarItens(pedido); err != nil {
+				return nil, err
+			}
+			return pedido, nil
+		}
+	}
+	return nil, entities.ErrPedidoNaoEncontrado
+}
+
+func (pr *pedidoMemoriaRepository) AtualizarStatusPedido(c context.Context, pedidoID int, statusAtual, novoStatus string, ultimaAtualizacao time.Time) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.pedidos[pedidoID]
+	if !ok {
+		return entities.ErrPedidoNaoEncontrado
+	}
+	nova := linha
+	nova.status = entities.StatusPedido(novoStatus)
+	nova.ultimaAtualizacao = datetime(ultimaAtualizacao)
+	if compararTexto(string(linha.status), statusAtual) != 0 || nova == linha {
+		return entities.ErrConflitoStatus
+	}
+	t.pedidos[pedidoID] = nova
+	return nil
+}
+
+func (pr *pedidoMemoriaRepository) AtualizarStatusPagamento(c context.Context, pedidoID int, statusPagamento string, ultimaAtualizacao time.Time) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.pedidos[pedidoID]
+	nova := linha
+	nova.statusPagamento = entities.StatusPagamento(statusPagamento)
+	nova.ultimaAtualizacao = datetime(ultimaAtualizacao)
+	if !ok || nova == linha {
+		return fmt.Errorf("pedido não encontrado ou status de pagamento não foi alterado")
+	}
+	t.pedidos[pedidoID] = nova
+	return nil
+}
+
+// CancelarPedido grava o cancelamento e devolve ao estoque os produtos reservados pelo pedido
+func (pr *pedidoMemoriaRepository) CancelarPedido(c context.Context, pedido *entities.Pedido) error {
+	if pedido.Cancelamento == nil {
+		return fmt.Errorf("o pedido %d não foi cancelado", pedido.ID)
+	}
+
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.pedidos[pedido.ID]
+	if !ok {
+		return entities.ErrPedidoNaoEncontrado
+	}
+	if linha.status == entities.Cancelado {
+		return entities.ErrConflitoStatus
+	}
+
+	cancelamento := *pedido.Cancelamento
+	cancelamento.CanceladoEm = datetime(cancelamento.CanceladoEm)
+	linha.status = pedido.Status
+	linha.statusPagamento = pedido.StatusPagamento
+	linha.cancelamento = &cancelamento
+	linha.previsaoPronto = nil
+	linha.ultimaAtualizacao = datetime(pedido.UltimaAtualizacao)
+	t.pedidos[pedido.ID] = linha
+
+	// Devolver o que cada linha reservou na compra; sem controle de estoque hoje, não há para onde devolver
+	for produtoID, quantidade := range t.reservasDoPedido(pedido.ID) {
+		produto, ok := t.produtos[produtoID]
+		if !ok || produto.estoque == nil {
+			continue
+		}
+		restante := *produto.estoque + quantidade
+		produto.estoque = &restante
+		t.produtos[produtoID] = produto
+	}
+
+	// O pedido cancelado deixa de contar no limite de usos do cupom
+	if linha.cupom != nil {
+		if i := t.indiceCupom(*linha.cupom); i >= 0 && t.cupons[i].Usos > 0 {
+			t.cupons[i].Usos--
+		}
+	}
+	return nil
+}
+
+// reservasDoPedido soma, por produto, as unidades que as linhas e os componentes de combo do pedido
+// tiraram do estoque
+func (t *tabelas) reservasDoPedido(pedidoID int) map[int]int {
+	reservas := map[int]int{}
+	for _, item := range t.itens {
+		if item.pedidoID != pedidoID {
+			continue
+		}
+		reservas[item.produtoID] += item.reservado
+		for _, componente := range item.componentes {
+			reservas[componente.produtoID] += componente.reservado
+		}
+	}
+	return reservas
+}
+
+// reservado é quanto uma linha do pedido tirou do estoque: tudo, se o produto controla o estoque, ou nada
+func reservado(controlados map[int]bool, produtoID, quantidade int) int {
+	if controlados[produtoID] {
+		return quantidade
+	}
+	return 0
+}
+
+// ConsultarPedidos filtra os pedidos como a consulta SQL e carrega os itens só dos pedidos da página
+func (pr *pedidoMemoriaRepository) ConsultarPedidos(c context.Context, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error) {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	valorOrdem, ok := colunasOrdemPedido[consulta.Ordem.Campo]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entities.ErrOrdemInvalida, consulta.Ordem.Campo)
+	}
+	var cursor interface{}
+	if consulta.Cursor != nil {
+		valor, err := consulta.ValorCursor()
+		if err != nil {
+			return nil, err
+		}
+		cursor = valor
+	}
+
+	// O nome do cliente é comparado como o LIKE do MySQL, sem diferenciar acentos
+	filtros := consulta
+	filtros.ClienteNome = ""
+	var pedidos []*entities.Pedido
+	for _, linha := range t.pedidos {
+		pedido := linha.entidade()
+		if filtros.Atende(*pedido) && contemTexto(pedido.ClienteNome, consulta.ClienteNome) {
+			pedidos = append(pedidos, pedido)
+		}
+	}
+
+	pagina := paginar(pedidos, consulta.Ordem, cursor, consulta.Cursor, consulta.Limite,
+		func(p *entities.Pedido) (interface{}, int) { return valorOrdem(p), p.ID },
+		func(p *entities.Pedido) entities.Cursor { return consulta.CursorDe(*p) },
+	)
+	for _, pedido := range pagina.Itens {
+		if err := t.carregarItens(pedido); err != nil {
+			return nil, err
+		}
+	}
+	return pagina, nil
+}
+
+// colunasOrdemPedido dá o valor pelo qual cada campo de ordenação da listagem ordena os pedidos
+var colunasOrdemPedido = map[string]func(p *entities.Pedido) interface{}{
+	entities.OrdemPedidoCriacao: func(p *entities.Pedido) interface{} { return p.CriadoEm },
+	entities.OrdemPedidoTotal:   func(p *entities.Pedido) interface{} { return p.Total },
+}
+
+// ListarFilaCozinha devolve só o que a fila da cozinha usa: ID, tempo estimado, previsão e status
+func (pr *pedidoMemoriaRepository) ListarFilaCozinha(c context.Context) ([]*entities.Pedido, error) {
+	defer pr.banco.travar(c)()
+
+	var fila []*entities.Pedido
+	for _, linha := range pr.banco.pedidosPorID() {
+		if linha.status == entities.Recebido || linha.status == entities.EmPreparacao {
+			fila = append(fila, &entities.Pedido{
+				ID:             linha.id,
+				TempoEstimado:  linha.tempoEstimado,
+				PrevisaoPronto: copia(linha.previsaoPronto),
+				Status:         linha.status,
+			})
+		}
+	}
+	return fila, nil
+}
+
+// AtualizarPrevisoes grava a previsão recalculada sem mexer na última atualização dos pedidos
+func (pr *pedidoMemoriaRepository) AtualizarPrevisoes(c context.Context, pedidos []*entities.Pedido) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	for _, p := range pedidos {
+		if linha, ok := t.pedidos[p.ID]; ok {
+			linha.previsaoPronto = datetimeOuNulo(p.PrevisaoPronto)
+			t.pedidos[p.ID] = linha
+		}
+	}
+	return nil
+}
+
+// pedidosPorID lista as linhas de Pedido na ordem da chave primária
+func (t *tabelas) pedidosPorID() []pedidoLinha {
+	linhas := make([]pedidoLinha, 0, len(t.pedidos))
+	for _, linha := range t.pedidos {
+		linhas = append(linhas, linha)
+	}
+	slices.SortFunc(linhas, func(a, b pedidoLinha) int { return cmp.Compare(a.id, b.id) })
+	return linhas
+}
+
+// entidade monta o pedido com as colunas lidas pela implementação MySQL, ainda sem os itens
+func (linha pedidoLinha) entidade() *entities.Pedido {
+	pedido := &entities.Pedido{
+		ID:                linha.id,
+		ClienteNome:       linha.clienteNome,
+		Cliente:           copia(linha.cliente),
+		CodigoRetirada:    linha.codigoRetirada,
+		Subtotal:          linha.subtotal,
+		Desconto:          linha.desconto,
+		Total:             linha.total,
+		Cupom:             copia(linha.cupom),
+		TempoEstimado:     linha.tempoEstimado,
+		PrevisaoPronto:    copia(linha.previsaoPronto),
+		CriadoEm:          linha.criadoEm,
+		UltimaAtualizacao: linha.ultimaAtualizacao,
+		Status:            linha.status,
+		StatusPagamento:   linha.statusPagamento,
+		Personalizacao:    copia(linha.personalizacao),
+		Cancelamento:      copia(linha.cancelamento),
+	}
+	return pedido
+}
+
+// carregarItens remonta as linhas do pedido. Nome, categoria e preço vêm da cópia gravada no pedido;
+// descrição, tempo de preparo e alérgenos, do catálogo atual, como no JOIN da consulta MySQL. O mesmo
+// vale para os componentes de combo.
+func (t *tabelas) carregarItens(pedido *entities.Pedido) error {
+	pedido.Itens = []entities.ItemPedido{}
+	for _, linha := range t.itens {
+		if linha.pedidoID != pedido.ID {
+			continue
+		}
+
+		catalogo := t.produtos[linha.produtoID]
+		produto := entities.Produto{
+			ID:                  linha.produtoID,
+			Nome:                linha.nome,
+			Descricao:           catalogo.descricao,
+			Preco:               linha.preco,
+			Categoria:           linha.categoria,
+			TempoPreparoMinutos: catalogo.tempoPreparo,
+		}
+		produto.Alergenos = slices.Clone(catalogo.dieta.Alergenos)
+
+		var err error
+		precoUnitario := produto.Preco
+		for _, m := range linha.modificadores {
+			if precoUnitario, err = precoUnitario.Add(m.Preco); err != nil {
+				return fmt.Errorf("erro ao calcular o preço do item do pedido %d: %w", pedido.ID, err)
+			}
+		}
+		subtotal, err := precoUnitario.Mul(linha.quantidade)
+		if err != nil {
+			return fmt.Errorf("erro ao calcular o subtotal do item do pedido %d: %w", pedido.ID, err)
+		}
+		item := entities.ItemPedido{
+			Produto:       produto,
+			Quantidade:    linha.quantidade,
+			Modificadores: slices.Clone(linha.modificadores),
+			PrecoUnitario: precoUnitario,
+			Subtotal:      subtotal,
+		}
+
+		for _, componente := range linha.componentes {
+			catalogo := t.produtos[componente.produtoID]
+			item.Componentes = append(item.Componentes, entities.ItemCombo{
+				Produto: entities.Produto{
+					ID:                    componente.produtoID,
+					Nome:                  componente.nome,
+					Descricao:             catalogo.descricao,
+					Preco:                 componente.preco,
+					Categoria:             componente.categoria,
+					TempoPreparoMinutos:   catalogo.tempoPreparo,
+					InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: slices.Clone(catalogo.dieta.Alergenos)},
+				},
+				Quantidade: componente.quantidade,
+			})
+		}
+		pedido.Itens = append(pedido.Itens, item)
+	}
+	return nil
+}
+
+// clientePersistido guarda só as partes informadas da referência; sem nenhuma, o cliente informou só o nome
+func clientePersistido(ref *entities.ReferenciaCliente) *entities.ReferenciaCliente {
+	if ref == nil || (ref.CPF == "" && ref.IDExterno == "") {
+		return nil
+	}
+	return copia(ref)
+}
+
+// tempoPersistido reproduz a coluna TIME, que guarda o tempo estimado em segundos inteiros
+func tempoPersistido(d time.Duration) time.Duration {
+	tempo, _ := entities.DuracaoParse(entities.FormatarDuracao(d))
+	return tempo
+}
+
+// diaRetirada é a data, no fuso da lanchonete, em que a sequência de retirada é contada
+func diaRetirada(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+// contemTexto reproduz o LIKE '%texto%' sobre uma coluna com a collation das tabelas
+func contemTexto(valor, texto string) bool {
+	return strings.Contains(semAcento.Replace(strings.ToLower(valor)), semAcento.Replace(strings.ToLower(texto)))
+}
@@ -0,0 +1,325 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// produtoLinha é uma linha da tabela Produto
+type produtoLinha struct {
+	id           int
+	nome         string
+	descricao    string
+	preco        entities.Money
+	categoria    entities.CatProduto
+	tempoPreparo int
+	esgotado     bool
+	estoque      *int
+	arquivadoEm  *time.Time
+	dieta        entities.InformacoesDieteticas
+}
+
+// componenteLinha é uma linha da tabela ComboComponente: produto fixo ou categoria a escolher
+type componenteLinha struct {
+	id         int
+	comboID    int
+	produtoID  int
+	categoria  entities.CatProduto
+	quantidade int
+}
+
+type produtoMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewProdutoMemoriaRepository(banco *Banco) repository.ProdutoRepository {
+	return &produtoMemoriaRepository{banco: banco}
+}
+
+func (pr *produtoMemoriaRepository) AdicionarProduto(c context.Context, produto *entities.Produto) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	if !t.existeCategoria(produto.Categoria) {
+		return fmt.Errorf("a categoria %q não existe", produto.Categoria)
+	}
+	for _, componente := range produto.Componentes {
+		if componente.Produto != nil {
+			if _, ok := t.produtos[componente.Produto.ID]; !ok {
+				return fmt.Errorf("erro ao inserir componente do combo: o produto %d não existe", componente.Produto.ID)
+			}
+		} else if !t.existeCategoria(componente.Categoria) {
+			return fmt.Errorf("erro ao inserir componente do combo: a categoria %q não existe", componente.Categoria)
+		}
+	}
+
+	produto.ID = t.proximoID("Produto")
+	t.produtos[produto.ID] = produtoLinha{
+		id:           produto.ID,
+		nome:         produto.Nome,
+		descricao:    produto.Descricao,
+		preco:        decimal(produto.Preco),
+		categoria:    produto.Categoria,
+		tempoPreparo: produto.TempoPreparoMinutos,
+		esgotado:     produto.Esgotado,
+		estoque:      copia(produto.Estoque),
+		dieta:        copiarDieta(produto.InformacoesDieteticas),
+	}
+
+	for i := range produto.Componentes {
+		componente := &produto.Componentes[i]
+		linha := componenteLinha{comboID: produto.ID, quantidade: componente.Quantidade}
+		if componente.Produto != nil {
+			linha.produtoID = componente.Produto.ID
+		} else {
+			linha.categoria = componente.Categoria
+		}
+		linha.id = t.proximoID("ComboComponente")
+		componente.ID = linha.id
+		t.componentes = append(t.componentes, linha)
+	}
+	return nil
+}
+
+func (pr *produtoMemoriaRepository) BuscarProdutoPorId(c context.Context, id int) (*entities.Produto, error) {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.produtos[id]
+	if !ok {
+		return nil, entities.ErrProdutoNaoEncontrado
+	}
+	produto := t.produtoCompleto(linha)
+	produto.ArquivadoEm = copia(linha.arquivadoEm)
+	produto.Modificadores = t.gruposDoProduto(id)
+	return produto, nil
+}
+
+// ConsultarProdutos aplica os filtros da consulta, como a consulta SQL, e devolve a página seguinte ao cursor
+func (pr *produtoMemoriaRepository) ConsultarProdutos(c context.Context, consulta entities.ConsultaProdutos) (*entities.Pagina[*entities.Produto], error) {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	valorOrdem, ok := colunasOrdemProduto[consulta.Ordem.Campo]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entities.ErrOrdemInvalida, consulta.Ordem.Campo)
+	}
+	var cursor interface{}
+	if consulta.Cursor != nil {
+		valor, err := consulta.ValorCursor()
+		if err != nil {
+			return nil, err
+		}
+		cursor = valor
+	}
+
+	var produtos []*entities.Produto
+	for _, linha := range t.produtos {
+		// Os produtos arquivados ficam de fora antes de montar os componentes, como no SQL
+		if linha.arquivadoEm != nil {
+			continue
+		}
+		// Uma categoria inativa sai do cardápio com todos os seus produtos, como no SQL
+		if !consulta.IncluirIndisponiveis && !t.categoriaAtiva(linha.categoria) {
+			continue
+		}
+		if produto := t.produtoCompleto(linha); consulta.Atende(*produto) {
+			produtos = append(produtos, produto)
+		}
+	}
+
+	return paginar(produtos, consulta.Ordem, cursor, consulta.Cursor, consulta.Limite,
+		func(p *entities.Produto) (interface{}, int) { return valorOrdem(p), p.ID },
+		func(p *entities.Produto) entities.Cursor { return consulta.CursorDe(*p) },
+	), nil
+}
+
+// colunasOrdemProduto dá o valor pelo qual cada campo de ordenação do cardápio ordena os produtos
+var colunasOrdemProduto = map[string]func(p *entities.Produto) interface{}{
+	entities.OrdemProdutoNome:  func(p *entities.Produto) interface{} { return p.Nome },
+	entities.OrdemProdutoPreco: func(p *entities.Produto) interface{} { return p.Preco },
+}
+
+func (pr *produtoMemoriaRepository) EditarProduto(c context.Context, produto *entities.Produto) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.produtos[produto.ID]
+	if !ok {
+		return entities.ErrProdutoNaoEncontrado
+	}
+	if linha.categoria != produto.Categoria && !t.existeCategoria(produto.Categoria) {
+		return fmt.Errorf("erro ao atualizar produto: a categoria %q não existe", produto.Categoria)
+	}
+
+	linha.nome = produto.Nome
+	linha.descricao = produto.Descricao
+	linha.preco = decimal(produto.Preco)
+	linha.categoria = produto.Categoria
+	linha.tempoPreparo = produto.TempoPreparoMinutos
+	linha.dieta = copiarDieta(produto.InformacoesDieteticas)
+	t.produtos[produto.ID] = linha
+	return nil
+}
+
+func (pr *produtoMemoriaRepository) ArquivarProduto(c context.Context, produto *entities.Produto) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.produtos[produto.ID]
+	if !ok || produto.ArquivadoEm == nil {
+		return entities.ErrProdutoNaoEncontrado
+	}
+	if linha.arquivadoEm != nil {
+		return entities.ErrProdutoArquivado
+	}
+	linha.arquivadoEm = datetimeOuNulo(produto.ArquivadoEm)
+	t.produtos[produto.ID] = linha
+	return nil
+}
+
+func (pr *produtoMemoriaRepository) RestaurarProduto(c context.Context, produto *entities.Produto) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.produtos[produto.ID]
+	if !ok {
+		return entities.ErrProdutoNaoEncontrado
+	}
+	if linha.arquivadoEm == nil {
+		return entities.ErrProdutoNaoArquivado
+	}
+	linha.arquivadoEm = nil
+	t.produtos[produto.ID] = linha
+	return nil
+}
+
+func (pr *produtoMemoriaRepository) ListarPorCategoria(c context.Context, categoria string) ([]*entities.Produto, error) {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	var produtos []*entities.Produto
+	for _, linha := range t.produtosPorID() {
+		if linha.arquivadoEm == nil && compararTexto(string(linha.categoria), categoria) == 0 {
+			produtos = append(produtos, t.produtoCompleto(linha))
+		}
+	}
+	return produtos, nil
+}
+
+func (pr *produtoMemoriaRepository) AtualizarDisponibilidade(c context.Context, id int, esgotado bool) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.produtos[id]
+	if !ok {
+		return entities.ErrProdutoNaoEncontrado
+	}
+	linha.esgotado = esgotado
+	t.produtos[id] = linha
+	return nil
+}
+
+func (pr *produtoMemoriaRepository) DefinirEstoque(c context.Context, id int, estoque *int) error {
+	defer pr.banco.travar(c)()
+	t := &pr.banco.tabelas
+
+	linha, ok := t.produtos[id]
+	if !ok {
+		return entities.ErrProdutoNaoEncontrado
+	}
+	linha.estoque = copia(estoque)
+	t.produtos[id] = linha
+	return nil
+}
+
+// produtosPorID lista as linhas de Produto na ordem da chave primária
+func (t *tabelas) produtosPorID() []produtoLinha {
+	linhas := make([]produtoLinha, 0, len(t.produtos))
+	for _, linha := range t.produtos {
+		linhas = append(linhas, linha)
+	}
+	slices.SortFunc(linhas, func(a, b produtoLinha) int { return cmp.Compare(a.id, b.id) })
+	return linhas
+}
+
+// produtoCompleto monta o produto com componentes e imagens, como as listagens do MySQL: sem a data
+// de arquivamento e sem os grupos de modificadores, que só a busca pelo ID carrega
+func (t *tabelas) produtoCompleto(linha produtoLinha) *entities.Produto {
+	produto := &entities.Produto{
+		ID:                    linha.id,
+		Nome:                  linha.nome,
+		Descricao:             linha.descricao,
+		Preco:                 linha.preco,
+		Categoria:             linha.categoria,
+		TempoPreparoMinutos:   linha.tempoPreparo,
+		Esgotado:              linha.esgotado,
+		Estoque:               copia(linha.estoque),
+		InformacoesDieteticas: copiarDieta(linha.dieta),
+	}
+
+	if produto.EhCombo() {
+		produto.Componentes = []entities.ComponenteCombo{}
+		for _, cl := range t.componentes {
+			if cl.comboID != linha.id {
+				continue
+			}
+			componente := entities.ComponenteCombo{ID: cl.id, Quantidade: cl.quantidade}
+			if item, ok := t.produtos[cl.produtoID]; ok {
+				componente.Produto = &entities.Produto{
+					ID:                  item.id,
+					Nome:                item.nome,
+					Descricao:           item.descricao,
+					Preco:               item.preco,
+					Categoria:           item.categoria,
+					TempoPreparoMinutos: item.tempoPreparo,
+					InformacoesDieteticas: entities.InformacoesDieteticas{
+						Alergenos: slices.Clone(item.dieta.Alergenos),
+						TagsDieta: slices.Clone(item.dieta.TagsDieta),
+					},
+				}
+			} else {
+				componente.Categoria = cl.categoria
+			}
+			produto.Componentes = append(produto.Componentes, componente)
+		}
+	}
+
+	if imagens := t.imagens[linha.id]; len(imagens) > 0 {
+		produto.Imagens = slices.Clone(imagens)
+	}
+	return produto
+}
+
+// copiarDieta guarda as informações dietéticas como voltam das colunas do MySQL: listas vazias viram nil
+func copiarDieta(info entities.InformacoesDieteticas) entities.InformacoesDieteticas {
+	copiada := entities.InformacoesDieteticas{Nutricao: copia(info.Nutricao)}
+	if len(info.Alergenos) > 0 {
+		copiada.Alergenos = slices.Clone(info.Alergenos)
+	}
+	if len(info.TagsDieta) > 0 {
+		copiada.TagsDieta = slices.Clone(info.TagsDieta)
+	}
+	return copiada
+}
+
+// compararTexto compara como a collation utf8mb4_0900_ai_ci das tabelas: sem diferenciar maiúsculas e acentos
+func compararTexto(a, b string) int {
+	return strings.Compare(semAcento.Replace(strings.ToLower(a)), semAcento.Replace(strings.ToLower(b)))
+}
+
+var semAcento = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
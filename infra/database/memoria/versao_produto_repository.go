@@ -0,0 +1,61 @@
+package memoria
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type versaoProdutoMemoriaRepository struct {
+	banco *Banco
+}
+
+func NewVersaoProdutoMemoriaRepository(banco *Banco) repository.VersaoProdutoRepository {
+	return &versaoProdutoMemoriaRepository{banco: banco}
+}
+
+func (vr *versaoProdutoMemoriaRepository) RegistrarVersao(c context.Context, versao *entities.VersaoProduto) error {
+	defer vr.banco.travar(c)()
+	t := &vr.banco.tabelas
+
+	if _, ok := t.produtos[versao.ProdutoID]; !ok {
+		return fmt.Errorf("erro ao registrar versão do produto: o produto %d não existe", versao.ProdutoID)
+	}
+
+	versao.ID = t.proximoID("ProdutoVersao")
+	linha := *versao
+	linha.Preco = decimal(linha.Preco)
+	linha.VigenteDesde = datetime(linha.VigenteDesde)
+	t.versoes = append(t.versoes, linha)
+	return nil
+}
+
+func (vr *versaoProdutoMemoriaRepository) ListarVersoes(c context.Context, produtoID int) ([]entities.VersaoProduto, error) {
+	defer vr.banco.travar(c)()
+	return vr.banco.versoesDoProduto(produtoID), nil
+}
+
+func (vr *versaoProdutoMemoriaRepository) BuscarVersaoEm(c context.Context, produtoID int, em time.Time) (*entities.VersaoProduto, error) {
+	defer vr.banco.travar(c)()
+
+	return entities.VersaoVigente(vr.banco.versoesDoProduto(produtoID), datetime(em))
+}
+
+// versoesDoProduto lista as versões do produto em ordem cronológica
+func (t *tabelas) versoesDoProduto(produtoID int) []entities.VersaoProduto {
+	versoes := []entities.VersaoProduto{}
+	for _, versao := range t.versoes {
+		if versao.ProdutoID == produtoID {
+			versoes = append(versoes, versao)
+		}
+	}
+	slices.SortFunc(versoes, func(a, b entities.VersaoProduto) int {
+		return cmp.Or(a.VigenteDesde.Compare(b.VigenteDesde), cmp.Compare(a.ID, b.ID))
+	})
+	return versoes
+}
@@ -0,0 +1,388 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	esquema "lanchonete/db"
+)
+
+const (
+	// travaMigracoes é a trava nomeada do MySQL que impede duas réplicas de migrar ao mesmo tempo
+	travaMigracoes = "lanchonete_migracoes"
+	// esperaTrava é quanto uma réplica espera a outra terminar de migrar
+	esperaTrava = 5 * time.Minute
+)
+
+var (
+	ErrTravaMigracoes     = errors.New("outra instância está migrando o banco")
+	ErrMigracaoIncompleta = errors.New("uma migração anterior ficou incompleta")
+	ErrBancoLegado        = errors.New("o banco tem tabelas do serviço, mas não o esquema base do antigo init.sql")
+)
+
+// Migracao é uma alteração numerada do esquema: Subir aplica a alteração e Descer a desfaz
+type Migracao struct {
+	Versao int
+	Nome   string
+	Subir  string
+	Descer string
+}
+
+// Migrador aplica e desfaz as migrações, registrando na tabela SchemaMigracao as versões aplicadas
+type Migrador struct {
+	db        *sql.DB
+	migracoes []Migracao
+	// legado reconhece a versão de um banco criado antes das migrações; vazio, todo banco sem versão é novo
+	legado []esquema.Marco
+}
+
+func NewMigrador(db *sql.DB, arquivos fs.FS, legado []esquema.Marco) (*Migrador, error) {
+	migracoes, err := CarregarMigracoes(arquivos)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrador{db: db, migracoes: migracoes, legado: legado}, nil
+}
+
+var arquivoMigracao = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// CarregarMigracoes lê os arquivos NNN_nome.up.sql e NNN_nome.down.sql, em qualquer pasta de arquivos.
+// Cada versão precisa dos dois arquivos e as versões precisam ser contínuas a partir de 1.
+func CarregarMigracoes(arquivos fs.FS) ([]Migracao, error) {
+	porVersao := map[int]*Migracao{}
+	err := fs.WalkDir(arquivos, ".", func(caminho string, entrada fs.DirEntry, err error) error {
+		if err != nil || entrada.IsDir() {
+			return err
+		}
+		partes := arquivoMigracao.FindStringSubmatch(path.Base(caminho))
+		if partes == nil {
+			return nil
+		}
+
+		conteudo, err := fs.ReadFile(arquivos, caminho)
+		if err != nil {
+			return fmt.Errorf("erro ao ler a migração %s: %w", caminho, err)
+		}
+		versao, _ := strconv.Atoi(partes[1])
+		migracao, ok := porVersao[versao]
+		if !ok {
+			migracao = &Migracao{Versao: versao, Nome: partes[2]}
+			porVersao[versao] = migracao
+		}
+		if migracao.Nome != partes[2] {
+			return fmt.Errorf("a versão %d tem dois nomes: %s e %s", versao, migracao.Nome, partes[2])
+		}
+
+		if partes[3] == "up" {
+			migracao.Subir = string(conteudo)
+		} else {
+			migracao.Descer = string(conteudo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	migracoes := make([]Migracao, 0, len(porVersao))
+	for _, migracao := range porVersao {
+		migracoes = append(migracoes, *migracao)
+	}
+	sort.Slice(migracoes, func(i, j int) bool { return migracoes[i].Versao < migracoes[j].Versao })
+
+	for i, migracao := range migracoes {
+		if migracao.Versao != i+1 {
+			return nil, fmt.Errorf("falta a migração de versão %d", i+1)
+		}
+		if strings.TrimSpace(migracao.Subir) == "" || strings.TrimSpace(migracao.Descer) == "" {
+			return nil, fmt.Errorf("a migração %03d_%s precisa dos arquivos up e down", migracao.Versao, migracao.Nome)
+		}
+	}
+	return migracoes, nil
+}
+
+// Subir aplica as migrações pendentes e devolve quantas foram aplicadas
+func (m *Migrador) Subir(c context.Context) (int, error) {
+	aplicadas := 0
+	err := m.comTrava(c, func(conn *sql.Conn) error {
+		atual, err := m.versaoAtual(c, conn)
+		if err != nil {
+			return err
+		}
+		if atual == 0 {
+			if atual, err = m.adotarLegado(c, conn); err != nil {
+				return err
+			}
+		}
+		if ultima := len(m.migracoes); atual > ultima {
+			log.Printf("⚠️ o banco está na versão %d, mais nova que a última migração conhecida (%d)", atual, ultima)
+			return nil
+		}
+
+		for _, migracao := range m.migracoes[atual:] {
+			if err := m.aplicar(c, conn, migracao.Versao, migracao.Nome, migracao.Subir); err != nil {
+				return err
+			}
+			if _, err := conn.ExecContext(c, `UPDATE SchemaMigracao SET concluida = TRUE WHERE versao = ?`, migracao.Versao); err != nil {
+				return fmt.Errorf("erro ao registrar a migração %d: %w", migracao.Versao, err)
+			}
+			log.Printf("migração %03d_%s aplicada", migracao.Versao, migracao.Nome)
+			aplicadas++
+		}
+		return nil
+	})
+	return aplicadas, err
+}
+
+// Descer desfaz as últimas migrações aplicadas, da mais nova para a mais antiga
+func (m *Migrador) Descer(c context.Context, passos int) (int, error) {
+	desfeitas := 0
+	err := m.comTrava(c, func(conn *sql.Conn) error {
+		atual, err := m.versaoAtual(c, conn)
+		if err != nil {
+			return err
+		}
+		if atual > len(m.migracoes) {
+			return fmt.Errorf("o banco está na versão %d, mais nova que a última migração conhecida (%d)", atual, len(m.migracoes))
+		}
+
+		for ; desfeitas < passos && atual > 0; atual-- {
+			migracao := m.migracoes[atual-1]
+			// A versão fica marcada como incompleta até o down terminar
+			if _, err := conn.ExecContext(c, `UPDATE SchemaMigracao SET concluida = FALSE WHERE versao = ?`, migracao.Versao); err != nil {
+				return fmt.Errorf("erro ao registrar a migração %d: %w", migracao.Versao, err)
+			}
+			if err := executarComandos(c, conn, migracao.Descer); err != nil {
+				return fmt.Errorf("erro ao desfazer a migração %03d_%s: %w", migracao.Versao, migracao.Nome, err)
+			}
+			if _, err := conn.ExecContext(c, `DELETE FROM SchemaMigracao WHERE versao = ?`, migracao.Versao); err != nil {
+				return fmt.Errorf("erro ao registrar a migração %d: %w", migracao.Versao, err)
+			}
+			log.Printf("migração %03d_%s desfeita", migracao.Versao, migracao.Nome)
+			desfeitas++
+		}
+		return nil
+	})
+	return desfeitas, err
+}
+
+// Versao informa a última migração aplicada ao banco; zero quando nenhuma foi aplicada
+func (m *Migrador) Versao(c context.Context) (int, error) {
+	conn, err := m.db.Conn(c)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao obter conexão: %w", err)
+	}
+	defer conn.Close()
+
+	if err := criarTabelaVersao(c, conn); err != nil {
+		return 0, err
+	}
+	return m.versaoAtual(c, conn)
+}
+
+// comTrava executa fn segurando a trava de migrações. Travas nomeadas pertencem à sessão, então a trava
+// e as migrações usam a mesma conexão e a trava cai sozinha se a instância morrer no meio.
+func (m *Migrador) comTrava(c context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(c)
+	if err != nil {
+		return fmt.Errorf("erro ao obter conexão: %w", err)
+	}
+	defer conn.Close()
+
+	var obtida sql.NullInt64
+	if err := conn.QueryRowContext(c, `SELECT GET_LOCK(?, ?)`, travaMigracoes, int(esperaTrava.Seconds())).Scan(&obtida); err != nil {
+		return fmt.Errorf("erro ao obter a trava de migrações: %w", err)
+	}
+	if obtida.Int64 != 1 {
+		return ErrTravaMigracoes
+	}
+	defer conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, travaMigracoes)
+
+	if err := criarTabelaVersao(c, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func criarTabelaVersao(c context.Context, conn *sql.Conn) error {
+	query := "CREATE TABLE IF NOT EXISTS `SchemaMigracao` (" +
+		"`versao` INT NOT NULL, " +
+		"`nome` VARCHAR(100) NOT NULL, " +
+		"`concluida` BOOLEAN NOT NULL DEFAULT FALSE, " +
+		"`aplicadaEm` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, " +
+		"PRIMARY KEY (`versao`)" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci"
+	if _, err := conn.ExecContext(c, query); err != nil {
+		return fmt.Errorf("erro ao criar a tabela de versões do esquema: %w", err)
+	}
+	return nil
+}
+
+// versaoAtual recusa continuar se alguma migração ficou pela metade: o MySQL não desfaz DDL,
+// então o esquema precisa ser conferido e a linha da versão corrigida à mão
+func (m *Migrador) versaoAtual(c context.Context, conn *sql.Conn) (int, error) {
+	var incompleta sql.NullInt64
+	if err := conn.QueryRowContext(c, `SELECT MIN(versao) FROM SchemaMigracao WHERE concluida = FALSE`).Scan(&incompleta); err != nil {
+		return 0, fmt.Errorf("erro ao ler a versão do esquema: %w", err)
+	}
+	if incompleta.Valid {
+		return 0, fmt.Errorf("%w: versão %d; confira o esquema e corrija a tabela SchemaMigracao", ErrMigracaoIncompleta, incompleta.Int64)
+	}
+
+	var versao sql.NullInt64
+	if err := conn.QueryRowContext(c, `SELECT MAX(versao) FROM SchemaMigracao`).Scan(&versao); err != nil {
+		return 0, fmt.Errorf("erro ao ler a versão do esquema: %w", err)
+	}
+	return int(versao.Int64), nil
+}
+
+// adotarLegado registra as migrações já contidas num banco criado pelo antigo init.sql, sem executá-las.
+// A versão do banco é a do marco mais novo que ele tem.
+func (m *Migrador) adotarLegado(c context.Context, conn *sql.Conn) (int, error) {
+	if len(m.legado) == 0 {
+		return 0, nil
+	}
+
+	base := m.legado[0]
+	if existe, err := existeMarco(c, conn, esquema.Marco{Tabela: base.Tabela}); err != nil || !existe {
+		return 0, err
+	}
+	if existe, err := existeMarco(c, conn, base); err != nil {
+		return 0, err
+	} else if !existe {
+		return 0, fmt.Errorf("%w: falta a coluna %s.%s", ErrBancoLegado, base.Tabela, base.Coluna)
+	}
+
+	versao := base.Versao
+	for _, marco := range m.legado[1:] {
+		existe, err := existeMarco(c, conn, marco)
+		if err != nil {
+			return 0, err
+		}
+		if existe && marco.Versao > versao {
+			versao = marco.Versao
+		}
+	}
+	if versao > len(m.migracoes) {
+		return 0, fmt.Errorf("o marco da versão %d não tem migração correspondente", versao)
+	}
+
+	for _, migracao := range m.migracoes[:versao] {
+		if _, err := conn.ExecContext(c, `INSERT INTO SchemaMigracao (versao, nome, concluida) VALUES (?, ?, TRUE)`, migracao.Versao, migracao.Nome); err != nil {
+			return 0, fmt.Errorf("erro ao registrar a migração %d: %w", migracao.Versao, err)
+		}
+	}
+	log.Printf("banco criado pelo init.sql registrado na versão %d", versao)
+	return versao, nil
+}
+
+// existeMarco informa se o banco tem a tabela do marco e, quando o marco tem coluna, a coluna
+func existeMarco(c context.Context, conn *sql.Conn, marco esquema.Marco) (bool, error) {
+	query := `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?`
+	args := []any{marco.Tabela}
+	if marco.Coluna != "" {
+		query = `SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?`
+		args = append(args, marco.Coluna)
+	}
+
+	var total int
+	if err := conn.QueryRowContext(c, query, args...).Scan(&total); err != nil {
+		return false, fmt.Errorf("erro ao inspecionar o esquema: %w", err)
+	}
+	return total > 0, nil
+}
+
+// aplicar registra a versão como incompleta e executa os comandos da migração
+func (m *Migrador) aplicar(c context.Context, conn *sql.Conn, versao int, nome, comandos string) error {
+	if _, err := conn.ExecContext(c, `INSERT INTO SchemaMigracao (versao, nome, concluida) VALUES (?, ?, FALSE)`, versao, nome); err != nil {
+		return fmt.Errorf("erro ao registrar a migração %d: %w", versao, err)
+	}
+	if err := executarComandos(c, conn, comandos); err != nil {
+		return fmt.Errorf("erro ao aplicar a migração %03d_%s: %w", versao, nome, err)
+	}
+	return nil
+}
+
+func executarComandos(c context.Context, conn *sql.Conn, comandos string) error {
+	for _, comando := range DividirComandos(comandos) {
+		if _, err := conn.ExecContext(c, comando); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DividirComandos separa um arquivo SQL nos comandos terminados por ponto e vírgula, ignorando os que
+// aparecem em textos entre aspas e em comentários. O driver executa um comando por vez.
+func DividirComandos(arquivo string) []string {
+	var comandos []string
+	var atual strings.Builder
+	var aspas rune
+	comentarioLinha, comentarioBloco := false, false
+
+	runas := []rune(arquivo)
+	for i := 0; i < len(runas); i++ {
+		r := runas[i]
+		proxima := rune(0)
+		if i+1 < len(runas) {
+			proxima = runas[i+1]
+		}
+
+		switch {
+		case comentarioLinha:
+			if r == '\n' {
+				comentarioLinha = false
+				atual.WriteRune(r)
+			}
+			continue
+		case comentarioBloco:
+			if r == '*' && proxima == '/' {
+				comentarioBloco = false
+				i++
+			}
+			continue
+		case aspas != 0:
+			atual.WriteRune(r)
+			if r == '\\' && aspas != '`' && proxima != 0 {
+				atual.WriteRune(proxima)
+				i++
+			} else if r == aspas {
+				aspas = 0
+			}
+			continue
+		}
+
+		switch {
+		case r == '-' && proxima == '-', r == '#':
+			comentarioLinha = true
+		case r == '/' && proxima == '*':
+			comentarioBloco = true
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			aspas = r
+			atual.WriteRune(r)
+		case r == ';':
+			if comando := strings.TrimSpace(atual.String()); comando != "" {
+				comandos = append(comandos, comando)
+			}
+			atual.Reset()
+		default:
+			atual.WriteRune(r)
+		}
+	}
+
+	if comando := strings.TrimSpace(atual.String()); comando != "" {
+		comandos = append(comandos, comando)
+	}
+	return comandos
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	esquema "lanchonete/db"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarregarMigracoes_Embutidas(t *testing.T) {
+	arquivos, err := fs.Sub(esquema.Migracoes, "migrations")
+	assert.NoError(t, err)
+
+	migracoes, err := CarregarMigracoes(arquivos)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(migracoes), esquema.MarcosLegado[len(esquema.MarcosLegado)-1].Versao)
+	for i, migracao := range migracoes {
+		assert.Equal(t, i+1, migracao.Versao)
+		assert.NotEmpty(t, DividirComandos(migracao.Subir), migracao.Nome)
+		assert.NotEmpty(t, DividirComandos(migracao.Descer), migracao.Nome)
+	}
+}
+
+func TestCarregarMigracoes_Invalidas(t *testing.T) {
+	sql := &fstest.MapFile{Data: []byte("SELECT 1;")}
+	testCases := []struct {
+		name     string
+		arquivos fstest.MapFS
+	}{
+		{"sem down", fstest.MapFS{"001_a.up.sql": sql}},
+		{"versão faltando", fstest.MapFS{"001_a.up.sql": sql, "001_a.down.sql": sql, "003_c.up.sql": sql, "003_c.down.sql": sql}},
+		{"nomes diferentes", fstest.MapFS{"001_a.up.sql": sql, "001_b.down.sql": sql}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CarregarMigracoes(tc.arquivos)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDividirComandos(t *testing.T) {
+	arquivo := `-- comentário; com ponto e vírgula
+CREATE TABLE t (nome VARCHAR(10) DEFAULT 'a;b'); # outro; comentário
+/* bloco; */ INSERT INTO t VALUES ('it''s; \'ok\'');
+UPDATE ` + "`t;`" + ` SET nome = "x;y"
+`
+
+	comandos := DividirComandos(arquivo)
+
+	assert.Equal(t, []string{
+		"CREATE TABLE t (nome VARCHAR(10) DEFAULT 'a;b')",
+		"INSERT INTO t VALUES ('it''s; \\'ok\\'')",
+		"UPDATE `t;` SET nome = \"x;y\"",
+	}, comandos)
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type categoriaMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewCategoriaMysqlRepository(db *sql.DB) repository.CategoriaRepository {
+	return &categoriaMysqlRepository{db: db}
+}
+
+const colunasCategoria = `idCategoria, nome, slug, ordem, ativa, obrigatoriaNoPedido, tempoPreparoMinutos`
+
+func (cr *categoriaMysqlRepository) AdicionarCategoria(c context.Context, categoria *entities.Categoria) error {
+	query := `INSERT INTO Categoria (nome, slug, ordem, ativa, obrigatoriaNoPedido, tempoPreparoMinutos) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := cr.db.ExecContext(c, query, categoria.Nome, categoria.Slug, categoria.Ordem, categoria.Ativa, categoria.ObrigatoriaNoPedido, categoria.TempoPreparoMinutos)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir categoria: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da categoria: %w", err)
+	}
+	categoria.ID = int(id)
+	return nil
+}
+
+func (cr *categoriaMysqlRepository) BuscarCategoriaPorId(c context.Context, id int) (*entities.Categoria, error) {
+	return cr.buscarCategoria(c, `idCategoria = ?`, id)
+}
+
+func (cr *categoriaMysqlRepository) BuscarCategoriaPorNome(c context.Context, nome string) (*entities.Categoria, error) {
+	// BINARY mantém a distinção entre maiúsculas e minúsculas que o ENUM fazia
+	return cr.buscarCategoria(c, `nome = BINARY ?`, nome)
+}
+
+func (cr *categoriaMysqlRepository) BuscarCategoriaPorSlug(c context.Context, slug string) (*entities.Categoria, error) {
+	return cr.buscarCategoria(c, `slug = ?`, slug)
+}
+
+func (cr *categoriaMysqlRepository) buscarCategoria(c context.Context, condicao string, valor interface{}) (*entities.Categoria, error) {
+	query := `SELECT ` + colunasCategoria + ` FROM Categoria WHERE ` + condicao
+	categoria, err := scanCategoria(cr.db.QueryRowContext(c, query, valor))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCategoriaNaoEncontrada
+		}
+		return nil, fmt.Errorf("erro ao buscar categoria: %w", err)
+	}
+	return categoria, nil
+}
+
+func (cr *categoriaMysqlRepository) ListarCategorias(c context.Context) ([]*entities.Categoria, error) {
+	query := `SELECT ` + colunasCategoria + ` FROM Categoria ORDER BY ordem, nome`
+	rows, err := cr.db.QueryContext(c, query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar categorias: %w", err)
+	}
+	defer rows.Close()
+
+	var categorias []*entities.Categoria
+	for rows.Next() {
+		categoria, err := scanCategoria(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear categoria: %w", err)
+		}
+		categorias = append(categorias, categoria)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração das categorias: %w", err)
+	}
+	return categorias, nil
+}
+
+func (cr *categoriaMysqlRepository) EditarCategoria(c context.Context, categoria *entities.Categoria) error {
+	// As chaves estrangeiras com ON UPDATE CASCADE levam o novo nome aos produtos, combos e cupons
+	query := `UPDATE Categoria SET nome = ?, slug = ?, ordem = ?, ativa = ?, obrigatoriaNoPedido = ?, tempoPreparoMinutos = ? WHERE idCategoria = ?`
+	res, err := cr.db.ExecContext(c, query, categoria.Nome, categoria.Slug, categoria.Ordem, categoria.Ativa, categoria.ObrigatoriaNoPedido, categoria.TempoPreparoMinutos, categoria.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar categoria: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar atualização: %w", err)
+	}
+	if rowsAffected == 0 {
+		// O MySQL não conta linhas sem alteração; confirmar se a categoria existe
+		if _, err := cr.BuscarCategoriaPorId(c, categoria.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cr *categoriaMysqlRepository) RemoverCategoria(c context.Context, id int) error {
+	categoria, err := cr.BuscarCategoriaPorId(c, id)
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT (SELECT COUNT(*) FROM Produto WHERE categoriaProduto = ?)
+		+ (SELECT COUNT(*) FROM ComboComponente WHERE categoria = ?)
+		+ (SELECT COUNT(*) FROM Cupom WHERE categoria = ?)`
+	var usos int
+	if err := cr.db.QueryRowContext(c, query, categoria.Nome, categoria.Nome, categoria.Nome).Scan(&usos); err != nil {
+		return fmt.Errorf("erro ao verificar uso da categoria: %w", err)
+	}
+	if usos > 0 {
+		return entities.ErrCategoriaEmUso
+	}
+
+	if _, err := cr.db.ExecContext(c, `DELETE FROM Categoria WHERE idCategoria = ?`, id); err != nil {
+		return fmt.Errorf("erro ao remover categoria: %w", err)
+	}
+	return nil
+}
+
+func scanCategoria(row scanner) (*entities.Categoria, error) {
+	var categoria entities.Categoria
+	err := row.Scan(&categoria.ID, &categoria.Nome, &categoria.Slug, &categoria.Ordem, &categoria.Ativa, &categoria.ObrigatoriaNoPedido, &categoria.TempoPreparoMinutos)
+	if err != nil {
+		return nil, err
+	}
+	return &categoria, nil
+}
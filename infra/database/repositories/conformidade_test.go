@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"os"
+	"testing"
+
+	esquema "lanchonete/db"
+	"lanchonete/infra/database"
+	"lanchonete/infra/database/conformidade"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// A suíte de conformidade roda contra um MySQL de verdade, num banco descartável que ela migra e
+// esvazia antes de cada teste:
+//
+//	CONFORMIDADE_DSN='root:password@tcp(localhost:3306)/lanchonete_teste?parseTime=true' \
+//	  go test ./infra/database/repositories -run Conformidade
+//
+// Os repositórios em memória passam pela mesma suíte em infra/database/memoria.
+func TestProdutoMysqlRepository_Conformidade(t *testing.T) {
+	conformidade.TestarProdutoRepository(t, novosRepositoriosMysql(abrirBancoConformidade(t)))
+}
+
+func TestPedidoMysqlRepository_Conformidade(t *testing.T) {
+	conformidade.TestarPedidoRepository(t, novosRepositoriosMysql(abrirBancoConformidade(t)))
+}
+
+func TestModificadorMysqlRepository_Conformidade(t *testing.T) {
+	conformidade.TestarModificadorRepository(t, novosRepositoriosMysql(abrirBancoConformidade(t)))
+}
+
+func abrirBancoConformidade(t *testing.T) *sql.DB {
+	dsn := os.Getenv("CONFORMIDADE_DSN")
+	if dsn == "" {
+		t.Skip("CONFORMIDADE_DSN não definida")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("erro ao abrir o banco: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migracoes, err := fs.Sub(esquema.Migracoes, "migrations")
+	if err != nil {
+		t.Fatalf("erro ao ler as migrações: %v", err)
+	}
+	// Um banco descartável nunca vem do init.sql, então não há esquema legado para adotar
+	migrador, err := database.NewMigrador(db, migracoes, nil)
+	if err != nil {
+		t.Fatalf("erro ao carregar as migrações: %v", err)
+	}
+	if _, err := migrador.Subir(context.Background()); err != nil {
+		t.Fatalf("erro ao migrar o banco: %v", err)
+	}
+	return db
+}
+
+// novosRepositoriosMysql esvazia todas as tabelas, inclusive o cardápio de exemplo das migrações,
+// e recomeça os IDs, para que cada teste parta de um banco vazio
+func novosRepositoriosMysql(db *sql.DB) conformidade.Fabrica {
+	return func(t *testing.T) conformidade.Repositorios {
+		ctx := context.Background()
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("erro ao conectar ao banco: %v", err)
+		}
+		defer conn.Close()
+
+		rows, err := conn.QueryContext(ctx, `SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name <> 'SchemaMigracao'`)
+		if err != nil {
+			t.Fatalf("erro ao listar as tabelas: %v", err)
+		}
+		var tabelas []string
+		for rows.Next() {
+			var tabela string
+			if err := rows.Scan(&tabela); err != nil {
+				t.Fatalf("erro ao listar as tabelas: %v", err)
+			}
+			tabelas = append(tabelas, tabela)
+		}
+		rows.Close()
+
+		// As chaves estrangeiras são desligadas só nesta conexão, enquanto as tabelas são esvaziadas
+		comandos := []string{"SET FOREIGN_KEY_CHECKS = 0"}
+		for _, tabela := range tabelas {
+			comandos = append(comandos, "TRUNCATE TABLE `"+tabela+"`")
+		}
+		comandos = append(comandos, "SET FOREIGN_KEY_CHECKS = 1")
+		for _, comando := range comandos {
+			if _, err := conn.ExecContext(ctx, comando); err != nil {
+				t.Fatalf("erro ao esvaziar o banco (%s): %v", comando, err)
+			}
+		}
+
+		return conformidade.Repositorios{
+			Categorias:    NewCategoriaMysqlRepository(db),
+			Produtos:      NewProdutoMysqlRepository(db),
+			Pedidos:       NewPedidoMysqlRepository(db),
+			Cupons:        NewCupomMysqlRepository(db),
+			Modificadores: NewModificadorMysqlRepository(db),
+		}
+	}
+}
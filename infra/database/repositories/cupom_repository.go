@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type cupomMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewCupomMysqlRepository(db *sql.DB) repository.CupomRepository {
+	return &cupomMysqlRepository{db: db}
+}
+
+const colunasCupom = `idCupom, codigo, tipo, percentual, valor, compre, ganhe, categoria, valorMinimo, validoDe, validoAte, limiteUsos, usos`
+
+func (cr *cupomMysqlRepository) AdicionarCupom(c context.Context, cupom *entities.Cupom) error {
+	query := `INSERT INTO Cupom (codigo, tipo, percentual, valor, compre, ganhe, categoria, valorMinimo, validoDe, validoAte, limiteUsos)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := cr.db.ExecContext(c, query, cupom.Codigo, cupom.Tipo, cupom.Percentual, cupom.Valor, cupom.Compre, cupom.Ganhe,
+		categoriaOuNulo(cupom.Categoria), cupom.ValorMinimo, cupom.ValidoDe, cupom.ValidoAte, cupom.LimiteUsos)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir cupom: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do cupom: %w", err)
+	}
+	cupom.ID = int(id)
+	return nil
+}
+
+func (cr *cupomMysqlRepository) BuscarCupomPorCodigo(c context.Context, codigo string) (*entities.Cupom, error) {
+	query := `SELECT ` + colunasCupom + ` FROM Cupom WHERE codigo = ?`
+	cupom, err := scanCupom(cr.db.QueryRowContext(c, query, codigo))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCupomNaoEncontrado
+		}
+		return nil, fmt.Errorf("erro ao buscar cupom: %w", err)
+	}
+	return cupom, nil
+}
+
+func (cr *cupomMysqlRepository) ListarCupons(c context.Context) ([]*entities.Cupom, error) {
+	query := `SELECT ` + colunasCupom + ` FROM Cupom ORDER BY codigo`
+	rows, err := cr.db.QueryContext(c, query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar cupons: %w", err)
+	}
+	defer rows.Close()
+
+	var cupons []*entities.Cupom
+	for rows.Next() {
+		cupom, err := scanCupom(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao escanear cupom: %w", err)
+		}
+		cupons = append(cupons, cupom)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração dos cupons: %w", err)
+	}
+	return cupons, nil
+}
+
+func (cr *cupomMysqlRepository) EditarCupom(c context.Context, cupom *entities.Cupom) error {
+	query := `UPDATE Cupom SET tipo = ?, percentual = ?, valor = ?, compre = ?, ganhe = ?, categoria = ?, valorMinimo = ?,
+		validoDe = ?, validoAte = ?, limiteUsos = ? WHERE codigo = ?`
+	res, err := cr.db.ExecContext(c, query, cupom.Tipo, cupom.Percentual, cupom.Valor, cupom.Compre, cupom.Ganhe,
+		categoriaOuNulo(cupom.Categoria), cupom.ValorMinimo, cupom.ValidoDe, cupom.ValidoAte, cupom.LimiteUsos, cupom.Codigo)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar cupom: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar atualização: %w", err)
+	}
+	if rowsAffected == 0 {
+		// O MySQL não conta linhas sem alteração; confirmar se o cupom existe
+		if _, err := cr.BuscarCupomPorCodigo(c, cupom.Codigo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cr *cupomMysqlRepository) RemoverCupom(c context.Context, codigo string) error {
+	res, err := cr.db.ExecContext(c, `DELETE FROM Cupom WHERE codigo = ?`, codigo)
+	if err != nil {
+		return fmt.Errorf("erro ao remover cupom: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar remoção: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.ErrCupomNaoEncontrado
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCupom(row scanner) (*entities.Cupom, error) {
+	var cupom entities.Cupom
+	var categoria sql.NullString
+	var validoDe, validoAte sql.NullTime
+	err := row.Scan(&cupom.ID, &cupom.Codigo, &cupom.Tipo, &cupom.Percentual, &cupom.Valor, &cupom.Compre, &cupom.Ganhe,
+		&categoria, &cupom.ValorMinimo, &validoDe, &validoAte, &cupom.LimiteUsos, &cupom.Usos)
+	if err != nil {
+		return nil, err
+	}
+
+	cupom.Categoria = entities.CatProduto(categoria.String)
+	cupom.ValidoDe = validoDe.Time
+	if validoAte.Valid {
+		cupom.ValidoAte = &validoAte.Time
+	}
+	return &cupom, nil
+}
+
+func categoriaOuNulo(categoria entities.CatProduto) interface{} {
+	if categoria == "" {
+		return nil
+	}
+	return categoria
+}
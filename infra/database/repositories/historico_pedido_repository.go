@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type historicoPedidoMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewHistoricoPedidoMysqlRepository(db *sql.DB) repository.HistoricoPedidoRepository {
+	return &historicoPedidoMysqlRepository{db: db}
+}
+
+func (hr *historicoPedidoMysqlRepository) RegistrarAlteracao(c context.Context, alteracao *entities.AlteracaoPedido) error {
+	query := `INSERT INTO Pedido_Historico (idPedido, campo, statusAnterior, statusNovo, origem, alteradoEm) VALUES (?, ?, ?, ?, ?, ?)`
+	var de *string
+	if alteracao.De != "" {
+		de = &alteracao.De
+	}
+	res, err := conexao(c, hr.db).ExecContext(c, query,
+		alteracao.PedidoID,
+		alteracao.Campo,
+		de,
+		alteracao.Para,
+		alteracao.Origem,
+		alteracao.AlteradoEm,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar alteração do pedido: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da alteração do pedido: %w", err)
+	}
+	alteracao.ID = int(id)
+
+	return nil
+}
+
+func (hr *historicoPedidoMysqlRepository) ListarHistorico(c context.Context, pedidoID int) ([]entities.AlteracaoPedido, error) {
+	query := `SELECT idAlteracao, idPedido, campo, statusAnterior, statusNovo, origem, alteradoEm
+		FROM Pedido_Historico WHERE idPedido = ? ORDER BY alteradoEm, idAlteracao`
+
+	rows, err := hr.db.QueryContext(c, query, pedidoID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico do pedido: %w", err)
+	}
+	defer rows.Close()
+
+	historico := []entities.AlteracaoPedido{}
+	for rows.Next() {
+		var a entities.AlteracaoPedido
+		var de sql.NullString
+		if err := rows.Scan(&a.ID, &a.PedidoID, &a.Campo, &de, &a.Para, &a.Origem, &a.AlteradoEm); err != nil {
+			return nil, fmt.Errorf("erro ao escanear alteração do pedido: %w", err)
+		}
+		a.De = de.String
+		historico = append(historico, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração do histórico do pedido: %w", err)
+	}
+
+	return historico, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type imagemProdutoMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewImagemProdutoMysqlRepository(db *sql.DB) repository.ImagemProdutoRepository {
+	return &imagemProdutoMysqlRepository{db: db}
+}
+
+func (ir *imagemProdutoMysqlRepository) ListarImagens(c context.Context, produtoID int) ([]entities.ImagemProduto, error) {
+	return listarImagensPorProduto(c, ir.db, produtoID)
+}
+
+func (ir *imagemProdutoMysqlRepository) SalvarImagens(c context.Context, produtoID int, imagens []entities.ImagemProduto) error {
+	tx, err := ir.db.BeginTx(c, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	if _, err := tx.ExecContext(c, `DELETE FROM ProdutoImagem WHERE idProduto = ?`, produtoID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao remover imagens anteriores do produto: %w", err)
+	}
+
+	query := `INSERT INTO ProdutoImagem (idProduto, variante, chave, url, largura, altura) VALUES (?, ?, ?, ?, ?, ?)`
+	for _, imagem := range imagens {
+		if _, err := tx.ExecContext(c, query, produtoID, imagem.Variante, imagem.Chave, imagem.URL, imagem.Largura, imagem.Altura); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao inserir imagem do produto: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+func (ir *imagemProdutoMysqlRepository) RemoverImagens(c context.Context, produtoID int) error {
+	if _, err := ir.db.ExecContext(c, `DELETE FROM ProdutoImagem WHERE idProduto = ?`, produtoID); err != nil {
+		return fmt.Errorf("erro ao remover imagens do produto: %w", err)
+	}
+	return nil
+}
+
+// listarImagensPorProduto carrega as variantes de imagem gravadas para um produto
+func listarImagensPorProduto(c context.Context, db executor, produtoID int) ([]entities.ImagemProduto, error) {
+	imagens, err := listarImagensDosProdutos(c, db, []int{produtoID})
+	if err != nil {
+		return nil, err
+	}
+	return imagens[produtoID], nil
+}
+
+// listarImagensDosProdutos carrega numa só consulta as variantes de imagem dos produtos, por ID do produto
+func listarImagensDosProdutos(c context.Context, db executor, produtoIDs []int) (map[int][]entities.ImagemProduto, error) {
+	imagens := map[int][]entities.ImagemProduto{}
+	if len(produtoIDs) == 0 {
+		return imagens, nil
+	}
+
+	marcadores, args := listaIN(produtoIDs)
+	query := `SELECT idProduto, variante, chave, url, largura, altura FROM ProdutoImagem
+		WHERE idProduto IN (` + marcadores + `)
+		ORDER BY idProduto, largura DESC`
+	rows, err := db.QueryContext(c, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar imagens do produto: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var produtoID int
+		var imagem entities.ImagemProduto
+		if err := rows.Scan(&produtoID, &imagem.Variante, &imagem.Chave, &imagem.URL, &imagem.Largura, &imagem.Altura); err != nil {
+			return nil, fmt.Errorf("erro ao escanear imagem do produto: %w", err)
+		}
+		imagens[produtoID] = append(imagens[produtoID], imagem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração das imagens do produto: %w", err)
+	}
+	return imagens, nil
+}
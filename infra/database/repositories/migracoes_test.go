@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"os"
+	"testing"
+
+	esquema "lanchonete/db"
+	"lanchonete/infra/database"
+	"lanchonete/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Os testes das migrações usam o mesmo banco descartável da suíte de conformidade (CONFORMIDADE_DSN):
+// apagam todas as tabelas, montam o ponto de partida e deixam o banco na última versão.
+
+func TestMigrador_BancoDoInitSQLBase(t *testing.T) {
+	db := abrirBancoVazio(t)
+	ctx := context.Background()
+
+	// O esquema e o cardápio do antigo db/init.sql, com um pedido feito antes das migrações
+	initSQL, err := os.ReadFile("testdata/init_base.sql")
+	if err != nil {
+		t.Fatalf("erro ao ler o init.sql: %v", err)
+	}
+	executar(t, db, string(initSQL))
+	executar(t, db, `INSERT INTO Pedido (idPedido, clienteNome, totalPedido, status, statusPagamento) VALUES (1, 'Maria', 28.5, 'Recebido', 'Pago');
+		INSERT INTO Pedido_Produto (idPedido, idProduto, quantidade) VALUES (1, 1, 1), (1, 2, 1);`)
+
+	migrador := novoMigrador(t, db)
+	ultima := totalMigracoes(t)
+
+	aplicadas, err := migrador.Subir(ctx)
+
+	if err != nil {
+		t.Fatalf("erro ao migrar o banco do init.sql: %v", err)
+	}
+	assert.Equal(t, ultima-1, aplicadas, "o esquema base é registrado sem ser executado")
+	versao, err := migrador.Versao(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, ultima, versao)
+
+	pedido, err := NewPedidoMysqlRepository(db).BuscarPedido(ctx, 1)
+	if err != nil {
+		t.Fatalf("erro ao buscar o pedido anterior às migrações: %v", err)
+	}
+	assert.Equal(t, "Maria", pedido.ClienteNome)
+	assert.Equal(t, entities.Recebido, pedido.Status)
+	assert.Equal(t, entities.PagamentoPago, pedido.StatusPagamento)
+	assert.Equal(t, int64(2850), pedido.Total.Centavos)
+	assert.False(t, pedido.CriadoEm.IsZero())
+	if assert.Len(t, pedido.Itens, 2) {
+		assert.Equal(t, "X-Salada", pedido.Itens[0].Produto.Nome, "a linha recebe o nome do catálogo")
+		assert.Equal(t, int64(2250), pedido.Itens[0].Produto.Preco.Centavos)
+	}
+
+	// O banco já tinha pedidos, então o cardápio da loja não ganha o combo de exemplo
+	var combos int
+	assert.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM Produto WHERE categoriaProduto = 'Combo'`).Scan(&combos))
+	assert.Zero(t, combos)
+
+	// Todas as migrações desfazem até o esquema base e voltam a subir
+	desfeitas, err := migrador.Descer(ctx, ultima-1)
+	if err != nil {
+		t.Fatalf("erro ao desfazer as migrações: %v", err)
+	}
+	assert.Equal(t, ultima-1, desfeitas)
+	aplicadas, err = migrador.Subir(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, ultima-1, aplicadas)
+}
+
+func TestMigrador_BancoDoInitSQLIntermediario(t *testing.T) {
+	db := abrirBancoVazio(t)
+	ctx := context.Background()
+
+	// Um banco do init.sql de antes do histórico de produtos, no ponto em que a migração 9 o deixava
+	migrador := novoMigrador(t, db)
+	if _, err := migrador.Subir(ctx); err != nil {
+		t.Fatalf("erro ao migrar o banco: %v", err)
+	}
+	if _, err := migrador.Descer(ctx, totalMigracoes(t)-9); err != nil {
+		t.Fatalf("erro ao desfazer as migrações: %v", err)
+	}
+	executar(t, db, "DROP TABLE SchemaMigracao")
+
+	aplicadas, err := novoMigrador(t, db).Subir(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, totalMigracoes(t)-9, aplicadas)
+}
+
+func TestMigrador_BancoNovo(t *testing.T) {
+	db := abrirBancoVazio(t)
+	ctx := context.Background()
+
+	aplicadas, err := novoMigrador(t, db).Subir(ctx)
+
+	if err != nil {
+		t.Fatalf("erro ao migrar o banco: %v", err)
+	}
+	assert.Equal(t, totalMigracoes(t), aplicadas)
+
+	// Num banco novo o cardápio de exemplo vem completo, com o combo e os modificadores
+	combo, err := NewProdutoMysqlRepository(db).BuscarProdutoPorId(ctx, 11)
+	if err != nil {
+		t.Fatalf("erro ao buscar o combo de exemplo: %v", err)
+	}
+	assert.Equal(t, "Combo X-Salada", combo.Nome)
+	assert.Len(t, combo.Componentes, 3)
+	grupos, err := NewModificadorMysqlRepository(db).ListarGruposPorProduto(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, grupos, 2)
+}
+
+// abrirBancoVazio apaga todas as tabelas do banco descartável, inclusive a de versões
+func abrirBancoVazio(t *testing.T) *sql.DB {
+	dsn := os.Getenv("CONFORMIDADE_DSN")
+	if dsn == "" {
+		t.Skip("CONFORMIDADE_DSN não definida")
+	}
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("erro ao abrir o banco: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query(`SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE()`)
+	if err != nil {
+		t.Fatalf("erro ao listar as tabelas: %v", err)
+	}
+	comandos := "SET FOREIGN_KEY_CHECKS = 0;"
+	for rows.Next() {
+		var tabela string
+		if err := rows.Scan(&tabela); err != nil {
+			t.Fatalf("erro ao listar as tabelas: %v", err)
+		}
+		comandos += "DROP TABLE `" + tabela + "`;"
+	}
+	rows.Close()
+	executar(t, db, comandos+"SET FOREIGN_KEY_CHECKS = 1;")
+	return db
+}
+
+// executar roda os comandos numa única conexão, para que os ajustes da sessão valham para todos
+func executar(t *testing.T, db *sql.DB, comandos string) {
+	t.Helper()
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("erro ao conectar ao banco: %v", err)
+	}
+	defer conn.Close()
+	for _, comando := range database.DividirComandos(comandos) {
+		if _, err := conn.ExecContext(context.Background(), comando); err != nil {
+			t.Fatalf("erro ao executar %q: %v", comando, err)
+		}
+	}
+}
+
+func novoMigrador(t *testing.T, db *sql.DB) *database.Migrador {
+	t.Helper()
+	migracoes, err := fs.Sub(esquema.Migracoes, "migrations")
+	if err != nil {
+		t.Fatalf("erro ao ler as migrações: %v", err)
+	}
+	migrador, err := database.NewMigrador(db, migracoes, esquema.MarcosLegado)
+	if err != nil {
+		t.Fatalf("erro ao carregar as migrações: %v", err)
+	}
+	return migrador
+}
+
+func totalMigracoes(t *testing.T) int {
+	t.Helper()
+	migracoes, err := fs.Sub(esquema.Migracoes, "migrations")
+	if err != nil {
+		t.Fatalf("erro ao ler as migrações: %v", err)
+	}
+	lista, err := database.CarregarMigracoes(migracoes)
+	if err != nil {
+		t.Fatalf("erro ao carregar as migrações: %v", err)
+	}
+	return len(lista)
+}
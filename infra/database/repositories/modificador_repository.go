@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type modificadorMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewModificadorMysqlRepository(db *sql.DB) repository.ModificadorRepository {
+	return &modificadorMysqlRepository{db: db}
+}
+
+func (mr *modificadorMysqlRepository) AdicionarGrupo(c context.Context, grupo *entities.GrupoModificador) error {
+	tx, err := mr.db.BeginTx(c, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	query := `INSERT INTO GrupoModificador (idProduto, nomeGrupo, minSelecoes, maxSelecoes) VALUES (?, ?, ?, ?)`
+	res, err := tx.ExecContext(c, query, grupo.ProdutoID, grupo.Nome, grupo.MinSelecoes, grupo.MaxSelecoes)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao inserir grupo de modificadores: %w", err)
+	}
+
+	grupoID, err := res.LastInsertId()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao obter ID do grupo de modificadores: %w", err)
+	}
+	grupo.ID = int(grupoID)
+
+	opcaoQuery := `INSERT INTO Modificador (idGrupo, nomeModificador, precoModificador) VALUES (?, ?, ?)`
+	for i := range grupo.Opcoes {
+		res, err := tx.ExecContext(c, opcaoQuery, grupo.ID, grupo.Opcoes[i].Nome, grupo.Opcoes[i].Preco)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao inserir modificador: %w", err)
+		}
+
+		opcaoID, err := res.LastInsertId()
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao obter ID do modificador: %w", err)
+		}
+		grupo.Opcoes[i].ID = int(opcaoID)
+		grupo.Opcoes[i].GrupoID = grupo.ID
+	}
+
+	return tx.Commit()
+}
+
+func (mr *modificadorMysqlRepository) ListarGruposPorProduto(c context.Context, produtoID int) ([]entities.GrupoModificador, error) {
+	return listarGruposPorProduto(c, mr.db, produtoID)
+}
+
+func (mr *modificadorMysqlRepository) RemoverGrupo(c context.Context, grupoID int) error {
+	query := `DELETE FROM GrupoModificador WHERE idGrupo = ?`
+	result, err := mr.db.ExecContext(c, query, grupoID)
+	if err != nil {
+		return fmt.Errorf("erro ao remover grupo de modificadores: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar remoção: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("grupo de modificadores não encontrado")
+	}
+
+	return nil
+}
+
+// listarGruposPorProduto carrega os grupos de modificadores de um produto com suas opções, inclusive os
+// grupos ainda sem nenhuma opção
+func listarGruposPorProduto(c context.Context, db *sql.DB, produtoID int) ([]entities.GrupoModificador, error) {
+	query := `SELECT g.idGrupo, g.idProduto, g.nomeGrupo, g.minSelecoes, g.maxSelecoes, m.idModificador, m.nomeModificador, m.precoModificador
+		FROM GrupoModificador g
+		LEFT JOIN Modificador m ON m.idGrupo = g.idGrupo
+		WHERE g.idProduto = ?
+		ORDER BY g.idGrupo, m.idModificador`
+
+	rows, err := db.QueryContext(c, query, produtoID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar modificadores do produto: %w", err)
+	}
+	defer rows.Close()
+
+	grupos := []entities.GrupoModificador{}
+	for rows.Next() {
+		var g entities.GrupoModificador
+		var modificadorID sql.NullInt64
+		var nome sql.NullString
+		var preco entities.Money
+		if err := rows.Scan(&g.ID, &g.ProdutoID, &g.Nome, &g.MinSelecoes, &g.MaxSelecoes, &modificadorID, &nome, &preco); err != nil {
+			return nil, fmt.Errorf("erro ao escanear modificador: %w", err)
+		}
+
+		if len(grupos) == 0 || grupos[len(grupos)-1].ID != g.ID {
+			g.Opcoes = []entities.Modificador{}
+			grupos = append(grupos, g)
+		}
+		// Um grupo sem opções vem numa única linha, com as colunas do modificador nulas
+		if !modificadorID.Valid {
+			continue
+		}
+		atual := &grupos[len(grupos)-1]
+		atual.Opcoes = append(atual.Opcoes, entities.Modificador{ID: int(modificadorID.Int64), GrupoID: g.ID, Nome: nome.String, Preco: preco})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração dos modificadores: %w", err)
+	}
+
+	return grupos, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// tamanhoMaximoErro é o tamanho da coluna ultimoErro
+const tamanhoMaximoErro = 500
+
+type outboxMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxMysqlRepository(db *sql.DB) repository.OutboxRepository {
+	return &outboxMysqlRepository{db: db}
+}
+
+const colunasOutbox = `idEvento, fila, tipo, payload, status, tentativas, ultimoErro, criadoEm, proximaTentativa, publicadoEm`
+
+func (or *outboxMysqlRepository) RegistrarEvento(c context.Context, evento *entities.EventoOutbox) error {
+	query := `INSERT INTO Outbox (fila, tipo, payload, status, tentativas, criadoEm, proximaTentativa) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := conexao(c, or.db).ExecContext(c, query,
+		evento.Fila,
+		evento.Tipo,
+		[]byte(evento.Payload),
+		evento.Status,
+		evento.Tentativas,
+		evento.CriadoEm,
+		evento.ProximaTentativa,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar evento na outbox: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID do evento: %w", err)
+	}
+	evento.ID = id
+
+	return nil
+}
+
+// ListarPendentes usa SKIP LOCKED para que duas instâncias do relay não peguem o mesmo evento
+func (or *outboxMysqlRepository) ListarPendentes(c context.Context, agora time.Time, limite int) ([]*entities.EventoOutbox, error) {
+	query := `SELECT ` + colunasOutbox + ` FROM Outbox WHERE status = ? AND proximaTentativa <= ? ORDER BY idEvento LIMIT ? FOR UPDATE SKIP LOCKED`
+	return or.listarEventos(c, query, entities.EventoPendente, agora, limite)
+}
+
+func (or *outboxMysqlRepository) ListarNaoPublicados(c context.Context) ([]*entities.EventoOutbox, error) {
+	query := `SELECT ` + colunasOutbox + ` FROM Outbox WHERE status <> ? ORDER BY idEvento`
+	return or.listarEventos(c, query, entities.EventoPublicado)
+}
+
+func (or *outboxMysqlRepository) AtualizarEvento(c context.Context, evento *entities.EventoOutbox) error {
+	ultimoErro := sql.NullString{String: evento.UltimoErro, Valid: evento.UltimoErro != ""}
+	if len(ultimoErro.String) > tamanhoMaximoErro {
+		ultimoErro.String = ultimoErro.String[:tamanhoMaximoErro]
+	}
+
+	query := `UPDATE Outbox SET status = ?, tentativas = ?, ultimoErro = ?, proximaTentativa = ?, publicadoEm = ? WHERE idEvento = ?`
+	result, err := conexao(c, or.db).ExecContext(c, query,
+		evento.Status,
+		evento.Tentativas,
+		ultimoErro,
+		evento.ProximaTentativa,
+		evento.PublicadoEm,
+		evento.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar evento da outbox: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar atualização: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("evento não encontrado")
+	}
+
+	return nil
+}
+
+func (or *outboxMysqlRepository) BuscarEvento(c context.Context, id int64) (*entities.EventoOutbox, error) {
+	eventos, err := or.listarEventos(c, `SELECT `+colunasOutbox+` FROM Outbox WHERE idEvento = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(eventos) == 0 {
+		return nil, fmt.Errorf("evento não encontrado")
+	}
+	return eventos[0], nil
+}
+
+func (or *outboxMysqlRepository) listarEventos(c context.Context, query string, args ...interface{}) ([]*entities.EventoOutbox, error) {
+	rows, err := conexao(c, or.db).QueryContext(c, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar eventos da outbox: %w", err)
+	}
+	defer rows.Close()
+
+	eventos := []*entities.EventoOutbox{}
+	for rows.Next() {
+		var e entities.EventoOutbox
+		var payload []byte
+		var ultimoErro sql.NullString
+		var publicadoEm sql.NullTime
+		if err := rows.Scan(
+			&e.ID,
+			&e.Fila,
+			&e.Tipo,
+			&payload,
+			&e.Status,
+			&e.Tentativas,
+			&ultimoErro,
+			&e.CriadoEm,
+			&e.ProximaTentativa,
+			&publicadoEm,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao escanear evento da outbox: %w", err)
+		}
+
+		e.Payload = payload
+		e.UltimoErro = ultimoErro.String
+		if publicadoEm.Valid {
+			e.PublicadoEm = &publicadoEm.Time
+		}
+		eventos = append(eventos, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração dos eventos da outbox: %w", err)
+	}
+
+	return eventos, nil
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"strings"
+
+	"lanchonete/internal/domain/entities"
+)
+
+// As listagens paginadas usam keyset: a página seguinte continua do valor do campo ordenado e do ID
+// guardados no cursor, lendo pelo índice (campo, ID) em vez de pular linhas com OFFSET.
+
+// clausulaWhere junta as condições dos filtros, ou devolve vazio quando não há filtro
+func clausulaWhere(condicoes []string) string {
+	if len(condicoes) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(condicoes, ` AND `)
+}
+
+// listaIN monta os marcadores e os argumentos de uma condição IN com os IDs
+func listaIN(ids []int) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", "), args
+}
+
+// depoisDoCursor é a condição das linhas que vêm depois do cursor na ordenação; recebe como
+// argumentos o valor do cursor duas vezes e o ID
+func depoisDoCursor(coluna, colunaID string, ordem entities.Ordenacao) string {
+	comparacao := ">"
+	if ordem.Decrescente {
+		comparacao = "<"
+	}
+	return `(` + coluna + ` ` + comparacao + ` ? OR (` + coluna + ` = ? AND ` + colunaID + ` ` + comparacao + ` ?))`
+}
+
+// ordenarPor ordena pelo campo pedido e desempata pelo ID, no mesmo sentido
+func ordenarPor(coluna, colunaID string, ordem entities.Ordenacao) string {
+	sentido := "ASC"
+	if ordem.Decrescente {
+		sentido = "DESC"
+	}
+	return ` ORDER BY ` + coluna + ` ` + sentido + `, ` + colunaID + ` ` + sentido
+}
+
+// escaparLike protege os curingas do LIKE no texto buscado
+func escaparLike(texto string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(texto)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"lanchonete/internal/domain/entities"
@@ -19,16 +21,55 @@ func NewPedidoMysqlRepository(db *sql.DB) repository.PedidoRepository {
 }
 
 func (pr *pedidoMysqlRepository) CriarPedido(c context.Context, pedido *entities.Pedido) error {
-	tx, err := pr.db.BeginTx(c, nil)
+	tx, err := iniciarTransacao(c, pr.db)
 	if err != nil {
 		return fmt.Errorf("erro ao iniciar transação: %w", err)
 	}
 
-	query := `INSERT INTO Pedido (clienteNome, totalPedido, tempoEstimado, status, statusPagamento, personalizacao) VALUES (?, ?, ?, ?, ?, ?)`
+	// Reservar o estoque na mesma transação, antes de qualquer outra escrita
+	controlados, err := reservarEstoque(c, tx.Tx, pedido)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Contabilizar o uso do cupom na mesma transação, respeitando o limite mesmo com pedidos simultâneos
+	if pedido.Cupom != nil {
+		usoQuery := `UPDATE Cupom SET usos = usos + 1 WHERE codigo = ? AND (limiteUsos = 0 OR usos < limiteUsos)`
+		res, err := tx.ExecContext(c, usoQuery, *pedido.Cupom)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao registrar uso do cupom: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			tx.Rollback()
+			return entities.ErrCupomEsgotado
+		}
+	}
+
+	dia := diaRetirada(pedido.UltimaAtualizacao)
+	codigo, err := proximoCodigoRetirada(c, tx.Tx, dia)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	cliente := novoClientePersistido(pedido.Cliente)
+	query := `INSERT INTO Pedido (clienteNome, clienteCpf, clienteIdExterno, codigoRetirada, dataRetirada, subtotalPedido, descontoPedido, totalPedido, cupom, tempoEstimado, previsaoPronto, criadoEm, ultimaAtualizacao, status, statusPagamento, personalizacao) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	res, err := tx.ExecContext(c, query,
 		pedido.ClienteNome,
+		cliente.cpf,
+		cliente.idExterno,
+		codigo,
+		dia,
+		pedido.Subtotal,
+		pedido.Desconto,
 		pedido.Total,
-		"00:15:00",
+		pedido.Cupom,
+		entities.FormatarDuracao(pedido.TempoEstimado),
+		pedido.PrevisaoPronto,
+		pedido.CriadoEm,
+		pedido.UltimaAtualizacao,
 		pedido.Status,
 		pedido.StatusPagamento,
 		pedido.Personalizacao,
@@ -44,73 +85,147 @@ func (pr *pedidoMysqlRepository) CriarPedido(c context.Context, pedido *entities
 		return fmt.Errorf("erro ao obter ID do pedido: %w", err)
 	}
 	pedido.ID = int(pedidoID)
-
-	// Inserir produtos relacionados
-	prodQuery := `INSERT INTO Pedido_Produto (idPedido, idProduto, quantidade) VALUES (?, ?, ?)`
-	for _, prod := range pedido.Produtos {
-		_, err := tx.ExecContext(c, prodQuery, pedidoID, prod.ID, 1)
+	pedido.CodigoRetirada = codigo
+
+	// Inserir itens relacionados, copiando nome, categoria e preço do produto, dos modificadores e dos
+	// componentes de combo no momento da compra. Cada linha guarda as unidades que tirou do estoque, que o
+	// cancelamento devolve.
+	prodQuery := `INSERT INTO Pedido_Produto (idPedido, idProduto, nomeProduto, categoriaProduto, precoProduto, quantidade, quantidadeReservada) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	modQuery := `INSERT INTO Pedido_Produto_Modificador (idPedidoProduto, idModificador, nomeModificador, precoModificador) VALUES (?, ?, ?, ?)`
+	compQuery := `INSERT INTO Pedido_Produto_Componente (idPedidoProduto, idProduto, nomeProduto, categoriaProduto, precoProduto, quantidade, quantidadeReservada) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	for _, item := range pedido.Itens {
+		res, err := tx.ExecContext(c, prodQuery, pedidoID, item.Produto.ID, item.Produto.Nome, item.Produto.Categoria, item.Produto.Preco, item.Quantidade, reservado(controlados, item.Produto.ID, item.Quantidade))
 		if err != nil {
 			tx.Rollback()
 			return fmt.Errorf("erro ao inserir produto no pedido: %w", err)
 		}
+
+		itemID, err := res.LastInsertId()
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao obter ID do item do pedido: %w", err)
+		}
+
+		for _, mod := range item.Modificadores {
+			_, err := tx.ExecContext(c, modQuery, itemID, mod.ID, mod.Nome, mod.Preco)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("erro ao inserir modificador do item: %w", err)
+			}
+		}
+
+		for _, componente := range item.Componentes {
+			_, err := tx.ExecContext(c, compQuery, itemID, componente.Produto.ID, componente.Produto.Nome, componente.Produto.Categoria, componente.Produto.Preco, componente.Quantidade, reservado(controlados, componente.Produto.ID, componente.Quantidade*item.Quantidade))
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("erro ao inserir componente do combo: %w", err)
+			}
+		}
 	}
 
 	return tx.Commit()
 }
 
-func (pr *pedidoMysqlRepository) BuscarPedido(c context.Context, identificacao int) (*entities.Pedido, error) {
-	query := `SELECT idPedido, clienteNome, totalPedido, tempoEstimado, status, statusPagamento, personalizacao FROM Pedido WHERE idPedido = ?`
-
-	var pedido entities.Pedido
-	var clienteNome string
-	var tempoEstimado string
-	var personalizacao *string
+// proximoCodigoRetirada avança a sequência de retirada do dia e devolve o código do pedido.
+// A linha do dia fica bloqueada até o fim da transação, então pedidos simultâneos recebem códigos diferentes
+// e uma transação desfeita não consome o código.
+func proximoCodigoRetirada(c context.Context, tx *sql.Tx, dia string) (string, error) {
+	query := `INSERT INTO SequenciaRetirada (dia, ultimo) VALUES (?, LAST_INSERT_ID(1)) ON DUPLICATE KEY UPDATE ultimo = LAST_INSERT_ID(ultimo + 1)`
+	if _, err := tx.ExecContext(c, query, dia); err != nil {
+		return "", fmt.Errorf("erro ao gerar código de retirada: %w", err)
+	}
 
-	fmt.Println("TimeStamp: ", pedido.TimeStamp, "ID: ", identificacao)
-	err := pr.db.QueryRowContext(c, query, identificacao).Scan(
-		&pedido.ID,
-		&clienteNome,
-		&pedido.Total,
-		&tempoEstimado,
-		&pedido.Status,
-		&pedido.StatusPagamento,
-		&personalizacao,
-	)
-	fmt.Println("Repository pedido: ", pedido.TimeStamp)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("pedido não encontrado")
-		}
-		return nil, fmt.Errorf("erro ao buscar pedido: %w", err)
+	var sequencia int
+	if err := tx.QueryRowContext(c, `SELECT LAST_INSERT_ID()`).Scan(&sequencia); err != nil {
+		return "", fmt.Errorf("erro ao obter sequência de retirada: %w", err)
 	}
+	return entities.CodigoRetiradaNew(sequencia)
+}
 
-	pedido.Produtos = []entities.Produto{}
-	pedido.ClienteNome = clienteNome
-	pedido.Personalizacao = personalizacao
+// diaRetirada é a data, no fuso da lanchonete, em que a sequência de retirada é contada.
+// Vai como texto para o driver não converter a data para UTC.
+func diaRetirada(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
 
-	// Buscar produtos
-	prodQuery := `SELECT p.idProduto, p.nomeProduto, p.descricaoProduto, p.precoProduto, p.categoriaProduto FROM Produto p JOIN Pedido_Produto pp ON pp.idProduto = p.idProduto WHERE pp.idPedido = ?`
+// reservarEstoque bloqueia as linhas dos produtos do pedido, recusa o pedido se algum estiver
+// indisponível e baixa o estoque dos produtos controlados, que devolve indexados pelo ID
+func reservarEstoque(c context.Context, tx *sql.Tx, pedido *entities.Pedido) (map[int]bool, error) {
+	consumo := entities.ConsumoDeProdutos(pedido.Itens)
+	// Bloquear sempre na mesma ordem evita deadlock entre pedidos simultâneos
+	sort.Slice(consumo, func(i, j int) bool { return consumo[i].Produto.ID < consumo[j].Produto.ID })
+
+	var indisponiveis []string
+	for i := range consumo {
+		produto := &consumo[i].Produto
+		var estoque sql.NullInt64
+		err := tx.QueryRowContext(c, `SELECT esgotado, estoque, archived_at FROM Produto WHERE idProduto = ? FOR UPDATE`, produto.ID).
+			Scan(&produto.Esgotado, &estoque, &produto.ArquivadoEm)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao verificar estoque do produto %d: %w", produto.ID, err)
+		}
 
-	rows, err := pr.db.QueryContext(c, prodQuery, identificacao)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar produtos do pedido: %w", err)
+		produto.Estoque = nil
+		if estoque.Valid {
+			restante := int(estoque.Int64)
+			produto.Estoque = &restante
+		}
+		if !produto.Disponivel(consumo[i].Quantidade) {
+			indisponiveis = append(indisponiveis, produto.Nome)
+		}
+	}
+	if len(indisponiveis) > 0 {
+		return nil, &entities.ProdutosIndisponiveisError{Produtos: indisponiveis}
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var p entities.Produto
-		if err := rows.Scan(&p.ID, &p.Nome, &p.Descricao, &p.Preco, &p.Categoria); err != nil {
-			return nil, fmt.Errorf("erro ao escanear produto: %v", err)
+	controlados := map[int]bool{}
+	for _, item := range consumo {
+		if !item.Produto.ControlaEstoque() {
+			continue
+		}
+		if _, err := tx.ExecContext(c, `UPDATE Produto SET estoque = estoque - ? WHERE idProduto = ?`, item.Quantidade, item.Produto.ID); err != nil {
+			return nil, fmt.Errorf("erro ao baixar estoque do produto %d: %w", item.Produto.ID, err)
 		}
-		pedido.Produtos = append(pedido.Produtos, p)
+		pedido.AtualizarEstoque(item.Produto.ID, *item.Produto.Estoque-item.Quantidade)
+		controlados[item.Produto.ID] = true
+	}
+	return controlados, nil
+}
+
+// reservado é quanto uma linha do pedido tirou do estoque: tudo, se o produto controla o estoque, ou nada
+func reservado(controlados map[int]bool, produtoID, quantidade int) int {
+	if controlados[produtoID] {
+		return quantidade
 	}
+	return 0
+}
+
+func (pr *pedidoMysqlRepository) BuscarPedido(c context.Context, identificacao int) (*entities.Pedido, error) {
+	return pr.buscarUmPedido(c, `SELECT `+colunasPedido+` FROM Pedido WHERE idPedido = ?`, identificacao)
+}
 
-	return &pedido, nil
+// BuscarPedidoPorCodigo encontra o pedido pelo código de retirada, que só se repete em dias diferentes
+func (pr *pedidoMysqlRepository) BuscarPedidoPorCodigo(c context.Context, codigo string, dia time.Time) (*entities.Pedido, error) {
+	return pr.buscarUmPedido(c, `SELECT `+colunasPedido+` FROM Pedido WHERE dataRetirada = ? AND codigoRetirada = ?`, diaRetirada(dia), codigo)
 }
 
-func (pr *pedidoMysqlRepository) AtualizarStatusPedido(c context.Context, identificacao int, status string, ultimaAtualizacao time.Time) error {
-	query := `UPDATE Pedido SET status = ?, ultimaAtualizacao = ? WHERE idPedido = ?`
-	result, err := pr.db.ExecContext(c, query, status, ultimaAtualizacao, identificacao)
+func (pr *pedidoMysqlRepository) buscarUmPedido(c context.Context, query string, args ...interface{}) (*entities.Pedido, error) {
+	pedidos, err := pr.listarPedidos(c, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pedidos) == 0 {
+		return nil, entities.ErrPedidoNaoEncontrado
+	}
+	return pedidos[0], nil
+}
+
+// AtualizarStatusPedido só grava se o pedido ainda estiver no status lido por quem o alterou, para que
+// duas mudanças concorrentes não se sobreponham
+func (pr *pedidoMysqlRepository) AtualizarStatusPedido(c context.Context, identificacao int, statusAtual, novoStatus string, ultimaAtualizacao time.Time) error {
+	db := conexao(c, pr.db)
+	query := `UPDATE Pedido SET status = ?, ultimaAtualizacao = ? WHERE idPedido = ? AND status = ?`
+	result, err := db.ExecContext(c, query, novoStatus, ultimaAtualizacao, identificacao, statusAtual)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar status do pedido: %w", err)
 	}
@@ -120,7 +235,14 @@ func (pr *pedidoMysqlRepository) AtualizarStatusPedido(c context.Context, identi
 		return fmt.Errorf("erro ao verificar atualização: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("pedido não encontrado ou status não foi alterado")
+		var existe bool
+		if err := db.QueryRowContext(c, `SELECT EXISTS(SELECT 1 FROM Pedido WHERE idPedido = ?)`, identificacao).Scan(&existe); err != nil {
+			return fmt.Errorf("erro ao verificar pedido: %w", err)
+		}
+		if !existe {
+			return entities.ErrPedidoNaoEncontrado
+		}
+		return entities.ErrConflitoStatus
 	}
 
 	return nil
@@ -128,7 +250,7 @@ func (pr *pedidoMysqlRepository) AtualizarStatusPedido(c context.Context, identi
 
 func (pr *pedidoMysqlRepository) AtualizarStatusPagamento(c context.Context, identificacao int, statusPagamento string, ultimaAtualizacao time.Time) error {
 	query := `UPDATE Pedido SET statusPagamento = ?, ultimaAtualizacao = ? WHERE idPedido = ?`
-	result, err := pr.db.ExecContext(c, query, statusPagamento, ultimaAtualizacao, identificacao)
+	result, err := conexao(c, pr.db).ExecContext(c, query, statusPagamento, ultimaAtualizacao, identificacao)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar status de pagamento do pedido: %w", err)
 	}
@@ -144,10 +266,223 @@ func (pr *pedidoMysqlRepository) AtualizarStatusPagamento(c context.Context, ide
 	return nil
 }
 
-func (pr *pedidoMysqlRepository) ListarTodosOsPedidos(c context.Context) ([]*entities.Pedido, error) {
-	query := `SELECT idPedido, clienteNome, totalPedido, tempoEstimado, status, statusPagamento, personalizacao FROM Pedido`
+// CancelarPedido grava o cancelamento e devolve ao estoque os produtos reservados pelo pedido, na mesma transação
+func (pr *pedidoMysqlRepository) CancelarPedido(c context.Context, pedido *entities.Pedido) error {
+	if pedido.Cancelamento == nil {
+		return fmt.Errorf("o pedido %d não foi cancelado", pedido.ID)
+	}
+
+	tx, err := iniciarTransacao(c, pr.db)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	// A condição de status impede que dois cancelamentos simultâneos devolvam o estoque duas vezes
+	query := `UPDATE Pedido SET status = ?, statusPagamento = ?, atorCancelamento = ?, motivoCancelamento = ?, canceladoEm = ?, reembolsoNecessario = ?, previsaoPronto = NULL, ultimaAtualizacao = ?
+		WHERE idPedido = ? AND status <> ?`
+	result, err := tx.ExecContext(c, query,
+		pedido.Status,
+		pedido.StatusPagamento,
+		pedido.Cancelamento.Ator,
+		pedido.Cancelamento.Motivo,
+		pedido.Cancelamento.CanceladoEm,
+		pedido.Cancelamento.ReembolsoNecessario,
+		pedido.UltimaAtualizacao,
+		pedido.ID,
+		entities.Cancelado,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao cancelar pedido: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao verificar cancelamento: %w", err)
+	}
+	if rowsAffected == 0 {
+		tx.Rollback()
+		var existe bool
+		if err := conexao(c, pr.db).QueryRowContext(c, `SELECT EXISTS(SELECT 1 FROM Pedido WHERE idPedido = ?)`, pedido.ID).Scan(&existe); err != nil {
+			return fmt.Errorf("erro ao verificar pedido: %w", err)
+		}
+		if !existe {
+			return entities.ErrPedidoNaoEncontrado
+		}
+		return entities.ErrConflitoStatus
+	}
+
+	// Devolver o que cada linha de fato reservou na compra, e não o que o catálogo controla hoje
+	reservas, err := tx.QueryContext(c, `SELECT idProduto, SUM(quantidadeReservada) FROM (
+			SELECT idProduto, quantidadeReservada FROM Pedido_Produto WHERE idPedido = ?
+			UNION ALL
+			SELECT ppc.idProduto, ppc.quantidadeReservada FROM Pedido_Produto_Componente ppc
+			JOIN Pedido_Produto pp ON pp.id = ppc.idPedidoProduto WHERE pp.idPedido = ?
+		) reservas
+		GROUP BY idProduto HAVING SUM(quantidadeReservada) > 0
+		ORDER BY idProduto`, pedido.ID, pedido.ID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao buscar o estoque reservado pelo pedido: %w", err)
+	}
+	var consumo []entities.ConsumoProduto
+	for reservas.Next() {
+		var item entities.ConsumoProduto
+		if err := reservas.Scan(&item.Produto.ID, &item.Quantidade); err != nil {
+			reservas.Close()
+			tx.Rollback()
+			return fmt.Errorf("erro ao escanear o estoque reservado: %w", err)
+		}
+		consumo = append(consumo, item)
+	}
+	reservas.Close()
+	if err := reservas.Err(); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro na iteração do estoque reservado: %w", err)
+	}
+
+	for _, item := range consumo {
+		// Se o controle de estoque foi desligado depois da compra, não há estoque para onde devolver
+		_, err := tx.ExecContext(c, `UPDATE Produto SET estoque = estoque + ? WHERE idProduto = ? AND estoque IS NOT NULL`, item.Quantidade, item.Produto.ID)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao devolver estoque do produto %d: %w", item.Produto.ID, err)
+		}
+	}
+
+	// O pedido cancelado deixa de contar no limite de usos do cupom
+	_, err = tx.ExecContext(c, `UPDATE Cupom cu JOIN Pedido p ON p.cupom = cu.codigo SET cu.usos = cu.usos - 1 WHERE p.idPedido = ? AND cu.usos > 0`, pedido.ID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("erro ao devolver o uso do cupom: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// cancelamentoPersistido recebe as colunas de cancelamento, nulas enquanto o pedido não é cancelado
+type cancelamentoPersistido struct {
+	ator        sql.NullString
+	motivo      sql.NullString
+	canceladoEm sql.NullTime
+	reembolso   bool
+}
+
+func (cp cancelamentoPersistido) entidade() *entities.Cancelamento {
+	if !cp.ator.Valid {
+		return nil
+	}
+	return &entities.Cancelamento{
+		Ator:                entities.AtorCancelamento(cp.ator.String),
+		Motivo:              cp.motivo.String,
+		CanceladoEm:         cp.canceladoEm.Time,
+		ReembolsoNecessario: cp.reembolso,
+	}
+}
+
+// clientePersistido recebe as colunas de identificação do cliente, nulas quando o cliente só informou o nome
+type clientePersistido struct {
+	cpf       sql.NullString
+	idExterno sql.NullString
+}
+
+func novoClientePersistido(ref *entities.ReferenciaCliente) clientePersistido {
+	if ref == nil {
+		return clientePersistido{}
+	}
+	return clientePersistido{
+		cpf:       sql.NullString{String: ref.CPF, Valid: ref.CPF != ""},
+		idExterno: sql.NullString{String: ref.IDExterno, Valid: ref.IDExterno != ""},
+	}
+}
+
+func (cp clientePersistido) entidade() *entities.ReferenciaCliente {
+	if !cp.cpf.Valid && !cp.idExterno.Valid {
+		return nil
+	}
+	return &entities.ReferenciaCliente{CPF: cp.cpf.String, IDExterno: cp.idExterno.String}
+}
+
+const colunasPedido = `idPedido, clienteNome, clienteCpf, clienteIdExterno, codigoRetirada, subtotalPedido, descontoPedido, totalPedido, cupom, tempoEstimado, previsaoPronto, criadoEm, ultimaAtualizacao, status, statusPagamento, personalizacao, atorCancelamento, motivoCancelamento, canceladoEm, reembolsoNecessario`
+
+// colunasOrdemPedido traduz os campos de ordenação da listagem para as colunas indexadas
+var colunasOrdemPedido = map[string]string{
+	entities.OrdemPedidoCriacao: "criadoEm",
+	entities.OrdemPedidoTotal:   "totalPedido",
+}
+
+// ConsultarPedidos conta os pedidos que atendem aos filtros e carrega a página seguinte ao cursor
+func (pr *pedidoMysqlRepository) ConsultarPedidos(c context.Context, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error) {
+	var condicoes []string
+	var args []interface{}
+	if consulta.Status != "" {
+		condicoes = append(condicoes, `status = ?`)
+		args = append(args, consulta.Status)
+	}
+	if consulta.StatusPagamento != "" {
+		condicoes = append(condicoes, `statusPagamento = ?`)
+		args = append(args, consulta.StatusPagamento)
+	}
+	if consulta.De != nil {
+		condicoes = append(condicoes, `criadoEm >= ?`)
+		args = append(args, *consulta.De)
+	}
+	if consulta.Ate != nil {
+		condicoes = append(condicoes, `criadoEm <= ?`)
+		args = append(args, *consulta.Ate)
+	}
+	if consulta.ClienteNome != "" {
+		condicoes = append(condicoes, `clienteNome LIKE ?`)
+		args = append(args, "%"+escaparLike(consulta.ClienteNome)+"%")
+	}
+	if consulta.Cliente != nil {
+		var cliente []string
+		if consulta.Cliente.CPF != "" {
+			cliente = append(cliente, `clienteCpf = ?`)
+			args = append(args, consulta.Cliente.CPF)
+		}
+		if consulta.Cliente.IDExterno != "" {
+			cliente = append(cliente, `clienteIdExterno = ?`)
+			args = append(args, consulta.Cliente.IDExterno)
+		}
+		condicoes = append(condicoes, `(`+strings.Join(cliente, " OR ")+`)`)
+	}
+
+	var total int
+	if err := conexao(c, pr.db).QueryRowContext(c, `SELECT COUNT(*) FROM Pedido`+clausulaWhere(condicoes), args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("erro ao contar pedidos: %w", err)
+	}
+
+	coluna, ok := colunasOrdemPedido[consulta.Ordem.Campo]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entities.ErrOrdemInvalida, consulta.Ordem.Campo)
+	}
+	if consulta.Cursor != nil {
+		valor, err := consulta.ValorCursor()
+		if err != nil {
+			return nil, err
+		}
+		condicoes = append(condicoes, depoisDoCursor(coluna, "idPedido", consulta.Ordem))
+		args = append(args, valor, valor, consulta.Cursor.ID)
+	}
 
-	rows, err := pr.db.QueryContext(c, query)
+	// Um pedido além do limite indica se existe próxima página
+	query := `SELECT ` + colunasPedido + ` FROM Pedido` + clausulaWhere(condicoes) + ordenarPor(coluna, "idPedido", consulta.Ordem) + ` LIMIT ?`
+	pedidos, err := pr.listarPedidos(c, query, append(args, consulta.Limite+1)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.PaginaNew(pedidos, consulta.Limite, total, func(p *entities.Pedido) entities.Cursor {
+		return consulta.CursorDe(*p)
+	}), nil
+}
+
+// listarPedidos carrega os pedidos da consulta e depois os itens de todos eles de uma vez, para que o
+// número de consultas não cresça com o número de pedidos
+func (pr *pedidoMysqlRepository) listarPedidos(c context.Context, query string, args ...interface{}) ([]*entities.Pedido, error) {
+	rows, err := conexao(c, pr.db).QueryContext(c, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar pedidos: %w", err)
 	}
@@ -155,89 +490,274 @@ func (pr *pedidoMysqlRepository) ListarTodosOsPedidos(c context.Context) ([]*ent
 
 	var pedidos []*entities.Pedido
 	for rows.Next() {
-		var p entities.Pedido
-		var clienteNome string
-		var tempoEstimadoStr string
-		var personalizacao *string
-
-		if err := rows.Scan(
-			&p.ID,
-			&clienteNome,
-			&p.Total,
-			&tempoEstimadoStr,
-			&p.Status,
-			&p.StatusPagamento,
-			&personalizacao,
-		); err != nil {
-			return nil, fmt.Errorf("erro ao escanear pedido: %w", err)
-		}
-
-		// pega a data atual (ano, mês e dia)
-		now := time.Now()
-		dataHoje := now.Format("2006-01-02") // ex: "2025-05-28"
-
-		// concatena uma data fixa com o horário recebido
-		datetimeStr := dataHoje + " " + tempoEstimadoStr // data fixa arbitrária
-
-		// parse para time.Time completo
-		_, err := time.Parse("2006-01-02 15:04:05", datetimeStr)
+		p, err := escanearPedido(rows)
 		if err != nil {
+			return nil, err
+		}
+		pedidos = append(pedidos, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração dos pedidos: %w", err)
+	}
+	rows.Close()
+
+	ids := make([]int, len(pedidos))
+	for i, p := range pedidos {
+		ids[i] = p.ID
+	}
+	itens, err := pr.buscarItensDosPedidos(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pedidos {
+		p.Itens = itens[p.ID]
+		if p.Itens == nil {
+			p.Itens = []entities.ItemPedido{}
+		}
+	}
+
+	return pedidos, nil
+}
+
+// escanearPedido lê uma linha com as colunasPedido
+func escanearPedido(rows *sql.Rows) (*entities.Pedido, error) {
+	var p entities.Pedido
+	var clienteNome string
+	var cliente clientePersistido
+	var codigoRetirada sql.NullString
+	var tempoEstimadoStr string
+	var personalizacao *string
+	var ultimaAtualizacao sql.NullTime
+	var cancelamento cancelamentoPersistido
+
+	if err := rows.Scan(
+		&p.ID,
+		&clienteNome,
+		&cliente.cpf,
+		&cliente.idExterno,
+		&codigoRetirada,
+		&p.Subtotal,
+		&p.Desconto,
+		&p.Total,
+		&p.Cupom,
+		&tempoEstimadoStr,
+		&p.PrevisaoPronto,
+		&p.CriadoEm,
+		&ultimaAtualizacao,
+		&p.Status,
+		&p.StatusPagamento,
+		&personalizacao,
+		&cancelamento.ator,
+		&cancelamento.motivo,
+		&cancelamento.canceladoEm,
+		&cancelamento.reembolso,
+	); err != nil {
+		return nil, fmt.Errorf("erro ao escanear pedido: %w", err)
+	}
+
+	tempoEstimado, err := entities.DuracaoParse(tempoEstimadoStr)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao converter tempoEstimado: %w", err)
+	}
+
+	p.TempoEstimado = tempoEstimado
+	p.UltimaAtualizacao = ultimaAtualizacao.Time
+	p.ClienteNome = clienteNome
+	p.Cliente = cliente.entidade()
+	p.CodigoRetirada = codigoRetirada.String
+	p.Personalizacao = personalizacao
+	p.Cancelamento = cancelamento.entidade()
+
+	return &p, nil
+}
+
+func (pr *pedidoMysqlRepository) ListarFilaCozinha(c context.Context) ([]*entities.Pedido, error) {
+	query := `SELECT idPedido, tempoEstimado, previsaoPronto, status FROM Pedido WHERE status IN (?, ?) ORDER BY idPedido`
+
+	rows, err := conexao(c, pr.db).QueryContext(c, query, entities.Recebido, entities.EmPreparacao)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar fila da cozinha: %w", err)
+	}
+	defer rows.Close()
+
+	var fila []*entities.Pedido
+	for rows.Next() {
+		var p entities.Pedido
+		var tempoEstimado string
+		if err := rows.Scan(&p.ID, &tempoEstimado, &p.PrevisaoPronto, &p.Status); err != nil {
+			return nil, fmt.Errorf("erro ao escanear pedido da fila: %w", err)
+		}
+		if p.TempoEstimado, err = entities.DuracaoParse(tempoEstimado); err != nil {
 			return nil, fmt.Errorf("erro ao converter tempoEstimado: %w", err)
 		}
+		fila = append(fila, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração da fila da cozinha: %w", err)
+	}
 
-		p.TimeStamp = "00:15:00" // Definindo um valor fixo para o TimeStamp
-		p.ClienteNome = clienteNome
-		p.Personalizacao = personalizacao
-		p.Produtos = []entities.Produto{}
+	return fila, nil
+}
 
-		// Buscar ids dos produtos do pedido
-		prodIDsQuery := `SELECT idProduto FROM Pedido_Produto WHERE idPedido = ?`
-		prodIDRows, err := pr.db.QueryContext(c, prodIDsQuery, p.ID)
-		if err != nil {
-			return nil, fmt.Errorf("erro ao buscar ids dos produtos: %w", err)
+func (pr *pedidoMysqlRepository) AtualizarPrevisoes(c context.Context, pedidos []*entities.Pedido) error {
+	tx, err := iniciarTransacao(c, pr.db)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	// Não altera ultimaAtualizacao: a previsão muda por causa da fila, não do próprio pedido
+	query := `UPDATE Pedido SET previsaoPronto = ?, ultimaAtualizacao = ultimaAtualizacao WHERE idPedido = ?`
+	for _, p := range pedidos {
+		if _, err := tx.ExecContext(c, query, p.PrevisaoPronto, p.ID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao atualizar previsão do pedido %d: %w", p.ID, err)
 		}
+	}
 
-		var produtos []entities.Produto
+	return tx.Commit()
+}
 
-		for prodIDRows.Next() {
-			var idProduto int
-			if err := prodIDRows.Scan(&idProduto); err != nil {
-				prodIDRows.Close()
-				return nil, fmt.Errorf("erro ao escanear idProduto: %w", err)
-			}
+// lotePedidosItens limita quantos pedidos entram no IN de cada consulta de itens
+const lotePedidosItens = 1000
+
+// buscarItensDosPedidos carrega as linhas dos pedidos com seus produtos, modificadores e componentes de
+// combo, em três consultas por lote de pedidos. Nome, categoria e preço vêm da cópia gravada no pedido,
+// não do catálogo atual
+func (pr *pedidoMysqlRepository) buscarItensDosPedidos(c context.Context, pedidoIDs []int) (map[int][]entities.ItemPedido, error) {
+	itens := map[int][]entities.ItemPedido{}
+	for inicio := 0; inicio < len(pedidoIDs); inicio += lotePedidosItens {
+		fim := min(inicio+lotePedidosItens, len(pedidoIDs))
+		if err := pr.buscarItensDoLote(c, pedidoIDs[inicio:fim], itens); err != nil {
+			return nil, err
+		}
+	}
+	return itens, nil
+}
 
-			// Busca dados completos do produto
-			produtoQuery := `SELECT idProduto, nomeProduto, descricaoProduto, precoProduto, categoriaProduto FROM Produto WHERE idProduto = ?`
-			var produto entities.Produto
-			err = pr.db.QueryRowContext(c, produtoQuery, idProduto).Scan(
-				&produto.ID,
-				&produto.Nome,
-				&produto.Descricao,
-				&produto.Preco,
-				&produto.Categoria,
-			)
-			if err != nil {
-				prodIDRows.Close()
-				return nil, fmt.Errorf("erro ao buscar produto para id %d: %w", idProduto, err)
-			}
+func (pr *pedidoMysqlRepository) buscarItensDoLote(c context.Context, pedidoIDs []int, itens map[int][]entities.ItemPedido) error {
+	marcadores, args := listaIN(pedidoIDs)
+
+	prodQuery := `SELECT pp.id, pp.idPedido, pp.idProduto, pp.nomeProduto, p.descricaoProduto, pp.precoProduto, pp.categoriaProduto, p.tempoPreparoMinutos, pp.quantidade, p.alergenos
+		FROM Produto p JOIN Pedido_Produto pp ON pp.idProduto = p.idProduto
+		WHERE pp.idPedido IN (` + marcadores + `)
+		ORDER BY pp.id`
+
+	rows, err := conexao(c, pr.db).QueryContext(c, prodQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar produtos do pedido: %w", err)
+	}
+	defer rows.Close()
 
-			produtos = append(produtos, produto)
+	type linha struct {
+		pedidoID   int
+		produto    entities.Produto
+		quantidade int
+	}
+	var ids []int
+	linhas := map[int]*linha{}
+	for rows.Next() {
+		var itemID int
+		var l linha
+		var alergenos string
+		if err := rows.Scan(&itemID, &l.pedidoID, &l.produto.ID, &l.produto.Nome, &l.produto.Descricao, &l.produto.Preco, &l.produto.Categoria, &l.produto.TempoPreparoMinutos, &l.quantidade, &alergenos); err != nil {
+			return fmt.Errorf("erro ao escanear produto: %v", err)
 		}
-		prodIDRows.Close()
+		// Os alérgenos vêm do catálogo atual: um alérgeno descoberto depois também deve ser avisado
+		l.produto.Alergenos = separarValores[entities.Alergeno](alergenos)
+		ids = append(ids, itemID)
+		linhas[itemID] = &l
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro na iteração dos produtos do pedido: %w", err)
+	}
 
-		if err := prodIDRows.Err(); err != nil {
-			return nil, fmt.Errorf("erro na iteração dos ids dos produtos: %w", err)
+	modQuery := `SELECT ppm.idPedidoProduto, ppm.idModificador, ppm.nomeModificador, ppm.precoModificador
+		FROM Pedido_Produto_Modificador ppm JOIN Pedido_Produto pp ON pp.id = ppm.idPedidoProduto
+		WHERE pp.idPedido IN (` + marcadores + `)
+		ORDER BY ppm.id`
+
+	modRows, err := conexao(c, pr.db).QueryContext(c, modQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar modificadores do pedido: %w", err)
+	}
+	defer modRows.Close()
+
+	modificadores := map[int][]entities.Modificador{}
+	for modRows.Next() {
+		var itemID int
+		var modID sql.NullInt64
+		var m entities.Modificador
+		if err := modRows.Scan(&itemID, &modID, &m.Nome, &m.Preco); err != nil {
+			return fmt.Errorf("erro ao escanear modificador: %w", err)
 		}
+		m.ID = int(modID.Int64)
+		modificadores[itemID] = append(modificadores[itemID], m)
+	}
+	if err := modRows.Err(); err != nil {
+		return fmt.Errorf("erro na iteração dos modificadores do pedido: %w", err)
+	}
 
-		// Atribui a lista de produtos ao pedido
-		p.Produtos = produtos
+	compQuery := `SELECT ppc.idPedidoProduto, ppc.idProduto, ppc.nomeProduto, p.descricaoProduto, ppc.precoProduto, ppc.categoriaProduto, p.tempoPreparoMinutos, ppc.quantidade, p.alergenos
+		FROM Pedido_Produto_Componente ppc
+		JOIN Pedido_Produto pp ON pp.id = ppc.idPedidoProduto
+		JOIN Produto p ON p.idProduto = ppc.idProduto
+		WHERE pp.idPedido IN (` + marcadores + `)
+		ORDER BY ppc.id`
 
-		pedidos = append(pedidos, &p)
+	compRows, err := conexao(c, pr.db).QueryContext(c, compQuery, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar componentes dos combos do pedido: %w", err)
+	}
+	defer compRows.Close()
+
+	componentes := map[int][]entities.ItemCombo{}
+	for compRows.Next() {
+		var itemID int
+		var ic entities.ItemCombo
+		var alergenos string
+		if err := compRows.Scan(&itemID, &ic.Produto.ID, &ic.Produto.Nome, &ic.Produto.Descricao, &ic.Produto.Preco, &ic.Produto.Categoria, &ic.Produto.TempoPreparoMinutos, &ic.Quantidade, &alergenos); err != nil {
+			return fmt.Errorf("erro ao escanear componente do combo: %w", err)
+		}
+		ic.Produto.Alergenos = separarValores[entities.Alergeno](alergenos)
+		componentes[itemID] = append(componentes[itemID], ic)
+	}
+	if err := compRows.Err(); err != nil {
+		return fmt.Errorf("erro na iteração dos componentes dos combos do pedido: %w", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("erro na iteração dos pedidos: %w", err)
+	for _, id := range ids {
+		l := linhas[id]
+		item, err := novoItemPedido(l.produto, l.quantidade, modificadores[id])
+		if err != nil {
+			return fmt.Errorf("erro ao remontar o item do pedido %d: %w", l.pedidoID, err)
+		}
+		item.Componentes = componentes[id]
+		itens[l.pedidoID] = append(itens[l.pedidoID], item)
 	}
 
-	return pedidos, nil
+	return nil
+}
+
+// novoItemPedido remonta a linha do pedido a partir do produto, da quantidade e dos modificadores persistidos
+func novoItemPedido(produto entities.Produto, quantidade int, modificadores []entities.Modificador) (entities.ItemPedido, error) {
+	var err error
+	precoUnitario := produto.Preco
+	for _, m := range modificadores {
+		if precoUnitario, err = precoUnitario.Add(m.Preco); err != nil {
+			return entities.ItemPedido{}, err
+		}
+	}
+
+	subtotal, err := precoUnitario.Mul(quantidade)
+	if err != nil {
+		return entities.ItemPedido{}, err
+	}
+
+	return entities.ItemPedido{
+		Produto:       produto,
+		Quantidade:    quantidade,
+		Modificadores: modificadores,
+		PrecoUnitario: precoUnitario,
+		Subtotal:      subtotal,
+	}, nil
 }
@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Os benchmarks rodam contra um MySQL de verdade, num banco descartável com o esquema das migrações:
+//
+//	DB_HOST=localhost DB_PORT=3306 DB_USER=root DB_PASS=password DB_NAME=lanchonete_bench go run . migrate
+//	PEDIDO_BENCH_DSN='root:password@tcp(localhost:3306)/lanchonete_bench?parseTime=true' \
+//	  go test ./infra/database/repositories -run '^$' -bench Pedido -benchtime 20x
+//
+// Cada rodada acrescenta pedidos ao banco e lê uma página da listagem, do tamanho máximo, informando
+// quantas consultas ela fez (consultas/op), que deve ficar constante enquanto pedidos/op cresce, ao
+// lado do tempo por página.
+const clienteBench = "benchmark"
+
+func abrirBancoBench(b *testing.B) *sql.DB {
+	dsn := os.Getenv("PEDIDO_BENCH_DSN")
+	if dsn == "" {
+		b.Skip("PEDIDO_BENCH_DSN não definida")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		b.Fatalf("erro ao abrir o banco: %v", err)
+	}
+	// Uma única conexão, para que o contador de consultas da sessão veja tudo o que o repositório faz
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	if err := db.Ping(); err != nil {
+		b.Fatalf("erro ao conectar ao banco: %v", err)
+	}
+
+	limparPedidosBench(b, db)
+	b.Cleanup(func() {
+		limparPedidosBench(b, db)
+		db.Close()
+	})
+	return db
+}
+
+func limparPedidosBench(b *testing.B, db *sql.DB) {
+	if _, err := db.Exec(`DELETE FROM Pedido WHERE clienteNome = ?`, clienteBench); err != nil {
+		b.Fatalf("erro ao limpar os pedidos do benchmark: %v", err)
+	}
+}
+
+// semearPedidos grava pedidos com dois produtos, um deles com modificador, e um combo com três componentes
+func semearPedidos(b *testing.B, db *sql.DB, quantidade int) {
+	tx, err := db.Begin()
+	if err != nil {
+		b.Fatalf("erro ao iniciar transação: %v", err)
+	}
+	defer tx.Rollback()
+
+	for i := 0; i < quantidade; i++ {
+		res, err := tx.Exec(`INSERT INTO Pedido (clienteNome, subtotalPedido, totalPedido) VALUES (?, 70.5, 70.5)`, clienteBench)
+		if err != nil {
+			b.Fatalf("erro ao semear pedido: %v", err)
+		}
+		pedidoID, _ := res.LastInsertId()
+
+		res, err = tx.Exec(`INSERT INTO Pedido_Produto (idPedido, idProduto, nomeProduto, categoriaProduto, precoProduto, quantidade) VALUES (?, 1, 'X-Salada', 'Lanche', 22.5, 1)`, pedidoID)
+		if err != nil {
+			b.Fatalf("erro ao semear item: %v", err)
+		}
+		itemID, _ := res.LastInsertId()
+		if _, err := tx.Exec(`INSERT INTO Pedido_Produto_Modificador (idPedidoProduto, nomeModificador, precoModificador) VALUES (?, 'Bacon extra', 4)`, itemID); err != nil {
+			b.Fatalf("erro ao semear modificador: %v", err)
+		}
+
+		if _, err := tx.Exec(`INSERT INTO Pedido_Produto (idPedido, idProduto, nomeProduto, categoriaProduto, precoProduto, quantidade) VALUES (?, 2, 'Coca-cola', 'Bebida', 6, 1)`, pedidoID); err != nil {
+			b.Fatalf("erro ao semear item: %v", err)
+		}
+
+		res, err = tx.Exec(`INSERT INTO Pedido_Produto (idPedido, idProduto, nomeProduto, categoriaProduto, precoProduto, quantidade) VALUES (?, 11, 'Combo X-Salada', 'Combo', 42, 1)`, pedidoID)
+		if err != nil {
+			b.Fatalf("erro ao semear combo: %v", err)
+		}
+		comboID, _ := res.LastInsertId()
+		if _, err := tx.Exec(`INSERT INTO Pedido_Produto_Componente (idPedidoProduto, idProduto, nomeProduto, categoriaProduto, quantidade) VALUES (?, 1, 'X-Salada', 'Lanche', 1), (?, 3, 'Batata-frita', 'Acompanhamento', 1), (?, 2, 'Coca-cola', 'Bebida', 1)`, comboID, comboID, comboID); err != nil {
+			b.Fatalf("erro ao semear componentes: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("erro ao confirmar a semeadura: %v", err)
+	}
+}
+
+// consultasDaSessao lê o contador de comandos da conexão; a própria leitura conta como um
+func consultasDaSessao(b *testing.B, db *sql.DB) int {
+	var nome string
+	var valor int
+	if err := db.QueryRow(`SHOW SESSION STATUS LIKE 'Questions'`).Scan(&nome, &valor); err != nil {
+		b.Fatalf("erro ao ler o contador de consultas: %v", err)
+	}
+	return valor
+}
+
+func BenchmarkConsultarPedidos(b *testing.B) {
+	db := abrirBancoBench(b)
+	repo := NewPedidoMysqlRepository(db)
+	ctx := context.Background()
+	consulta := entities.ConsultaPedidos{ClienteNome: clienteBench, Limite: entities.LimiteMaximo}
+	if err := consulta.Validar(); err != nil {
+		b.Fatal(err)
+	}
+
+	semeados := 0
+	for _, total := range []int{10, 100, 1000} {
+		semearPedidos(b, db, total-semeados)
+		semeados = total
+
+		b.Run(fmt.Sprintf("pedidos=%d", total), func(b *testing.B) {
+			var consultas, pedidos int
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				antes := consultasDaSessao(b, db)
+				b.StartTimer()
+
+				pagina, err := repo.ConsultarPedidos(ctx, consulta)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+				consultas += consultasDaSessao(b, db) - antes - 1
+				pedidos += len(pagina.Itens)
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(consultas)/float64(b.N), "consultas/op")
+			b.ReportMetric(float64(pedidos)/float64(b.N), "pedidos/op")
+		})
+	}
+}
+
+func BenchmarkBuscarPedido(b *testing.B) {
+	db := abrirBancoBench(b)
+	repo := NewPedidoMysqlRepository(db)
+	ctx := context.Background()
+
+	semearPedidos(b, db, 1)
+	var pedidoID int
+	if err := db.QueryRow(`SELECT MAX(idPedido) FROM Pedido WHERE clienteNome = ?`, clienteBench).Scan(&pedidoID); err != nil {
+		b.Fatal(err)
+	}
+
+	var consultas int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		antes := consultasDaSessao(b, db)
+		b.StartTimer()
+
+		pedido, err := repo.BuscarPedido(ctx, pedidoID)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(pedido.Itens) != 3 || len(pedido.Itens[2].Componentes) != 3 {
+			b.Fatalf("pedido semeado incompleto: %+v", pedido.Itens)
+		}
+
+		b.StopTimer()
+		consultas += consultasDaSessao(b, db) - antes - 1
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(consultas)/float64(b.N), "consultas/op")
+}
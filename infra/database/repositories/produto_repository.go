@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
@@ -20,15 +22,28 @@ func NewProdutoMysqlRepository(db *sql.DB) repository.ProdutoRepository {
 }
 
 func (pr *produtoMysqlRepository) AdicionarProduto(c context.Context, produto *entities.Produto) error {
-	query := "INSERT INTO Produto (nomeProduto, descricaoProduto, precoProduto, categoriaProduto) VALUES (?, ?, ?, ?)"
-	result, err := pr.database.ExecContext(c, query, produto.Nome, produto.Descricao, produto.Preco, produto.Categoria)
+	tx, err := iniciarTransacao(c, pr.database)
 	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	dieta, err := novaDietaPersistida(produto.InformacoesDieteticas)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := "INSERT INTO Produto (nomeProduto, descricaoProduto, precoProduto, categoriaProduto, tempoPreparoMinutos, esgotado, estoque, alergenos, tagsDieta, informacaoNutricional) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	result, err := tx.ExecContext(c, query, produto.Nome, produto.Descricao, produto.Preco, produto.Categoria, produto.TempoPreparoMinutos, produto.Esgotado, produto.Estoque, dieta.alergenos, dieta.tags, dieta.nutricao)
+	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
 	// Captura o ID gerado automaticamente
 	lastInsertID, err := result.LastInsertId()
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
@@ -36,96 +51,273 @@ func (pr *produtoMysqlRepository) AdicionarProduto(c context.Context, produto *e
 	produto.ID = int(lastInsertID)
 	fmt.Printf("DEBUG: Produto criado com ID: %d\n", produto.ID)
 
+	// Componentes do combo: produto fixo ou categoria a ser escolhida no pedido
+	compQuery := "INSERT INTO ComboComponente (idCombo, idProduto, categoria, quantidade) VALUES (?, ?, ?, ?)"
+	for i := range produto.Componentes {
+		componente := &produto.Componentes[i]
+
+		var produtoID, categoria interface{}
+		if componente.Produto != nil {
+			produtoID = componente.Produto.ID
+		} else {
+			categoria = componente.Categoria
+		}
+
+		res, err := tx.ExecContext(c, compQuery, produto.ID, produtoID, categoria, componente.Quantidade)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao inserir componente do combo: %w", err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao obter ID do componente do combo: %w", err)
+		}
+		componente.ID = int(id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+
 	return nil
 }
 
 func (pr *produtoMysqlRepository) BuscarProdutoPorId(c context.Context, id int) (*entities.Produto, error) {
-	query := "SELECT idProduto, nomeProduto, descricaoProduto, precoProduto, categoriaProduto FROM Produto WHERE idProduto = ?"
+	query := "SELECT idProduto, nomeProduto, descricaoProduto, precoProduto, categoriaProduto, tempoPreparoMinutos, esgotado, estoque, archived_at, alergenos, tagsDieta, informacaoNutricional FROM Produto WHERE idProduto = ?"
 	var produto entities.Produto
+	var dieta dietaPersistida
 	err := pr.database.QueryRowContext(c, query, id).
-		Scan(&produto.ID, &produto.Nome, &produto.Descricao, &produto.Preco, &produto.Categoria)
+		Scan(&produto.ID, &produto.Nome, &produto.Descricao, &produto.Preco, &produto.Categoria, &produto.TempoPreparoMinutos, &produto.Esgotado, &produto.Estoque, &produto.ArquivadoEm, &dieta.alergenos, &dieta.tags, &dieta.nutricao)
 	fmt.Println("Repository Buscando produto:", produto.Nome)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("produto não encontrado")
+			return nil, entities.ErrProdutoNaoEncontrado
 		}
 		return nil, fmt.Errorf("erro ao buscar produto: %v", err)
 	}
 	fmt.Println("Repository Produto encontrado:", produto.Nome, produto.Descricao, produto.Preco, produto.Categoria)
+
+	if produto.InformacoesDieteticas, err = dieta.entidade(); err != nil {
+		return nil, err
+	}
+
+	produto.Modificadores, err = listarGruposPorProduto(c, pr.database, produto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pr.carregarComponentes(c, []*entities.Produto{&produto}); err != nil {
+		return nil, err
+	}
+
+	produto.Imagens, err = listarImagensPorProduto(c, pr.database, produto.ID)
+	if err != nil {
+		return nil, err
+	}
 	return &produto, nil
 }
 
-func (pr *produtoMysqlRepository) ListarTodosOsProdutos(c context.Context) ([]*entities.Produto, error) {
-	query := "SELECT idProduto, nomeProduto, descricaoProduto, precoProduto, categoriaProduto FROM Produto"
-	rows, err := pr.database.QueryContext(c, query)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar produtos: %v", err)
+// colunasOrdemProduto traduz os campos de ordenação do cardápio para as colunas indexadas
+var colunasOrdemProduto = map[string]string{
+	entities.OrdemProdutoNome:  "p.nomeProduto",
+	entities.OrdemProdutoPreco: "p.precoProduto",
+}
+
+// ConsultarProdutos conta os produtos do cardápio que atendem aos filtros e carrega a página seguinte ao cursor.
+// Os filtros de dieta consideram os alérgenos dos componentes dos combos, como FiltroDieta.Atende.
+func (pr *produtoMysqlRepository) ConsultarProdutos(c context.Context, consulta entities.ConsultaProdutos) (*entities.Pagina[*entities.Produto], error) {
+	condicoes := []string{`p.archived_at IS NULL`}
+	var args []interface{}
+	if consulta.Categoria != "" {
+		condicoes = append(condicoes, `p.categoriaProduto = ?`)
+		args = append(args, consulta.Categoria)
+	}
+	if consulta.PrecoMin != nil {
+		condicoes = append(condicoes, `p.precoProduto >= ?`)
+		args = append(args, *consulta.PrecoMin)
+	}
+	if consulta.PrecoMax != nil {
+		condicoes = append(condicoes, `p.precoProduto <= ?`)
+		args = append(args, *consulta.PrecoMax)
+	}
+	if !consulta.IncluirIndisponiveis {
+		condicoes = append(condicoes, `p.esgotado = FALSE AND (p.estoque IS NULL OR p.estoque >= 1)`)
+		// Uma categoria inativa sai do cardápio com todos os seus produtos
+		condicoes = append(condicoes, `EXISTS (SELECT 1 FROM Categoria cat WHERE cat.nome = p.categoriaProduto AND cat.ativa)`)
+	}
+	for _, tag := range consulta.Dieta.Tags {
+		condicoes = append(condicoes, `FIND_IN_SET(?, p.tagsDieta) > 0`)
+		args = append(args, tag)
+	}
+	for _, alergeno := range consulta.Dieta.SemAlergenos {
+		condicoes = append(condicoes, `FIND_IN_SET(?, p.alergenos) = 0 AND NOT EXISTS (
+			SELECT 1 FROM ComboComponente cc JOIN Produto cp ON cp.idProduto = cc.idProduto
+			WHERE cc.idCombo = p.idProduto AND FIND_IN_SET(?, cp.alergenos) > 0)`)
+		args = append(args, alergeno, alergeno)
 	}
-	defer rows.Close()
 
-	var produtos []*entities.Produto
-	for rows.Next() {
-		var p entities.Produto
-		if err := rows.Scan(&p.ID, &p.Nome, &p.Descricao, &p.Preco, &p.Categoria); err != nil {
-			return nil, fmt.Errorf("erro ao escanear produto: %v", err)
+	var total int
+	if err := pr.database.QueryRowContext(c, `SELECT COUNT(*) FROM Produto p`+clausulaWhere(condicoes), args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("erro ao contar produtos: %w", err)
+	}
+
+	coluna, ok := colunasOrdemProduto[consulta.Ordem.Campo]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entities.ErrOrdemInvalida, consulta.Ordem.Campo)
+	}
+	if consulta.Cursor != nil {
+		valor, err := consulta.ValorCursor()
+		if err != nil {
+			return nil, err
 		}
-		produtos = append(produtos, &p)
+		condicoes = append(condicoes, depoisDoCursor(coluna, "p.idProduto", consulta.Ordem))
+		args = append(args, valor, valor, consulta.Cursor.ID)
 	}
-	return produtos, nil
+
+	// Um produto além do limite indica se existe próxima página
+	query := `SELECT ` + colunasProduto + ` FROM Produto p` + clausulaWhere(condicoes) + ordenarPor(coluna, "p.idProduto", consulta.Ordem) + ` LIMIT ?`
+	produtos, err := pr.listarProdutos(c, query, append(args, consulta.Limite+1)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.PaginaNew(produtos, consulta.Limite, total, func(p *entities.Produto) entities.Cursor {
+		return consulta.CursorDe(*p)
+	}), nil
 }
 
 func (pr *produtoMysqlRepository) EditarProduto(c context.Context, produto *entities.Produto) error {
-	query := "UPDATE Produto SET nomeProduto = ?, descricaoProduto = ?, precoProduto = ?, categoriaProduto = ? WHERE nomeProduto = ?"
-	fmt.Println("Repository Atualizando produto:", produto.Nome, produto.Descricao, produto.Preco, produto.Categoria)
-	result, err := pr.database.ExecContext(c, query, produto.Nome, produto.Descricao, produto.Preco, produto.Categoria, produto.Nome)
+	dieta, err := novaDietaPersistida(produto.InformacoesDieteticas)
+	if err != nil {
+		return err
+	}
+
+	// Pela chave: o nome é um dos campos que a edição pode mudar
+	query := "UPDATE Produto SET nomeProduto = ?, descricaoProduto = ?, precoProduto = ?, categoriaProduto = ?, tempoPreparoMinutos = ?, alergenos = ?, tagsDieta = ?, informacaoNutricional = ? WHERE idProduto = ?"
+	result, err := conexao(c, pr.database).ExecContext(c, query, produto.Nome, produto.Descricao, produto.Preco, produto.Categoria, produto.TempoPreparoMinutos, dieta.alergenos, dieta.tags, dieta.nutricao, produto.ID)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar produto: %v", err)
 	}
+	return pr.confirmarAtualizacao(c, result, produto.ID)
+}
+
+func (pr *produtoMysqlRepository) AtualizarDisponibilidade(c context.Context, id int, esgotado bool) error {
+	// Só a coluna da disponibilidade: o estoque pode ter sido baixado por um pedido desde a leitura do produto
+	query := "UPDATE Produto SET esgotado = ? WHERE idProduto = ?"
+	result, err := conexao(c, pr.database).ExecContext(c, query, esgotado, id)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar disponibilidade do produto: %w", err)
+	}
+	return pr.confirmarAtualizacao(c, result, id)
+}
+
+func (pr *produtoMysqlRepository) DefinirEstoque(c context.Context, id int, estoque *int) error {
+	// Só a coluna do estoque: a disponibilidade manual pode ter sido alterada desde a leitura do produto
+	query := "UPDATE Produto SET estoque = ? WHERE idProduto = ?"
+	result, err := conexao(c, pr.database).ExecContext(c, query, estoque, id)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar estoque do produto: %w", err)
+	}
+	return pr.confirmarAtualizacao(c, result, id)
+}
 
+// confirmarAtualizacao devolve ErrProdutoNaoEncontrado quando o UPDATE não encontrou o produto
+func (pr *produtoMysqlRepository) confirmarAtualizacao(c context.Context, result sql.Result, id int) error {
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("erro ao verificar atualização: %v", err)
+		return fmt.Errorf("erro ao verificar atualização: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("produto não encontrado")
+		// O MySQL não conta linhas sem alteração; confirmar que o produto existe
+		var existe bool
+		if err := conexao(c, pr.database).QueryRowContext(c, "SELECT EXISTS(SELECT 1 FROM Produto WHERE idProduto = ?)", id).Scan(&existe); err != nil {
+			return fmt.Errorf("erro ao verificar atualização: %w", err)
+		}
+		if !existe {
+			return entities.ErrProdutoNaoEncontrado
+		}
 	}
-
 	return nil
 }
 
-func (pr *produtoMysqlRepository) RemoverProduto(c context.Context, id int) error {
-	query := "DELETE FROM Produto WHERE idProduto = ?"
-	result, err := pr.database.ExecContext(c, query, id)
+func (pr *produtoMysqlRepository) ArquivarProduto(c context.Context, produto *entities.Produto) error {
+	// Nunca apagar a linha: os pedidos antigos continuam referenciando o produto
+	query := "UPDATE Produto SET archived_at = ? WHERE idProduto = ? AND archived_at IS NULL"
+	result, err := conexao(c, pr.database).ExecContext(c, query, produto.ArquivadoEm, produto.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao arquivar produto: %v", err)
+	}
+	return pr.confirmarArquivamento(c, result, produto.ID, true)
+}
+
+func (pr *produtoMysqlRepository) RestaurarProduto(c context.Context, produto *entities.Produto) error {
+	query := "UPDATE Produto SET archived_at = NULL WHERE idProduto = ? AND archived_at IS NOT NULL"
+	result, err := conexao(c, pr.database).ExecContext(c, query, produto.ID)
 	if err != nil {
-		return fmt.Errorf("erro ao remover produto: %v", err)
+		return fmt.Errorf("erro ao restaurar produto: %v", err)
 	}
+	return pr.confirmarArquivamento(c, result, produto.ID, false)
+}
 
+// confirmarArquivamento distingue, quando o UPDATE não alterou a linha, o produto inexistente do
+// produto que outra requisição já arquivou ou restaurou
+func (pr *produtoMysqlRepository) confirmarArquivamento(c context.Context, result sql.Result, id int, arquivar bool) error {
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("erro ao verificar remoção: %v", err)
+		return fmt.Errorf("erro ao verificar arquivamento: %v", err)
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("produto não encontrado")
+	if rowsAffected > 0 {
+		return nil
 	}
 
-	return nil
+	var arquivado bool
+	err = conexao(c, pr.database).QueryRowContext(c, "SELECT archived_at IS NOT NULL FROM Produto WHERE idProduto = ?", id).Scan(&arquivado)
+	switch {
+	case err == sql.ErrNoRows:
+		return entities.ErrProdutoNaoEncontrado
+	case err != nil:
+		return fmt.Errorf("erro ao verificar arquivamento: %v", err)
+	case arquivar && arquivado:
+		return entities.ErrProdutoArquivado
+	case !arquivar && !arquivado:
+		return entities.ErrProdutoNaoArquivado
+	}
+	return entities.ErrProdutoNaoEncontrado
 }
 
 func (pr *produtoMysqlRepository) ListarPorCategoria(c context.Context, categoria string) ([]*entities.Produto, error) {
-	query := "SELECT idProduto, nomeProduto, descricaoProduto, precoProduto, categoriaProduto FROM Produto WHERE categoriaProduto = ?"
-	rows, err := pr.database.QueryContext(c, query, categoria)
+	query := `SELECT ` + colunasProduto + ` FROM Produto p WHERE p.categoriaProduto = ? AND p.archived_at IS NULL`
+	return pr.listarProdutos(c, query, categoria)
+}
+
+const colunasProduto = `p.idProduto, p.nomeProduto, p.descricaoProduto, p.precoProduto, p.categoriaProduto, p.tempoPreparoMinutos, p.esgotado, p.estoque, p.alergenos, p.tagsDieta, p.informacaoNutricional`
 
+// loteProdutosDetalhes limita quantos produtos entram no IN de cada consulta de componentes e imagens
+const loteProdutosDetalhes = 1000
+
+// listarProdutos lê os produtos da consulta, que seleciona as colunasProduto, com componentes e imagens.
+// Componentes e imagens vêm em uma consulta cada por lote de produtos, não uma por produto.
+func (pr *produtoMysqlRepository) listarProdutos(c context.Context, query string, args ...interface{}) ([]*entities.Produto, error) {
+	rows, err := conexao(c, pr.database).QueryContext(c, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar produtos por categoria: %v", err)
+		return nil, fmt.Errorf("erro ao buscar produtos: %v", err)
 	}
 	defer rows.Close()
 
 	var produtos []*entities.Produto
 	for rows.Next() {
 		var p entities.Produto
-		if err := rows.Scan(&p.ID, &p.Nome, &p.Descricao, &p.Preco, &p.Categoria); err != nil {
+		var dieta dietaPersistida
+		if err := rows.Scan(&p.ID, &p.Nome, &p.Descricao, &p.Preco, &p.Categoria, &p.TempoPreparoMinutos, &p.Esgotado, &p.Estoque, &dieta.alergenos, &dieta.tags, &dieta.nutricao); err != nil {
 			return nil, fmt.Errorf("erro ao escanear produto: %v", err)
 		}
+		info, err := dieta.entidade()
+		if err != nil {
+			return nil, err
+		}
+		p.InformacoesDieteticas = info
 		produtos = append(produtos, &p)
 	}
 
@@ -133,6 +325,150 @@ func (pr *produtoMysqlRepository) ListarPorCategoria(c context.Context, categori
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("erro durante a iteração dos produtos: %v", err)
 	}
+	rows.Close()
+
+	for inicio := 0; inicio < len(produtos); inicio += loteProdutosDetalhes {
+		lote := produtos[inicio:min(inicio+loteProdutosDetalhes, len(produtos))]
+		if err := pr.carregarComponentes(c, lote); err != nil {
+			return nil, err
+		}
+
+		ids := make([]int, len(lote))
+		for i, p := range lote {
+			ids[i] = p.ID
+		}
+		imagens, err := listarImagensDosProdutos(c, conexao(c, pr.database), ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range lote {
+			p.Imagens = imagens[p.ID]
+		}
+	}
 
 	return produtos, nil
 }
+
+// carregarComponentes preenche, numa só consulta, os componentes dos produtos que são combos
+func (pr *produtoMysqlRepository) carregarComponentes(c context.Context, produtos []*entities.Produto) error {
+	combos := map[int]*entities.Produto{}
+	var ids []int
+	for _, p := range produtos {
+		if p.EhCombo() {
+			p.Componentes = []entities.ComponenteCombo{}
+			combos[p.ID] = p
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	marcadores, args := listaIN(ids)
+	query := `SELECT cc.idCombo, cc.idComponente, cc.categoria, cc.quantidade,
+			p.idProduto, p.nomeProduto, p.descricaoProduto, p.precoProduto, p.categoriaProduto, p.tempoPreparoMinutos,
+			p.alergenos, p.tagsDieta
+		FROM ComboComponente cc LEFT JOIN Produto p ON p.idProduto = cc.idProduto
+		WHERE cc.idCombo IN (` + marcadores + `)
+		ORDER BY cc.idCombo, cc.idComponente`
+
+	rows, err := conexao(c, pr.database).QueryContext(c, query, args...)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar componentes do combo: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var comboID int
+		var componente entities.ComponenteCombo
+		var categoria, nome, descricao, categoriaProduto, alergenos, tags sql.NullString
+		var produtoID, tempoPreparo sql.NullInt64
+		var preco entities.Money
+		if err := rows.Scan(&comboID, &componente.ID, &categoria, &componente.Quantidade,
+			&produtoID, &nome, &descricao, &preco, &categoriaProduto, &tempoPreparo, &alergenos, &tags); err != nil {
+			return fmt.Errorf("erro ao escanear componente do combo: %w", err)
+		}
+
+		if produtoID.Valid {
+			componente.Produto = &entities.Produto{
+				ID:                  int(produtoID.Int64),
+				Nome:                nome.String,
+				Descricao:           descricao.String,
+				Preco:               preco,
+				Categoria:           entities.CatProduto(categoriaProduto.String),
+				TempoPreparoMinutos: int(tempoPreparo.Int64),
+				InformacoesDieteticas: entities.InformacoesDieteticas{
+					Alergenos: separarValores[entities.Alergeno](alergenos.String),
+					TagsDieta: separarValores[entities.TagDieta](tags.String),
+				},
+			}
+		} else {
+			componente.Categoria = entities.CatProduto(categoria.String)
+		}
+		combo := combos[comboID]
+		combo.Componentes = append(combo.Componentes, componente)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("erro na iteração dos componentes do combo: %w", err)
+	}
+	return nil
+}
+
+// dietaPersistida guarda as informações dietéticas como estão nas colunas do Produto:
+// alérgenos e tags separados por vírgula e a tabela nutricional em JSON
+type dietaPersistida struct {
+	alergenos string
+	tags      string
+	nutricao  []byte
+}
+
+func novaDietaPersistida(info entities.InformacoesDieteticas) (dietaPersistida, error) {
+	dieta := dietaPersistida{
+		alergenos: juntarValores(info.Alergenos),
+		tags:      juntarValores(info.TagsDieta),
+	}
+	if info.Nutricao != nil {
+		nutricao, err := json.Marshal(info.Nutricao)
+		if err != nil {
+			return dietaPersistida{}, fmt.Errorf("erro ao serializar informação nutricional: %w", err)
+		}
+		dieta.nutricao = nutricao
+	}
+	return dieta, nil
+}
+
+func (d dietaPersistida) entidade() (entities.InformacoesDieteticas, error) {
+	info := entities.InformacoesDieteticas{
+		Alergenos: separarValores[entities.Alergeno](d.alergenos),
+		TagsDieta: separarValores[entities.TagDieta](d.tags),
+	}
+	if len(d.nutricao) > 0 {
+		var nutricao entities.InformacaoNutricional
+		if err := json.Unmarshal(d.nutricao, &nutricao); err != nil {
+			return info, fmt.Errorf("erro ao ler informação nutricional: %w", err)
+		}
+		info.Nutricao = &nutricao
+	}
+	return info, nil
+}
+
+func juntarValores[T ~string](valores []T) string {
+	partes := make([]string, len(valores))
+	for i, v := range valores {
+		partes[i] = string(v)
+	}
+	return strings.Join(partes, ",")
+}
+
+func separarValores[T ~string](coluna string) []T {
+	if coluna == "" {
+		return nil
+	}
+	partes := strings.Split(coluna, ",")
+	valores := make([]T, len(partes))
+	for i, parte := range partes {
+		valores[i] = T(parte)
+	}
+	return valores
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"lanchonete/internal/domain/repository"
+)
+
+type chaveTransacao struct{}
+
+type transacaoMysql struct {
+	db *sql.DB
+}
+
+func NewTransacaoMysql(db *sql.DB) repository.Transacao {
+	return &transacaoMysql{db: db}
+}
+
+// Executar abre uma transação e a coloca no contexto passado a fn. Se o contexto já tem uma
+// transação, fn participa dela e quem a abriu decide o commit.
+func (t *transacaoMysql) Executar(c context.Context, fn func(c context.Context) error) error {
+	if _, ok := c.Value(chaveTransacao{}).(*sql.Tx); ok {
+		return fn(c)
+	}
+
+	tx, err := t.db.BeginTx(c, nil)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	if err := fn(context.WithValue(c, chaveTransacao{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+// executor é o que as consultas precisam, atendido tanto pelo banco quanto por uma transação
+type executor interface {
+	ExecContext(c context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(c context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(c context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// conexao devolve a transação aberta por Transacao.Executar, se houver, ou o próprio banco
+func conexao(c context.Context, db *sql.DB) executor {
+	if tx, ok := c.Value(chaveTransacao{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// transacaoLocal é a transação de um método do repositório que grava várias tabelas. Dentro de
+// Transacao.Executar ela é a transação externa, e Commit e Rollback ficam com quem a abriu.
+type transacaoLocal struct {
+	*sql.Tx
+	propria bool
+}
+
+func iniciarTransacao(c context.Context, db *sql.DB) (*transacaoLocal, error) {
+	if tx, ok := c.Value(chaveTransacao{}).(*sql.Tx); ok {
+		return &transacaoLocal{Tx: tx}, nil
+	}
+	tx, err := db.BeginTx(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &transacaoLocal{Tx: tx, propria: true}, nil
+}
+
+func (t *transacaoLocal) Commit() error {
+	if !t.propria {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *transacaoLocal) Rollback() error {
+	if !t.propria {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
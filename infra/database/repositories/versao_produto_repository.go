@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type versaoProdutoMysqlRepository struct {
+	db *sql.DB
+}
+
+func NewVersaoProdutoMysqlRepository(db *sql.DB) repository.VersaoProdutoRepository {
+	return &versaoProdutoMysqlRepository{db: db}
+}
+
+const colunasVersaoProduto = `idVersao, idProduto, nomeProduto, categoriaProduto, descricaoProduto, precoProduto, tempoPreparoMinutos, autor, vigenteDesde`
+
+func (vr *versaoProdutoMysqlRepository) RegistrarVersao(c context.Context, versao *entities.VersaoProduto) error {
+	query := `INSERT INTO ProdutoVersao (idProduto, nomeProduto, categoriaProduto, descricaoProduto, precoProduto, tempoPreparoMinutos, autor, vigenteDesde) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := conexao(c, vr.db).ExecContext(c, query,
+		versao.ProdutoID,
+		versao.Nome,
+		versao.Categoria,
+		versao.Descricao,
+		versao.Preco,
+		versao.TempoPreparoMinutos,
+		versao.Autor,
+		versao.VigenteDesde,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar versão do produto: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao obter ID da versão do produto: %w", err)
+	}
+	versao.ID = int(id)
+
+	return nil
+}
+
+func (vr *versaoProdutoMysqlRepository) ListarVersoes(c context.Context, produtoID int) ([]entities.VersaoProduto, error) {
+	query := `SELECT ` + colunasVersaoProduto + ` FROM ProdutoVersao WHERE idProduto = ? ORDER BY vigenteDesde, idVersao`
+
+	rows, err := vr.db.QueryContext(c, query, produtoID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico do produto: %w", err)
+	}
+	defer rows.Close()
+
+	versoes := []entities.VersaoProduto{}
+	for rows.Next() {
+		var v entities.VersaoProduto
+		if err := escanearVersaoProduto(rows, &v); err != nil {
+			return nil, err
+		}
+		versoes = append(versoes, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro na iteração do histórico do produto: %w", err)
+	}
+
+	return versoes, nil
+}
+
+func (vr *versaoProdutoMysqlRepository) BuscarVersaoEm(c context.Context, produtoID int, em time.Time) (*entities.VersaoProduto, error) {
+	query := `SELECT ` + colunasVersaoProduto + ` FROM ProdutoVersao
+		WHERE idProduto = ? AND vigenteDesde <= ?
+		ORDER BY vigenteDesde DESC, idVersao DESC
+		LIMIT 1`
+
+	var v entities.VersaoProduto
+	err := escanearVersaoProduto(vr.db.QueryRowContext(c, query, produtoID, em), &v)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrSemVersaoVigente
+		}
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// escanearVersaoProduto lê uma linha com as colunasVersaoProduto
+func escanearVersaoProduto(row interface{ Scan(...any) error }, v *entities.VersaoProduto) error {
+	err := row.Scan(&v.ID, &v.ProdutoID, &v.Nome, &v.Categoria, &v.Descricao, &v.Preco, &v.TempoPreparoMinutos, &v.Autor, &v.VigenteDesde)
+	if err == sql.ErrNoRows {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao escanear versão do produto: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"lanchonete/usecases"
+	"log"
+	"time"
+)
+
+// Relay roda o OutboxRelayUseCase em segundo plano, a cada intervalo, até o contexto ser cancelado
+type Relay struct {
+	useCase   usecases.OutboxRelayUseCase
+	intervalo time.Duration
+}
+
+func NewRelay(useCase usecases.OutboxRelayUseCase, intervalo time.Duration) *Relay {
+	return &Relay{
+		useCase:   useCase,
+		intervalo: intervalo,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	go r.executar(ctx)
+}
+
+func (r *Relay) executar(ctx context.Context) {
+	ticker := time.NewTicker(r.intervalo)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			publicados, err := r.useCase.Run(ctx)
+			if err != nil {
+				log.Printf("❌ Erro no relay da outbox: %v", err)
+				continue
+			}
+			if publicados > 0 {
+				log.Printf("📤 %d evento(s) da outbox publicados", publicados)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// infra/storage/local_storage.go
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalImageStorage grava as imagens em um diretório servido pela própria aplicação
+type LocalImageStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalImageStorage(dir, baseURL string) (*LocalImageStorage, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("o diretório das imagens não pode ser vazio")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("erro ao criar o diretório das imagens: %w", err)
+	}
+
+	return &LocalImageStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalImageStorage) Save(ctx context.Context, key string, content []byte, contentType string) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("erro ao criar o diretório da imagem: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return "", fmt.Errorf("erro ao gravar a imagem: %w", err)
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *LocalImageStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("erro ao apagar a imagem: %w", err)
+	}
+	return nil
+}
+
+// path resolve a chave dentro do diretório, recusando chaves que escapem dele
+func (s *LocalImageStorage) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("chave de imagem inválida: %s", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,85 @@
+// infra/storage/s3_storage.go
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3ImageStorage grava as imagens em um bucket S3 ou em um serviço compatível, como o MinIO
+type S3ImageStorage struct {
+	client    *s3.Client
+	bucket    string
+	publicURL string
+}
+
+// NewS3ImageStorage cria o armazenamento no bucket informado. Com endpoint, acessa um serviço
+// compatível com S3 em vez da AWS; publicURL é o endereço pelo qual o totem baixa as imagens.
+func NewS3ImageStorage(bucket, endpoint, publicURL string) (*S3ImageStorage, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("o bucket das imagens não pode ser vazio")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		// O MinIO aceita qualquer região, mas o SDK exige uma para assinar as requisições
+		cfg.Region = "us-east-1"
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			// Serviços compatíveis costumam não resolver o bucket como subdomínio
+			o.UsePathStyle = true
+		}
+	})
+
+	if publicURL == "" {
+		if endpoint != "" {
+			publicURL = strings.TrimSuffix(endpoint, "/") + "/" + bucket
+		} else {
+			publicURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, cfg.Region)
+		}
+	}
+
+	return &S3ImageStorage{
+		client:    client,
+		bucket:    bucket,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}, nil
+}
+
+func (s *S3ImageStorage) Save(ctx context.Context, key string, content []byte, contentType string) (string, error) {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(s.bucket),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(content),
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String("public, max-age=31536000, immutable"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("erro ao enviar a imagem ao bucket: %w", err)
+	}
+	return s.publicURL + "/" + key, nil
+}
+
+func (s *S3ImageStorage) Delete(ctx context.Context, key string) error {
+	// O S3 não acusa erro ao apagar uma chave inexistente
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao apagar a imagem do bucket: %w", err)
+	}
+	return nil
+}
@@ -8,44 +8,107 @@ import (
 
 // PedidoDTO representa os dados de um pedido para apresentação
 type PedidoDTO struct {
-	ID            string                `json:"id"`
-	Identificacao string                `json:"identificacao"`
-	Status        entities.StatusPedido `json:"status"`
-	TempoEstimado time.Duration         `json:"tempoEstimado"`
-	Itens         []ItemPedidoDTO       `json:"itens"`
-	Cliente       string                `json:"cliente"`
-	Total         float32               `json:"total"`
+	ID             string                 `json:"id"`
+	Identificacao  string                 `json:"identificacao"`
+	Status         entities.StatusPedido  `json:"status"`
+	TempoEstimado  string                 `json:"tempoEstimado"` // HH:MM:SS
+	PrevisaoPronto *time.Time             `json:"previsaoPronto,omitempty"`
+	Itens          []ItemPedidoDTO        `json:"itens"`
+	Cliente        string                 `json:"cliente"`
+	Subtotal       entities.Money         `json:"subtotal"`
+	Desconto       entities.Money         `json:"desconto"`
+	Cupom          *string                `json:"cupom,omitempty"`
+	Total          entities.Money         `json:"total"`
+	Cancelamento   *entities.Cancelamento `json:"cancelamento,omitempty"`
+	// Alérgenos do pedido, com os produtos que os contêm
+	AvisosAlergenos []entities.AvisoAlergeno `json:"avisosAlergenos,omitempty"`
+	// Código chamado no balcão; vazio nos pedidos feitos antes dos códigos de retirada
+	CodigoRetirada string `json:"codigoRetirada,omitempty"`
 }
 
 // ItemPedidoDTO representa os dados de um item de pedido para apresentação
 type ItemPedidoDTO struct {
-	ProdutoID     int     `json:"produtoId"`
-	NomeProduto   string  `json:"nomeProduto"`
-	Quantidade    int     `json:"quantidade"`
-	PrecoUnitario float32 `json:"precoUnitario"`
-	Subtotal      float32 `json:"subtotal"`
+	ProdutoID        int                 `json:"produtoId"`
+	NomeProduto      string              `json:"nomeProduto"`
+	CategoriaProduto entities.CatProduto `json:"categoriaProduto"`
+	Quantidade       int                 `json:"quantidade"`
+	Modificadores    []ModificadorDTO    `json:"modificadores,omitempty"`
+	Componentes      []ComponenteDTO     `json:"componentes,omitempty"`
+	PrecoUnitario    entities.Money      `json:"precoUnitario"`
+	Subtotal         entities.Money      `json:"subtotal"`
+}
+
+// ComponenteDTO representa um produto que compõe um combo em um item de pedido
+type ComponenteDTO struct {
+	ProdutoID   int    `json:"produtoId"`
+	NomeProduto string `json:"nomeProduto"`
+	Quantidade  int    `json:"quantidade"`
+}
+
+// ModificadorDTO representa um modificador escolhido em um item de pedido
+type ModificadorDTO struct {
+	ID    int            `json:"id"`
+	Nome  string         `json:"nome"`
+	Preco entities.Money `json:"preco"`
 }
 
 // NewPedidoDTO cria um novo DTO a partir de uma entidade Pedido
 func NewPedidoDTO(p *entities.Pedido) *PedidoDTO {
 	itens := make([]ItemPedidoDTO, 0)
-	for _, produto := range p.Produtos {
+	for _, item := range p.Itens {
+		modificadores := make([]ModificadorDTO, 0, len(item.Modificadores))
+		for _, m := range item.Modificadores {
+			modificadores = append(modificadores, ModificadorDTO{ID: m.ID, Nome: m.Nome, Preco: m.Preco})
+		}
+
+		componentes := make([]ComponenteDTO, 0, len(item.Componentes))
+		for _, c := range item.Componentes {
+			componentes = append(componentes, ComponenteDTO{ProdutoID: c.Produto.ID, NomeProduto: c.Produto.Nome, Quantidade: c.Quantidade})
+		}
+
 		itens = append(itens, ItemPedidoDTO{
-			ProdutoID:     produto.ID,
-			NomeProduto:   produto.Nome,
-			Quantidade:    1, // Default quantity since there's no quantity field in Produto
-			PrecoUnitario: produto.Preco,
-			Subtotal:      produto.Preco,
+			ProdutoID:        item.Produto.ID,
+			NomeProduto:      item.Produto.Nome,
+			CategoriaProduto: item.Produto.Categoria,
+			Quantidade:       item.Quantidade,
+			Modificadores:    modificadores,
+			Componentes:      componentes,
+			PrecoUnitario:    item.PrecoUnitario,
+			Subtotal:         item.Subtotal,
 		})
 	}
 
 	return &PedidoDTO{
-		ID:            fmt.Sprintf("%d", p.ID),
-		Identificacao: fmt.Sprintf("%d", p.ID),
-		Status:        p.Status,
-		TempoEstimado: time.Duration(900), // Default 15 minutes
-		Itens:         itens,
-		Cliente:       p.ClienteNome,
-		Total:         p.Total,
+		ID:              fmt.Sprintf("%d", p.ID),
+		Identificacao:   fmt.Sprintf("%d", p.ID),
+		Status:          p.Status,
+		TempoEstimado:   entities.FormatarDuracao(p.TempoEstimado),
+		PrevisaoPronto:  p.PrevisaoPronto,
+		Itens:           itens,
+		Cliente:         p.ClienteNome,
+		Subtotal:        p.Subtotal,
+		Desconto:        p.Desconto,
+		Cupom:           p.Cupom,
+		Total:           p.Total,
+		Cancelamento:    p.Cancelamento,
+		AvisosAlergenos: entities.AvisosAlergenos(p.Itens),
+		CodigoRetirada:  p.CodigoRetirada,
+	}
+}
+
+// ProximosStatusDTO representa os status aceitos pela troca de status do pedido; o cancelamento não
+// aparece porque é feito pela rota de cancelamento
+type ProximosStatusDTO struct {
+	ID             int                     `json:"id"`
+	StatusAtual    entities.StatusPedido   `json:"statusAtual"`
+	ProximosStatus []entities.StatusPedido `json:"proximosStatus"`
+}
+
+// NewProximosStatusDTO cria um novo DTO com os próximos status permitidos do pedido
+func NewProximosStatusDTO(p *entities.Pedido) *ProximosStatusDTO {
+	return &ProximosStatusDTO{
+		ID:             p.ID,
+		StatusAtual:    p.Status,
+		ProximosStatus: p.ProximosStatus(),
 	}
 }
@@ -1,13 +1,24 @@
 package presenters
 
-import "lanchonete/internal/domain/entities"
+import (
+	"lanchonete/internal/domain/entities"
+	"time"
+)
 
 type ProdutoDTO struct {
-	Identificacao int                 `json:"identificacao"`
-	Nome          string              `json:"nome"`
-	Categoria     entities.CatProduto `json:"categoria"`
-	Descricao     string              `json:"descricao"`
-	Preco         float32             `json:"preco"`
+	Identificacao int                         `json:"identificacao"`
+	Nome          string                      `json:"nome"`
+	Categoria     entities.CatProduto         `json:"categoria"`
+	Descricao     string                      `json:"descricao"`
+	Preco         entities.Money              `json:"preco"`
+	TempoPreparo  string                      `json:"tempoPreparo"` // HH:MM:SS; combos usam o tempo dos componentes
+	Esgotado      bool                        `json:"esgotado"`
+	Estoque       *int                        `json:"estoque,omitempty"` // Ausente quando o estoque não é controlado
+	ArquivadoEm   *time.Time                  `json:"arquivadoEm,omitempty"`
+	Modificadores []entities.GrupoModificador `json:"modificadores,omitempty"`
+	Componentes   []entities.ComponenteCombo  `json:"componentes,omitempty"`
+	// URL da imagem em cada tamanho: original, media e miniatura
+	Imagens map[entities.VarianteImagem]string `json:"imagens,omitempty"`
 }
 
 func NewProdutoDTO(produto *entities.Produto) *ProdutoDTO {
@@ -21,5 +32,12 @@ func NewProdutoDTO(produto *entities.Produto) *ProdutoDTO {
 		Categoria:     produto.Categoria,
 		Descricao:     produto.Descricao,
 		Preco:         produto.Preco,
+		TempoPreparo:  entities.FormatarDuracao(produto.TempoPreparo()),
+		Esgotado:      produto.Esgotado,
+		Estoque:       produto.Estoque,
+		ArquivadoEm:   produto.ArquivadoEm,
+		Modificadores: produto.Modificadores,
+		Componentes:   produto.Componentes,
+		Imagens:       entities.URLsImagens(produto.Imagens),
 	}
 }
@@ -0,0 +1,49 @@
+package presenters
+
+import (
+	"lanchonete/internal/domain/entities"
+	"time"
+)
+
+// VersaoProdutoDTO representa uma versão do produto no histórico do catálogo
+type VersaoProdutoDTO struct {
+	ID           int                 `json:"idVersao"`
+	ProdutoID    int                 `json:"idProduto"`
+	Nome         string              `json:"nomeProduto"`
+	Categoria    entities.CatProduto `json:"categoriaProduto"`
+	Descricao    string              `json:"descricaoProduto"`
+	Preco        entities.Money      `json:"precoProduto"`
+	TempoPreparo string              `json:"tempoPreparo"` // HH:MM:SS
+	Autor        string              `json:"autor"`
+	VigenteDesde time.Time           `json:"vigenteDesde"`
+	// Campos que mudaram em relação à versão anterior; ausente na primeira versão
+	CamposAlterados []string `json:"camposAlterados,omitempty"`
+}
+
+func NewVersaoProdutoDTO(v entities.VersaoProduto, anterior *entities.VersaoProduto) VersaoProdutoDTO {
+	return VersaoProdutoDTO{
+		ID:              v.ID,
+		ProdutoID:       v.ProdutoID,
+		Nome:            v.Nome,
+		Categoria:       v.Categoria,
+		Descricao:       v.Descricao,
+		Preco:           v.Preco,
+		TempoPreparo:    entities.FormatarDuracao(time.Duration(v.TempoPreparoMinutos) * time.Minute),
+		Autor:           v.Autor,
+		VigenteDesde:    v.VigenteDesde,
+		CamposAlterados: v.CamposAlterados(anterior),
+	}
+}
+
+// NewHistoricoProdutoDTO converte as versões, em ordem cronológica, indicando o que mudou em cada uma
+func NewHistoricoProdutoDTO(versoes []entities.VersaoProduto) []VersaoProdutoDTO {
+	historico := make([]VersaoProdutoDTO, 0, len(versoes))
+	for i := range versoes {
+		var anterior *entities.VersaoProduto
+		if i > 0 {
+			anterior = &versoes[i-1]
+		}
+		historico = append(historico, NewVersaoProdutoDTO(versoes[i], anterior))
+	}
+	return historico
+}
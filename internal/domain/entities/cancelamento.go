@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AtorCancelamento identifica quem pediu o cancelamento do pedido
+type AtorCancelamento string
+
+const (
+	CanceladoPeloCliente   AtorCancelamento = "Cliente"
+	CanceladoPelaLoja      AtorCancelamento = "Loja"
+	CanceladoPeloPagamento AtorCancelamento = "Pagamento" // Pagamento recusado ou cancelado pelo serviço de pagamento
+)
+
+// cancelamentoPermitido define, para cada ator, em quais status ele pode cancelar o pedido.
+// O cliente só desiste antes de a cozinha começar; a loja pode cancelar até o pedido ficar pronto.
+var cancelamentoPermitido = map[AtorCancelamento][]StatusPedido{
+	CanceladoPeloCliente:   {Pendente, Recebido},
+	CanceladoPelaLoja:      {Pendente, Recebido, EmPreparacao},
+	CanceladoPeloPagamento: {Pendente, Recebido, EmPreparacao},
+}
+
+var (
+	ErrAtorCancelamentoInvalido = errors.New("responsável pelo cancelamento inválido")
+	ErrMotivoCancelamento       = errors.New("o motivo do cancelamento é obrigatório")
+	// ErrCancelamentoViaStatus impede cancelar pela troca de status, que não registra motivo nem devolve o estoque
+	ErrCancelamentoViaStatus = errors.New("o pedido deve ser cancelado pelo cancelamento, informando motivo e responsável")
+)
+
+// CancelamentoNaoPermitidoError indica que o ator não pode cancelar o pedido no status em que ele está
+type CancelamentoNaoPermitidoError struct {
+	Ator   AtorCancelamento
+	Status StatusPedido
+}
+
+func (e *CancelamentoNaoPermitidoError) Error() string {
+	return fmt.Sprintf("cancelamento não permitido: %s não pode cancelar um pedido com status %s", e.Ator, e.Status)
+}
+
+// Cancelamento registra quem cancelou o pedido, por quê e se o valor pago precisa ser devolvido
+type Cancelamento struct {
+	Ator                AtorCancelamento `json:"ator"`
+	Motivo              string           `json:"motivo"`
+	CanceladoEm         time.Time        `json:"canceladoEm"`
+	ReembolsoNecessario bool             `json:"reembolsoNecessario"`
+}
+
+// PodeCancelar informa se o ator pode cancelar um pedido no status informado
+func (a AtorCancelamento) PodeCancelar(status StatusPedido) bool {
+	for _, permitido := range cancelamentoPermitido[a] {
+		if permitido == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Cancelar cancela o pedido conforme as regras do ator. Um pedido já pago fica marcado para reembolso;
+// um pagamento ainda pendente é cancelado junto com o pedido.
+func (p *Pedido) Cancelar(ator AtorCancelamento, motivo string, agora time.Time) error {
+	if _, ok := cancelamentoPermitido[ator]; !ok {
+		return ErrAtorCancelamentoInvalido
+	}
+
+	motivo = strings.TrimSpace(motivo)
+	if motivo == "" {
+		return ErrMotivoCancelamento
+	}
+
+	if !p.Status.PodeTransitar(Cancelado) {
+		return &TransicaoStatusError{De: p.Status, Para: Cancelado}
+	}
+	if !ator.PodeCancelar(p.Status) {
+		return &CancelamentoNaoPermitidoError{Ator: ator, Status: p.Status}
+	}
+
+	reembolso := p.StatusPagamento == PagamentoPago
+	if err := p.UpdateStatus(Cancelado); err != nil {
+		return err
+	}
+	if p.StatusPagamento == PagamentoPendente {
+		p.StatusPagamento = PagamentoCancelado
+	}
+
+	p.UltimaAtualizacao = agora
+	p.Cancelamento = &Cancelamento{
+		Ator:                ator,
+		Motivo:              motivo,
+		CanceladoEm:         agora,
+		ReembolsoNecessario: reembolso,
+	}
+	return nil
+}
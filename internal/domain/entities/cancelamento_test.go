@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPedido_Cancelar(t *testing.T) {
+	agora := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("cliente cancela pedido pendente", func(t *testing.T) {
+		pedido := &Pedido{Status: Pendente, StatusPagamento: PagamentoPendente}
+
+		err := pedido.Cancelar(CanceladoPeloCliente, " desisti ", agora)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Cancelado, pedido.Status)
+		assert.Equal(t, PagamentoCancelado, pedido.StatusPagamento, "pagamento pendente é cancelado junto")
+		assert.Equal(t, &Cancelamento{Ator: CanceladoPeloCliente, Motivo: "desisti", CanceladoEm: agora}, pedido.Cancelamento)
+	})
+
+	t.Run("pedido pago precisa de reembolso", func(t *testing.T) {
+		pedido := &Pedido{Status: EmPreparacao, StatusPagamento: PagamentoPago}
+
+		err := pedido.Cancelar(CanceladoPelaLoja, "faltou pão", agora)
+
+		assert.NoError(t, err)
+		assert.Equal(t, PagamentoPago, pedido.StatusPagamento, "o serviço de pagamento decide o estorno")
+		assert.True(t, pedido.Cancelamento.ReembolsoNecessario)
+	})
+
+	t.Run("cliente não cancela pedido em preparação", func(t *testing.T) {
+		pedido := &Pedido{Status: EmPreparacao, StatusPagamento: PagamentoPago}
+
+		err := pedido.Cancelar(CanceladoPeloCliente, "demorou", agora)
+
+		var naoPermitido *CancelamentoNaoPermitidoError
+		assert.True(t, errors.As(err, &naoPermitido))
+		assert.Equal(t, EmPreparacao, pedido.Status)
+		assert.Nil(t, pedido.Cancelamento)
+	})
+
+	t.Run("ninguém cancela pedido pronto ou já cancelado", func(t *testing.T) {
+		for _, status := range []StatusPedido{Pronto, Finalizado, Cancelado} {
+			pedido := &Pedido{Status: status, StatusPagamento: PagamentoPago}
+
+			err := pedido.Cancelar(CanceladoPelaLoja, "erro", agora)
+
+			var transicao *TransicaoStatusError
+			assert.True(t, errors.As(err, &transicao), "status %s", status)
+		}
+	})
+
+	t.Run("motivo e ator obrigatórios", func(t *testing.T) {
+		pedido := &Pedido{Status: Pendente, StatusPagamento: PagamentoPendente}
+
+		assert.ErrorIs(t, pedido.Cancelar(CanceladoPeloCliente, "  ", agora), ErrMotivoCancelamento)
+		assert.ErrorIs(t, pedido.Cancelar("Cozinha", "erro", agora), ErrAtorCancelamentoInvalido)
+		assert.Equal(t, Pendente, pedido.Status)
+	})
+}
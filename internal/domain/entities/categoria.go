@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrCategoriaInvalida      = errors.New("categoria inválida")
+	ErrCategoriaInativa       = errors.New("a categoria está inativa")
+	ErrCategoriaNaoEncontrada = errors.New("categoria não encontrada")
+	// ErrCategoriaReservada protege a categoria Combo, que identifica os produtos montados com componentes
+	ErrCategoriaReservada = errors.New("a categoria Combo é reservada e não pode ser alterada")
+	ErrCategoriaEmUso     = errors.New("a categoria possui produtos, cupons ou combos e não pode ser removida")
+)
+
+// Categoria agrupa os produtos do cardápio. As categorias são cadastradas pela loja; o nome é gravado
+// nos produtos e o slug identifica a categoria nas URLs.
+type Categoria struct {
+	ID    int        `json:"idCategoria"`
+	Nome  CatProduto `json:"nome"`
+	Slug  string     `json:"slug"`
+	Ordem int        `json:"ordem"`
+	Ativa bool       `json:"ativa"`
+	// ObrigatoriaNoPedido exige que todo pedido tenha ao menos um item da categoria
+	ObrigatoriaNoPedido bool `json:"obrigatoriaNoPedido"`
+	// TempoPreparoMinutos é o tempo de preparo dos produtos da categoria que não informam o seu
+	TempoPreparoMinutos int `json:"tempoPreparoMinutos"`
+}
+
+func CategoriaNew(nome string, ordem int, ativa bool, obrigatoriaNoPedido bool) (*Categoria, error) {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return nil, errors.New("o nome da categoria é obrigatório")
+	}
+	if len(nome) > 30 {
+		return nil, errors.New("o nome da categoria deve ter no máximo 30 caracteres")
+	}
+	if ordem < 0 {
+		return nil, errors.New("a ordem de exibição não pode ser negativa")
+	}
+
+	slug := SlugCategoria(nome)
+	if slug == "" {
+		return nil, errors.New("o nome da categoria precisa ter letras ou números")
+	}
+
+	return &Categoria{
+		Nome:                CatProduto(nome),
+		Slug:                slug,
+		Ordem:               ordem,
+		Ativa:               ativa,
+		ObrigatoriaNoPedido: obrigatoriaNoPedido,
+	}, nil
+}
+
+// DefinirTempoPreparo define o tempo de preparo padrão dos produtos da categoria, em minutos
+func (c *Categoria) DefinirTempoPreparo(minutos int) error {
+	if minutos < 0 {
+		return errors.New("o tempo de preparo não pode ser negativo")
+	}
+	c.TempoPreparoMinutos = minutos
+	return nil
+}
+
+// Reservada informa se a categoria é a de combos, que não pode ser renomeada nem removida
+func (c Categoria) Reservada() bool {
+	return c.Nome == Combo
+}
+
+var semAcento = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// SlugCategoria gera o identificador da categoria nas URLs, ex.: "Café da manhã" vira "cafe-da-manha"
+func SlugCategoria(nome string) string {
+	nome = semAcento.Replace(strings.ToLower(strings.TrimSpace(nome)))
+
+	var slug strings.Builder
+	hifen := false
+	for _, r := range nome {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if hifen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			hifen = false
+		default:
+			hifen = true
+		}
+	}
+	return slug.String()
+}
+
+// VerificarCategoriasAtivas recusa o pedido listando os produtos, inclusive os combos e seus componentes,
+// cujas categorias estão inativas: uma categoria inativa sai do cardápio com todos os seus produtos
+func VerificarCategoriasAtivas(itens []ItemPedido, categorias []Categoria) error {
+	inativas := map[CatProduto]bool{}
+	for _, categoria := range categorias {
+		if !categoria.Ativa {
+			inativas[categoria.Nome] = true
+		}
+	}
+
+	var indisponiveis []string
+	for _, consumo := range ConsumoDeProdutos(itens) {
+		if inativas[consumo.Produto.Categoria] {
+			indisponiveis = append(indisponiveis, consumo.Produto.Nome)
+		}
+	}
+	if len(indisponiveis) > 0 {
+		return &ProdutosIndisponiveisError{Produtos: indisponiveis}
+	}
+	return nil
+}
+
+// CategoriasObrigatorias filtra as categorias ativas das quais todo pedido precisa ter um item
+func CategoriasObrigatorias(categorias []Categoria) []Categoria {
+	obrigatorias := []Categoria{}
+	for _, categoria := range categorias {
+		if categoria.Ativa && categoria.ObrigatoriaNoPedido {
+			obrigatorias = append(obrigatorias, categoria)
+		}
+	}
+	return obrigatorias
+}
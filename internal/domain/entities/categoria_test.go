@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// categoriasCardapio reproduz as categorias do cardápio inicial, com o lanche obrigatório no pedido
+var categoriasCardapio = []Categoria{
+	{ID: 1, Nome: Lanche, Slug: "lanche", Ordem: 1, Ativa: true, ObrigatoriaNoPedido: true, TempoPreparoMinutos: 10},
+	{ID: 2, Nome: Acompanhamento, Slug: "acompanhamento", Ordem: 2, Ativa: true, TempoPreparoMinutos: 6},
+	{ID: 3, Nome: Bebida, Slug: "bebida", Ordem: 3, Ativa: true, TempoPreparoMinutos: 1},
+	{ID: 4, Nome: Sobremesa, Slug: "sobremesa", Ordem: 4, Ativa: true, TempoPreparoMinutos: 3},
+	{ID: 5, Nome: Combo, Slug: "combo", Ordem: 5, Ativa: true},
+}
+
+func categoriaDoCardapio(nome CatProduto) *Categoria {
+	for i := range categoriasCardapio {
+		if categoriasCardapio[i].Nome == nome {
+			categoria := categoriasCardapio[i]
+			return &categoria
+		}
+	}
+	return nil
+}
+
+func TestCategoriaNew(t *testing.T) {
+	categoria, err := CategoriaNew("  Café da Manhã ", 6, true, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, CatProduto("Café da Manhã"), categoria.Nome)
+	assert.Equal(t, "cafe-da-manha", categoria.Slug)
+	assert.Equal(t, 6, categoria.Ordem)
+	assert.True(t, categoria.Ativa)
+	assert.False(t, categoria.Reservada())
+
+	casos := []struct {
+		name  string
+		nome  string
+		ordem int
+	}{
+		{"Nome vazio", " ", 1},
+		{"Nome sem letras", "@#$", 1},
+		{"Nome longo demais", "Categoria com um nome grande demais para o cardápio", 1},
+		{"Ordem negativa", "Salgados", -1},
+	}
+	for _, caso := range casos {
+		t.Run(caso.name, func(t *testing.T) {
+			categoria, err := CategoriaNew(caso.nome, caso.ordem, true, false)
+			assert.Error(t, err)
+			assert.Nil(t, categoria)
+		})
+	}
+}
+
+func TestSlugCategoria(t *testing.T) {
+	assert.Equal(t, "lanche", SlugCategoria("Lanche"))
+	assert.Equal(t, "cafe-da-manha", SlugCategoria("Café da manhã"))
+	assert.Equal(t, "acai-sucos", SlugCategoria("Açaí & Sucos"))
+	assert.Equal(t, "porcoes-2-pessoas", SlugCategoria(" Porções (2 pessoas) "))
+}
+
+func TestCategoriasObrigatorias(t *testing.T) {
+	categorias := append([]Categoria{
+		{ID: 6, Nome: "Salgados", Ativa: false, ObrigatoriaNoPedido: true},
+	}, categoriasCardapio...)
+
+	obrigatorias := CategoriasObrigatorias(categorias)
+
+	assert.Len(t, obrigatorias, 1, "categoria inativa não é exigida")
+	assert.Equal(t, Lanche, obrigatorias[0].Nome)
+}
+
+func TestRegrasPedido_CategoriaObrigatoriaConfiguravel(t *testing.T) {
+	regras := RegrasPedidoNew(ConfigRegrasPedido{})
+	soBebida, _ := PedidoNew("Maria", []ItemPedido{{Produto: cocaCola, Quantidade: 1}}, nil)
+
+	err := regras.Validar(soBebida, categoriasCardapio)
+	assert.ErrorContains(t, err, "ao menos um item da categoria Lanche")
+
+	semObrigatorias := []Categoria{{ID: 1, Nome: Lanche, Ativa: true}, {ID: 3, Nome: Bebida, Ativa: true}}
+	err = regras.Validar(soBebida, semObrigatorias)
+	assert.NoError(t, err, "sem categorias obrigatórias o pedido só de bebida é aceito")
+
+	bebidaObrigatoria := []Categoria{{ID: 1, Nome: Lanche, Ativa: true}, {ID: 3, Nome: Bebida, Ativa: true, ObrigatoriaNoPedido: true}}
+	soLanche, _ := PedidoNew("Maria", []ItemPedido{{Produto: xSalada, Quantidade: 1}}, nil)
+	err = regras.Validar(soLanche, bebidaObrigatoria)
+	assert.ErrorContains(t, err, "ao menos um item da categoria Bebida")
+}
+
+func TestCategoria_DefinirTempoPreparo(t *testing.T) {
+	categoria, err := CategoriaNew("Salgados", 6, true, false)
+	assert.NoError(t, err)
+
+	assert.NoError(t, categoria.DefinirTempoPreparo(7))
+	assert.Equal(t, 7, categoria.TempoPreparoMinutos)
+	assert.Error(t, categoria.DefinirTempoPreparo(-1))
+
+	produto, err := ProdutoNew("Coxinha", categoria, "Coxinha de frango", Reais(7))
+	assert.NoError(t, err)
+	assert.Equal(t, 7*time.Minute, produto.TempoPreparo(), "o produto herda o tempo da categoria")
+}
+
+func TestVerificarCategoriasAtivas(t *testing.T) {
+	categorias := append([]Categoria{}, categoriasCardapio...)
+	itens := []ItemPedido{{Produto: xSalada, Quantidade: 1}, {Produto: mousse, Quantidade: 1}}
+	assert.NoError(t, VerificarCategoriasAtivas(itens, categorias))
+
+	categorias[3].Ativa = false
+	err := VerificarCategoriasAtivas(itens, categorias)
+
+	var indisponiveis *ProdutosIndisponiveisError
+	assert.ErrorAs(t, err, &indisponiveis)
+	assert.Equal(t, []string{"Mousse de chocolate"}, indisponiveis.Produtos)
+	assert.ErrorIs(t, err, ErrProdutoIndisponivel)
+}
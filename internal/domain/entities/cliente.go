@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrCPFInvalido                 = errors.New("CPF inválido")
+	ErrClienteSemReferencia        = errors.New("informe o CPF ou o identificador do cliente")
+	ErrClienteNaoEncontrado        = errors.New("cliente não encontrado")
+	ErrServicoClientesIndisponivel = errors.New("o serviço de clientes está indisponível")
+)
+
+// ReferenciaCliente identifica um cliente cadastrado no serviço de clientes, pelo CPF ou pelo
+// identificador que o serviço atribuiu a ele. O pedido guarda apenas a referência; os dados do
+// cliente continuam no serviço de clientes.
+type ReferenciaCliente struct {
+	CPF       string `json:"cpf,omitempty"`
+	IDExterno string `json:"id_externo,omitempty"`
+}
+
+// Cliente são os dados que o serviço de clientes devolve para identificar o pedido
+type Cliente struct {
+	IDExterno string `json:"id_externo"`
+	CPF       string `json:"cpf,omitempty"`
+	Nome      string `json:"nome"`
+}
+
+// ReferenciaClienteNew valida a referência ao cliente. O CPF é guardado só com os dígitos.
+func ReferenciaClienteNew(cpf, idExterno string) (*ReferenciaCliente, error) {
+	idExterno = strings.TrimSpace(idExterno)
+	if strings.TrimSpace(cpf) == "" && idExterno == "" {
+		return nil, ErrClienteSemReferencia
+	}
+	if len(idExterno) > 64 {
+		return nil, errors.New("o identificador do cliente deve ter no máximo 64 caracteres")
+	}
+
+	ref := &ReferenciaCliente{IDExterno: idExterno}
+	if strings.TrimSpace(cpf) != "" {
+		normalizado, err := NormalizarCPF(cpf)
+		if err != nil {
+			return nil, err
+		}
+		ref.CPF = normalizado
+	}
+	return ref, nil
+}
+
+// Mascarada devolve uma cópia da referência com o CPF mascarado, ex.: "***.982.247-**", para os dados
+// que saem do serviço, como os eventos. O identificador externo não é dado pessoal e segue inteiro.
+func (r *ReferenciaCliente) Mascarada() *ReferenciaCliente {
+	if r == nil {
+		return nil
+	}
+	mascarada := *r
+	if len(r.CPF) == 11 {
+		mascarada.CPF = "***." + r.CPF[3:6] + "." + r.CPF[6:9] + "-**"
+	} else if r.CPF != "" {
+		mascarada.CPF = "***"
+	}
+	return &mascarada
+}
+
+// Identifica informa se a referência do pedido é do mesmo cliente, pelo CPF ou pelo identificador externo
+func (r ReferenciaCliente) Identifica(cliente *ReferenciaCliente) bool {
+	if cliente == nil {
+		return false
+	}
+	return (r.CPF != "" && cliente.CPF == r.CPF) || (r.IDExterno != "" && cliente.IDExterno == r.IDExterno)
+}
+
+// ParseReferenciaCliente interpreta o valor informado na busca de pedidos: um CPF, com ou sem
+// pontuação, ou o identificador do cliente no serviço de clientes
+func ParseReferenciaCliente(valor string) (*ReferenciaCliente, error) {
+	if cpf, err := NormalizarCPF(valor); err == nil {
+		return &ReferenciaCliente{CPF: cpf}, nil
+	}
+	return ReferenciaClienteNew("", valor)
+}
+
+// NormalizarCPF remove a pontuação do CPF e confere os dígitos verificadores
+func NormalizarCPF(cpf string) (string, error) {
+	digitos := make([]int, 0, 11)
+	for _, r := range strings.TrimSpace(cpf) {
+		switch {
+		case r >= '0' && r <= '9':
+			digitos = append(digitos, int(r-'0'))
+		case r == '.' || r == '-':
+		default:
+			return "", ErrCPFInvalido
+		}
+	}
+	if len(digitos) != 11 {
+		return "", ErrCPFInvalido
+	}
+
+	// Sequências como 111.111.111-11 passam pelo cálculo, mas não são CPFs válidos
+	repetido := true
+	for _, d := range digitos[1:] {
+		if d != digitos[0] {
+			repetido = false
+			break
+		}
+	}
+	if repetido {
+		return "", ErrCPFInvalido
+	}
+
+	if digitoVerificadorCPF(digitos[:9]) != digitos[9] || digitoVerificadorCPF(digitos[:10]) != digitos[10] {
+		return "", ErrCPFInvalido
+	}
+
+	var normalizado strings.Builder
+	for _, d := range digitos {
+		normalizado.WriteByte(byte('0' + d))
+	}
+	return normalizado.String(), nil
+}
+
+// digitoVerificadorCPF calcula o próximo dígito verificador a partir dos dígitos anteriores
+func digitoVerificadorCPF(digitos []int) int {
+	soma := 0
+	peso := len(digitos) + 1
+	for _, d := range digitos {
+		soma += d * peso
+		peso--
+	}
+	resto := soma * 10 % 11
+	if resto == 10 {
+		return 0
+	}
+	return resto
+}
+
+// IdentificarCliente associa ao pedido o cliente encontrado no serviço de clientes.
+// O nome cadastrado substitui o nome digitado no totem.
+func (p *Pedido) IdentificarCliente(ref ReferenciaCliente, cliente Cliente) {
+	if ref.IDExterno == "" {
+		ref.IDExterno = cliente.IDExterno
+	}
+	if ref.CPF == "" && cliente.CPF != "" {
+		if cpf, err := NormalizarCPF(cliente.CPF); err == nil {
+			ref.CPF = cpf
+		}
+	}
+	p.Cliente = &ref
+	if nome := strings.TrimSpace(cliente.Nome); nome != "" {
+		p.ClienteNome = nome
+	}
+}
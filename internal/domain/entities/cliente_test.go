@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizarCPF(t *testing.T) {
+	cpf, err := NormalizarCPF("529.982.247-25")
+	assert.NoError(t, err)
+	assert.Equal(t, "52998224725", cpf)
+
+	cpf, err = NormalizarCPF("11144477735")
+	assert.NoError(t, err)
+	assert.Equal(t, "11144477735", cpf)
+
+	for _, invalido := range []string{"", "529.982.247-24", "111.111.111-11", "5299822472", "529982247250", "529 982 247 25", "abc"} {
+		_, err := NormalizarCPF(invalido)
+		assert.ErrorIs(t, err, ErrCPFInvalido, invalido)
+	}
+}
+
+func TestReferenciaClienteNew(t *testing.T) {
+	ref, err := ReferenciaClienteNew("529.982.247-25", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "52998224725", ref.CPF)
+
+	ref, err = ReferenciaClienteNew("", " c-37 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "c-37", ref.IDExterno)
+
+	_, err = ReferenciaClienteNew(" ", "")
+	assert.ErrorIs(t, err, ErrClienteSemReferencia)
+
+	_, err = ReferenciaClienteNew("123", "c-37")
+	assert.ErrorIs(t, err, ErrCPFInvalido)
+}
+
+func TestReferenciaCliente_Mascarada(t *testing.T) {
+	ref := &ReferenciaCliente{CPF: "52998224725", IDExterno: "37"}
+
+	mascarada := ref.Mascarada()
+
+	assert.Equal(t, "***.982.247-**", mascarada.CPF)
+	assert.Equal(t, "37", mascarada.IDExterno)
+	assert.Equal(t, "52998224725", ref.CPF, "a referência do pedido não muda")
+	assert.Equal(t, "", (&ReferenciaCliente{IDExterno: "37"}).Mascarada().CPF)
+	assert.Nil(t, (*ReferenciaCliente)(nil).Mascarada())
+}
+
+func TestParseReferenciaCliente(t *testing.T) {
+	ref, err := ParseReferenciaCliente("529.982.247-25")
+	assert.NoError(t, err)
+	assert.Equal(t, ReferenciaCliente{CPF: "52998224725"}, *ref)
+
+	ref, err = ParseReferenciaCliente("37")
+	assert.NoError(t, err)
+	assert.Equal(t, ReferenciaCliente{IDExterno: "37"}, *ref)
+}
+
+func TestPedido_IdentificarCliente(t *testing.T) {
+	pedido := &Pedido{ClienteNome: "Ana"}
+	pedido.IdentificarCliente(ReferenciaCliente{CPF: "52998224725"}, Cliente{IDExterno: "37", Nome: "Ana Souza"})
+
+	assert.Equal(t, "Ana Souza", pedido.ClienteNome)
+	assert.Equal(t, &ReferenciaCliente{CPF: "52998224725", IDExterno: "37"}, pedido.Cliente)
+
+	semNome := &Pedido{ClienteNome: "Bruno"}
+	semNome.IdentificarCliente(ReferenciaCliente{IDExterno: "40"}, Cliente{IDExterno: "40", CPF: "111.444.777-35"})
+	assert.Equal(t, "Bruno", semNome.ClienteNome)
+	assert.Equal(t, "11144477735", semNome.Cliente.CPF)
+}
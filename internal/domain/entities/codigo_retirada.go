@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// porLetraRetirada é quantos códigos cabem em cada letra: A-001 a A-999, depois B-001...
+const porLetraRetirada = 999
+
+var (
+	ErrCodigoRetiradaInvalido   = errors.New("código de retirada inválido, use o formato A-017")
+	ErrCodigosRetiradaEsgotados = errors.New("os códigos de retirada do dia se esgotaram")
+)
+
+// CodigoRetiradaNew monta o código chamado no balcão a partir da sequência do dia, que começa em 1.
+// A sequência volta a 1 todo dia, então o código só identifica o pedido junto com a data.
+func CodigoRetiradaNew(sequencia int) (string, error) {
+	if sequencia <= 0 {
+		return "", fmt.Errorf("sequência de retirada inválida: %d", sequencia)
+	}
+	letra := (sequencia - 1) / porLetraRetirada
+	if letra >= 26 {
+		return "", ErrCodigosRetiradaEsgotados
+	}
+	numero := (sequencia-1)%porLetraRetirada + 1
+	return fmt.Sprintf("%c-%03d", 'A'+letra, numero), nil
+}
+
+// NormalizarCodigoRetirada padroniza o código digitado para busca, ex: "a17" ou " A-017 " → "A-017"
+func NormalizarCodigoRetirada(codigo string) (string, error) {
+	codigo = strings.ToUpper(strings.TrimSpace(codigo))
+	if codigo == "" || codigo[0] < 'A' || codigo[0] > 'Z' {
+		return "", ErrCodigoRetiradaInvalido
+	}
+
+	digitos := strings.TrimPrefix(codigo[1:], "-")
+	if len(digitos) == 0 || len(digitos) > 3 {
+		return "", ErrCodigoRetiradaInvalido
+	}
+	numero := 0
+	for _, r := range digitos {
+		if r < '0' || r > '9' {
+			return "", ErrCodigoRetiradaInvalido
+		}
+		numero = numero*10 + int(r-'0')
+	}
+	if numero == 0 {
+		return "", ErrCodigoRetiradaInvalido
+	}
+	return fmt.Sprintf("%c-%03d", codigo[0], numero), nil
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodigoRetiradaNew(t *testing.T) {
+	tests := []struct {
+		sequencia int
+		esperado  string
+	}{
+		{1, "A-001"},
+		{17, "A-017"},
+		{999, "A-999"},
+		{1000, "B-001"},
+		{26 * 999, "Z-999"},
+	}
+
+	for _, tt := range tests {
+		codigo, err := CodigoRetiradaNew(tt.sequencia)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.esperado, codigo)
+	}
+}
+
+func TestCodigoRetiradaNew_Errors(t *testing.T) {
+	_, err := CodigoRetiradaNew(0)
+	assert.Error(t, err)
+
+	_, err = CodigoRetiradaNew(26*999 + 1)
+	assert.ErrorIs(t, err, ErrCodigosRetiradaEsgotados)
+}
+
+func TestNormalizarCodigoRetirada(t *testing.T) {
+	for _, valor := range []string{"A-017", " a-017 ", "a17", "A017", "a-17"} {
+		codigo, err := NormalizarCodigoRetirada(valor)
+		assert.NoError(t, err, valor)
+		assert.Equal(t, "A-017", codigo, valor)
+	}
+
+	for _, valor := range []string{"", "17", "A-", "A-000", "A-1234", "AB-017", "A-01x", "Ç-017"} {
+		_, err := NormalizarCodigoRetirada(valor)
+		assert.ErrorIs(t, err, ErrCodigoRetiradaInvalido, valor)
+	}
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ComponenteCombo é uma posição do combo: um produto fixo (ex: X-Salada) ou a escolha
+// livre de um produto de uma categoria (ex: qualquer Bebida)
+type ComponenteCombo struct {
+	ID         int        `json:"idComponente,omitempty"`
+	Produto    *Produto   `json:"produto,omitempty"`
+	Categoria  CatProduto `json:"categoria,omitempty"`
+	Quantidade int        `json:"quantidade"`
+}
+
+// ItemCombo é um produto que compõe uma linha de combo no pedido
+type ItemCombo struct {
+	Produto    Produto `json:"produto"`
+	Quantidade int     `json:"quantidade"`
+}
+
+// ComboNew cria um combo: um produto com preço próprio composto por outros produtos
+func ComboNew(nome string, descricao string, preco Money, componentes []ComponenteCombo) (*Produto, error) {
+	if strings.TrimSpace(nome) == "" || !preco.IsPositive() {
+		return nil, errors.New("todos os campos são obrigatórios e o preço maior que zero")
+	}
+
+	if len(componentes) < 2 {
+		return nil, errors.New("um combo precisa ter ao menos dois componentes")
+	}
+
+	validados := make([]ComponenteCombo, 0, len(componentes))
+	for _, componente := range componentes {
+		if (componente.Produto == nil) == (componente.Categoria == "") {
+			return nil, errors.New("cada componente do combo deve ter um produto ou uma categoria")
+		}
+		if componente.Produto != nil && !categoriaDeItem(componente.Produto.Categoria) {
+			return nil, fmt.Errorf("o produto %s não pode compor um combo", componente.Produto.Nome)
+		}
+		if componente.Produto == nil && !categoriaDeItem(componente.Categoria) {
+			return nil, fmt.Errorf("categoria inválida para componente de combo: %s", componente.Categoria)
+		}
+		if componente.Quantidade < 0 {
+			return nil, errors.New("a quantidade de um componente do combo não pode ser negativa")
+		}
+		if componente.Quantidade == 0 {
+			componente.Quantidade = 1
+		}
+		validados = append(validados, componente)
+	}
+
+	return &Produto{
+		Nome:        nome,
+		Categoria:   Combo,
+		Descricao:   descricao,
+		Preco:       preco,
+		Componentes: validados,
+	}, nil
+}
+
+// resolverComponentes expande um combo nos produtos que o compõem, preenchendo cada
+// posição por categoria com um dos produtos escolhidos pelo cliente
+func resolverComponentes(produto Produto, escolhas []ItemCombo) ([]ItemCombo, error) {
+	if !produto.EhCombo() {
+		if len(escolhas) > 0 {
+			return nil, fmt.Errorf("o produto %s não é um combo", produto.Nome)
+		}
+		return nil, nil
+	}
+
+	usadas := make([]bool, len(escolhas))
+	componentes := make([]ItemCombo, 0, len(produto.Componentes))
+	for _, componente := range produto.Componentes {
+		if componente.Produto != nil {
+			componentes = append(componentes, ItemCombo{Produto: *componente.Produto, Quantidade: componente.Quantidade})
+			continue
+		}
+
+		escolhida := -1
+		for i, escolha := range escolhas {
+			if !usadas[i] && escolha.Produto.Categoria == componente.Categoria {
+				escolhida = i
+				break
+			}
+		}
+		if escolhida < 0 {
+			return nil, fmt.Errorf("o combo %s exige a escolha de um produto da categoria %s", produto.Nome, componente.Categoria)
+		}
+
+		usadas[escolhida] = true
+		componentes = append(componentes, ItemCombo{Produto: escolhas[escolhida].Produto, Quantidade: componente.Quantidade})
+	}
+
+	for i, escolha := range escolhas {
+		if !usadas[i] {
+			return nil, fmt.Errorf("o produto %s não corresponde a nenhuma escolha do combo %s", escolha.Produto.Nome, produto.Nome)
+		}
+	}
+
+	return componentes, nil
+}
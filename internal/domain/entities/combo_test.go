@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	xSalada     = Produto{ID: 1, Nome: "X-Salada", Categoria: Lanche, Preco: Reais(22.5), TempoPreparoMinutos: 10}
+	batataFrita = Produto{ID: 3, Nome: "Batata-frita", Categoria: Acompanhamento, Preco: Reais(18), TempoPreparoMinutos: 6}
+	cocaCola    = Produto{ID: 2, Nome: "Coca-cola", Categoria: Bebida, Preco: Reais(6), TempoPreparoMinutos: 1}
+	mousse      = Produto{ID: 4, Nome: "Mousse de chocolate", Categoria: Sobremesa, Preco: Reais(12.5), TempoPreparoMinutos: 3}
+)
+
+func comboXSalada() Produto {
+	return Produto{
+		ID:        11,
+		Nome:      "Combo X-Salada",
+		Categoria: Combo,
+		Preco:     Reais(42),
+		Componentes: []ComponenteCombo{
+			{ID: 1, Produto: &xSalada, Quantidade: 1},
+			{ID: 2, Produto: &batataFrita, Quantidade: 1},
+			{ID: 3, Categoria: Bebida, Quantidade: 1},
+		},
+	}
+}
+
+func TestComboNew_Success(t *testing.T) {
+	componentes := []ComponenteCombo{
+		{Produto: &xSalada, Quantidade: 1},
+		{Categoria: Bebida},
+	}
+
+	combo, err := ComboNew("Combo X-Salada", "X-Salada e bebida", Reais(26), componentes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Combo, combo.Categoria)
+	assert.True(t, combo.EhCombo())
+	assert.Equal(t, Reais(26), combo.Preco)
+	assert.Len(t, combo.Componentes, 2)
+	assert.Equal(t, 1, combo.Componentes[1].Quantidade, "quantidade omitida vale 1")
+}
+
+func TestComboNew_Errors(t *testing.T) {
+	combo := comboXSalada()
+
+	tests := []struct {
+		name        string
+		nome        string
+		preco       Money
+		componentes []ComponenteCombo
+	}{
+		{"Nome vazio", "", Reais(20), []ComponenteCombo{{Produto: &xSalada}, {Categoria: Bebida}}},
+		{"Preço zero", "Combo", Reais(0), []ComponenteCombo{{Produto: &xSalada}, {Categoria: Bebida}}},
+		{"Um único componente", "Combo", Reais(20), []ComponenteCombo{{Produto: &xSalada}}},
+		{"Componente sem produto nem categoria", "Combo", Reais(20), []ComponenteCombo{{Produto: &xSalada}, {Quantidade: 1}}},
+		{"Componente com produto e categoria", "Combo", Reais(20), []ComponenteCombo{{Produto: &xSalada}, {Produto: &cocaCola, Categoria: Bebida}}},
+		{"Combo dentro de combo", "Combo", Reais(20), []ComponenteCombo{{Produto: &xSalada}, {Produto: &combo}}},
+		{"Categoria Combo", "Combo", Reais(20), []ComponenteCombo{{Produto: &xSalada}, {Categoria: Combo}}},
+		{"Quantidade negativa", "Combo", Reais(20), []ComponenteCombo{{Produto: &xSalada, Quantidade: -1}, {Categoria: Bebida}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			combo, err := ComboNew(tt.nome, "", tt.preco, tt.componentes)
+			assert.Error(t, err)
+			assert.Nil(t, combo)
+		})
+	}
+}
+
+func TestProdutoNew_RejeitaCombo(t *testing.T) {
+	produto, err := ProdutoNew("Combo", categoriaDoCardapio(Combo), "", Reais(30))
+
+	assert.Nil(t, produto)
+	assert.ErrorContains(t, err, "combos devem ser cadastrados com seus componentes")
+}
+
+func TestItemPedidoNew_Combo(t *testing.T) {
+	item, err := ItemPedidoNew(comboXSalada(), 2, nil, []ItemCombo{{Produto: cocaCola}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(42), item.PrecoUnitario, "o combo tem preço próprio")
+	assert.Equal(t, Reais(84), item.Subtotal)
+	assert.Len(t, item.Componentes, 3)
+	assert.Equal(t, "X-Salada", item.Componentes[0].Produto.Nome)
+	assert.Equal(t, "Batata-frita", item.Componentes[1].Produto.Nome)
+	assert.Equal(t, "Coca-cola", item.Componentes[2].Produto.Nome)
+	assert.True(t, item.ContemCategoria(Lanche))
+}
+
+func TestItemPedidoNew_ComboEscolhasInvalidas(t *testing.T) {
+	tests := []struct {
+		name     string
+		produto  Produto
+		escolhas []ItemCombo
+		mensagem string
+	}{
+		{"Falta a bebida", comboXSalada(), nil, "exige a escolha de um produto da categoria Bebida"},
+		{"Escolha de outra categoria", comboXSalada(), []ItemCombo{{Produto: mousse}}, "exige a escolha de um produto da categoria Bebida"},
+		{"Escolha sobrando", comboXSalada(), []ItemCombo{{Produto: cocaCola}, {Produto: cocaCola}}, "não corresponde a nenhuma escolha do combo"},
+		{"Escolhas em produto que não é combo", xSalada, []ItemCombo{{Produto: cocaCola}}, "não é um combo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := ItemPedidoNew(tt.produto, 1, nil, tt.escolhas)
+			assert.Nil(t, item)
+			assert.ErrorContains(t, err, tt.mensagem)
+		})
+	}
+}
+
+func TestPedidoNew_ComboSatisfazRegraDoLanche(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: comboXSalada(), Quantidade: 1, Componentes: []ItemCombo{{Produto: cocaCola}}},
+		{Produto: mousse, Quantidade: 1},
+	}
+
+	pedido, err := PedidoNew("Maria", itens, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(54.5), pedido.Total)
+	assert.NoError(t, RegrasPedidoNew(ConfigRegrasPedido{}).Validar(pedido, categoriasCardapio))
+}
+
+func TestPedidoNew_ComboSemLanche(t *testing.T) {
+	comboBebida := Produto{ID: 12, Nome: "Combo Sobremesa", Categoria: Combo, Preco: Reais(15), Componentes: []ComponenteCombo{
+		{Produto: &mousse, Quantidade: 1},
+		{Categoria: Bebida, Quantidade: 1},
+	}}
+	itens := []ItemPedido{{Produto: comboBebida, Quantidade: 1, Componentes: []ItemCombo{{Produto: cocaCola}}}}
+
+	pedido, err := PedidoNew("Maria", itens, nil)
+	assert.NoError(t, err)
+
+	err = RegrasPedidoNew(ConfigRegrasPedido{}).Validar(pedido, categoriasCardapio)
+	assert.ErrorContains(t, err, "o pedido precisa ter ao menos um item da categoria Lanche")
+}
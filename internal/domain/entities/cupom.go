@@ -0,0 +1,189 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type TipoDesconto string
+
+const (
+	DescontoPercentual  TipoDesconto = "Percentual"
+	DescontoValorFixo   TipoDesconto = "ValorFixo"
+	DescontoCompreGanhe TipoDesconto = "CompreGanhe" // Compre X unidades e ganhe Y
+)
+
+var (
+	ErrCupomInvalido       = errors.New("cupom inválido")
+	ErrCupomNaoEncontrado  = errors.New("cupom não encontrado")
+	ErrCupomForaDaValidade = errors.New("cupom fora do período de validade")
+	ErrCupomEsgotado       = errors.New("cupom atingiu o limite de usos")
+	ErrCupomValorMinimo    = errors.New("o pedido não atinge o valor mínimo do cupom")
+	ErrCupomNaoAplicavel   = errors.New("o cupom não se aplica aos itens do pedido")
+)
+
+// errosDeCupom são os motivos pelos quais um cupom pode ser recusado em um pedido
+var errosDeCupom = []error{ErrCupomInvalido, ErrCupomForaDaValidade, ErrCupomEsgotado, ErrCupomValorMinimo, ErrCupomNaoAplicavel}
+
+// CupomRecusado informa se o erro é a recusa de um cupom, e não uma falha do sistema
+func CupomRecusado(err error) bool {
+	for _, e := range errosDeCupom {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cupom é um código promocional que concede desconto a um pedido
+type Cupom struct {
+	ID         int          `json:"idCupom"`
+	Codigo     string       `json:"codigo"`
+	Tipo       TipoDesconto `json:"tipo"`
+	Percentual int          `json:"percentual,omitempty"` // DescontoPercentual: de 1 a 100
+	Valor      Money        `json:"valor"`                // DescontoValorFixo: valor abatido
+	Compre     int          `json:"compre,omitempty"`     // DescontoCompreGanhe: unidades pagas
+	Ganhe      int          `json:"ganhe,omitempty"`      // DescontoCompreGanhe: unidades grátis a cada Compre+Ganhe
+	// Categoria restringe o desconto aos itens da categoria; vazio vale para o pedido todo
+	Categoria   CatProduto `json:"categoria,omitempty"`
+	ValorMinimo Money      `json:"valorMinimo"`
+	ValidoDe    time.Time  `json:"validoDe"`
+	ValidoAte   *time.Time `json:"validoAte,omitempty"`
+	LimiteUsos  int        `json:"limiteUsos"` // 0 significa sem limite
+	Usos        int        `json:"usos"`
+}
+
+// NormalizarCodigoCupom padroniza o código para busca, ex: " natal10 " → "NATAL10"
+func NormalizarCodigoCupom(codigo string) string {
+	return strings.ToUpper(strings.TrimSpace(codigo))
+}
+
+// CupomNew valida as regras do cupom e normaliza seu código
+func CupomNew(cupom Cupom) (*Cupom, error) {
+	cupom.Codigo = NormalizarCodigoCupom(cupom.Codigo)
+	if cupom.Codigo == "" || strings.ContainsAny(cupom.Codigo, " \t") {
+		return nil, errors.New("o código do cupom é obrigatório e não pode ter espaços")
+	}
+
+	switch cupom.Tipo {
+	case DescontoPercentual:
+		if cupom.Percentual < 1 || cupom.Percentual > 100 {
+			return nil, errors.New("o percentual de desconto deve estar entre 1 e 100")
+		}
+	case DescontoValorFixo:
+		if !cupom.Valor.IsPositive() {
+			return nil, errors.New("o valor do desconto deve ser maior que zero")
+		}
+	case DescontoCompreGanhe:
+		if cupom.Compre < 1 || cupom.Ganhe < 1 {
+			return nil, errors.New("a promoção compre e ganhe exige ao menos uma unidade comprada e uma grátis")
+		}
+	default:
+		return nil, fmt.Errorf("tipo de desconto inválido: %s", cupom.Tipo)
+	}
+
+	if cupom.Categoria != "" && !categoriaDeItem(cupom.Categoria) && cupom.Categoria != Combo {
+		return nil, errors.New("categoria inválida")
+	}
+
+	if cupom.ValorMinimo.Centavos < 0 {
+		return nil, errors.New("o valor mínimo do pedido não pode ser negativo")
+	}
+
+	if cupom.ValidoAte != nil && !cupom.ValidoAte.After(cupom.ValidoDe) {
+		return nil, errors.New("o fim da validade deve ser posterior ao início")
+	}
+
+	if cupom.LimiteUsos < 0 {
+		return nil, errors.New("o limite de usos não pode ser negativo")
+	}
+
+	return &cupom, nil
+}
+
+// Disponivel informa se o cupom pode ser usado no instante informado
+func (c *Cupom) Disponivel(agora time.Time) error {
+	if agora.Before(c.ValidoDe) || (c.ValidoAte != nil && agora.After(*c.ValidoAte)) {
+		return ErrCupomForaDaValidade
+	}
+	if c.LimiteUsos > 0 && c.Usos >= c.LimiteUsos {
+		return ErrCupomEsgotado
+	}
+	return nil
+}
+
+// Desconto calcula o desconto do cupom sobre os itens do pedido, nunca maior que o valor dos itens elegíveis
+func (c *Cupom) Desconto(itens []ItemPedido, subtotal Money, agora time.Time) (Money, error) {
+	if err := c.Disponivel(agora); err != nil {
+		return Money{}, err
+	}
+
+	if subtotal.Centavos < c.ValorMinimo.Centavos {
+		return Money{}, ErrCupomValorMinimo
+	}
+
+	// Os itens elegíveis são somados por linha, preço unitário vezes quantidade, sem expandir cada unidade
+	var err error
+	base := Centavos(0)
+	unidades := 0
+	var linhas []ItemPedido
+	for _, item := range itens {
+		if c.Categoria != "" && item.Produto.Categoria != c.Categoria {
+			continue
+		}
+		if base, err = base.Add(item.Subtotal); err != nil {
+			return Money{}, err
+		}
+		unidades += item.Quantidade
+		linhas = append(linhas, item)
+	}
+
+	if !base.IsPositive() {
+		return Money{}, ErrCupomNaoAplicavel
+	}
+
+	switch c.Tipo {
+	case DescontoPercentual:
+		return base.Porcentagem(c.Percentual)
+	case DescontoValorFixo:
+		if c.Valor.Centavos > base.Centavos {
+			return base, nil
+		}
+		return c.Valor, nil
+	case DescontoCompreGanhe:
+		// A cada Compre+Ganhe unidades elegíveis, as Ganhe mais baratas saem de graça
+		gratis := unidades / (c.Compre + c.Ganhe) * c.Ganhe
+		if gratis == 0 {
+			return Money{}, ErrCupomNaoAplicavel
+		}
+		return unidadesMaisBaratas(linhas, gratis)
+	default:
+		return Money{}, fmt.Errorf("tipo de desconto inválido: %s", c.Tipo)
+	}
+}
+
+// unidadesMaisBaratas soma o preço das n unidades mais baratas, tomando as linhas da mais barata para a
+// mais cara; ordena as linhas recebidas
+func unidadesMaisBaratas(linhas []ItemPedido, n int) (Money, error) {
+	sort.SliceStable(linhas, func(i, j int) bool { return linhas[i].PrecoUnitario.Centavos < linhas[j].PrecoUnitario.Centavos })
+
+	total := Centavos(0)
+	for _, linha := range linhas {
+		if n == 0 {
+			break
+		}
+		quantidade := min(linha.Quantidade, n)
+		valor, err := linha.PrecoUnitario.Mul(quantidade)
+		if err != nil {
+			return Money{}, err
+		}
+		if total, err = total.Add(valor); err != nil {
+			return Money{}, err
+		}
+		n -= quantidade
+	}
+	return total, nil
+}
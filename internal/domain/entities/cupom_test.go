@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func itensParaCupom() []ItemPedido {
+	return []ItemPedido{
+		{Produto: xSalada, Quantidade: 2, PrecoUnitario: Reais(22.5), Subtotal: Reais(45)},
+		{Produto: cocaCola, Quantidade: 3, PrecoUnitario: Reais(6), Subtotal: Reais(18)},
+	}
+}
+
+func TestCupomNew_Success(t *testing.T) {
+	cupom, err := CupomNew(Cupom{Codigo: " natal10 ", Tipo: DescontoPercentual, Percentual: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "NATAL10", cupom.Codigo)
+}
+
+func TestCupomNew_Errors(t *testing.T) {
+	agora := time.Now()
+	antes := agora.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		cupom Cupom
+	}{
+		{"Código vazio", Cupom{Codigo: " ", Tipo: DescontoPercentual, Percentual: 10}},
+		{"Código com espaço", Cupom{Codigo: "NATAL 10", Tipo: DescontoPercentual, Percentual: 10}},
+		{"Tipo inválido", Cupom{Codigo: "X", Tipo: "Brinde"}},
+		{"Percentual zero", Cupom{Codigo: "X", Tipo: DescontoPercentual}},
+		{"Percentual acima de 100", Cupom{Codigo: "X", Tipo: DescontoPercentual, Percentual: 101}},
+		{"Valor fixo zero", Cupom{Codigo: "X", Tipo: DescontoValorFixo}},
+		{"Compre e ganhe sem unidades grátis", Cupom{Codigo: "X", Tipo: DescontoCompreGanhe, Compre: 2}},
+		{"Valor mínimo negativo", Cupom{Codigo: "X", Tipo: DescontoPercentual, Percentual: 10, ValorMinimo: Reais(-1)}},
+		{"Validade invertida", Cupom{Codigo: "X", Tipo: DescontoPercentual, Percentual: 10, ValidoDe: agora, ValidoAte: &antes}},
+		{"Limite de usos negativo", Cupom{Codigo: "X", Tipo: DescontoPercentual, Percentual: 10, LimiteUsos: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupom, err := CupomNew(tt.cupom)
+			assert.Error(t, err)
+			assert.Nil(t, cupom)
+		})
+	}
+}
+
+func TestCupom_Desconto(t *testing.T) {
+	agora := time.Now()
+
+	tests := []struct {
+		name     string
+		cupom    Cupom
+		desconto Money
+	}{
+		{"Percentual sobre o pedido", Cupom{Tipo: DescontoPercentual, Percentual: 10}, Reais(6.3)},
+		{"Percentual restrito a bebidas", Cupom{Tipo: DescontoPercentual, Percentual: 50, Categoria: Bebida}, Reais(9)},
+		{"Valor fixo", Cupom{Tipo: DescontoValorFixo, Valor: Reais(5)}, Reais(5)},
+		{"Valor fixo limitado aos itens elegíveis", Cupom{Tipo: DescontoValorFixo, Valor: Reais(30), Categoria: Bebida}, Reais(18)},
+		{"Compre 2 ganhe 1 bebida", Cupom{Tipo: DescontoCompreGanhe, Compre: 2, Ganhe: 1, Categoria: Bebida}, Reais(6)},
+		{"Compre 1 ganhe 1 no pedido todo: as unidades mais baratas saem de graça", Cupom{Tipo: DescontoCompreGanhe, Compre: 1, Ganhe: 1}, Reais(12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desconto, err := tt.cupom.Desconto(itensParaCupom(), Reais(63), agora)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.desconto, desconto)
+		})
+	}
+}
+
+func TestCupom_DescontoCompreGanheEntreLinhas(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: Produto{Categoria: Lanche}, PrecoUnitario: Reais(10), Quantidade: 5, Subtotal: Reais(50)},
+		{Produto: Produto{Categoria: Bebida}, PrecoUnitario: Reais(4), Quantidade: 2, Subtotal: Reais(8)},
+		{Produto: Produto{Categoria: Sobremesa}, PrecoUnitario: Reais(20), Quantidade: 1, Subtotal: Reais(20)},
+	}
+	cupom := Cupom{Tipo: DescontoCompreGanhe, Compre: 1, Ganhe: 1}
+
+	// 8 unidades dão 4 grátis: as 2 bebidas e 2 dos lanches, sem tocar na sobremesa
+	desconto, err := cupom.Desconto(itens, Reais(78), time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(28), desconto)
+	assert.Equal(t, Reais(10), itens[0].PrecoUnitario, "as linhas do pedido não são reordenadas")
+}
+
+func TestCupom_DescontoRecusado(t *testing.T) {
+	agora := time.Now()
+	ontem := agora.Add(-24 * time.Hour)
+	amanha := agora.Add(24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		cupom Cupom
+		erro  error
+	}{
+		{"Ainda não começou", Cupom{Tipo: DescontoPercentual, Percentual: 10, ValidoDe: amanha}, ErrCupomForaDaValidade},
+		{"Vencido", Cupom{Tipo: DescontoPercentual, Percentual: 10, ValidoDe: ontem.Add(-time.Hour), ValidoAte: &ontem}, ErrCupomForaDaValidade},
+		{"Esgotado", Cupom{Tipo: DescontoPercentual, Percentual: 10, LimiteUsos: 5, Usos: 5}, ErrCupomEsgotado},
+		{"Abaixo do valor mínimo", Cupom{Tipo: DescontoPercentual, Percentual: 10, ValorMinimo: Reais(100)}, ErrCupomValorMinimo},
+		{"Nenhum item da categoria", Cupom{Tipo: DescontoPercentual, Percentual: 10, Categoria: Sobremesa}, ErrCupomNaoAplicavel},
+		{"Unidades insuficientes para o compre e ganhe", Cupom{Tipo: DescontoCompreGanhe, Compre: 3, Ganhe: 1, Categoria: Lanche}, ErrCupomNaoAplicavel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cupom.Desconto(itensParaCupom(), Reais(63), agora)
+			assert.ErrorIs(t, err, tt.erro)
+			assert.True(t, CupomRecusado(err))
+		})
+	}
+}
+
+func TestPedido_AplicarCupom(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: xSalada, Quantidade: 2},
+		{Produto: cocaCola, Quantidade: 3},
+	}
+	pedido, err := PedidoNew("Maria", itens, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pedido.Total, pedido.Subtotal)
+	assert.True(t, pedido.Desconto.IsZero())
+
+	err = pedido.AplicarCupom(&Cupom{Codigo: "BEBIDA50", Tipo: DescontoPercentual, Percentual: 50, Categoria: Bebida}, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(63), pedido.Subtotal)
+	assert.Equal(t, Reais(9), pedido.Desconto)
+	assert.Equal(t, Reais(54), pedido.Total)
+	assert.Equal(t, "BEBIDA50", *pedido.Cupom)
+}
+
+func TestPedido_AplicarCupomRecusadoNaoAlteraTotal(t *testing.T) {
+	pedido, _ := PedidoNew("Maria", []ItemPedido{{Produto: xSalada, Quantidade: 1}}, nil)
+
+	err := pedido.AplicarCupom(&Cupom{Codigo: "MIN50", Tipo: DescontoValorFixo, Valor: Reais(5), ValorMinimo: Reais(50)}, time.Now())
+
+	assert.ErrorIs(t, err, ErrCupomValorMinimo)
+	assert.Nil(t, pedido.Cupom)
+	assert.Equal(t, Reais(22.5), pedido.Total)
+}
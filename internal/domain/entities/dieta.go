@@ -0,0 +1,245 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Alergeno identifica um ingrediente que pode causar reação alérgica ou intolerância
+type Alergeno string
+
+const (
+	AlergenoGluten     Alergeno = "gluten"
+	AlergenoLactose    Alergeno = "lactose"
+	AlergenoOvo        Alergeno = "ovo"
+	AlergenoAmendoim   Alergeno = "amendoim"
+	AlergenoCastanhas  Alergeno = "castanhas"
+	AlergenoSoja       Alergeno = "soja"
+	AlergenoPeixe      Alergeno = "peixe"
+	AlergenoCrustaceos Alergeno = "crustaceos"
+)
+
+// NomeAlergeno é o nome exibido ao cliente de cada alérgeno
+var NomeAlergeno = map[Alergeno]string{
+	AlergenoGluten:     "glúten",
+	AlergenoLactose:    "lactose",
+	AlergenoOvo:        "ovo",
+	AlergenoAmendoim:   "amendoim",
+	AlergenoCastanhas:  "castanhas",
+	AlergenoSoja:       "soja",
+	AlergenoPeixe:      "peixe",
+	AlergenoCrustaceos: "crustáceos",
+}
+
+// TagDieta marca produtos adequados a uma dieta
+type TagDieta string
+
+const (
+	TagVegano      TagDieta = "vegano"
+	TagVegetariano TagDieta = "vegetariano"
+	TagSemGluten   TagDieta = "sem-gluten"
+)
+
+// alergenosIncompativeis lista os alérgenos que um produto com a tag não pode conter
+var alergenosIncompativeis = map[TagDieta][]Alergeno{
+	TagVegano:      {AlergenoLactose, AlergenoOvo, AlergenoPeixe, AlergenoCrustaceos},
+	TagVegetariano: {AlergenoPeixe, AlergenoCrustaceos},
+	TagSemGluten:   {AlergenoGluten},
+}
+
+var (
+	ErrAlergenoDesconhecido  = errors.New("alérgeno desconhecido")
+	ErrTagDietaDesconhecida  = errors.New("tag de dieta desconhecida")
+	ErrTagIncompativel       = errors.New("a tag de dieta contradiz os alérgenos do produto")
+	ErrInformacaoNutricional = errors.New("informação nutricional inválida")
+)
+
+// InformacaoNutricional traz os valores de uma porção do produto
+type InformacaoNutricional struct {
+	PorcaoGramas int     `json:"porcaoGramas"`
+	Calorias     int     `json:"kcal"`
+	Proteinas    float64 `json:"proteinas"`
+	Carboidratos float64 `json:"carboidratos"`
+	Gorduras     float64 `json:"gorduras"`
+	SodioMg      int     `json:"sodioMg"`
+}
+
+// InformacoesDieteticas reúne os alérgenos, as tags de dieta e a tabela nutricional do produto
+type InformacoesDieteticas struct {
+	Alergenos []Alergeno `json:"alergenos,omitempty"`
+	TagsDieta []TagDieta `json:"tagsDieta,omitempty"`
+	// Nutricao é nil quando a loja não informou a tabela nutricional
+	Nutricao *InformacaoNutricional `json:"informacaoNutricional,omitempty"`
+}
+
+// ParseAlergeno aceita o identificador ou o nome exibido, ex.: "gluten" ou "Glúten"
+func ParseAlergeno(valor string) (Alergeno, error) {
+	alergeno := Alergeno(SlugCategoria(valor))
+	if _, ok := NomeAlergeno[alergeno]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrAlergenoDesconhecido, valor)
+	}
+	return alergeno, nil
+}
+
+// ParseTagDieta aceita o identificador ou o nome exibido, ex.: "sem-gluten" ou "sem glúten"
+func ParseTagDieta(valor string) (TagDieta, error) {
+	tag := TagDieta(SlugCategoria(valor))
+	if _, ok := alergenosIncompativeis[tag]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrTagDietaDesconhecida, valor)
+	}
+	return tag, nil
+}
+
+// DefinirInformacoesDieteticas valida e grava no produto os alérgenos, as tags e a tabela nutricional.
+// As listas são normalizadas, sem repetições e em ordem alfabética.
+func (p *Produto) DefinirInformacoesDieteticas(info InformacoesDieteticas) error {
+	alergenos := []Alergeno{}
+	for _, a := range info.Alergenos {
+		alergeno, err := ParseAlergeno(string(a))
+		if err != nil {
+			return err
+		}
+		alergenos = append(alergenos, alergeno)
+	}
+
+	tags := []TagDieta{}
+	for _, t := range info.TagsDieta {
+		tag, err := ParseTagDieta(string(t))
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+	}
+
+	alergenos = semRepeticao(alergenos)
+	tags = semRepeticao(tags)
+
+	for _, tag := range tags {
+		for _, proibido := range alergenosIncompativeis[tag] {
+			for _, alergeno := range alergenos {
+				if alergeno == proibido {
+					return fmt.Errorf("%w: %s não pode conter %s", ErrTagIncompativel, tag, NomeAlergeno[alergeno])
+				}
+			}
+		}
+	}
+
+	if n := info.Nutricao; n != nil {
+		if n.PorcaoGramas <= 0 {
+			return fmt.Errorf("%w: a porção deve ser maior que zero", ErrInformacaoNutricional)
+		}
+		if n.Calorias < 0 || n.Proteinas < 0 || n.Carboidratos < 0 || n.Gorduras < 0 || n.SodioMg < 0 {
+			return fmt.Errorf("%w: os valores não podem ser negativos", ErrInformacaoNutricional)
+		}
+	}
+
+	p.Alergenos = alergenos
+	p.TagsDieta = tags
+	p.Nutricao = info.Nutricao
+	return nil
+}
+
+// ContemAlergeno informa se o produto contém o alérgeno. Num combo, vale também para os componentes fixos
+func (p Produto) ContemAlergeno(alergeno Alergeno) bool {
+	for _, a := range p.Alergenos {
+		if a == alergeno {
+			return true
+		}
+	}
+	for _, componente := range p.Componentes {
+		if componente.Produto != nil && componente.Produto.ContemAlergeno(alergeno) {
+			return true
+		}
+	}
+	return false
+}
+
+// TemTagDieta informa se o produto foi marcado com a tag de dieta
+func (p Produto) TemTagDieta(tag TagDieta) bool {
+	for _, t := range p.TagsDieta {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// FiltroDieta seleciona os produtos do cardápio com todas as tags e sem nenhum dos alérgenos informados
+type FiltroDieta struct {
+	Tags         []TagDieta
+	SemAlergenos []Alergeno
+}
+
+// Vazio informa se o filtro não restringe nenhum produto
+func (f FiltroDieta) Vazio() bool {
+	return len(f.Tags) == 0 && len(f.SemAlergenos) == 0
+}
+
+// Atende informa se o produto passa pelo filtro
+func (f FiltroDieta) Atende(produto Produto) bool {
+	for _, tag := range f.Tags {
+		if !produto.TemTagDieta(tag) {
+			return false
+		}
+	}
+	for _, alergeno := range f.SemAlergenos {
+		if produto.ContemAlergeno(alergeno) {
+			return false
+		}
+	}
+	return true
+}
+
+// AvisoAlergeno alerta que o pedido contém um alérgeno e indica quais produtos o trazem
+type AvisoAlergeno struct {
+	Alergeno Alergeno `json:"alergeno"`
+	Mensagem string   `json:"mensagem"`
+	Produtos []string `json:"produtos"`
+}
+
+// AvisosAlergenos lista os alérgenos presentes nos itens do pedido, incluindo os produtos escolhidos nos combos
+func AvisosAlergenos(itens []ItemPedido) []AvisoAlergeno {
+	produtosPorAlergeno := map[Alergeno][]string{}
+	registrar := func(produto Produto) {
+		for _, alergeno := range produto.Alergenos {
+			produtosPorAlergeno[alergeno] = append(produtosPorAlergeno[alergeno], produto.Nome)
+		}
+	}
+	for _, item := range itens {
+		registrar(item.Produto)
+		for _, componente := range item.Componentes {
+			registrar(componente.Produto)
+		}
+	}
+
+	alergenos := make([]Alergeno, 0, len(produtosPorAlergeno))
+	for alergeno := range produtosPorAlergeno {
+		alergenos = append(alergenos, alergeno)
+	}
+	alergenos = semRepeticao(alergenos)
+
+	avisos := make([]AvisoAlergeno, 0, len(alergenos))
+	for _, alergeno := range alergenos {
+		produtos := semRepeticao(produtosPorAlergeno[alergeno])
+		avisos = append(avisos, AvisoAlergeno{
+			Alergeno: alergeno,
+			Mensagem: fmt.Sprintf("Contém %s: %s", NomeAlergeno[alergeno], strings.Join(produtos, ", ")),
+			Produtos: produtos,
+		})
+	}
+	return avisos
+}
+
+// semRepeticao ordena a lista e remove os valores repetidos
+func semRepeticao[T ~string](valores []T) []T {
+	sort.Slice(valores, func(i, j int) bool { return valores[i] < valores[j] })
+	unicos := valores[:0]
+	for i, v := range valores {
+		if i == 0 || v != valores[i-1] {
+			unicos = append(unicos, v)
+		}
+	}
+	return unicos
+}
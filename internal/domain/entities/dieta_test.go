@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDefinirInformacoesDieteticas(t *testing.T) {
+	produto := &Produto{Nome: "X-Salada"}
+
+	err := produto.DefinirInformacoesDieteticas(InformacoesDieteticas{
+		Alergenos: []Alergeno{"ovo", "Glúten", "ovo"},
+		Nutricao:  &InformacaoNutricional{PorcaoGramas: 250, Calorias: 610, Proteinas: 28.5},
+	})
+
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if !reflect.DeepEqual(produto.Alergenos, []Alergeno{AlergenoGluten, AlergenoOvo}) {
+		t.Errorf("Alérgenos não normalizados: %v", produto.Alergenos)
+	}
+	if len(produto.TagsDieta) != 0 {
+		t.Errorf("Esperado sem tags, recebido %v", produto.TagsDieta)
+	}
+	if produto.Nutricao == nil || produto.Nutricao.Calorias != 610 {
+		t.Errorf("Informação nutricional incorreta: %v", produto.Nutricao)
+	}
+}
+
+func TestDefinirInformacoesDieteticas_Invalidas(t *testing.T) {
+	testCases := []struct {
+		name     string
+		info     InformacoesDieteticas
+		esperado error
+	}{
+		{"alérgeno desconhecido", InformacoesDieteticas{Alergenos: []Alergeno{"pimenta"}}, ErrAlergenoDesconhecido},
+		{"tag desconhecida", InformacoesDieteticas{TagsDieta: []TagDieta{"paleo"}}, ErrTagDietaDesconhecida},
+		{"vegano com lactose", InformacoesDieteticas{Alergenos: []Alergeno{AlergenoLactose}, TagsDieta: []TagDieta{TagVegano}}, ErrTagIncompativel},
+		{"sem glúten com glúten", InformacoesDieteticas{Alergenos: []Alergeno{AlergenoGluten}, TagsDieta: []TagDieta{"sem glúten"}}, ErrTagIncompativel},
+		{"vegetariano com peixe", InformacoesDieteticas{Alergenos: []Alergeno{AlergenoPeixe}, TagsDieta: []TagDieta{TagVegetariano}}, ErrTagIncompativel},
+		{"porção zerada", InformacoesDieteticas{Nutricao: &InformacaoNutricional{Calorias: 100}}, ErrInformacaoNutricional},
+		{"valor negativo", InformacoesDieteticas{Nutricao: &InformacaoNutricional{PorcaoGramas: 100, Gorduras: -1}}, ErrInformacaoNutricional},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			produto := &Produto{Nome: "Produto", InformacoesDieteticas: InformacoesDieteticas{Alergenos: []Alergeno{AlergenoSoja}}}
+
+			err := produto.DefinirInformacoesDieteticas(tc.info)
+
+			if !errors.Is(err, tc.esperado) {
+				t.Errorf("Esperado %v, recebido %v", tc.esperado, err)
+			}
+			if !reflect.DeepEqual(produto.Alergenos, []Alergeno{AlergenoSoja}) {
+				t.Errorf("O produto não deveria ser alterado, alérgenos %v", produto.Alergenos)
+			}
+		})
+	}
+}
+
+func TestVeganoAceitaGluten(t *testing.T) {
+	produto := &Produto{Nome: "Pão de fermentação natural"}
+
+	err := produto.DefinirInformacoesDieteticas(InformacoesDieteticas{
+		Alergenos: []Alergeno{AlergenoGluten},
+		TagsDieta: []TagDieta{TagVegano, TagVegetariano},
+	})
+
+	if err != nil {
+		t.Errorf("Esperado nil, recebido %v", err)
+	}
+}
+
+func TestFiltroDieta_Atende(t *testing.T) {
+	batata := Produto{Nome: "Batata-frita", InformacoesDieteticas: InformacoesDieteticas{TagsDieta: []TagDieta{TagSemGluten, TagVegano}}}
+	mousse := Produto{Nome: "Mousse", InformacoesDieteticas: InformacoesDieteticas{Alergenos: []Alergeno{AlergenoLactose, AlergenoOvo}, TagsDieta: []TagDieta{TagVegetariano}}}
+	xSalada := Produto{ID: 1, Nome: "X-Salada", InformacoesDieteticas: InformacoesDieteticas{Alergenos: []Alergeno{AlergenoGluten}}}
+	combo := Produto{Nome: "Combo X-Salada", Categoria: Combo, Componentes: []ComponenteCombo{{Produto: &xSalada, Quantidade: 1}, {Categoria: Bebida, Quantidade: 1}}}
+
+	testCases := []struct {
+		name    string
+		filtro  FiltroDieta
+		atendem []string
+	}{
+		{"sem filtro", FiltroDieta{}, []string{"Batata-frita", "Mousse", "X-Salada", "Combo X-Salada"}},
+		{"vegano", FiltroDieta{Tags: []TagDieta{TagVegano}}, []string{"Batata-frita"}},
+		{"sem lactose", FiltroDieta{SemAlergenos: []Alergeno{AlergenoLactose}}, []string{"Batata-frita", "X-Salada", "Combo X-Salada"}},
+		{"sem glúten considera o combo", FiltroDieta{SemAlergenos: []Alergeno{AlergenoGluten}}, []string{"Batata-frita", "Mousse"}},
+		{"vegetariano sem ovo", FiltroDieta{Tags: []TagDieta{TagVegetariano}, SemAlergenos: []Alergeno{AlergenoOvo}}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var atendem []string
+			for _, produto := range []Produto{batata, mousse, xSalada, combo} {
+				if tc.filtro.Atende(produto) {
+					atendem = append(atendem, produto.Nome)
+				}
+			}
+			if !reflect.DeepEqual(atendem, tc.atendem) {
+				t.Errorf("Esperado %v, recebido %v", tc.atendem, atendem)
+			}
+		})
+	}
+}
+
+func TestAvisosAlergenos(t *testing.T) {
+	xSalada := Produto{Nome: "X-Salada", InformacoesDieteticas: InformacoesDieteticas{Alergenos: []Alergeno{AlergenoGluten, AlergenoOvo}}}
+	mousse := Produto{Nome: "Mousse", InformacoesDieteticas: InformacoesDieteticas{Alergenos: []Alergeno{AlergenoLactose, AlergenoOvo}}}
+	coca := Produto{Nome: "Coca-cola"}
+	combo := Produto{Nome: "Combo X-Salada", Categoria: Combo}
+
+	itens := []ItemPedido{
+		{Produto: combo, Quantidade: 1, Componentes: []ItemCombo{{Produto: xSalada, Quantidade: 1}, {Produto: coca, Quantidade: 1}}},
+		{Produto: mousse, Quantidade: 2},
+		{Produto: xSalada, Quantidade: 1},
+	}
+
+	avisos := AvisosAlergenos(itens)
+
+	esperado := []AvisoAlergeno{
+		{Alergeno: AlergenoGluten, Mensagem: "Contém glúten: X-Salada", Produtos: []string{"X-Salada"}},
+		{Alergeno: AlergenoLactose, Mensagem: "Contém lactose: Mousse", Produtos: []string{"Mousse"}},
+		{Alergeno: AlergenoOvo, Mensagem: "Contém ovo: Mousse, X-Salada", Produtos: []string{"Mousse", "X-Salada"}},
+	}
+	if !reflect.DeepEqual(avisos, esperado) {
+		t.Errorf("Esperado %v, recebido %v", esperado, avisos)
+	}
+
+	if avisos := AvisosAlergenos([]ItemPedido{{Produto: coca, Quantidade: 1}}); len(avisos) != 0 {
+		t.Errorf("Esperado nenhum aviso, recebido %v", avisos)
+	}
+}
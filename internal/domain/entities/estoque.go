@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrProdutoIndisponivel = errors.New("produto indisponível")
+	ErrEstoqueNegativo     = errors.New("o estoque não pode ser negativo")
+)
+
+// ProdutosIndisponiveisError lista os produtos do pedido que estão esgotados ou sem estoque suficiente
+type ProdutosIndisponiveisError struct {
+	Produtos []string
+}
+
+func (e *ProdutosIndisponiveisError) Error() string {
+	return fmt.Sprintf("produtos indisponíveis: %s", strings.Join(e.Produtos, ", "))
+}
+
+func (e *ProdutosIndisponiveisError) Unwrap() error {
+	return ErrProdutoIndisponivel
+}
+
+// ControlaEstoque informa se o produto tem quantidade em estoque controlada
+func (p Produto) ControlaEstoque() bool {
+	return p.Estoque != nil
+}
+
+// Disponivel informa se o produto pode ser pedido na quantidade informada
+func (p Produto) Disponivel(quantidade int) bool {
+	if p.Esgotado || p.Arquivado() {
+		return false
+	}
+	return p.Estoque == nil || *p.Estoque >= quantidade
+}
+
+// MarcarEsgotado liga ou desliga manualmente a disponibilidade do produto
+func (p *Produto) MarcarEsgotado(esgotado bool) {
+	p.Esgotado = esgotado
+}
+
+// AjustarEstoque define a quantidade em estoque; nil deixa de controlar o estoque do produto
+func (p *Produto) AjustarEstoque(estoque *int) error {
+	if estoque != nil && *estoque < 0 {
+		return ErrEstoqueNegativo
+	}
+	p.Estoque = estoque
+	return nil
+}
+
+// ConsumoProduto é a quantidade de um produto que um pedido retira do estoque
+type ConsumoProduto struct {
+	Produto    Produto
+	Quantidade int
+}
+
+// ConsumoDeProdutos soma, por produto, as unidades pedidas diretamente e as que compõem combos
+func ConsumoDeProdutos(itens []ItemPedido) []ConsumoProduto {
+	var consumo []ConsumoProduto
+	indice := map[int]int{}
+	somar := func(produto Produto, quantidade int) {
+		if i, ok := indice[produto.ID]; ok {
+			consumo[i].Quantidade += quantidade
+			return
+		}
+		indice[produto.ID] = len(consumo)
+		consumo = append(consumo, ConsumoProduto{Produto: produto, Quantidade: quantidade})
+	}
+
+	for _, item := range itens {
+		somar(item.Produto, item.Quantidade)
+		for _, componente := range item.Componentes {
+			somar(componente.Produto, componente.Quantidade*item.Quantidade)
+		}
+	}
+	return consumo
+}
+
+// verificarDisponibilidade recusa o pedido listando todos os produtos que não podem ser atendidos
+func verificarDisponibilidade(itens []ItemPedido) error {
+	var indisponiveis []string
+	for _, c := range ConsumoDeProdutos(itens) {
+		if !c.Produto.Disponivel(c.Quantidade) {
+			indisponiveis = append(indisponiveis, c.Produto.Nome)
+		}
+	}
+	if len(indisponiveis) > 0 {
+		return &ProdutosIndisponiveisError{Produtos: indisponiveis}
+	}
+	return nil
+}
+
+// AtualizarEstoque registra o estoque restante do produto em todas as linhas do pedido que o contêm
+func (p *Pedido) AtualizarEstoque(produtoID int, estoque int) {
+	for i := range p.Itens {
+		if p.Itens[i].Produto.ID == produtoID {
+			restante := estoque
+			p.Itens[i].Produto.Estoque = &restante
+		}
+		for j := range p.Itens[i].Componentes {
+			if p.Itens[i].Componentes[j].Produto.ID == produtoID {
+				restante := estoque
+				p.Itens[i].Componentes[j].Produto.Estoque = &restante
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func estoque(n int) *int {
+	return &n
+}
+
+func TestProduto_Disponivel(t *testing.T) {
+	assert.True(t, xSalada.Disponivel(100), "sem controle de estoque está sempre disponível")
+
+	esgotado := xSalada
+	esgotado.MarcarEsgotado(true)
+	assert.False(t, esgotado.Disponivel(1))
+
+	controlado := cocaCola
+	assert.NoError(t, controlado.AjustarEstoque(estoque(2)))
+	assert.True(t, controlado.ControlaEstoque())
+	assert.True(t, controlado.Disponivel(2))
+	assert.False(t, controlado.Disponivel(3))
+
+	assert.Error(t, controlado.AjustarEstoque(estoque(-1)))
+	assert.NoError(t, controlado.AjustarEstoque(nil))
+	assert.False(t, controlado.ControlaEstoque())
+}
+
+func TestConsumoDeProdutos(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: xSalada, Quantidade: 1},
+		{Produto: comboXSalada(), Quantidade: 2, Componentes: []ItemCombo{
+			{Produto: xSalada, Quantidade: 1},
+			{Produto: cocaCola, Quantidade: 1},
+		}},
+	}
+
+	consumo := ConsumoDeProdutos(itens)
+
+	assert.Len(t, consumo, 3)
+	assert.Equal(t, xSalada.ID, consumo[0].Produto.ID)
+	assert.Equal(t, 3, consumo[0].Quantidade, "1 avulso mais 1 em cada um dos 2 combos")
+	assert.Equal(t, 2, consumo[1].Quantidade)
+	assert.Equal(t, 2, consumo[2].Quantidade)
+}
+
+func TestPedidoNew_ProdutosIndisponiveis(t *testing.T) {
+	semEstoque := cocaCola
+	semEstoque.Estoque = estoque(1)
+	esgotado := mousse
+	esgotado.Esgotado = true
+
+	_, err := PedidoNew("Maria", []ItemPedido{
+		{Produto: xSalada, Quantidade: 1},
+		{Produto: semEstoque, Quantidade: 2},
+		{Produto: esgotado, Quantidade: 1},
+	}, nil)
+
+	var indisponiveis *ProdutosIndisponiveisError
+	assert.True(t, errors.As(err, &indisponiveis))
+	assert.True(t, errors.Is(err, ErrProdutoIndisponivel))
+	assert.Equal(t, []string{"Coca-cola", "Mousse de chocolate"}, indisponiveis.Produtos)
+	assert.Equal(t, "produtos indisponíveis: Coca-cola, Mousse de chocolate", err.Error())
+}
+
+func TestPedido_AtualizarEstoque(t *testing.T) {
+	pedido := &Pedido{Itens: []ItemPedido{
+		{Produto: cocaCola, Quantidade: 1},
+		{Produto: comboXSalada(), Quantidade: 1, Componentes: []ItemCombo{{Produto: cocaCola, Quantidade: 1}}},
+	}}
+
+	pedido.AtualizarEstoque(cocaCola.ID, 0)
+
+	assert.Equal(t, 0, *pedido.Itens[0].Produto.Estoque)
+	assert.Equal(t, 0, *pedido.Itens[1].Componentes[0].Produto.Estoque)
+	assert.Nil(t, pedido.Itens[1].Produto.Estoque)
+}
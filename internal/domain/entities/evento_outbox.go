@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Filas para onde os eventos são publicados
+const (
+	FilaProdutos = "produtos"
+	FilaPedidos  = "pedidos"
+)
+
+// StatusEvento indica em que ponto da publicação o evento da outbox está
+type StatusEvento string
+
+const (
+	EventoPendente  StatusEvento = "pendente"
+	EventoPublicado StatusEvento = "publicado"
+	EventoFalhou    StatusEvento = "falhou" // esgotou as tentativas; só volta a ser publicado se for reprocessado
+)
+
+var ErrEventoJaPublicado = errors.New("o evento já foi publicado")
+
+// EventoOutbox é um evento de domínio gravado na mesma transação da alteração que o gerou.
+// O relay o publica depois, tentando de novo enquanto a fila estiver indisponível.
+type EventoOutbox struct {
+	ID               int64           `json:"id"`
+	Fila             string          `json:"fila"`
+	Tipo             string          `json:"tipo"`
+	Payload          json.RawMessage `json:"payload"`
+	Status           StatusEvento    `json:"status"`
+	Tentativas       int             `json:"tentativas"`
+	UltimoErro       string          `json:"ultimo_erro,omitempty"`
+	CriadoEm         time.Time       `json:"criado_em"`
+	ProximaTentativa time.Time       `json:"proxima_tentativa"`
+	PublicadoEm      *time.Time      `json:"publicado_em,omitempty"`
+}
+
+func EventoOutboxNew(fila, tipo string, payload interface{}, agora time.Time) (*EventoOutbox, error) {
+	if fila != FilaProdutos && fila != FilaPedidos {
+		return nil, fmt.Errorf("fila de eventos inválida: %q", fila)
+	}
+	if tipo == "" {
+		return nil, errors.New("o tipo do evento é obrigatório")
+	}
+
+	dados, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível serializar o evento %s: %w", tipo, err)
+	}
+
+	return &EventoOutbox{
+		Fila:             fila,
+		Tipo:             tipo,
+		Payload:          dados,
+		Status:           EventoPendente,
+		CriadoEm:         agora,
+		ProximaTentativa: agora,
+	}, nil
+}
+
+// PoliticaRetentativa define quantas vezes e com que intervalo o relay tenta publicar um evento
+type PoliticaRetentativa struct {
+	MaxTentativas int
+	Espera        time.Duration // espera após a primeira falha; dobra a cada nova falha
+	EsperaMaxima  time.Duration
+}
+
+var PoliticaRetentativaPadrao = PoliticaRetentativa{MaxTentativas: 10, Espera: 5 * time.Second, EsperaMaxima: 10 * time.Minute}
+
+// PrazoReservaEvento é por quanto tempo o evento reservado por um relay fica fora das listagens de
+// pendentes. Se o relay parar antes de registrar o resultado, o evento volta depois do prazo.
+const PrazoReservaEvento = 2 * time.Minute
+
+// espera calcula o intervalo até a próxima tentativa depois da falha de número "tentativas"
+func (p PoliticaRetentativa) espera(tentativas int) time.Duration {
+	espera := p.Espera
+	for i := 1; i < tentativas && espera < p.EsperaMaxima; i++ {
+		espera *= 2
+	}
+	if espera > p.EsperaMaxima {
+		return p.EsperaMaxima
+	}
+	return espera
+}
+
+// Reservar tira o evento das listagens de pendentes até o fim do prazo, enquanto um relay o publica
+func (e *EventoOutbox) Reservar(agora time.Time, prazo time.Duration) {
+	e.ProximaTentativa = agora.Add(prazo)
+}
+
+// MarcarPublicado registra que o evento chegou à fila
+func (e *EventoOutbox) MarcarPublicado(agora time.Time) {
+	e.Status = EventoPublicado
+	e.PublicadoEm = &agora
+	e.UltimoErro = ""
+}
+
+// RegistrarFalha agenda a próxima tentativa com espera exponencial ou, se as tentativas
+// se esgotaram, marca o evento como falho para alguém olhar
+func (e *EventoOutbox) RegistrarFalha(err error, agora time.Time, politica PoliticaRetentativa) {
+	e.Tentativas++
+	e.UltimoErro = err.Error()
+	if e.Tentativas >= politica.MaxTentativas {
+		e.Status = EventoFalhou
+		return
+	}
+	e.ProximaTentativa = agora.Add(politica.espera(e.Tentativas))
+}
+
+// Reprocessar devolve o evento à fila do relay, com as tentativas zeradas
+func (e *EventoOutbox) Reprocessar(agora time.Time) error {
+	if e.Status == EventoPublicado {
+		return ErrEventoJaPublicado
+	}
+	e.Status = EventoPendente
+	e.Tentativas = 0
+	e.ProximaTentativa = agora
+	return nil
+}
+
+// Travado informa se o evento precisa de atenção: falhou ou está esperando publicação há mais que o limite
+func (e EventoOutbox) Travado(agora time.Time, limite time.Duration) bool {
+	switch e.Status {
+	case EventoFalhou:
+		return true
+	case EventoPendente:
+		return agora.Sub(e.CriadoEm) > limite
+	default:
+		return false
+	}
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventoOutboxNew(t *testing.T) {
+	agora := time.Now()
+
+	evento, err := EventoOutboxNew(FilaPedidos, "pedido_criado", map[string]interface{}{"id_pedido": 7, "total": Reais(30)}, agora)
+
+	assert.NoError(t, err)
+	assert.Equal(t, EventoPendente, evento.Status)
+	assert.Equal(t, agora, evento.ProximaTentativa)
+	assert.JSONEq(t, `{"id_pedido":7,"total":30}`, string(evento.Payload))
+
+	_, err = EventoOutboxNew("pagamentos", "pedido_criado", nil, agora)
+	assert.Error(t, err)
+
+	_, err = EventoOutboxNew(FilaProdutos, "", nil, agora)
+	assert.Error(t, err)
+
+	_, err = EventoOutboxNew(FilaProdutos, "produto_criado", func() {}, agora)
+	assert.Error(t, err)
+}
+
+func TestEventoOutbox_RegistrarFalha(t *testing.T) {
+	agora := time.Now()
+	politica := PoliticaRetentativa{MaxTentativas: 4, Espera: time.Second, EsperaMaxima: 3 * time.Second}
+	evento, _ := EventoOutboxNew(FilaProdutos, "produto_criado", nil, agora)
+
+	esperas := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	for i, espera := range esperas {
+		evento.RegistrarFalha(errors.New("fila indisponível"), agora, politica)
+
+		assert.Equal(t, EventoPendente, evento.Status)
+		assert.Equal(t, i+1, evento.Tentativas)
+		assert.Equal(t, agora.Add(espera), evento.ProximaTentativa, "tentativa %d", i+1)
+	}
+
+	evento.RegistrarFalha(errors.New("fila indisponível"), agora, politica)
+	assert.Equal(t, EventoFalhou, evento.Status)
+	assert.Equal(t, "fila indisponível", evento.UltimoErro)
+}
+
+func TestEventoOutbox_Reservar(t *testing.T) {
+	agora := time.Now()
+	evento, _ := EventoOutboxNew(FilaPedidos, "pedido_criado", nil, agora)
+
+	evento.Reservar(agora, PrazoReservaEvento)
+
+	assert.Equal(t, EventoPendente, evento.Status, "a reserva não muda o status")
+	assert.Equal(t, agora.Add(PrazoReservaEvento), evento.ProximaTentativa)
+	assert.Zero(t, evento.Tentativas)
+}
+
+func TestEventoOutbox_MarcarPublicadoEReprocessar(t *testing.T) {
+	agora := time.Now()
+	evento, _ := EventoOutboxNew(FilaProdutos, "produto_criado", nil, agora.Add(-time.Hour))
+	evento.Status = EventoFalhou
+	evento.Tentativas = 10
+
+	assert.NoError(t, evento.Reprocessar(agora))
+	assert.Equal(t, EventoPendente, evento.Status)
+	assert.Zero(t, evento.Tentativas)
+	assert.Equal(t, agora, evento.ProximaTentativa)
+
+	evento.MarcarPublicado(agora)
+	assert.Equal(t, EventoPublicado, evento.Status)
+	assert.Equal(t, &agora, evento.PublicadoEm)
+	assert.ErrorIs(t, evento.Reprocessar(agora), ErrEventoJaPublicado)
+}
+
+func TestEventoOutbox_Travado(t *testing.T) {
+	agora := time.Now()
+	tests := []struct {
+		name     string
+		status   StatusEvento
+		criadoEm time.Time
+		travado  bool
+	}{
+		{"Pendente recente", EventoPendente, agora.Add(-time.Minute), false},
+		{"Pendente há muito tempo", EventoPendente, agora.Add(-time.Hour), true},
+		{"Falhou", EventoFalhou, agora, true},
+		{"Publicado", EventoPublicado, agora.Add(-time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		evento := EventoOutbox{Status: tt.status, CriadoEm: tt.criadoEm}
+		assert.Equal(t, tt.travado, evento.Travado(agora, 5*time.Minute), tt.name)
+	}
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// OrigemAlteracao identifica quem mudou o status do pedido
+type OrigemAlteracao string
+
+const (
+	OrigemHTTP          OrigemAlteracao = "http"      // API, usada pelo totem e pela cozinha
+	OrigemConsumidorSQS OrigemAlteracao = "sqs"       // eventos do serviço de pagamento
+	OrigemAgendador     OrigemAlteracao = "agendador" // rotinas automáticas
+	OrigemMigracao      OrigemAlteracao = "migracao"  // status que os pedidos tinham quando o histórico foi criado
+	OrigemDesconhecida  OrigemAlteracao = "desconhecida"
+)
+
+// CampoAlteracao indica qual dos status do pedido mudou
+type CampoAlteracao string
+
+const (
+	AlteracaoStatus    CampoAlteracao = "status"
+	AlteracaoPagamento CampoAlteracao = "pagamento"
+)
+
+// AlteracaoPedido registra uma mudança de status ou de status de pagamento do pedido.
+// A criação do pedido é registrada como uma alteração de status sem valor anterior.
+type AlteracaoPedido struct {
+	ID         int             `json:"idAlteracao"`
+	PedidoID   int             `json:"idPedido"`
+	Campo      CampoAlteracao  `json:"campo"`
+	De         string          `json:"de,omitempty"`
+	Para       string          `json:"para"`
+	Origem     OrigemAlteracao `json:"origem"`
+	AlteradoEm time.Time       `json:"alteradoEm"`
+}
+
+func AlteracaoPedidoNew(pedidoID int, campo CampoAlteracao, de, para string, origem OrigemAlteracao, em time.Time) (*AlteracaoPedido, error) {
+	if pedidoID <= 0 {
+		return nil, errors.New("a alteração precisa de um pedido cadastrado")
+	}
+	if campo != AlteracaoStatus && campo != AlteracaoPagamento {
+		return nil, errors.New("campo alterado inválido")
+	}
+	if para == "" {
+		return nil, errors.New("o novo status é obrigatório")
+	}
+	if origem == "" {
+		origem = OrigemDesconhecida
+	}
+
+	return &AlteracaoPedido{
+		PedidoID:   pedidoID,
+		Campo:      campo,
+		De:         de,
+		Para:       para,
+		Origem:     origem,
+		AlteradoEm: em,
+	}, nil
+}
+
+// EtapaPedido informa quanto tempo o pedido ficou em um status
+type EtapaPedido struct {
+	Status StatusPedido `json:"status"`
+	Inicio time.Time    `json:"inicio"`
+	// Fim é nil enquanto o pedido continua no status
+	Fim     *time.Time `json:"fim,omitempty"`
+	Duracao string     `json:"duracao"` // HH:MM:SS
+	// Segundos traz a mesma duração para quem agrega os tempos
+	Segundos int64 `json:"segundos"`
+}
+
+// DuracoesEtapas calcula, a partir do histórico em ordem cronológica, o tempo em cada status do pedido.
+// A etapa atual é medida até agora; os status finais, Finalizado e Cancelado, não são etapas.
+func DuracoesEtapas(historico []AlteracaoPedido, agora time.Time) []EtapaPedido {
+	etapas := []EtapaPedido{}
+	for _, alteracao := range historico {
+		if alteracao.Campo != AlteracaoStatus {
+			continue
+		}
+
+		if n := len(etapas); n > 0 && etapas[n-1].Fim == nil {
+			fim := alteracao.AlteradoEm
+			etapas[n-1].Fim = &fim
+		}
+
+		status := StatusPedido(alteracao.Para)
+		if len(transicoesStatus[status]) == 0 {
+			continue
+		}
+		etapas = append(etapas, EtapaPedido{Status: status, Inicio: alteracao.AlteradoEm})
+	}
+
+	for i := range etapas {
+		fim := agora
+		if etapas[i].Fim != nil {
+			fim = *etapas[i].Fim
+		}
+		duracao := fim.Sub(etapas[i].Inicio)
+		if duracao < 0 {
+			duracao = 0
+		}
+		etapas[i].Duracao = FormatarDuracao(duracao)
+		etapas[i].Segundos = int64(duracao / time.Second)
+	}
+	return etapas
+}
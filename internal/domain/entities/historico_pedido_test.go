@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlteracaoPedidoNew(t *testing.T) {
+	agora := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	alteracao, err := AlteracaoPedidoNew(42, AlteracaoStatus, string(Recebido), string(EmPreparacao), OrigemHTTP, agora)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, alteracao.PedidoID)
+	assert.Equal(t, "Em preparação", alteracao.Para)
+	assert.Equal(t, OrigemHTTP, alteracao.Origem)
+
+	semOrigem, err := AlteracaoPedidoNew(42, AlteracaoPagamento, "Pendente", "Pago", "", agora)
+	assert.NoError(t, err)
+	assert.Equal(t, OrigemDesconhecida, semOrigem.Origem)
+
+	_, err = AlteracaoPedidoNew(0, AlteracaoStatus, "", "Pendente", OrigemHTTP, agora)
+	assert.Error(t, err)
+	_, err = AlteracaoPedidoNew(42, "cupom", "", "X", OrigemHTTP, agora)
+	assert.Error(t, err)
+	_, err = AlteracaoPedidoNew(42, AlteracaoStatus, "Pendente", "", OrigemHTTP, agora)
+	assert.Error(t, err)
+}
+
+func TestDuracoesEtapas(t *testing.T) {
+	inicio := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	historico := []AlteracaoPedido{
+		{Campo: AlteracaoStatus, Para: string(Pendente), AlteradoEm: inicio},
+		{Campo: AlteracaoPagamento, De: "Pendente", Para: "Pago", AlteradoEm: inicio.Add(2 * time.Minute)},
+		{Campo: AlteracaoStatus, De: string(Pendente), Para: string(Recebido), AlteradoEm: inicio.Add(2 * time.Minute)},
+		{Campo: AlteracaoStatus, De: string(Recebido), Para: string(EmPreparacao), AlteradoEm: inicio.Add(5 * time.Minute)},
+	}
+
+	etapas := DuracoesEtapas(historico, inicio.Add(17*time.Minute))
+
+	assert.Len(t, etapas, 3)
+	assert.Equal(t, Pendente, etapas[0].Status)
+	assert.Equal(t, "00:02:00", etapas[0].Duracao)
+	assert.Equal(t, int64(180), etapas[1].Segundos)
+	assert.Equal(t, EmPreparacao, etapas[2].Status)
+	assert.Nil(t, etapas[2].Fim, "a etapa atual ainda não terminou")
+	assert.Equal(t, "00:12:00", etapas[2].Duracao)
+
+	finalizado := append(historico,
+		AlteracaoPedido{Campo: AlteracaoStatus, De: string(EmPreparacao), Para: string(Pronto), AlteradoEm: inicio.Add(20 * time.Minute)},
+		AlteracaoPedido{Campo: AlteracaoStatus, De: string(Pronto), Para: string(Finalizado), AlteradoEm: inicio.Add(23 * time.Minute)},
+	)
+	etapas = DuracoesEtapas(finalizado, inicio.Add(time.Hour))
+
+	assert.Len(t, etapas, 4, "o status final não é uma etapa")
+	assert.Equal(t, "00:15:00", etapas[2].Duracao)
+	assert.Equal(t, "00:03:00", etapas[3].Duracao)
+	assert.NotNil(t, etapas[3].Fim)
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// VarianteImagem identifica cada tamanho gerado a partir da imagem enviada para o produto
+type VarianteImagem string
+
+const (
+	ImagemOriginal  VarianteImagem = "original"
+	ImagemMedia     VarianteImagem = "media"     // cardápio do totem
+	ImagemMiniatura VarianteImagem = "miniatura" // listas e carrinho
+)
+
+// LarguraVariante é a largura máxima, em pixels, de cada variante redimensionada; a altura segue a proporção
+var LarguraVariante = map[VarianteImagem]int{
+	ImagemMedia:     480,
+	ImagemMiniatura: 160,
+}
+
+// TamanhoMaximoImagem limita o arquivo enviado, antes do redimensionamento
+const TamanhoMaximoImagem = 5 << 20
+
+// DimensaoMaximaImagem e PixelsMaximosImagem limitam a imagem decodificada: um arquivo pequeno e bem
+// comprimido pode declarar dimensões que ocupariam gigabytes de memória
+const (
+	DimensaoMaximaImagem = 6000
+	PixelsMaximosImagem  = 24_000_000
+)
+
+// FormatosImagem associa os tipos de conteúdo aceitos à extensão usada no armazenamento
+var FormatosImagem = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+var (
+	ErrFormatoImagem   = errors.New("formato de imagem não suportado: envie JPEG ou PNG")
+	ErrImagemGrande    = fmt.Errorf("a imagem deve ter no máximo %d MB", TamanhoMaximoImagem>>20)
+	ErrImagemVazia     = errors.New("a imagem está vazia")
+	ErrDimensoesImagem = fmt.Errorf("a imagem deve ter no máximo %d pixels de largura e de altura e %d megapixels",
+		DimensaoMaximaImagem, PixelsMaximosImagem/1_000_000)
+	ErrProdutoSemFoto = errors.New("o produto não tem imagem")
+)
+
+// ImagemProduto é um arquivo de imagem do produto já armazenado
+type ImagemProduto struct {
+	Variante VarianteImagem `json:"variante"`
+	// Chave do arquivo no armazenamento, usada para apagá-lo
+	Chave   string `json:"-"`
+	URL     string `json:"url"`
+	Largura int    `json:"largura"`
+	Altura  int    `json:"altura"`
+}
+
+// ValidarImagem confere o tipo e o tamanho do arquivo enviado e devolve a extensão do formato
+func ValidarImagem(contentType string, tamanho int) (string, error) {
+	extensao, ok := FormatosImagem[contentType]
+	if !ok {
+		return "", ErrFormatoImagem
+	}
+	if tamanho == 0 {
+		return "", ErrImagemVazia
+	}
+	if tamanho > TamanhoMaximoImagem {
+		return "", ErrImagemGrande
+	}
+	return extensao, nil
+}
+
+// ValidarDimensoesImagem confere a largura e a altura declaradas pela imagem, antes de decodificá-la
+func ValidarDimensoesImagem(largura, altura int) error {
+	if largura <= 0 || altura <= 0 {
+		return ErrFormatoImagem
+	}
+	if largura > DimensaoMaximaImagem || altura > DimensaoMaximaImagem || largura*altura > PixelsMaximosImagem {
+		return ErrDimensoesImagem
+	}
+	return nil
+}
+
+// ChaveImagem monta o caminho do arquivo no armazenamento. O instante do envio entra no nome
+// para que uma nova imagem não seja confundida com a anterior em caches de navegador ou CDN.
+func ChaveImagem(produtoID int, variante VarianteImagem, enviadaEm time.Time, extensao string) string {
+	return fmt.Sprintf("produtos/%d/%s-%d.%s", produtoID, variante, enviadaEm.UnixNano(), extensao)
+}
+
+// URLsImagens resume as imagens do produto em variante -> URL, o formato usado nos eventos
+func URLsImagens(imagens []ImagemProduto) map[VarianteImagem]string {
+	urls := map[VarianteImagem]string{}
+	for _, imagem := range imagens {
+		urls[imagem.Variante] = imagem.URL
+	}
+	return urls
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidarImagem(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		tamanho     int
+		extensao    string
+		esperado    error
+	}{
+		{"jpeg", "image/jpeg", 1024, "jpg", nil},
+		{"png no limite", "image/png", TamanhoMaximoImagem, "png", nil},
+		{"gif", "image/gif", 1024, "", ErrFormatoImagem},
+		{"vazia", "image/png", 0, "", ErrImagemVazia},
+		{"grande demais", "image/jpeg", TamanhoMaximoImagem + 1, "", ErrImagemGrande},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extensao, err := ValidarImagem(tc.contentType, tc.tamanho)
+			if !errors.Is(err, tc.esperado) {
+				t.Errorf("Esperado erro %v, recebido %v", tc.esperado, err)
+			}
+			if extensao != tc.extensao {
+				t.Errorf("Esperada extensão %q, recebida %q", tc.extensao, extensao)
+			}
+		})
+	}
+}
+
+func TestValidarDimensoesImagem(t *testing.T) {
+	testCases := []struct {
+		name     string
+		largura  int
+		altura   int
+		esperado error
+	}{
+		{"comum", 1200, 800, nil},
+		{"no limite de pixels", 6000, 4000, nil},
+		{"largura demais", DimensaoMaximaImagem + 1, 10, ErrDimensoesImagem},
+		{"altura demais", 10, DimensaoMaximaImagem + 1, ErrDimensoesImagem},
+		{"pixels demais", 6000, 4001, ErrDimensoesImagem},
+		{"sem dimensões", 0, 10, ErrFormatoImagem},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidarDimensoesImagem(tc.largura, tc.altura); !errors.Is(err, tc.esperado) {
+				t.Errorf("Esperado erro %v, recebido %v", tc.esperado, err)
+			}
+		})
+	}
+}
+
+func TestChaveImagem(t *testing.T) {
+	enviadaEm := time.Unix(0, 1700000000000000000)
+
+	chave := ChaveImagem(7, ImagemMiniatura, enviadaEm, "png")
+
+	if chave != "produtos/7/miniatura-1700000000000000000.png" {
+		t.Errorf("Chave incorreta: %s", chave)
+	}
+}
+
+func TestURLsImagens(t *testing.T) {
+	imagens := []ImagemProduto{
+		{Variante: ImagemOriginal, URL: "http://imagens/original.jpg"},
+		{Variante: ImagemMiniatura, URL: "http://imagens/miniatura.jpg"},
+	}
+
+	urls := URLsImagens(imagens)
+
+	if len(urls) != 2 || urls[ImagemMiniatura] != "http://imagens/miniatura.jpg" {
+		t.Errorf("URLs incorretas: %v", urls)
+	}
+	if len(URLsImagens(nil)) != 0 {
+		t.Error("Esperado mapa vazio para produto sem imagens")
+	}
+}
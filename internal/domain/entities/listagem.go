@@ -0,0 +1,326 @@
+package entities
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// LimitePadrao é o tamanho da página quando o cliente não informa um limite
+	LimitePadrao = 20
+	LimiteMaximo = 100
+)
+
+var (
+	ErrCursorInvalido  = errors.New("cursor inválido")
+	ErrOrdemInvalida   = errors.New("ordenação inválida")
+	ErrLimiteInvalido  = fmt.Errorf("o limite deve estar entre 1 e %d", LimiteMaximo)
+	ErrPeriodoInvalido = errors.New("o início do período deve ser anterior ao fim")
+	ErrFaixaPreco      = errors.New("o preço mínimo deve ser menor ou igual ao máximo")
+)
+
+// Pagina é um trecho de uma listagem ordenada. Total conta todos os itens que atendem aos filtros,
+// em todas as páginas, e ProximoCursor fica vazio na última página.
+type Pagina[T any] struct {
+	Itens         []T
+	Total         int
+	ProximoCursor string
+}
+
+// Ordenacao é o campo pelo qual a listagem é ordenada; o ID desempata itens com o mesmo valor
+type Ordenacao struct {
+	Campo       string
+	Decrescente bool
+}
+
+// OrdenacaoParse lê a ordenação no formato da query string: o nome do campo, com "-" na frente para
+// ordem decrescente (ex: "-total")
+func OrdenacaoParse(valor string) Ordenacao {
+	valor = strings.TrimSpace(valor)
+	if strings.HasPrefix(valor, "-") {
+		return Ordenacao{Campo: strings.TrimPrefix(valor, "-"), Decrescente: true}
+	}
+	return Ordenacao{Campo: valor}
+}
+
+func (o Ordenacao) String() string {
+	if o.Decrescente {
+		return "-" + o.Campo
+	}
+	return o.Campo
+}
+
+// validar aplica a ordenação padrão quando nenhuma foi informada e recusa campos não permitidos
+func (o *Ordenacao) validar(padrao Ordenacao, campos ...string) error {
+	if o.Campo == "" {
+		*o = padrao
+		return nil
+	}
+	for _, campo := range campos {
+		if o.Campo == campo {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q; use %s", ErrOrdemInvalida, o.String(), strings.Join(campos, ", "))
+}
+
+// Cursor aponta para o último item de uma página: a listagem continua a partir dos itens que vêm
+// depois dele na ordenação. Guardar o valor do campo, e não a posição, mantém as páginas estáveis
+// quando itens são incluídos durante a navegação.
+type Cursor struct {
+	Ordem string `json:"o"`
+	Valor string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// String codifica o cursor no texto opaco devolvido ao cliente
+func (c Cursor) String() string {
+	dados, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(dados)
+}
+
+// CursorParse decodifica o cursor recebido do cliente; texto vazio significa a primeira página
+func CursorParse(texto string) (*Cursor, error) {
+	texto = strings.TrimSpace(texto)
+	if texto == "" {
+		return nil, nil
+	}
+
+	dados, err := base64.RawURLEncoding.DecodeString(texto)
+	if err != nil {
+		return nil, ErrCursorInvalido
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(dados, &cursor); err != nil || cursor.Ordem == "" || cursor.ID <= 0 {
+		return nil, ErrCursorInvalido
+	}
+	return &cursor, nil
+}
+
+// validarPaginacao confere o limite e se o cursor foi gerado pela mesma ordenação da consulta
+func validarPaginacao(limite *int, cursor *Cursor, ordem Ordenacao) error {
+	if *limite == 0 {
+		*limite = LimitePadrao
+	}
+	if *limite < 0 || *limite > LimiteMaximo {
+		return ErrLimiteInvalido
+	}
+	if cursor != nil && cursor.Ordem != ordem.String() {
+		return fmt.Errorf("%w: gerado para a ordenação %q", ErrCursorInvalido, cursor.Ordem)
+	}
+	return nil
+}
+
+// Campos de ordenação da listagem de pedidos
+const (
+	OrdemPedidoCriacao = "criado_em"
+	OrdemPedidoTotal   = "total"
+)
+
+// ConsultaPedidos são os filtros, a ordenação e a página pedidos na listagem de pedidos.
+// Filtros vazios não restringem a listagem.
+type ConsultaPedidos struct {
+	Status          StatusPedido
+	StatusPagamento StatusPagamento
+	De              *time.Time         // Pedidos feitos a partir deste instante
+	Ate             *time.Time         // Pedidos feitos até este instante
+	ClienteNome     string             // Parte do nome do cliente, sem diferenciar maiúsculas
+	Cliente         *ReferenciaCliente // Pedidos do cliente, pelo CPF ou pelo identificador externo
+	Ordem           Ordenacao
+	Cursor          *Cursor
+	Limite          int
+}
+
+// Validar confere os filtros e completa a ordenação e o limite padrão: pedidos mais antigos primeiro,
+// como a listagem sempre mostrou
+func (c *ConsultaPedidos) Validar() error {
+	if c.Status != "" {
+		if _, ok := transicoesStatus[c.Status]; !ok {
+			return ErrStatusInvalido
+		}
+	}
+	if c.StatusPagamento != "" {
+		if _, ok := transicoesPagamento[c.StatusPagamento]; !ok {
+			return ErrStatusPagamentoInvalido
+		}
+	}
+	if c.De != nil && c.Ate != nil && c.De.After(*c.Ate) {
+		return ErrPeriodoInvalido
+	}
+	c.ClienteNome = strings.TrimSpace(c.ClienteNome)
+	if c.Cliente != nil && c.Cliente.CPF == "" && c.Cliente.IDExterno == "" {
+		return ErrClienteSemReferencia
+	}
+
+	if err := c.Ordem.validar(Ordenacao{Campo: OrdemPedidoCriacao}, OrdemPedidoCriacao, OrdemPedidoTotal); err != nil {
+		return err
+	}
+	if err := validarPaginacao(&c.Limite, c.Cursor, c.Ordem); err != nil {
+		return err
+	}
+	if c.Cursor != nil {
+		if _, err := c.ValorCursor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Atende informa se o pedido passa pelos filtros da consulta
+func (c ConsultaPedidos) Atende(pedido Pedido) bool {
+	if c.Status != "" && pedido.Status != c.Status {
+		return false
+	}
+	if c.StatusPagamento != "" && pedido.StatusPagamento != c.StatusPagamento {
+		return false
+	}
+	if c.De != nil && pedido.CriadoEm.Before(*c.De) {
+		return false
+	}
+	if c.Ate != nil && pedido.CriadoEm.After(*c.Ate) {
+		return false
+	}
+	if c.ClienteNome != "" && !strings.Contains(strings.ToLower(pedido.ClienteNome), strings.ToLower(c.ClienteNome)) {
+		return false
+	}
+	if c.Cliente != nil && !c.Cliente.Identifica(pedido.Cliente) {
+		return false
+	}
+	return true
+}
+
+// CursorDe gera o cursor que continua a listagem depois do pedido
+func (c ConsultaPedidos) CursorDe(pedido Pedido) Cursor {
+	cursor := Cursor{Ordem: c.Ordem.String(), ID: pedido.ID}
+	switch c.Ordem.Campo {
+	case OrdemPedidoTotal:
+		cursor.Valor = strconv.FormatInt(pedido.Total.Centavos, 10)
+	default:
+		cursor.Valor = pedido.CriadoEm.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// ValorCursor converte o valor guardado no cursor para o tipo do campo ordenado: time.Time para
+// criado_em e Money para total
+func (c ConsultaPedidos) ValorCursor() (interface{}, error) {
+	switch c.Ordem.Campo {
+	case OrdemPedidoTotal:
+		return valorMonetarioCursor(c.Cursor.Valor)
+	default:
+		criadoEm, err := time.Parse(time.RFC3339Nano, c.Cursor.Valor)
+		if err != nil {
+			return nil, ErrCursorInvalido
+		}
+		return criadoEm, nil
+	}
+}
+
+// Campos de ordenação da listagem de produtos
+const (
+	OrdemProdutoNome  = "nome"
+	OrdemProdutoPreco = "preco"
+)
+
+// ConsultaProdutos são os filtros, a ordenação e a página pedidos na listagem do cardápio.
+// Produtos arquivados nunca aparecem; os indisponíveis só quando pedidos.
+type ConsultaProdutos struct {
+	Categoria            CatProduto
+	PrecoMin             *Money
+	PrecoMax             *Money
+	IncluirIndisponiveis bool
+	Dieta                FiltroDieta
+	Ordem                Ordenacao
+	Cursor               *Cursor
+	Limite               int
+}
+
+// Validar confere os filtros e completa a ordenação e o limite padrão: ordem alfabética
+func (c *ConsultaProdutos) Validar() error {
+	if c.PrecoMin != nil && c.PrecoMax != nil && c.PrecoMin.Centavos > c.PrecoMax.Centavos {
+		return ErrFaixaPreco
+	}
+
+	if err := c.Ordem.validar(Ordenacao{Campo: OrdemProdutoNome}, OrdemProdutoNome, OrdemProdutoPreco); err != nil {
+		return err
+	}
+	if err := validarPaginacao(&c.Limite, c.Cursor, c.Ordem); err != nil {
+		return err
+	}
+	if c.Cursor != nil {
+		if _, err := c.ValorCursor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Atende informa se o produto passa pelos filtros da consulta
+func (c ConsultaProdutos) Atende(produto Produto) bool {
+	if produto.Arquivado() {
+		return false
+	}
+	if c.Categoria != "" && produto.Categoria != c.Categoria {
+		return false
+	}
+	if c.PrecoMin != nil && produto.Preco.Centavos < c.PrecoMin.Centavos {
+		return false
+	}
+	if c.PrecoMax != nil && produto.Preco.Centavos > c.PrecoMax.Centavos {
+		return false
+	}
+	if !c.IncluirIndisponiveis && !produto.Disponivel(1) {
+		return false
+	}
+	return c.Dieta.Atende(produto)
+}
+
+// CursorDe gera o cursor que continua a listagem depois do produto
+func (c ConsultaProdutos) CursorDe(produto Produto) Cursor {
+	cursor := Cursor{Ordem: c.Ordem.String(), ID: produto.ID}
+	switch c.Ordem.Campo {
+	case OrdemProdutoPreco:
+		cursor.Valor = strconv.FormatInt(produto.Preco.Centavos, 10)
+	default:
+		cursor.Valor = produto.Nome
+	}
+	return cursor
+}
+
+// ValorCursor converte o valor guardado no cursor para o tipo do campo ordenado: string para nome
+// e Money para preco
+func (c ConsultaProdutos) ValorCursor() (interface{}, error) {
+	switch c.Ordem.Campo {
+	case OrdemProdutoPreco:
+		return valorMonetarioCursor(c.Cursor.Valor)
+	default:
+		return c.Cursor.Valor, nil
+	}
+}
+
+func valorMonetarioCursor(valor string) (Money, error) {
+	centavos, err := strconv.ParseInt(valor, 10, 64)
+	if err != nil {
+		return Money{}, ErrCursorInvalido
+	}
+	return Centavos(centavos), nil
+}
+
+// PaginaNew monta a página a partir dos itens lidos depois do cursor. Quem consulta lê um item além
+// do limite: se ele vier, existe próxima página e ela continua a partir do último item mostrado.
+func PaginaNew[T any](itens []T, limite, total int, cursorDe func(T) Cursor) *Pagina[T] {
+	pagina := &Pagina[T]{Itens: itens, Total: total}
+	if pagina.Itens == nil {
+		pagina.Itens = []T{}
+	}
+	if len(itens) > limite {
+		pagina.Itens = itens[:limite]
+		pagina.ProximoCursor = cursorDe(pagina.Itens[limite-1]).String()
+	}
+	return pagina
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrdenacaoParse(t *testing.T) {
+	assert.Equal(t, Ordenacao{Campo: "total", Decrescente: true}, OrdenacaoParse("-total"))
+	assert.Equal(t, Ordenacao{Campo: "nome"}, OrdenacaoParse(" nome "))
+	assert.Equal(t, "-total", OrdenacaoParse("-total").String())
+}
+
+func TestCursor_IdaEVolta(t *testing.T) {
+	cursor := Cursor{Ordem: "-preco", Valor: "2590", ID: 7}
+
+	lido, err := CursorParse(cursor.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &cursor, lido)
+}
+
+func TestCursorParse(t *testing.T) {
+	vazio, err := CursorParse("")
+	assert.NoError(t, err)
+	assert.Nil(t, vazio)
+
+	for _, texto := range []string{"não é base64", "bm9wZQ", Cursor{Valor: "x", ID: 1}.String(), Cursor{Ordem: "nome"}.String()} {
+		_, err := CursorParse(texto)
+		assert.ErrorIs(t, err, ErrCursorInvalido, texto)
+	}
+}
+
+func TestConsultaPedidos_Validar_Padroes(t *testing.T) {
+	consulta := ConsultaPedidos{}
+
+	assert.NoError(t, consulta.Validar())
+	assert.Equal(t, Ordenacao{Campo: OrdemPedidoCriacao}, consulta.Ordem)
+	assert.Equal(t, LimitePadrao, consulta.Limite)
+}
+
+func TestConsultaPedidos_Validar_Invalida(t *testing.T) {
+	ontem := time.Now().Add(-24 * time.Hour)
+	hoje := time.Now()
+	cursorTotal := ConsultaPedidos{Ordem: Ordenacao{Campo: OrdemPedidoTotal}}.CursorDe(Pedido{ID: 3, Total: Centavos(1000)})
+
+	testCases := []struct {
+		name     string
+		consulta ConsultaPedidos
+		esperado error
+	}{
+		{"status desconhecido", ConsultaPedidos{Status: "Perdido"}, ErrStatusInvalido},
+		{"pagamento desconhecido", ConsultaPedidos{StatusPagamento: "Fiado"}, ErrStatusPagamentoInvalido},
+		{"período invertido", ConsultaPedidos{De: &hoje, Ate: &ontem}, ErrPeriodoInvalido},
+		{"cliente sem referência", ConsultaPedidos{Cliente: &ReferenciaCliente{}}, ErrClienteSemReferencia},
+		{"campo de ordenação desconhecido", ConsultaPedidos{Ordem: Ordenacao{Campo: "cliente"}}, ErrOrdemInvalida},
+		{"limite acima do máximo", ConsultaPedidos{Limite: LimiteMaximo + 1}, ErrLimiteInvalido},
+		{"limite negativo", ConsultaPedidos{Limite: -1}, ErrLimiteInvalido},
+		{"cursor de outra ordenação", ConsultaPedidos{Cursor: &cursorTotal}, ErrCursorInvalido},
+		{"cursor com valor inválido", ConsultaPedidos{Cursor: &Cursor{Ordem: OrdemPedidoCriacao, Valor: "ontem", ID: 1}}, ErrCursorInvalido},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.consulta.Validar()
+			assert.True(t, errors.Is(err, tc.esperado), "esperado %v, recebido %v", tc.esperado, err)
+		})
+	}
+}
+
+func TestConsultaPedidos_Atende(t *testing.T) {
+	criadoEm := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	pedido := Pedido{ClienteNome: "Ana Souza", Status: Recebido, StatusPagamento: PagamentoPago, CriadoEm: criadoEm}
+	antes := criadoEm.Add(-time.Hour)
+	depois := criadoEm.Add(time.Hour)
+
+	assert.True(t, ConsultaPedidos{}.Atende(pedido))
+	assert.True(t, ConsultaPedidos{Status: Recebido, StatusPagamento: PagamentoPago, ClienteNome: "souza", De: &antes, Ate: &depois}.Atende(pedido))
+	assert.False(t, ConsultaPedidos{Status: Pronto}.Atende(pedido))
+	assert.False(t, ConsultaPedidos{StatusPagamento: PagamentoPendente}.Atende(pedido))
+	assert.False(t, ConsultaPedidos{De: &depois}.Atende(pedido))
+	assert.False(t, ConsultaPedidos{Ate: &antes}.Atende(pedido))
+	assert.False(t, ConsultaPedidos{ClienteNome: "Bruno"}.Atende(pedido))
+
+	// O cliente é identificado pelo CPF ou pelo identificador externo; pedidos de balcão não têm cliente
+	assert.False(t, ConsultaPedidos{Cliente: &ReferenciaCliente{CPF: "52998224725"}}.Atende(pedido))
+	pedido.Cliente = &ReferenciaCliente{CPF: "52998224725", IDExterno: "37"}
+	assert.True(t, ConsultaPedidos{Cliente: &ReferenciaCliente{CPF: "52998224725"}}.Atende(pedido))
+	assert.True(t, ConsultaPedidos{Cliente: &ReferenciaCliente{IDExterno: "37"}}.Atende(pedido))
+	assert.False(t, ConsultaPedidos{Cliente: &ReferenciaCliente{IDExterno: "40"}}.Atende(pedido))
+}
+
+func TestConsultaPedidos_CursorDe(t *testing.T) {
+	criadoEm := time.Date(2026, 3, 10, 12, 30, 15, 250000000, time.UTC)
+	pedido := Pedido{ID: 42, Total: Centavos(3590), CriadoEm: criadoEm}
+
+	porData := ConsultaPedidos{}
+	assert.NoError(t, porData.Validar())
+	cursor := porData.CursorDe(pedido)
+	porData.Cursor = &cursor
+	valor, err := porData.ValorCursor()
+	assert.NoError(t, err)
+	assert.True(t, criadoEm.Equal(valor.(time.Time)))
+
+	porTotal := ConsultaPedidos{Ordem: Ordenacao{Campo: OrdemPedidoTotal, Decrescente: true}}
+	cursor = porTotal.CursorDe(pedido)
+	assert.Equal(t, "-total", cursor.Ordem)
+	porTotal.Cursor = &cursor
+	assert.NoError(t, porTotal.Validar())
+	valor, err = porTotal.ValorCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, Centavos(3590), valor)
+}
+
+func TestConsultaProdutos_Validar(t *testing.T) {
+	consulta := ConsultaProdutos{}
+	assert.NoError(t, consulta.Validar())
+	assert.Equal(t, Ordenacao{Campo: OrdemProdutoNome}, consulta.Ordem)
+	assert.Equal(t, LimitePadrao, consulta.Limite)
+
+	min, max := Reais(30), Reais(10)
+	faixa := ConsultaProdutos{PrecoMin: &min, PrecoMax: &max}
+	assert.ErrorIs(t, faixa.Validar(), ErrFaixaPreco)
+
+	ordem := ConsultaProdutos{Ordem: Ordenacao{Campo: "estoque"}}
+	assert.ErrorIs(t, ordem.Validar(), ErrOrdemInvalida)
+
+	cursor := Cursor{Ordem: OrdemProdutoPreco, Valor: "barato", ID: 1}
+	preco := ConsultaProdutos{Ordem: Ordenacao{Campo: OrdemProdutoPreco}, Cursor: &cursor}
+	assert.ErrorIs(t, preco.Validar(), ErrCursorInvalido)
+}
+
+func TestConsultaProdutos_Atende(t *testing.T) {
+	zero := 0
+	arquivadoEm := time.Now()
+	lanche := Produto{ID: 1, Nome: "X-Salada", Categoria: Lanche, Preco: Reais(22.5),
+		InformacoesDieteticas: InformacoesDieteticas{Alergenos: []Alergeno{AlergenoGluten}}}
+	semEstoque := Produto{ID: 2, Nome: "Suco", Categoria: Bebida, Preco: Reais(8), Estoque: &zero}
+	arquivado := Produto{ID: 3, Nome: "Milkshake", Categoria: Sobremesa, Preco: Reais(15), ArquivadoEm: &arquivadoEm}
+	min, max := Reais(20), Reais(25)
+
+	assert.True(t, ConsultaProdutos{}.Atende(lanche))
+	assert.True(t, ConsultaProdutos{Categoria: Lanche, PrecoMin: &min, PrecoMax: &max}.Atende(lanche))
+	assert.False(t, ConsultaProdutos{Categoria: Bebida}.Atende(lanche))
+	assert.False(t, ConsultaProdutos{PrecoMax: &min}.Atende(semEstoque))
+	assert.False(t, ConsultaProdutos{PrecoMin: &max}.Atende(lanche))
+	assert.False(t, ConsultaProdutos{Dieta: FiltroDieta{SemAlergenos: []Alergeno{AlergenoGluten}}}.Atende(lanche))
+	assert.False(t, ConsultaProdutos{}.Atende(semEstoque))
+	assert.True(t, ConsultaProdutos{IncluirIndisponiveis: true}.Atende(semEstoque))
+	assert.False(t, ConsultaProdutos{IncluirIndisponiveis: true}.Atende(arquivado))
+}
+
+func TestConsultaProdutos_CursorDe(t *testing.T) {
+	produto := Produto{ID: 5, Nome: "Batata-frita", Preco: Reais(12.9)}
+
+	porNome := ConsultaProdutos{}
+	assert.NoError(t, porNome.Validar())
+	cursor := porNome.CursorDe(produto)
+	porNome.Cursor = &cursor
+	valor, err := porNome.ValorCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, "Batata-frita", valor)
+
+	porPreco := ConsultaProdutos{Ordem: Ordenacao{Campo: OrdemProdutoPreco}}
+	cursor = porPreco.CursorDe(produto)
+	porPreco.Cursor = &cursor
+	valor, err = porPreco.ValorCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(12.9), valor)
+}
+
+func TestPaginaNew(t *testing.T) {
+	cursorDe := func(id int) Cursor { return Cursor{Ordem: "id", Valor: "", ID: id} }
+
+	ultima := PaginaNew([]int{1, 2}, 2, 5, cursorDe)
+	assert.Equal(t, []int{1, 2}, ultima.Itens)
+	assert.Equal(t, 5, ultima.Total)
+	assert.Empty(t, ultima.ProximoCursor)
+
+	comProxima := PaginaNew([]int{1, 2, 3}, 2, 5, cursorDe)
+	assert.Equal(t, []int{1, 2}, comProxima.Itens)
+	cursor, err := CursorParse(comProxima.ProximoCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cursor.ID)
+
+	vazia := PaginaNew[int](nil, 2, 0, cursorDe)
+	assert.NotNil(t, vazia.Itens)
+}
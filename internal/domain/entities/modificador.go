@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Modificador é uma opção de personalização de um produto, ex: "Bacon extra" ou "Sem cebola"
+type Modificador struct {
+	ID      int    `json:"idModificador"`
+	GrupoID int    `json:"idGrupo,omitempty"`
+	Nome    string `json:"nomeModificador"`
+	Preco   Money  `json:"precoModificador"` // Acréscimo sobre o preço do produto
+}
+
+// GrupoModificador agrupa as opções de um produto e limita quantas podem ser escolhidas, ex: "Adicionais"
+type GrupoModificador struct {
+	ID          int           `json:"idGrupo"`
+	ProdutoID   int           `json:"idProduto"`
+	Nome        string        `json:"nomeGrupo"`
+	MinSelecoes int           `json:"minSelecoes"`
+	MaxSelecoes int           `json:"maxSelecoes"`
+	Opcoes      []Modificador `json:"opcoes"`
+}
+
+func GrupoModificadorNew(produtoID int, nome string, minSelecoes int, maxSelecoes int, opcoes []Modificador) (*GrupoModificador, error) {
+	if strings.TrimSpace(nome) == "" {
+		return nil, errors.New("o nome do grupo de modificadores é obrigatório")
+	}
+
+	if minSelecoes < 0 || maxSelecoes < 1 || minSelecoes > maxSelecoes {
+		return nil, errors.New("limites de seleção inválidos: é preciso 0 <= mínimo <= máximo e máximo >= 1")
+	}
+
+	if len(opcoes) < minSelecoes || len(opcoes) == 0 {
+		return nil, errors.New("o grupo precisa ter opções suficientes para o mínimo de seleções")
+	}
+
+	for _, opcao := range opcoes {
+		if strings.TrimSpace(opcao.Nome) == "" {
+			return nil, errors.New("todas as opções do grupo precisam de nome")
+		}
+		if opcao.Preco.Centavos < 0 {
+			return nil, errors.New("o acréscimo de uma opção não pode ser negativo")
+		}
+	}
+
+	return &GrupoModificador{
+		ProdutoID:   produtoID,
+		Nome:        nome,
+		MinSelecoes: minSelecoes,
+		MaxSelecoes: maxSelecoes,
+		Opcoes:      opcoes,
+	}, nil
+}
+
+// resolverModificadores troca os modificadores escolhidos (apenas IDs) pelos dados do catálogo
+// do produto e valida os limites de seleção de cada grupo
+func resolverModificadores(produto Produto, escolhidos []Modificador) ([]Modificador, error) {
+	catalogo := map[int]Modificador{}
+	for _, grupo := range produto.Modificadores {
+		for _, opcao := range grupo.Opcoes {
+			opcao.GrupoID = grupo.ID
+			catalogo[opcao.ID] = opcao
+		}
+	}
+
+	resolvidos := make([]Modificador, 0, len(escolhidos))
+	porGrupo := map[int]int{}
+	vistos := map[int]bool{}
+	for _, escolhido := range escolhidos {
+		opcao, ok := catalogo[escolhido.ID]
+		if !ok {
+			return nil, fmt.Errorf("modificador %d não pertence ao produto %s", escolhido.ID, produto.Nome)
+		}
+		if vistos[opcao.ID] {
+			return nil, fmt.Errorf("modificador %s escolhido mais de uma vez", opcao.Nome)
+		}
+		vistos[opcao.ID] = true
+		porGrupo[opcao.GrupoID]++
+		resolvidos = append(resolvidos, opcao)
+	}
+
+	for _, grupo := range produto.Modificadores {
+		qtd := porGrupo[grupo.ID]
+		if qtd < grupo.MinSelecoes || qtd > grupo.MaxSelecoes {
+			return nil, fmt.Errorf("o grupo %s do produto %s exige entre %d e %d seleções", grupo.Nome, produto.Nome, grupo.MinSelecoes, grupo.MaxSelecoes)
+		}
+	}
+
+	return resolvidos, nil
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func xSaladaComModificadores() Produto {
+	return Produto{
+		ID:        1,
+		Nome:      "X-Salada",
+		Categoria: Lanche,
+		Preco:     Reais(22.5),
+		Modificadores: []GrupoModificador{
+			{ID: 1, ProdutoID: 1, Nome: "Adicionais", MinSelecoes: 0, MaxSelecoes: 2, Opcoes: []Modificador{
+				{ID: 1, Nome: "Bacon extra", Preco: Reais(4.0)},
+				{ID: 2, Nome: "Queijo extra", Preco: Reais(3.0)},
+				{ID: 3, Nome: "Ovo", Preco: Reais(2.5)},
+			}},
+			{ID: 2, ProdutoID: 1, Nome: "Remover", MinSelecoes: 0, MaxSelecoes: 1, Opcoes: []Modificador{
+				{ID: 4, Nome: "Sem cebola", Preco: Reais(0)},
+			}},
+		},
+	}
+}
+
+func TestGrupoModificadorNew_Success(t *testing.T) {
+	opcoes := []Modificador{{Nome: "Bacon extra", Preco: Reais(4.0)}, {Nome: "Queijo extra", Preco: Reais(3.0)}}
+
+	grupo, err := GrupoModificadorNew(1, "Adicionais", 0, 2, opcoes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, grupo.ProdutoID)
+	assert.Equal(t, "Adicionais", grupo.Nome)
+	assert.Equal(t, 2, grupo.MaxSelecoes)
+	assert.Len(t, grupo.Opcoes, 2)
+}
+
+func TestGrupoModificadorNew_Errors(t *testing.T) {
+	opcao := []Modificador{{Nome: "Bacon extra", Preco: Reais(4.0)}}
+
+	tests := []struct {
+		name   string
+		nome   string
+		min    int
+		max    int
+		opcoes []Modificador
+	}{
+		{"Nome vazio", "  ", 0, 1, opcao},
+		{"Mínimo negativo", "Adicionais", -1, 1, opcao},
+		{"Máximo zero", "Adicionais", 0, 0, opcao},
+		{"Mínimo maior que máximo", "Adicionais", 2, 1, opcao},
+		{"Sem opções", "Adicionais", 0, 1, nil},
+		{"Opções insuficientes para o mínimo", "Adicionais", 2, 2, opcao},
+		{"Opção sem nome", "Adicionais", 0, 1, []Modificador{{Nome: ""}}},
+		{"Acréscimo negativo", "Adicionais", 0, 1, []Modificador{{Nome: "Desconto", Preco: Reais(-1)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grupo, err := GrupoModificadorNew(1, tt.nome, tt.min, tt.max, tt.opcoes)
+			assert.Error(t, err)
+			assert.Nil(t, grupo)
+		})
+	}
+}
+
+func TestItemPedidoNew_ComModificadores(t *testing.T) {
+	escolhidos := []Modificador{{ID: 1}, {ID: 4}}
+
+	item, err := ItemPedidoNew(xSaladaComModificadores(), 2, escolhidos, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, item.Modificadores, 2)
+	assert.Equal(t, "Bacon extra", item.Modificadores[0].Nome)
+	assert.Equal(t, 1, item.Modificadores[0].GrupoID)
+	assert.Equal(t, "Sem cebola", item.Modificadores[1].Nome)
+	assert.Equal(t, Reais(26.5), item.PrecoUnitario)
+	assert.Equal(t, Reais(53.0), item.Subtotal)
+}
+
+func TestItemPedidoNew_UsaPrecoDoCatalogo(t *testing.T) {
+	// O preço enviado pelo cliente é ignorado; vale o acréscimo cadastrado
+	escolhidos := []Modificador{{ID: 2, Nome: "Queijo extra", Preco: Reais(0)}}
+
+	item, err := ItemPedidoNew(xSaladaComModificadores(), 1, escolhidos, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(25.5), item.PrecoUnitario)
+}
+
+func TestItemPedidoNew_ModificadorInvalido(t *testing.T) {
+	tests := []struct {
+		name       string
+		escolhidos []Modificador
+		mensagem   string
+	}{
+		{"Modificador de outro produto", []Modificador{{ID: 99}}, "não pertence ao produto"},
+		{"Modificador repetido", []Modificador{{ID: 1}, {ID: 1}}, "mais de uma vez"},
+		{"Acima do máximo do grupo", []Modificador{{ID: 1}, {ID: 2}, {ID: 3}}, "exige entre 0 e 2 seleções"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := ItemPedidoNew(xSaladaComModificadores(), 1, tt.escolhidos, nil)
+			assert.Nil(t, item)
+			assert.ErrorContains(t, err, tt.mensagem)
+		})
+	}
+}
+
+func TestItemPedidoNew_GrupoObrigatorio(t *testing.T) {
+	produto := xSaladaComModificadores()
+	produto.Modificadores[1].MinSelecoes = 1
+
+	_, err := ItemPedidoNew(produto, 1, nil, nil)
+	assert.ErrorContains(t, err, "o grupo Remover do produto X-Salada exige entre 1 e 1 seleções")
+
+	_, err = ItemPedidoNew(produto, 1, []Modificador{{ID: 4}}, nil)
+	assert.NoError(t, err)
+}
+
+func TestPedidoNew_TotalComModificadores(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: xSaladaComModificadores(), Quantidade: 2, Modificadores: []Modificador{{ID: 1}, {ID: 2}}},
+		{Produto: Produto{ID: 2, Nome: "Coca-cola", Categoria: Bebida, Preco: Reais(6.0)}, Quantidade: 1},
+	}
+
+	pedido, err := PedidoNew("João Silva", itens, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(29.5), pedido.Itens[0].PrecoUnitario)
+	assert.Equal(t, Reais(65.0), pedido.Total)
+}
@@ -0,0 +1,271 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BRL é a moeda padrão de todos os valores da lanchonete
+const BRL = "BRL"
+
+var (
+	ErrMoedasDiferentes = errors.New("operação entre moedas diferentes")
+	// ErrValorMonetarioExcedido indica um valor que não cabe em centavos de 64 bits
+	ErrValorMonetarioExcedido = errors.New("valor monetário fora do limite representável")
+)
+
+// Money representa um valor monetário exato em centavos, evitando erros de arredondamento de float
+type Money struct {
+	Centavos int64
+	Moeda    string
+}
+
+// Centavos cria um valor em reais a partir de uma quantidade inteira de centavos
+func Centavos(centavos int64) Money {
+	return Money{Centavos: centavos, Moeda: BRL}
+}
+
+// Reais cria um valor fixo a partir de um número decimal, arredondando para o centavo mais próximo.
+// Entra em pânico com NaN, infinito ou valores fora do limite; valores vindos de fora passam por
+// MoneyParse, que devolve o erro.
+func Reais(valor float64) Money {
+	m, err := reaisDe(valor)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func reaisDe(valor float64) (Money, error) {
+	return MoneyParse(strconv.FormatFloat(valor, 'f', -1, 64))
+}
+
+// decimalSimples é o formato aceito por MoneyParse: dígitos com ponto decimal opcional, sem
+// expoente nem fração como "1e3" ou "1/3", que big.Rat também aceitaria
+var decimalSimples = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// MoneyParse converte um decimal em texto (ex: "25.90") para Money, arredondando para o centavo
+func MoneyParse(valor string) (Money, error) {
+	texto := strings.TrimSpace(valor)
+	if !decimalSimples.MatchString(texto) {
+		return Money{}, fmt.Errorf("valor monetário inválido: %q", valor)
+	}
+	r, ok := new(big.Rat).SetString(texto)
+	if !ok {
+		return Money{}, fmt.Errorf("valor monetário inválido: %q", valor)
+	}
+	centavos, err := arredondarCentavos(r.Mul(r, big.NewRat(100, 1)))
+	if err != nil {
+		return Money{}, fmt.Errorf("valor monetário inválido: %q: %w", valor, err)
+	}
+	return Centavos(centavos), nil
+}
+
+// arredondarCentavos é a única regra de arredondamento monetário: meio centavo arredonda
+// para longe do zero (0,005 → 0,01 e -0,005 → -0,01)
+func arredondarCentavos(r *big.Rat) (int64, error) {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+
+	negativo := num.Sign() < 0
+	num.Abs(num)
+
+	// (2*num + den) / (2*den) = piso(num/den + 1/2)
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	quociente := new(big.Int).Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+
+	if negativo {
+		quociente.Neg(quociente)
+	}
+	if !quociente.IsInt64() {
+		return 0, ErrValorMonetarioExcedido
+	}
+	return quociente.Int64(), nil
+}
+
+// centavosDe converte o resultado exato de uma operação, recusando o que não cabe em int64
+func centavosDe(valor *big.Int) (int64, error) {
+	if !valor.IsInt64() {
+		return 0, ErrValorMonetarioExcedido
+	}
+	return valor.Int64(), nil
+}
+
+func (m Money) moeda() string {
+	if m.Moeda == "" {
+		return BRL
+	}
+	return m.Moeda
+}
+
+func (m Money) mesmaMoeda(outro Money) error {
+	if m.moeda() != outro.moeda() {
+		return fmt.Errorf("%w: %s e %s", ErrMoedasDiferentes, m.moeda(), outro.moeda())
+	}
+	return nil
+}
+
+// Add soma dois valores da mesma moeda
+func (m Money) Add(outro Money) (Money, error) {
+	if err := m.mesmaMoeda(outro); err != nil {
+		return Money{}, err
+	}
+	centavos, err := centavosDe(new(big.Int).Add(big.NewInt(m.Centavos), big.NewInt(outro.Centavos)))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Centavos: centavos, Moeda: m.moeda()}, nil
+}
+
+// Sub subtrai dois valores da mesma moeda
+func (m Money) Sub(outro Money) (Money, error) {
+	if err := m.mesmaMoeda(outro); err != nil {
+		return Money{}, err
+	}
+	centavos, err := centavosDe(new(big.Int).Sub(big.NewInt(m.Centavos), big.NewInt(outro.Centavos)))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Centavos: centavos, Moeda: m.moeda()}, nil
+}
+
+// Mul multiplica o valor por uma quantidade inteira
+func (m Money) Mul(quantidade int) (Money, error) {
+	centavos, err := centavosDe(new(big.Int).Mul(big.NewInt(m.Centavos), big.NewInt(int64(quantidade))))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Centavos: centavos, Moeda: m.moeda()}, nil
+}
+
+// Porcentagem retorna o percentual do valor, arredondado pela regra única de centavos
+func (m Money) Porcentagem(percentual int) (Money, error) {
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.Centavos), big.NewInt(int64(percentual))), big.NewInt(100))
+	centavos, err := arredondarCentavos(r)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Centavos: centavos, Moeda: m.moeda()}, nil
+}
+
+// IsZero informa se o valor é zero
+func (m Money) IsZero() bool {
+	return m.Centavos == 0
+}
+
+// IsPositive informa se o valor é maior que zero
+func (m Money) IsPositive() bool {
+	return m.Centavos > 0
+}
+
+// Float64 retorna o valor em reais; use apenas para apresentação, nunca para cálculos
+func (m Money) Float64() float64 {
+	return float64(m.Centavos) / 100
+}
+
+// String retorna o valor com duas casas decimais, ex: "25.90"
+func (m Money) String() string {
+	sinal := ""
+	centavos := m.Centavos
+	if centavos < 0 {
+		sinal = "-"
+		centavos = -centavos
+	}
+	return fmt.Sprintf("%s%d.%02d", sinal, centavos/100, centavos%100)
+}
+
+// moneyJSON é a forma serializada de valores em outra moeda que não o real
+type moneyJSON struct {
+	Valor json.Number `json:"valor"`
+	Moeda string      `json:"moeda"`
+}
+
+// MarshalJSON serializa valores em reais como número decimal com duas casas, ex: 25.90, e valores
+// em outra moeda como objeto com a moeda, ex: {"valor":25.90,"moeda":"USD"}
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.moeda() == BRL {
+		return []byte(m.String()), nil
+	}
+	return json.Marshal(moneyJSON{Valor: json.Number(m.String()), Moeda: m.Moeda})
+}
+
+// UnmarshalJSON aceita o valor em reais como número (25.9) ou texto ("25.90"), ou o objeto com a moeda
+func (m *Money) UnmarshalJSON(data []byte) error {
+	texto := string(data)
+	if texto == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(texto, "{") {
+		var objeto moneyJSON
+		if err := json.Unmarshal(data, &objeto); err != nil {
+			return err
+		}
+		valor, err := MoneyParse(objeto.Valor.String())
+		if err != nil {
+			return err
+		}
+		if objeto.Moeda != "" {
+			valor.Moeda = objeto.Moeda
+		}
+		*m = valor
+		return nil
+	}
+
+	if strings.HasPrefix(texto, `"`) {
+		if err := json.Unmarshal(data, &texto); err != nil {
+			return err
+		}
+	}
+
+	valor, err := MoneyParse(texto)
+	if err != nil {
+		return err
+	}
+	*m = valor
+	return nil
+}
+
+// Value grava o valor em colunas DECIMAL(10,2), que guardam apenas reais; um valor em outra moeda
+// é recusado em vez de ser gravado como se fosse em reais
+func (m Money) Value() (driver.Value, error) {
+	if m.moeda() != BRL {
+		return nil, fmt.Errorf("%w: a coluna guarda apenas %s, recebeu %s", ErrMoedasDiferentes, BRL, m.Moeda)
+	}
+	return m.String(), nil
+}
+
+// Scan lê o valor de colunas DECIMAL (texto) ou numéricas
+func (m *Money) Scan(src interface{}) error {
+	var valor Money
+	var err error
+
+	switch v := src.(type) {
+	case []byte:
+		valor, err = MoneyParse(string(v))
+	case string:
+		valor, err = MoneyParse(v)
+	case float64:
+		valor, err = reaisDe(v)
+	case float32:
+		valor, err = reaisDe(float64(v))
+	case int64:
+		valor, err = Centavos(v).Mul(100)
+	case nil:
+		valor = Centavos(0)
+	default:
+		return fmt.Errorf("tipo não suportado para valor monetário: %T", src)
+	}
+
+	if err != nil {
+		return err
+	}
+	*m = valor
+	return nil
+}
@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReais_Arredondamento(t *testing.T) {
+	tests := []struct {
+		name     string
+		valor    float64
+		centavos int64
+	}{
+		{"Valor exato", 25.90, 2590},
+		{"Inteiro", 6, 600},
+		{"Meio centavo arredonda para cima", 0.285, 29},
+		{"Abaixo de meio centavo arredonda para baixo", 0.284, 28},
+		{"Meio centavo negativo arredonda para longe do zero", -0.285, -29},
+		{"Float32 impreciso", float64(float32(19.99)), 1999},
+		{"Zero", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, Centavos(tt.centavos), Reais(tt.valor))
+		})
+	}
+}
+
+func TestMoneyParse(t *testing.T) {
+	m, err := MoneyParse("22.50")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2250), m.Centavos)
+	assert.Equal(t, BRL, m.Moeda)
+
+	m, err = MoneyParse("0.005")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), m.Centavos)
+
+	_, err = MoneyParse("-16.50")
+	assert.NoError(t, err)
+
+	// Só decimais simples: big.Rat aceitaria frações e notação científica
+	for _, invalido := range []string{"abc", "1/3", "1e3", "0x10", "1.", ".5", "1,50", ""} {
+		_, err = MoneyParse(invalido)
+		assert.Error(t, err, invalido)
+	}
+}
+
+func TestReais_ValorNaoRepresentavel(t *testing.T) {
+	assert.Panics(t, func() { Reais(math.NaN()) })
+	assert.Panics(t, func() { Reais(math.Inf(1)) })
+	assert.Panics(t, func() { Reais(1e30) })
+}
+
+// semErro devolve o resultado da operação monetária, falhando o teste se ela devolveu erro
+func semErro(t *testing.T) func(Money, error) Money {
+	return func(m Money, err error) Money {
+		t.Helper()
+		assert.NoError(t, err)
+		return m
+	}
+}
+
+func TestMoney_SemErroDeArredondamentoNaSoma(t *testing.T) {
+	valor := semErro(t)
+	// Em float32, somar 0.1 dez vezes não resulta exatamente em 1.0
+	total := Centavos(0)
+	for i := 0; i < 10; i++ {
+		total = valor(total.Add(Reais(0.1)))
+	}
+
+	assert.Equal(t, Reais(1.0), total)
+	assert.Equal(t, "1.00", total.String())
+}
+
+func TestMoney_Operacoes(t *testing.T) {
+	valor := semErro(t)
+	preco := Reais(6.00)
+
+	assert.Equal(t, Reais(18.00), valor(preco.Mul(3)))
+	assert.Equal(t, Reais(28.50), valor(preco.Add(Reais(22.50))))
+	assert.Equal(t, Reais(-16.50), valor(preco.Sub(Reais(22.50))))
+	assert.True(t, preco.IsPositive())
+	assert.False(t, Centavos(0).IsPositive())
+	assert.True(t, Money{}.IsZero())
+	assert.Equal(t, "-16.50", valor(preco.Sub(Reais(22.50))).String())
+}
+
+func TestMoney_Porcentagem(t *testing.T) {
+	valor := semErro(t)
+	assert.Equal(t, Reais(2.25), valor(Reais(22.50).Porcentagem(10)))
+	assert.Equal(t, Reais(0.01), valor(Reais(0.05).Porcentagem(15)), "0,0075 arredonda para 0,01")
+	assert.Equal(t, Reais(0.29), valor(Reais(1.90).Porcentagem(15)), "0,285 arredonda para longe do zero")
+	assert.Equal(t, Reais(22.50), valor(Reais(22.50).Porcentagem(100)))
+}
+
+func TestMoney_MoedasDiferentes(t *testing.T) {
+	usd := Money{Centavos: 100, Moeda: "USD"}
+
+	_, err := Reais(1).Add(usd)
+	assert.ErrorIs(t, err, ErrMoedasDiferentes)
+	_, err = Reais(1).Sub(usd)
+	assert.ErrorIs(t, err, ErrMoedasDiferentes)
+}
+
+func TestMoney_Overflow(t *testing.T) {
+	valor := semErro(t)
+	maximo := Centavos(math.MaxInt64)
+
+	_, err := maximo.Add(Centavos(1))
+	assert.ErrorIs(t, err, ErrValorMonetarioExcedido)
+	_, err = Centavos(math.MinInt64).Sub(Centavos(1))
+	assert.ErrorIs(t, err, ErrValorMonetarioExcedido)
+	_, err = maximo.Mul(2)
+	assert.ErrorIs(t, err, ErrValorMonetarioExcedido)
+	_, err = maximo.Porcentagem(200)
+	assert.ErrorIs(t, err, ErrValorMonetarioExcedido)
+	assert.Equal(t, Centavos(math.MaxInt64/2+1), valor(maximo.Porcentagem(50)), "o produto intermediário não transborda")
+
+	_, err = MoneyParse("92233720368547758.08")
+	assert.ErrorIs(t, err, ErrValorMonetarioExcedido)
+	_, err = MoneyParse("1000000000000000000000000000000")
+	assert.ErrorIs(t, err, ErrValorMonetarioExcedido)
+}
+
+func TestMoney_JSON(t *testing.T) {
+	body, err := json.Marshal(struct {
+		Preco Money `json:"preco"`
+	}{Reais(25.9)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"preco":25.90}`, string(body))
+
+	var payload struct {
+		Numero Money `json:"numero"`
+		Texto  Money `json:"texto"`
+	}
+	err = json.Unmarshal([]byte(`{"numero": 19.99, "texto": "5.5"}`), &payload)
+	assert.NoError(t, err)
+	assert.Equal(t, Reais(19.99), payload.Numero)
+	assert.Equal(t, Reais(5.50), payload.Texto)
+}
+
+func TestMoney_JSONOutraMoeda(t *testing.T) {
+	usd := Money{Centavos: 1250, Moeda: "USD"}
+
+	body, err := json.Marshal(struct {
+		Preco Money `json:"preco"`
+	}{usd})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"preco":{"valor":12.50,"moeda":"USD"}}`, string(body))
+
+	var payload struct {
+		Preco Money `json:"preco"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, usd, payload.Preco)
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var m Money
+
+	assert.NoError(t, m.Scan([]byte("22.50")))
+	assert.Equal(t, Reais(22.50), m)
+
+	assert.NoError(t, m.Scan(float64(6)))
+	assert.Equal(t, Reais(6), m)
+
+	assert.Error(t, m.Scan(true))
+	assert.ErrorIs(t, m.Scan(float64(1e30)), ErrValorMonetarioExcedido, "valor lido do banco devolve erro em vez de pânico")
+	assert.Error(t, m.Scan(math.NaN()))
+
+	gravado, err := Reais(12.5).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "12.50", gravado)
+
+	// As colunas DECIMAL guardam apenas reais; outra moeda não é gravada como se fosse real
+	_, err = Money{Centavos: 1250, Moeda: "USD"}.Value()
+	assert.ErrorIs(t, err, ErrMoedasDiferentes)
+}
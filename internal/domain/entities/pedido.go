@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -14,37 +15,254 @@ const (
 	EmPreparacao StatusPedido = "Em preparação"
 	Pronto       StatusPedido = "Pronto"
 	Finalizado   StatusPedido = "Finalizado"
+	Cancelado    StatusPedido = "Cancelado"
 )
 
+// transicoesStatus define, para cada status, para quais status o pedido pode avançar
+var transicoesStatus = map[StatusPedido][]StatusPedido{
+	Pendente:     {Recebido, Cancelado},
+	Recebido:     {EmPreparacao, Cancelado},
+	EmPreparacao: {Pronto, Cancelado},
+	Pronto:       {Finalizado},
+	Finalizado:   {},
+	Cancelado:    {},
+}
+
+var (
+	ErrStatusInvalido      = errors.New("status inválido")
+	ErrPedidoNaoEncontrado = errors.New("pedido não encontrado")
+	// ErrConflitoStatus indica que o status do pedido mudou entre a leitura e a gravação
+	ErrConflitoStatus = errors.New("o status do pedido foi alterado por outra operação, consulte o pedido e tente novamente")
+)
+
+// TransicaoStatusError indica uma mudança de status não permitida pela máquina de estados do pedido
+type TransicaoStatusError struct {
+	De   StatusPedido
+	Para StatusPedido
+}
+
+func (e *TransicaoStatusError) Error() string {
+	return fmt.Sprintf("transição de status inválida: %s → %s", e.De, e.Para)
+}
+
+// PodeTransitar informa se um pedido no status atual pode avançar para o novo status
+func (s StatusPedido) PodeTransitar(novo StatusPedido) bool {
+	for _, permitido := range transicoesStatus[s] {
+		if permitido == novo {
+			return true
+		}
+	}
+	return false
+}
+
+// ExigePagamento informa se o status só pode ser atingido com o pagamento confirmado
+func (s StatusPedido) ExigePagamento() bool {
+	switch s {
+	case Recebido, EmPreparacao, Pronto, Finalizado:
+		return true
+	default:
+		return false
+	}
+}
+
+type StatusPagamento string
+
+const (
+	PagamentoPendente  StatusPagamento = "Pendente"
+	PagamentoPago      StatusPagamento = "Pago"
+	PagamentoRecusado  StatusPagamento = "Recusado"
+	PagamentoCancelado StatusPagamento = "Cancelado"
+)
+
+// transicoesPagamento define, para cada status de pagamento, para quais status ele pode avançar
+var transicoesPagamento = map[StatusPagamento][]StatusPagamento{
+	PagamentoPendente:  {PagamentoPago, PagamentoRecusado, PagamentoCancelado},
+	PagamentoPago:      {PagamentoCancelado},
+	PagamentoRecusado:  {},
+	PagamentoCancelado: {},
+}
+
+var (
+	ErrStatusPagamentoInvalido = errors.New("status de pagamento inválido")
+	ErrPagamentoNaoConfirmado  = errors.New("o pedido só pode avançar após a confirmação do pagamento")
+	// ErrPagamentoPedidoCancelado recusa a confirmação de um pagamento que chegou depois do cancelamento;
+	// o serviço de pagamento deve estorná-lo
+	ErrPagamentoPedidoCancelado = errors.New("o pedido está cancelado e não pode ser pago, o pagamento deve ser estornado")
+)
+
+// TransicaoPagamentoError indica uma mudança de status de pagamento não permitida
+type TransicaoPagamentoError struct {
+	De   StatusPagamento
+	Para StatusPagamento
+}
+
+func (e *TransicaoPagamentoError) Error() string {
+	return fmt.Sprintf("transição de status de pagamento inválida: %s → %s", e.De, e.Para)
+}
+
+// PodeTransitar informa se o pagamento no status atual pode avançar para o novo status
+func (s StatusPagamento) PodeTransitar(novo StatusPagamento) bool {
+	for _, permitido := range transicoesPagamento[s] {
+		if permitido == novo {
+			return true
+		}
+	}
+	return false
+}
+
+// CancelaPedido informa se o status de pagamento leva ao cancelamento do pedido
+func (s StatusPagamento) CancelaPedido() bool {
+	return s == PagamentoRecusado || s == PagamentoCancelado
+}
+
+// ItemPedido representa uma linha do pedido: um produto, seus modificadores e a quantidade pedida
+type ItemPedido struct {
+	Produto       Produto       `json:"produto"`
+	Quantidade    int           `json:"quantidade"`
+	Modificadores []Modificador `json:"modificadores,omitempty"`
+	Componentes   []ItemCombo   `json:"componentes,omitempty"` // Produtos que compõem a linha quando ela é um combo
+	PrecoUnitario Money         `json:"preco_unitario"`        // Preço do produto somado aos acréscimos dos modificadores
+	Subtotal      Money         `json:"subtotal"`
+}
+
+// QuantidadeMaximaPorItem limita as unidades de uma linha do pedido
+const QuantidadeMaximaPorItem = 99
+
+var ErrQuantidadeExcedida = fmt.Errorf("a quantidade de cada item deve ser no máximo %d", QuantidadeMaximaPorItem)
+
+// ItemPedidoNew cria uma linha de pedido validando modificadores e escolhas de combo e calculando preço unitário e subtotal
+func ItemPedidoNew(produto Produto, quantidade int, modificadores []Modificador, escolhas []ItemCombo) (*ItemPedido, error) {
+	if quantidade <= 0 {
+		return nil, errors.New("a quantidade de cada item deve ser maior que zero")
+	}
+	if quantidade > QuantidadeMaximaPorItem {
+		return nil, ErrQuantidadeExcedida
+	}
+
+	escolhidos, err := resolverModificadores(produto, modificadores)
+	if err != nil {
+		return nil, err
+	}
+
+	componentes, err := resolverComponentes(produto, escolhas)
+	if err != nil {
+		return nil, err
+	}
+
+	precoUnitario := produto.Preco
+	for _, modificador := range escolhidos {
+		if precoUnitario, err = precoUnitario.Add(modificador.Preco); err != nil {
+			return nil, fmt.Errorf("preço do item %s: %w", produto.Nome, err)
+		}
+	}
+
+	subtotal, err := precoUnitario.Mul(quantidade)
+	if err != nil {
+		return nil, fmt.Errorf("subtotal do item %s: %w", produto.Nome, err)
+	}
+
+	return &ItemPedido{
+		Produto:       produto,
+		Quantidade:    quantidade,
+		Modificadores: escolhidos,
+		Componentes:   componentes,
+		PrecoUnitario: precoUnitario,
+		Subtotal:      subtotal,
+	}, nil
+}
+
+// ContemCategoria informa se a linha é um produto da categoria ou um combo que contém um
+func (i ItemPedido) ContemCategoria(categoria CatProduto) bool {
+	if i.Produto.Categoria == categoria {
+		return true
+	}
+	for _, componente := range i.Componentes {
+		if componente.Produto.Categoria == categoria {
+			return true
+		}
+	}
+	return false
+}
+
 type Pedido struct {
-	ID                int          `json:"id,omitempty"`
-	ClienteNome       string       `json:"cliente_nome,omitempty"` // Opcional: apenas nome do cliente
-	Status            StatusPedido `json:"status"`
-	StatusPagamento   string       `json:"status_pagamento"`
-	TimeStamp         string       `json:"time_stamp"`
-	UltimaAtualizacao time.Time    `json:"ultima_atualizacao"`
-	Total             float32      `json:"total"`
-	Personalizacao    *string      `json:"personalizacao,omitempty"` // Personalização específica do pedido
-	Produtos          []Produto    `json:"produtos"`
-}
-
-func PedidoNew(clienteNome string, produtos []Produto, personalizacao *string) (*Pedido, error) {
-	fmt.Println("Pedido Entity: ", produtos)
-	if len(produtos) == 0 {
-		return nil, errors.New("o pedido precisa ter ao menos um produto")
-	}
-
-	temLanche := false
-	total := float32(0)
-	for _, produto := range produtos {
-		total += produto.Preco
-		if produto.Categoria == Lanche {
-			temLanche = true
+	ID                int             `json:"id,omitempty"`
+	ClienteNome       string          `json:"cliente_nome,omitempty"` // Opcional: apenas nome do cliente
+	Status            StatusPedido    `json:"status"`
+	StatusPagamento   StatusPagamento `json:"status_pagamento"`
+	TempoEstimado     time.Duration   `json:"tempo_estimado"`            // Tempo de preparo do próprio pedido, serializado como HH:MM:SS
+	PrevisaoPronto    *time.Time      `json:"previsao_pronto,omitempty"` // Quando deve ficar pronto, considerando a fila da cozinha
+	UltimaAtualizacao time.Time       `json:"ultima_atualizacao"`
+	Subtotal          Money           `json:"subtotal"` // Soma dos itens, antes do desconto
+	Desconto          Money           `json:"desconto"`
+	Total             Money           `json:"total"`                    // Subtotal menos o desconto
+	Cupom             *string         `json:"cupom,omitempty"`          // Código do cupom aplicado
+	Personalizacao    *string         `json:"personalizacao,omitempty"` // Personalização específica do pedido
+	Itens             []ItemPedido    `json:"itens"`
+	Cancelamento      *Cancelamento   `json:"cancelamento,omitempty"` // Preenchido quando o pedido é cancelado
+	// Alérgenos presentes nos produtos do pedido, para o cliente e a cozinha conferirem
+	AvisosAlergenos []AvisoAlergeno `json:"avisos_alergenos,omitempty"`
+	// Tempo que o pedido passou em cada status, calculado a partir do histórico
+	Etapas []EtapaPedido `json:"etapas,omitempty"`
+	// Cliente identificado pelo CPF ou pelo serviço de clientes; nil quando o cliente só informou o nome
+	Cliente *ReferenciaCliente `json:"cliente,omitempty"`
+	// Código curto chamado no balcão, como A-017; a sequência recomeça todo dia
+	CodigoRetirada string `json:"codigo_retirada,omitempty"`
+	// Quando o pedido foi feito; a listagem filtra e ordena os pedidos por esta data
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+// MarshalJSON serializa o tempo estimado como HH:MM:SS, o mesmo formato do tempo de preparo dos
+// produtos, no lugar dos nanossegundos de time.Duration
+func (p Pedido) MarshalJSON() ([]byte, error) {
+	type pedidoJSON Pedido
+	return json.Marshal(struct {
+		pedidoJSON
+		TempoEstimado string `json:"tempo_estimado"`
+	}{pedidoJSON(p), FormatarDuracao(p.TempoEstimado)})
+}
+
+// UnmarshalJSON lê o tempo estimado no formato HH:MM:SS que MarshalJSON escreve
+func (p *Pedido) UnmarshalJSON(data []byte) error {
+	type pedidoJSON Pedido
+	var lido struct {
+		pedidoJSON
+		TempoEstimado string `json:"tempo_estimado"`
+	}
+	if err := json.Unmarshal(data, &lido); err != nil {
+		return err
+	}
+
+	*p = Pedido(lido.pedidoJSON)
+	if lido.TempoEstimado != "" {
+		tempo, err := DuracaoParse(lido.TempoEstimado)
+		if err != nil {
+			return err
 		}
+		p.TempoEstimado = tempo
 	}
+	return nil
+}
+
+// PedidoNew monta o pedido com os itens escolhidos. A composição do pedido, como a quantidade de itens
+// e as categorias obrigatórias, é conferida depois pelas RegrasPedido da loja.
+func PedidoNew(clienteNome string, itens []ItemPedido, personalizacao *string) (*Pedido, error) {
+	fmt.Println("Pedido Entity: ", itens)
 
-	if !temLanche {
-		return nil, errors.New("o pedido precisa ter ao menos um lanche")
+	total := Centavos(0)
+	linhas := make([]ItemPedido, 0, len(itens))
+	for _, item := range itens {
+		linha, err := ItemPedidoNew(item.Produto, item.Quantidade, item.Modificadores, item.Componentes)
+		if err != nil {
+			return nil, err
+		}
+		if total, err = total.Add(linha.Subtotal); err != nil {
+			return nil, fmt.Errorf("total do pedido: %w", err)
+		}
+		linhas = append(linhas, *linha)
+	}
+
+	if err := verificarDisponibilidade(linhas); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
@@ -52,34 +270,80 @@ func PedidoNew(clienteNome string, produtos []Produto, personalizacao *string) (
 	return &Pedido{
 		ClienteNome:       clienteNome,
 		Status:            Pendente,
-		StatusPagamento:   "Pendente",
-		TimeStamp:         "00:15:00",
+		StatusPagamento:   PagamentoPendente,
+		TempoEstimado:     EstimarTempoPreparo(linhas),
 		UltimaAtualizacao: now,
+		CriadoEm:          now,
+		Subtotal:          total,
 		Total:             total,
 		Personalizacao:    personalizacao,
-		Produtos:          produtos,
+		Itens:             linhas,
+		AvisosAlergenos:   AvisosAlergenos(linhas),
 	}, nil
 }
 
+// AplicarCupom calcula o desconto do cupom sobre os itens e recalcula o total do pedido
+func (p *Pedido) AplicarCupom(cupom *Cupom, agora time.Time) error {
+	desconto, err := cupom.Desconto(p.Itens, p.Subtotal, agora)
+	if err != nil {
+		return err
+	}
+
+	total, err := p.Subtotal.Sub(desconto)
+	if err != nil {
+		return fmt.Errorf("total do pedido: %w", err)
+	}
+
+	codigo := cupom.Codigo
+	p.Cupom = &codigo
+	p.Desconto = desconto
+	p.Total = total
+	return nil
+}
+
 func (p *Pedido) UpdateStatus(status StatusPedido) error {
-	switch status {
-	case Pendente, Recebido, EmPreparacao, Pronto, Finalizado:
-		p.Status = status
-		p.UltimaAtualizacao = time.Now()
-		return nil
-	default:
-		return errors.New("status inválido")
+	if _, ok := transicoesStatus[status]; !ok {
+		return ErrStatusInvalido
+	}
+
+	if !p.Status.PodeTransitar(status) {
+		return &TransicaoStatusError{De: p.Status, Para: status}
 	}
+
+	p.Status = status
+	p.UltimaAtualizacao = time.Now()
+	return nil
 }
 
-func (p *Pedido) UpdateStatusPagamento(statusPagamento string) error {
-	// Validar os status de pagamento válidos
-	switch statusPagamento {
-	case "Pendente", "Pago", "Recusado", "Cancelado":
-		p.StatusPagamento = statusPagamento
-		p.UltimaAtualizacao = time.Now()
-		return nil
-	default:
-		return errors.New("status de pagamento inválido")
+// ProximosStatus retorna os status para os quais o pedido pode avançar pela troca de status. O
+// cancelamento tem rota própria e os status de cozinha só aparecem com o pagamento confirmado.
+func (p *Pedido) ProximosStatus() []StatusPedido {
+	proximos := make([]StatusPedido, 0, len(transicoesStatus[p.Status]))
+	for _, status := range transicoesStatus[p.Status] {
+		if status == Cancelado || (status.ExigePagamento() && p.StatusPagamento != PagamentoPago) {
+			continue
+		}
+		proximos = append(proximos, status)
+	}
+	return proximos
+}
+
+func (p *Pedido) UpdateStatusPagamento(statusPagamento StatusPagamento) error {
+	if _, ok := transicoesPagamento[statusPagamento]; !ok {
+		return ErrStatusPagamentoInvalido
 	}
+
+	// O cancelamento encerra o pagamento pendente, então a confirmação que chega depois dele é recusada
+	// antes da transição; um pedido cancelado já pago tem o reembolso tratado no próprio cancelamento
+	if statusPagamento == PagamentoPago && p.Status == Cancelado && p.StatusPagamento == PagamentoCancelado {
+		return ErrPagamentoPedidoCancelado
+	}
+
+	if !p.StatusPagamento.PodeTransitar(statusPagamento) {
+		return &TransicaoPagamentoError{De: p.StatusPagamento, Para: statusPagamento}
+	}
+
+	p.StatusPagamento = statusPagamento
+	p.UltimaAtualizacao = time.Now()
+	return nil
 }
@@ -8,46 +8,80 @@ import (
 )
 
 func TestPedidoNew_Success(t *testing.T) {
-	produtos := []Produto{
-		{ID: 1, Nome: "Big Mac", Categoria: Lanche, Preco: 25.0},
-		{ID: 2, Nome: "Coca Cola", Categoria: Bebida, Preco: 5.0},
+	itens := []ItemPedido{
+		{Produto: Produto{ID: 1, Nome: "Big Mac", Categoria: Lanche, Preco: Reais(25.0)}, Quantidade: 1},
+		{Produto: Produto{ID: 2, Nome: "Coca Cola", Categoria: Bebida, Preco: Reais(5.0)}, Quantidade: 1},
 	}
 	personalizacao := "Sem cebola"
 
-	pedido, err := PedidoNew("João Silva", produtos, &personalizacao)
+	pedido, err := PedidoNew("João Silva", itens, &personalizacao)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, pedido)
 	assert.Equal(t, "João Silva", pedido.ClienteNome)
 	assert.Equal(t, Pendente, pedido.Status)
-	assert.Equal(t, "Pendente", pedido.StatusPagamento)
-	assert.Equal(t, float32(30.0), pedido.Total)
+	assert.Equal(t, PagamentoPendente, pedido.StatusPagamento)
+	assert.Equal(t, Reais(30.0), pedido.Total)
 	assert.Equal(t, &personalizacao, pedido.Personalizacao)
-	assert.Equal(t, produtos, pedido.Produtos)
+	assert.Len(t, pedido.Itens, 2)
+	assert.Equal(t, Reais(25.0), pedido.Itens[0].PrecoUnitario)
+	assert.Equal(t, Reais(25.0), pedido.Itens[0].Subtotal)
 	assert.NotZero(t, pedido.UltimaAtualizacao)
 }
 
-func TestPedidoNew_ErrorSemProdutos(t *testing.T) {
-	produtos := []Produto{}
+func TestPedidoNew_ItemComQuantidade(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: Produto{ID: 1, Nome: "X-Salada", Categoria: Lanche, Preco: Reais(22.5)}, Quantidade: 1},
+		{Produto: Produto{ID: 2, Nome: "Coca-cola", Categoria: Bebida, Preco: Reais(6.0)}, Quantidade: 3},
+	}
 
-	pedido, err := PedidoNew("João Silva", produtos, nil)
+	pedido, err := PedidoNew("João Silva", itens, nil)
 
-	assert.Error(t, err)
-	assert.Nil(t, pedido)
-	assert.Equal(t, "o pedido precisa ter ao menos um produto", err.Error())
+	assert.NoError(t, err)
+	assert.Len(t, pedido.Itens, 2)
+	assert.Equal(t, 3, pedido.Itens[1].Quantidade)
+	assert.Equal(t, Reais(6.0), pedido.Itens[1].PrecoUnitario)
+	assert.Equal(t, Reais(18.0), pedido.Itens[1].Subtotal)
+	assert.Equal(t, Reais(40.5), pedido.Total)
 }
 
-func TestPedidoNew_ErrorSemLanche(t *testing.T) {
-	produtos := []Produto{
-		{ID: 1, Nome: "Coca Cola", Categoria: Bebida, Preco: 5.0},
-		{ID: 2, Nome: "Batata Frita", Categoria: Acompanhamento, Preco: 8.0},
+func TestPedidoNew_ErrorQuantidadeInvalida(t *testing.T) {
+	tests := []struct {
+		name       string
+		quantidade int
+	}{
+		{"Quantidade zero", 0},
+		{"Quantidade negativa", -2},
 	}
 
-	pedido, err := PedidoNew("João Silva", produtos, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itens := []ItemPedido{
+				{Produto: Produto{ID: 1, Nome: "X-Salada", Categoria: Lanche, Preco: Reais(22.5)}, Quantidade: tt.quantidade},
+			}
+
+			pedido, err := PedidoNew("João Silva", itens, nil)
+
+			assert.Error(t, err)
+			assert.Nil(t, pedido)
+			assert.Equal(t, "a quantidade de cada item deve ser maior que zero", err.Error())
+		})
+	}
+}
 
-	assert.Error(t, err)
+func TestPedidoNew_QuantidadeExcedida(t *testing.T) {
+	itens := []ItemPedido{
+		{Produto: Produto{ID: 1, Nome: "X-Salada", Categoria: Lanche, Preco: Reais(22.5)}, Quantidade: QuantidadeMaximaPorItem + 1},
+	}
+
+	pedido, err := PedidoNew("João Silva", itens, nil)
+
+	assert.ErrorIs(t, err, ErrQuantidadeExcedida)
 	assert.Nil(t, pedido)
-	assert.Equal(t, "o pedido precisa ter ao menos um lanche", err.Error())
+
+	itens[0].Quantidade = QuantidadeMaximaPorItem
+	_, err = PedidoNew("João Silva", itens, nil)
+	assert.NoError(t, err)
 }
 
 func TestPedido_UpdateStatus_Success(t *testing.T) {
@@ -67,7 +101,7 @@ func TestPedido_UpdateStatus_Success(t *testing.T) {
 func TestPedido_UpdateStatus_AllValidStatuses(t *testing.T) {
 	pedido := &Pedido{Status: Pendente}
 
-	validStatuses := []StatusPedido{Pendente, Recebido, EmPreparacao, Pronto, Finalizado}
+	validStatuses := []StatusPedido{Recebido, EmPreparacao, Pronto, Finalizado}
 
 	for _, status := range validStatuses {
 		err := pedido.UpdateStatus(status)
@@ -76,48 +110,173 @@ func TestPedido_UpdateStatus_AllValidStatuses(t *testing.T) {
 	}
 }
 
+func TestPedido_UpdateStatus_Cancelamento(t *testing.T) {
+	for _, atual := range []StatusPedido{Pendente, Recebido, EmPreparacao} {
+		pedido := &Pedido{Status: atual}
+
+		err := pedido.UpdateStatus(Cancelado)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Cancelado, pedido.Status)
+	}
+}
+
+func TestPedido_UpdateStatus_TransicaoInvalida(t *testing.T) {
+	tests := []struct {
+		name  string
+		atual StatusPedido
+		novo  StatusPedido
+	}{
+		{"Finalizado para Pendente", Finalizado, Pendente},
+		{"Pendente para Pronto", Pendente, Pronto},
+		{"Recebido para Recebido", Recebido, Recebido},
+		{"Pronto para Em preparação", Pronto, EmPreparacao},
+		{"Pronto para Cancelado", Pronto, Cancelado},
+		{"Cancelado para Recebido", Cancelado, Recebido},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pedido := &Pedido{Status: tt.atual}
+
+			err := pedido.UpdateStatus(tt.novo)
+
+			var transicaoErr *TransicaoStatusError
+			assert.ErrorAs(t, err, &transicaoErr)
+			assert.Equal(t, tt.atual, transicaoErr.De)
+			assert.Equal(t, tt.novo, transicaoErr.Para)
+			assert.Equal(t, tt.atual, pedido.Status) // Status não deve mudar
+		})
+	}
+}
+
+func TestPedido_ProximosStatus(t *testing.T) {
+	tests := []struct {
+		atual     StatusPedido
+		pagamento StatusPagamento
+		proximos  []StatusPedido
+	}{
+		{Pendente, PagamentoPago, []StatusPedido{Recebido}},
+		{Pendente, PagamentoPendente, []StatusPedido{}},
+		{Recebido, PagamentoPago, []StatusPedido{EmPreparacao}},
+		{EmPreparacao, PagamentoPago, []StatusPedido{Pronto}},
+		{Pronto, PagamentoPago, []StatusPedido{Finalizado}},
+		{Finalizado, PagamentoPago, []StatusPedido{}},
+		{Cancelado, PagamentoCancelado, []StatusPedido{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.atual)+"/"+string(tt.pagamento), func(t *testing.T) {
+			pedido := &Pedido{Status: tt.atual, StatusPagamento: tt.pagamento}
+
+			assert.Equal(t, tt.proximos, pedido.ProximosStatus())
+		})
+	}
+}
+
 func TestPedido_UpdateStatus_InvalidStatus(t *testing.T) {
 	pedido := &Pedido{Status: Pendente}
 
 	err := pedido.UpdateStatus(StatusPedido("StatusInvalido"))
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrStatusInvalido)
 	assert.Equal(t, "status inválido", err.Error())
 	assert.Equal(t, Pendente, pedido.Status) // Status não deve mudar
 }
 
 func TestPedido_UpdateStatusPagamento_Success(t *testing.T) {
 	pedido := &Pedido{
-		StatusPagamento:   "Pendente",
+		StatusPagamento:   PagamentoPendente,
 		UltimaAtualizacao: time.Now().Add(-time.Hour),
 	}
 	oldTime := pedido.UltimaAtualizacao
 
-	err := pedido.UpdateStatusPagamento("Pago")
+	err := pedido.UpdateStatusPagamento(PagamentoPago)
 
 	assert.NoError(t, err)
-	assert.Equal(t, "Pago", pedido.StatusPagamento)
+	assert.Equal(t, PagamentoPago, pedido.StatusPagamento)
 	assert.True(t, pedido.UltimaAtualizacao.After(oldTime))
 }
 
 func TestPedido_UpdateStatusPagamento_AllValidStatuses(t *testing.T) {
-	pedido := &Pedido{StatusPagamento: "Pendente"}
-
-	validStatuses := []string{"Pendente", "Pago", "Recusado", "Cancelado"}
+	validStatuses := []StatusPagamento{PagamentoPago, PagamentoRecusado, PagamentoCancelado}
 
 	for _, status := range validStatuses {
+		pedido := &Pedido{StatusPagamento: PagamentoPendente}
+
 		err := pedido.UpdateStatusPagamento(status)
 		assert.NoError(t, err)
 		assert.Equal(t, status, pedido.StatusPagamento)
 	}
 }
 
+func TestPedido_UpdateStatusPagamento_TransicaoInvalida(t *testing.T) {
+	tests := []struct {
+		name  string
+		atual StatusPagamento
+		novo  StatusPagamento
+	}{
+		{"Pago para Pendente", PagamentoPago, PagamentoPendente},
+		{"Pago para Recusado", PagamentoPago, PagamentoRecusado},
+		{"Recusado para Pago", PagamentoRecusado, PagamentoPago},
+		{"Cancelado para Pago", PagamentoCancelado, PagamentoPago},
+		{"Pendente para Pendente", PagamentoPendente, PagamentoPendente},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pedido := &Pedido{StatusPagamento: tt.atual}
+
+			err := pedido.UpdateStatusPagamento(tt.novo)
+
+			var transicaoErr *TransicaoPagamentoError
+			assert.ErrorAs(t, err, &transicaoErr)
+			assert.Equal(t, tt.atual, pedido.StatusPagamento) // Status não deve mudar
+		})
+	}
+}
+
+func TestPedido_UpdateStatusPagamento_PedidoCancelado(t *testing.T) {
+	pedido := &Pedido{Status: Pendente, StatusPagamento: PagamentoPendente}
+	if err := pedido.Cancelar(CanceladoPeloCliente, "desisti", time.Now()); err != nil {
+		t.Fatalf("erro ao cancelar o pedido: %v", err)
+	}
+
+	err := pedido.UpdateStatusPagamento(PagamentoPago)
+
+	assert.ErrorIs(t, err, ErrPagamentoPedidoCancelado)
+	assert.Equal(t, PagamentoCancelado, pedido.StatusPagamento)
+}
+
+func TestPedido_UpdateStatusPagamento_PedidoPagoCancelado(t *testing.T) {
+	pedido := &Pedido{Status: Recebido, StatusPagamento: PagamentoPago}
+	if err := pedido.Cancelar(CanceladoPelaLoja, "faltou pão", time.Now()); err != nil {
+		t.Fatalf("erro ao cancelar o pedido: %v", err)
+	}
+
+	// A confirmação repetida de um pagamento já reembolsado pelo cancelamento não pede outro estorno
+	err := pedido.UpdateStatusPagamento(PagamentoPago)
+
+	var transicaoErr *TransicaoPagamentoError
+	assert.ErrorAs(t, err, &transicaoErr)
+	assert.Equal(t, PagamentoPago, pedido.StatusPagamento)
+}
+
 func TestPedido_UpdateStatusPagamento_InvalidStatus(t *testing.T) {
-	pedido := &Pedido{StatusPagamento: "Pendente"}
+	pedido := &Pedido{StatusPagamento: PagamentoPendente}
 
 	err := pedido.UpdateStatusPagamento("StatusInvalido")
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrStatusPagamentoInvalido)
 	assert.Equal(t, "status de pagamento inválido", err.Error())
-	assert.Equal(t, "Pendente", pedido.StatusPagamento) // Status não deve mudar
+	assert.Equal(t, PagamentoPendente, pedido.StatusPagamento) // Status não deve mudar
+}
+
+func TestStatusPedido_ExigePagamento(t *testing.T) {
+	assert.False(t, Pendente.ExigePagamento())
+	assert.False(t, Cancelado.ExigePagamento())
+	assert.True(t, Recebido.ExigePagamento())
+	assert.True(t, EmPreparacao.ExigePagamento())
+	assert.True(t, Pronto.ExigePagamento())
+	assert.True(t, Finalizado.ExigePagamento())
 }
@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AcrescimoPorUnidade é o tempo somado ao preparo de uma linha para cada unidade além da primeira
+const AcrescimoPorUnidade = time.Minute
+
+// DefinirTempoPreparo define o tempo de preparo do produto em minutos; zero mantém o tempo atual,
+// que na criação é o padrão da categoria
+func (p *Produto) DefinirTempoPreparo(minutos int) error {
+	if minutos < 0 {
+		return errors.New("o tempo de preparo não pode ser negativo")
+	}
+	if minutos > 0 {
+		p.TempoPreparoMinutos = minutos
+	}
+	return nil
+}
+
+// TempoPreparo retorna o tempo de preparo de uma unidade do produto
+func (p Produto) TempoPreparo() time.Duration {
+	return time.Duration(p.TempoPreparoMinutos) * time.Minute
+}
+
+// tempoDeUnidades é o tempo para preparar várias unidades de um produto: as unidades saem juntas,
+// com um pequeno acréscimo por unidade extra
+func tempoDeUnidades(produto Produto, quantidade int) time.Duration {
+	if quantidade <= 0 {
+		return 0
+	}
+	return produto.TempoPreparo() + time.Duration(quantidade-1)*AcrescimoPorUnidade
+}
+
+// TempoPreparo retorna o tempo de preparo da linha; um combo leva o tempo do seu componente mais demorado
+func (i ItemPedido) TempoPreparo() time.Duration {
+	if len(i.Componentes) == 0 {
+		return tempoDeUnidades(i.Produto, i.Quantidade)
+	}
+
+	var maior time.Duration
+	for _, componente := range i.Componentes {
+		if t := tempoDeUnidades(componente.Produto, componente.Quantidade*i.Quantidade); t > maior {
+			maior = t
+		}
+	}
+	return maior
+}
+
+// EstimarTempoPreparo estima o preparo de um pedido: as linhas são preparadas em paralelo,
+// então o pedido fica pronto quando a linha mais demorada fica pronta
+func EstimarTempoPreparo(itens []ItemPedido) time.Duration {
+	var maior time.Duration
+	for _, item := range itens {
+		if t := item.TempoPreparo(); t > maior {
+			maior = t
+		}
+	}
+	return maior
+}
+
+// NaFilaDaCozinha informa se o pedido no status ocupa a cozinha
+func (s StatusPedido) NaFilaDaCozinha() bool {
+	return s == Recebido || s == EmPreparacao
+}
+
+// RecalcularFila atualiza a previsão de cada pedido na fila da cozinha, atendidos um de cada vez
+// por ordem de chegada, e retorna quando a cozinha fica livre. Pedidos já em preparação
+// mantêm a previsão que ainda não venceu.
+func RecalcularFila(fila []*Pedido, agora time.Time) time.Time {
+	ordenada := make([]*Pedido, 0, len(fila))
+	for _, p := range fila {
+		if p.Status.NaFilaDaCozinha() {
+			ordenada = append(ordenada, p)
+		}
+	}
+
+	// Quem já está em preparação vem antes de quem apenas foi recebido
+	sort.SliceStable(ordenada, func(i, j int) bool {
+		if (ordenada[i].Status == EmPreparacao) != (ordenada[j].Status == EmPreparacao) {
+			return ordenada[i].Status == EmPreparacao
+		}
+		return ordenada[i].ID < ordenada[j].ID
+	})
+
+	livre := agora
+	for _, p := range ordenada {
+		if p.Status == EmPreparacao && p.PrevisaoPronto != nil && p.PrevisaoPronto.After(livre) {
+			livre = *p.PrevisaoPronto
+			continue
+		}
+		previsao := livre.Add(p.TempoEstimado)
+		p.PrevisaoPronto = &previsao
+		livre = previsao
+	}
+	return livre
+}
+
+// PreverPronto calcula a previsão do pedido como se ele entrasse agora no fim da fila da cozinha
+func (p *Pedido) PreverPronto(fila []*Pedido, agora time.Time) {
+	previsao := RecalcularFila(fila, agora).Add(p.TempoEstimado)
+	p.PrevisaoPronto = &previsao
+}
+
+// FormatarDuracao formata a duração como HH:MM:SS, ex: 17 minutos → "00:17:00"
+func FormatarDuracao(d time.Duration) string {
+	segundos := int64(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", segundos/3600, segundos%3600/60, segundos%60)
+}
+
+// DuracaoParse converte uma duração no formato HH:MM:SS
+func DuracaoParse(valor string) (time.Duration, error) {
+	var h, m, s int
+	if _, err := fmt.Sscanf(valor, "%d:%d:%d", &h, &m, &s); err != nil {
+		return 0, fmt.Errorf("duração inválida: %q", valor)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, nil
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduto_TempoPreparo(t *testing.T) {
+	assert.Equal(t, 10*time.Minute, xSalada.TempoPreparo())
+
+	personalizado := xSalada
+	personalizado.TempoPreparoMinutos = 14
+	assert.Equal(t, 14*time.Minute, personalizado.TempoPreparo())
+
+	produto, err := ProdutoNew("Suco", categoriaDoCardapio(Bebida), "Suco natural", Reais(8))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, produto.TempoPreparoMinutos, "sem tempo informado usa o padrão da categoria")
+
+	assert.NoError(t, produto.DefinirTempoPreparo(4))
+	assert.Equal(t, 4, produto.TempoPreparoMinutos)
+	assert.NoError(t, produto.DefinirTempoPreparo(0))
+	assert.Equal(t, 4, produto.TempoPreparoMinutos, "zero mantém o tempo atual")
+	assert.Error(t, produto.DefinirTempoPreparo(-1))
+}
+
+func TestItemPedido_TempoPreparo(t *testing.T) {
+	item, err := ItemPedidoNew(xSalada, 3, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 12*time.Minute, item.TempoPreparo(), "10 minutos mais 1 por unidade extra")
+
+	combo, err := ItemPedidoNew(comboXSalada(), 2, nil, []ItemCombo{{Produto: cocaCola, Quantidade: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, 11*time.Minute, combo.TempoPreparo(), "combo leva o tempo do X-Salada, o componente mais demorado")
+}
+
+func TestPedidoNew_TempoEstimado(t *testing.T) {
+	pedido, err := PedidoNew("Maria", []ItemPedido{
+		{Produto: xSalada, Quantidade: 1},
+		{Produto: batataFrita, Quantidade: 1},
+		{Produto: mousse, Quantidade: 2},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, pedido.TempoEstimado, "itens são preparados em paralelo")
+}
+
+func TestPedido_MarshalJSON_TempoEstimado(t *testing.T) {
+	pedido := Pedido{ID: 1, Status: Recebido, TempoEstimado: 17 * time.Minute}
+
+	dados, err := json.Marshal(&pedido)
+
+	assert.NoError(t, err)
+	var serializado map[string]any
+	assert.NoError(t, json.Unmarshal(dados, &serializado))
+	assert.Equal(t, "00:17:00", serializado["tempo_estimado"])
+	assert.Equal(t, "Recebido", serializado["status"])
+
+	var lido Pedido
+	assert.NoError(t, json.Unmarshal(dados, &lido))
+	assert.Equal(t, pedido.TempoEstimado, lido.TempoEstimado)
+	assert.Equal(t, Recebido, lido.Status)
+}
+
+func TestRecalcularFila(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	emPreparo := agora.Add(4 * time.Minute)
+
+	fila := []*Pedido{
+		{ID: 3, Status: Recebido, TempoEstimado: 10 * time.Minute},
+		{ID: 2, Status: Recebido, TempoEstimado: 6 * time.Minute},
+		{ID: 1, Status: EmPreparacao, TempoEstimado: 10 * time.Minute, PrevisaoPronto: &emPreparo},
+		{ID: 4, Status: Pronto, TempoEstimado: 5 * time.Minute},
+	}
+
+	livre := RecalcularFila(fila, agora)
+
+	assert.Equal(t, emPreparo, *fila[2].PrevisaoPronto, "pedido em preparação mantém a previsão")
+	assert.Equal(t, agora.Add(10*time.Minute), *fila[1].PrevisaoPronto)
+	assert.Equal(t, agora.Add(20*time.Minute), *fila[0].PrevisaoPronto)
+	assert.Nil(t, fila[3].PrevisaoPronto, "pedido pronto não ocupa a cozinha")
+	assert.Equal(t, agora.Add(20*time.Minute), livre)
+}
+
+func TestRecalcularFila_PrevisaoVencida(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	vencida := agora.Add(-time.Minute)
+
+	fila := []*Pedido{{ID: 1, Status: EmPreparacao, TempoEstimado: 8 * time.Minute, PrevisaoPronto: &vencida}}
+	RecalcularFila(fila, agora)
+
+	assert.Equal(t, agora.Add(8*time.Minute), *fila[0].PrevisaoPronto)
+}
+
+func TestPedido_PreverPronto(t *testing.T) {
+	agora := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fila := []*Pedido{{ID: 1, Status: Recebido, TempoEstimado: 10 * time.Minute}}
+
+	pedido := &Pedido{Status: Pendente, TempoEstimado: 3 * time.Minute}
+	pedido.PreverPronto(fila, agora)
+	assert.Equal(t, agora.Add(13*time.Minute), *pedido.PrevisaoPronto)
+
+	vazio := &Pedido{Status: Pendente, TempoEstimado: 3 * time.Minute}
+	vazio.PreverPronto(nil, agora)
+	assert.Equal(t, agora.Add(3*time.Minute), *vazio.PrevisaoPronto)
+}
+
+func TestFormatarDuracao(t *testing.T) {
+	assert.Equal(t, "00:17:00", FormatarDuracao(17*time.Minute))
+	assert.Equal(t, "01:02:03", FormatarDuracao(time.Hour+2*time.Minute+3*time.Second))
+
+	d, err := DuracaoParse("00:15:00")
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, d)
+
+	_, err = DuracaoParse("quinze")
+	assert.Error(t, err)
+}
@@ -2,17 +2,32 @@ package entities
 
 import (
 	"errors"
-	"fmt"
 	"strings"
+	"time"
 )
 
+// CatProduto é o nome de uma categoria cadastrada, gravado nos produtos, combos e cupons
 type CatProduto string
 
+// Categorias criadas com o cardápio inicial; outras podem ser cadastradas pela loja
 const (
 	Lanche         CatProduto = "Lanche"
 	Acompanhamento CatProduto = "Acompanhamento"
 	Bebida         CatProduto = "Bebida"
 	Sobremesa      CatProduto = "Sobremesa"
+	Combo          CatProduto = "Combo"
+)
+
+// categoriaDeItem informa se a categoria é de um item vendido individualmente (todas exceto Combo).
+// A existência da categoria é verificada no cadastro de categorias.
+func categoriaDeItem(categoria CatProduto) bool {
+	return strings.TrimSpace(string(categoria)) != "" && categoria != Combo
+}
+
+var (
+	ErrProdutoNaoEncontrado = errors.New("produto não encontrado")
+	ErrProdutoArquivado     = errors.New("o produto já está arquivado")
+	ErrProdutoNaoArquivado  = errors.New("o produto não está arquivado")
 )
 
 type Produto struct {
@@ -20,28 +35,73 @@ type Produto struct {
 	Nome      string     `json:"nomeProduto"`
 	Categoria CatProduto `json:"categoriaProduto"`
 	Descricao string     `json:"descricaoProduto"`
-	Preco     float32    `json:"precoProduto"`
+	Preco     Money      `json:"precoProduto"`
+	// Tempo de preparo de uma unidade; na criação, o padrão da categoria
+	TempoPreparoMinutos int `json:"tempoPreparoMinutos,omitempty"`
+	// Esgotado tira o produto do cardápio independentemente do estoque
+	Esgotado bool `json:"esgotado"`
+	// Unidades em estoque; nil quando o estoque do produto não é controlado
+	Estoque *int `json:"estoque,omitempty"`
+	// Quando o produto saiu do cardápio; produtos arquivados continuam nos pedidos antigos
+	ArquivadoEm *time.Time `json:"arquivadoEm,omitempty"`
+	// Grupos de modificadores que podem ser escolhidos ao pedir o produto
+	Modificadores []GrupoModificador `json:"modificadores,omitempty"`
+	// Componentes do combo; vazio para produtos que não são combos
+	Componentes []ComponenteCombo `json:"componentes,omitempty"`
+	// Imagem do produto nos tamanhos gerados no envio; vazio quando o produto não tem foto
+	Imagens []ImagemProduto `json:"imagens,omitempty"`
+	// Alérgenos, tags de dieta e tabela nutricional
+	InformacoesDieteticas
 }
 
-func ProdutoNew(nome string, categoria string, descricao string, preco float32) (*Produto, error) {
-	fmt.Println("Nome:", nome, "\nCategoria: ", categoria, "\nDescrição:", descricao, "\nPreço:", preco)
-	if strings.TrimSpace(nome) == "" || preco <= 0 || strings.TrimSpace(categoria) == "" {
+// ProdutoNew cria um produto da categoria cadastrada informada; nil indica uma categoria que não existe
+func ProdutoNew(nome string, categoria *Categoria, descricao string, preco Money) (*Produto, error) {
+	if strings.TrimSpace(nome) == "" || !preco.IsPositive() {
 		return nil, errors.New("todos os campos são obrigatórios e o preço maior que zero")
 	}
 
-	var cat_prod CatProduto
-
-	switch CatProduto(categoria) {
-	case Lanche, Acompanhamento, Bebida, Sobremesa:
-		cat_prod = CatProduto(categoria)
-	default:
-		return nil, errors.New("categoria inválida")
+	switch {
+	case categoria == nil:
+		return nil, ErrCategoriaInvalida
+	case categoria.Nome == Combo:
+		return nil, errors.New("combos devem ser cadastrados com seus componentes")
+	case !categoria.Ativa:
+		return nil, ErrCategoriaInativa
 	}
 
 	return &Produto{
-		Nome:      nome,
-		Categoria: cat_prod,
-		Descricao: descricao,
-		Preco:     preco,
+		Nome:                nome,
+		Categoria:           categoria.Nome,
+		Descricao:           descricao,
+		Preco:               preco,
+		TempoPreparoMinutos: categoria.TempoPreparoMinutos,
 	}, nil
 }
+
+// EhCombo informa se o produto é um combo
+func (p Produto) EhCombo() bool {
+	return p.Categoria == Combo
+}
+
+// Arquivado informa se o produto foi retirado do cardápio
+func (p Produto) Arquivado() bool {
+	return p.ArquivadoEm != nil
+}
+
+// Arquivar retira o produto do cardápio sem apagá-lo dos pedidos já feitos
+func (p *Produto) Arquivar(agora time.Time) error {
+	if p.Arquivado() {
+		return ErrProdutoArquivado
+	}
+	p.ArquivadoEm = &agora
+	return nil
+}
+
+// Restaurar devolve ao cardápio um produto arquivado
+func (p *Produto) Restaurar() error {
+	if !p.Arquivado() {
+		return ErrProdutoNaoArquivado
+	}
+	p.ArquivadoEm = nil
+	return nil
+}
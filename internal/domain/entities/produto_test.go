@@ -2,6 +2,7 @@ package entities
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,7 +13,7 @@ func TestProdutoNew_Success(t *testing.T) {
 		nomeProduto    string
 		categoria      string
 		descricao      string
-		preco          float32
+		preco          Money
 		expectedResult *Produto
 	}{
 		{
@@ -20,12 +21,12 @@ func TestProdutoNew_Success(t *testing.T) {
 			nomeProduto: "Big Mac",
 			categoria:   "Lanche",
 			descricao:   "Hamburger com dois hambúrgueres",
-			preco:       25.90,
+			preco:       Reais(25.90),
 			expectedResult: &Produto{
 				Nome:      "Big Mac",
 				Categoria: Lanche,
 				Descricao: "Hamburger com dois hambúrgueres",
-				Preco:     25.90,
+				Preco:     Reais(25.90),
 			},
 		},
 		{
@@ -33,12 +34,12 @@ func TestProdutoNew_Success(t *testing.T) {
 			nomeProduto: "Coca Cola",
 			categoria:   "Bebida",
 			descricao:   "Refrigerante de cola 350ml",
-			preco:       5.50,
+			preco:       Reais(5.50),
 			expectedResult: &Produto{
 				Nome:      "Coca Cola",
 				Categoria: Bebida,
 				Descricao: "Refrigerante de cola 350ml",
-				Preco:     5.50,
+				Preco:     Reais(5.50),
 			},
 		},
 		{
@@ -46,12 +47,12 @@ func TestProdutoNew_Success(t *testing.T) {
 			nomeProduto: "Batata Frita",
 			categoria:   "Acompanhamento",
 			descricao:   "Batata frita crocante",
-			preco:       8.00,
+			preco:       Reais(8.00),
 			expectedResult: &Produto{
 				Nome:      "Batata Frita",
 				Categoria: Acompanhamento,
 				Descricao: "Batata frita crocante",
-				Preco:     8.00,
+				Preco:     Reais(8.00),
 			},
 		},
 		{
@@ -59,19 +60,19 @@ func TestProdutoNew_Success(t *testing.T) {
 			nomeProduto: "Sundae Chocolate",
 			categoria:   "Sobremesa",
 			descricao:   "Sorvete com calda de chocolate",
-			preco:       12.50,
+			preco:       Reais(12.50),
 			expectedResult: &Produto{
 				Nome:      "Sundae Chocolate",
 				Categoria: Sobremesa,
 				Descricao: "Sorvete com calda de chocolate",
-				Preco:     12.50,
+				Preco:     Reais(12.50),
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			produto, err := ProdutoNew(tt.nomeProduto, tt.categoria, tt.descricao, tt.preco)
+			produto, err := ProdutoNew(tt.nomeProduto, categoriaDoCardapio(CatProduto(tt.categoria)), tt.descricao, tt.preco)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, produto)
@@ -97,28 +98,7 @@ func TestProdutoNew_ErrorNomeVazio(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			produto, err := ProdutoNew(tt.nomeProduto, "Lanche", "Descrição válida", 10.0)
-
-			assert.Error(t, err)
-			assert.Nil(t, produto)
-			assert.Equal(t, "todos os campos são obrigatórios e o preço maior que zero", err.Error())
-		})
-	}
-}
-
-func TestProdutoNew_ErrorCategoriaVazia(t *testing.T) {
-	tests := []struct {
-		name      string
-		categoria string
-	}{
-		{"Categoria vazia", ""},
-		{"Categoria apenas espaços", "   "},
-		{"Categoria com tabs", "\t\t"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			produto, err := ProdutoNew("Produto Teste", tt.categoria, "Descrição válida", 10.0)
+			produto, err := ProdutoNew(tt.nomeProduto, categoriaDoCardapio(Lanche), "Descrição válida", Reais(10.0))
 
 			assert.Error(t, err)
 			assert.Nil(t, produto)
@@ -130,16 +110,16 @@ func TestProdutoNew_ErrorCategoriaVazia(t *testing.T) {
 func TestProdutoNew_ErrorPrecoInvalido(t *testing.T) {
 	tests := []struct {
 		name  string
-		preco float32
+		preco Money
 	}{
-		{"Preço zero", 0.0},
-		{"Preço negativo", -1.0},
-		{"Preço muito negativo", -100.50},
+		{"Preço zero", Reais(0.0)},
+		{"Preço negativo", Reais(-1.0)},
+		{"Preço muito negativo", Reais(-100.50)},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			produto, err := ProdutoNew("Produto Teste", "Lanche", "Descrição válida", tt.preco)
+			produto, err := ProdutoNew("Produto Teste", categoriaDoCardapio(Lanche), "Descrição válida", tt.preco)
 
 			assert.Error(t, err)
 			assert.Nil(t, produto)
@@ -149,32 +129,39 @@ func TestProdutoNew_ErrorPrecoInvalido(t *testing.T) {
 }
 
 func TestProdutoNew_ErrorCategoriaInvalida(t *testing.T) {
+	inativa := Categoria{ID: 9, Nome: "Café da manhã", Slug: "cafe-da-manha", Ativa: false}
+
 	tests := []struct {
 		name      string
-		categoria string
+		categoria *Categoria
+		erro      error
 	}{
-		{"Categoria inexistente", "Categoria Inexistente"},
-		{"Case sensitive - lanche minúsculo", "lanche"},
-		{"Case sensitive - LANCHE maiúsculo", "LANCHE"},
-		{"Categoria com caracteres especiais", "Lanche@#$"},
-		{"Categoria numérica", "123"},
-		{"Categoria mista", "Lanche123"},
+		{"Categoria não cadastrada", nil, ErrCategoriaInvalida},
+		{"Categoria inativa", &inativa, ErrCategoriaInativa},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			produto, err := ProdutoNew("Produto Teste", tt.categoria, "Descrição válida", 10.0)
+			produto, err := ProdutoNew("Produto Teste", tt.categoria, "Descrição válida", Reais(10.0))
 
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, tt.erro)
 			assert.Nil(t, produto)
-			assert.Equal(t, "categoria inválida", err.Error())
 		})
 	}
 }
 
+func TestProdutoNew_CategoriaCadastrada(t *testing.T) {
+	cafe := Categoria{ID: 9, Nome: "Café da manhã", Slug: "cafe-da-manha", Ativa: true}
+
+	produto, err := ProdutoNew("Pão na chapa", &cafe, "Pão francês com manteiga", Reais(7))
+
+	assert.NoError(t, err)
+	assert.Equal(t, CatProduto("Café da manhã"), produto.Categoria)
+}
+
 func TestProdutoNew_ErrorMultiplosCampos(t *testing.T) {
 	// Teste quando múltiplos campos são inválidos
-	produto, err := ProdutoNew("", "", "", 0.0)
+	produto, err := ProdutoNew("", nil, "", Reais(0.0))
 
 	assert.Error(t, err)
 	assert.Nil(t, produto)
@@ -191,37 +178,55 @@ func TestProdutoNew_ValidarConstantesCategorias(t *testing.T) {
 
 func TestProdutoNew_DescricaoVazia(t *testing.T) {
 	// Teste se descrição vazia é aceita (baseado no código atual)
-	produto, err := ProdutoNew("Produto Teste", "Lanche", "", 10.0)
+	produto, err := ProdutoNew("Produto Teste", categoriaDoCardapio(Lanche), "", Reais(10.0))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, produto)
 	assert.Equal(t, "Produto Teste", produto.Nome)
 	assert.Equal(t, Lanche, produto.Categoria)
 	assert.Equal(t, "", produto.Descricao)
-	assert.Equal(t, float32(10.0), produto.Preco)
+	assert.Equal(t, Reais(10.0), produto.Preco)
 }
 
 func TestProdutoNew_PrecoDecimal(t *testing.T) {
 	// Teste com valores decimais precisos
-	produto, err := ProdutoNew("Produto Teste", "Lanche", "Descrição", 19.99)
+	produto, err := ProdutoNew("Produto Teste", categoriaDoCardapio(Lanche), "Descrição", Reais(19.99))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, produto)
-	assert.Equal(t, float32(19.99), produto.Preco)
+	assert.Equal(t, Reais(19.99), produto.Preco)
 }
 
 func TestProdutoNew_NomeComEspacos(t *testing.T) {
 	// Teste com nome que tem espaços mas não é vazio
-	produto, err := ProdutoNew("  Big Mac  ", "Lanche", "Hamburger", 25.90)
+	produto, err := ProdutoNew("  Big Mac  ", categoriaDoCardapio(Lanche), "Hamburger", Reais(25.90))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, produto)
 	assert.Equal(t, "  Big Mac  ", produto.Nome) // Mantém os espaços extras
 }
 
+func TestProduto_ArquivarRestaurar(t *testing.T) {
+	produto, err := ProdutoNew("Big Mac", categoriaDoCardapio(Lanche), "Hamburger", Reais(25.90))
+	assert.NoError(t, err)
+	assert.False(t, produto.Arquivado())
+
+	agora := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, produto.Arquivar(agora))
+	assert.True(t, produto.Arquivado())
+	assert.Equal(t, agora, *produto.ArquivadoEm)
+	assert.False(t, produto.Disponivel(1), "produto arquivado não pode ser pedido")
+	assert.ErrorIs(t, produto.Arquivar(agora), ErrProdutoArquivado)
+
+	assert.NoError(t, produto.Restaurar())
+	assert.False(t, produto.Arquivado())
+	assert.True(t, produto.Disponivel(1))
+	assert.ErrorIs(t, produto.Restaurar(), ErrProdutoNaoArquivado)
+}
+
 // Benchmark para testar performance
 func BenchmarkProdutoNew(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_, _ = ProdutoNew("Big Mac", "Lanche", "Hamburger com dois hambúrgueres", 25.90)
+		_, _ = ProdutoNew("Big Mac", categoriaDoCardapio(Lanche), "Hamburger com dois hambúrgueres", Reais(25.90))
 	}
 }
@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Códigos das regras de validação do pedido, devolvidos junto com cada violação
+const (
+	RegraPedidoVazio          = "PEDIDO_VAZIO"
+	RegraCategoriaObrigatoria = "CATEGORIA_OBRIGATORIA"
+	RegraMaximoItens          = "MAXIMO_ITENS"
+	RegraValorMaximo          = "VALOR_MAXIMO"
+	RegraLimiteProduto        = "LIMITE_PRODUTO"
+)
+
+// ViolacaoRegra descreve uma regra que o pedido não cumpre
+type ViolacaoRegra struct {
+	Codigo   string `json:"codigo"`
+	Mensagem string `json:"mensagem"`
+}
+
+// RegrasPedidoError reúne todas as regras violadas pelo pedido, para o cliente corrigir tudo de uma vez
+type RegrasPedidoError struct {
+	Violacoes []ViolacaoRegra
+}
+
+func (e *RegrasPedidoError) Error() string {
+	mensagens := make([]string, 0, len(e.Violacoes))
+	for _, v := range e.Violacoes {
+		mensagens = append(mensagens, v.Mensagem)
+	}
+	return strings.Join(mensagens, "; ")
+}
+
+// RegraPedido é uma regra de composição do pedido. As categorias cadastradas são passadas
+// porque algumas regras dependem delas.
+type RegraPedido interface {
+	Verificar(pedido *Pedido, categorias []Categoria) []ViolacaoRegra
+}
+
+// RegrasPedido é o conjunto de regras que a loja aplica aos pedidos
+type RegrasPedido []RegraPedido
+
+// ConfigRegrasPedido traz os limites configurados pela loja. Valores zero desativam a regra.
+type ConfigRegrasPedido struct {
+	MaximoItens    int         // unidades no pedido, contando os produtos dos combos uma vez por combo
+	ValorMaximo    Money       // total do pedido, depois do desconto
+	LimitesProduto map[int]int // unidades permitidas por produto, ex: itens promocionais
+	// Categorias que podem formar um pedido sozinhas, dispensando as obrigatórias, ex: só bebidas no balcão
+	CategoriasAvulsas []CatProduto
+}
+
+// RegrasPedidoNew monta as regras da loja. Todo pedido precisa de ao menos um produto e dos itens das
+// categorias obrigatórias; as demais regras só entram quando configuradas.
+func RegrasPedidoNew(config ConfigRegrasPedido) RegrasPedido {
+	regras := RegrasPedido{
+		PedidoNaoVazio{},
+		CategoriasObrigatoriasNoPedido{Avulsas: config.CategoriasAvulsas},
+	}
+	if config.MaximoItens > 0 {
+		regras = append(regras, MaximoItensNoPedido{Maximo: config.MaximoItens})
+	}
+	if config.ValorMaximo.IsPositive() {
+		regras = append(regras, ValorMaximoDoPedido{Maximo: config.ValorMaximo})
+	}
+	if len(config.LimitesProduto) > 0 {
+		regras = append(regras, LimitePorProduto{Limites: config.LimitesProduto})
+	}
+	return regras
+}
+
+// Validar aplica todas as regras e devolve um *RegrasPedidoError com todas as violações
+func (r RegrasPedido) Validar(pedido *Pedido, categorias []Categoria) error {
+	var violacoes []ViolacaoRegra
+	for _, regra := range r {
+		violacoes = append(violacoes, regra.Verificar(pedido, categorias)...)
+	}
+	if len(violacoes) > 0 {
+		return &RegrasPedidoError{Violacoes: violacoes}
+	}
+	return nil
+}
+
+// PedidoNaoVazio exige ao menos um produto no pedido
+type PedidoNaoVazio struct{}
+
+func (PedidoNaoVazio) Verificar(pedido *Pedido, _ []Categoria) []ViolacaoRegra {
+	if len(pedido.Itens) == 0 {
+		return []ViolacaoRegra{{Codigo: RegraPedidoVazio, Mensagem: "o pedido precisa ter ao menos um produto"}}
+	}
+	return nil
+}
+
+// CategoriasObrigatoriasNoPedido exige um item de cada categoria marcada como obrigatória no pedido,
+// exceto nos pedidos formados só por categorias avulsas
+type CategoriasObrigatoriasNoPedido struct {
+	Avulsas []CatProduto
+}
+
+func (r CategoriasObrigatoriasNoPedido) Verificar(pedido *Pedido, categorias []Categoria) []ViolacaoRegra {
+	if len(pedido.Itens) == 0 || r.somenteAvulsas(pedido.Itens) {
+		return nil
+	}
+
+	var violacoes []ViolacaoRegra
+	for _, obrigatoria := range CategoriasObrigatorias(categorias) {
+		contem := false
+		for _, linha := range pedido.Itens {
+			if linha.ContemCategoria(obrigatoria.Nome) {
+				contem = true
+				break
+			}
+		}
+		if !contem {
+			violacoes = append(violacoes, ViolacaoRegra{
+				Codigo:   RegraCategoriaObrigatoria,
+				Mensagem: fmt.Sprintf("o pedido precisa ter ao menos um item da categoria %s", obrigatoria.Nome),
+			})
+		}
+	}
+	return violacoes
+}
+
+func (r CategoriasObrigatoriasNoPedido) somenteAvulsas(itens []ItemPedido) bool {
+	if len(r.Avulsas) == 0 {
+		return false
+	}
+	for _, item := range itens {
+		avulsa := false
+		for _, categoria := range r.Avulsas {
+			if item.Produto.Categoria == categoria {
+				avulsa = true
+				break
+			}
+		}
+		if !avulsa {
+			return false
+		}
+	}
+	return true
+}
+
+// MaximoItensNoPedido limita as unidades do pedido
+type MaximoItensNoPedido struct {
+	Maximo int
+}
+
+func (r MaximoItensNoPedido) Verificar(pedido *Pedido, _ []Categoria) []ViolacaoRegra {
+	unidades := 0
+	for _, item := range pedido.Itens {
+		unidades += item.Quantidade
+	}
+	if unidades > r.Maximo {
+		return []ViolacaoRegra{{
+			Codigo:   RegraMaximoItens,
+			Mensagem: fmt.Sprintf("o pedido pode ter no máximo %d itens, mas tem %d", r.Maximo, unidades),
+		}}
+	}
+	return nil
+}
+
+// ValorMaximoDoPedido limita o total do pedido
+type ValorMaximoDoPedido struct {
+	Maximo Money
+}
+
+func (r ValorMaximoDoPedido) Verificar(pedido *Pedido, _ []Categoria) []ViolacaoRegra {
+	excedente, err := pedido.Total.Sub(r.Maximo)
+	if err != nil {
+		return []ViolacaoRegra{{Codigo: RegraValorMaximo, Mensagem: err.Error()}}
+	}
+	if excedente.IsPositive() {
+		return []ViolacaoRegra{{
+			Codigo:   RegraValorMaximo,
+			Mensagem: fmt.Sprintf("o total do pedido, %s, passa do máximo de %s", pedido.Total, r.Maximo),
+		}}
+	}
+	return nil
+}
+
+// LimitePorProduto limita as unidades de produtos específicos, contando também as que vêm em combos
+type LimitePorProduto struct {
+	Limites map[int]int
+}
+
+func (r LimitePorProduto) Verificar(pedido *Pedido, _ []Categoria) []ViolacaoRegra {
+	var violacoes []ViolacaoRegra
+	for _, consumo := range ConsumoDeProdutos(pedido.Itens) {
+		limite, ok := r.Limites[consumo.Produto.ID]
+		if ok && consumo.Quantidade > limite {
+			violacoes = append(violacoes, ViolacaoRegra{
+				Codigo:   RegraLimiteProduto,
+				Mensagem: fmt.Sprintf("o pedido pode ter no máximo %d unidades de %s", limite, consumo.Produto.Nome),
+			})
+		}
+	}
+	return violacoes
+}
+
+// LimitesProdutoParse lê os limites por produto no formato "idProduto:unidades", separados por vírgula, ex: "12:2,15:1"
+func LimitesProdutoParse(valor string) (map[int]int, error) {
+	limites := map[int]int{}
+	for _, par := range strings.Split(valor, ",") {
+		par = strings.TrimSpace(par)
+		if par == "" {
+			continue
+		}
+		id, unidades, ok := strings.Cut(par, ":")
+		produtoID, errID := strconv.Atoi(strings.TrimSpace(id))
+		limite, errLimite := strconv.Atoi(strings.TrimSpace(unidades))
+		if !ok || errID != nil || errLimite != nil || produtoID <= 0 || limite < 0 {
+			return nil, fmt.Errorf("limite por produto inválido: %q, use idProduto:unidades", par)
+		}
+		limites[produtoID] = limite
+	}
+	return limites, nil
+}
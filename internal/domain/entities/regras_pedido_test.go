@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegrasPedido_Validar(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ConfigRegrasPedido
+		itens   []ItemPedido
+		codigos []string
+	}{
+		{
+			name:  "Pedido válido sem regras configuradas",
+			itens: []ItemPedido{{Produto: xSalada, Quantidade: 1}, {Produto: cocaCola, Quantidade: 1}},
+		},
+		{
+			name:    "Pedido vazio",
+			itens:   []ItemPedido{},
+			codigos: []string{RegraPedidoVazio},
+		},
+		{
+			name:    "Sem lanche",
+			itens:   []ItemPedido{{Produto: cocaCola, Quantidade: 1}, {Produto: batataFrita, Quantidade: 1}},
+			codigos: []string{RegraCategoriaObrigatoria},
+		},
+		{
+			name:   "Só bebidas com bebida avulsa",
+			config: ConfigRegrasPedido{CategoriasAvulsas: []CatProduto{Bebida}},
+			itens:  []ItemPedido{{Produto: cocaCola, Quantidade: 2}},
+		},
+		{
+			name:    "Bebida avulsa não dispensa o lanche com acompanhamento",
+			config:  ConfigRegrasPedido{CategoriasAvulsas: []CatProduto{Bebida}},
+			itens:   []ItemPedido{{Produto: cocaCola, Quantidade: 1}, {Produto: batataFrita, Quantidade: 1}},
+			codigos: []string{RegraCategoriaObrigatoria},
+		},
+		{
+			name:   "No limite de itens",
+			config: ConfigRegrasPedido{MaximoItens: 3},
+			itens:  []ItemPedido{{Produto: xSalada, Quantidade: 2}, {Produto: cocaCola, Quantidade: 1}},
+		},
+		{
+			name:    "Acima do limite de itens",
+			config:  ConfigRegrasPedido{MaximoItens: 3},
+			itens:   []ItemPedido{{Produto: xSalada, Quantidade: 2}, {Produto: cocaCola, Quantidade: 2}},
+			codigos: []string{RegraMaximoItens},
+		},
+		{
+			name:   "No valor máximo",
+			config: ConfigRegrasPedido{ValorMaximo: Reais(45)},
+			itens:  []ItemPedido{{Produto: xSalada, Quantidade: 2}},
+		},
+		{
+			name:    "Acima do valor máximo",
+			config:  ConfigRegrasPedido{ValorMaximo: Reais(45)},
+			itens:   []ItemPedido{{Produto: xSalada, Quantidade: 2}, {Produto: cocaCola, Quantidade: 1}},
+			codigos: []string{RegraValorMaximo},
+		},
+		{
+			name:    "Acima do limite do produto promocional",
+			config:  ConfigRegrasPedido{LimitesProduto: map[int]int{2: 2}},
+			itens:   []ItemPedido{{Produto: xSalada, Quantidade: 1}, {Produto: cocaCola, Quantidade: 3}},
+			codigos: []string{RegraLimiteProduto},
+		},
+		{
+			name:    "Limite do produto conta as unidades dos combos",
+			config:  ConfigRegrasPedido{LimitesProduto: map[int]int{2: 2}},
+			itens:   []ItemPedido{{Produto: comboXSalada(), Quantidade: 2, Componentes: []ItemCombo{{Produto: cocaCola}}}, {Produto: cocaCola, Quantidade: 1}},
+			codigos: []string{RegraLimiteProduto},
+		},
+		{
+			name:    "Todas as violações de uma vez",
+			config:  ConfigRegrasPedido{MaximoItens: 2, ValorMaximo: Reais(10), LimitesProduto: map[int]int{2: 1}},
+			itens:   []ItemPedido{{Produto: cocaCola, Quantidade: 3}},
+			codigos: []string{RegraCategoriaObrigatoria, RegraMaximoItens, RegraValorMaximo, RegraLimiteProduto},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pedido, err := PedidoNew("Maria", tt.itens, nil)
+			assert.NoError(t, err)
+
+			err = RegrasPedidoNew(tt.config).Validar(pedido, categoriasCardapio)
+
+			if len(tt.codigos) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var violadas *RegrasPedidoError
+			assert.True(t, errors.As(err, &violadas))
+			var codigos []string
+			for _, v := range violadas.Violacoes {
+				codigos = append(codigos, v.Codigo)
+			}
+			assert.Equal(t, tt.codigos, codigos)
+		})
+	}
+}
+
+func TestRegrasPedidoError_Error(t *testing.T) {
+	err := &RegrasPedidoError{Violacoes: []ViolacaoRegra{
+		{Codigo: RegraMaximoItens, Mensagem: "o pedido pode ter no máximo 2 itens, mas tem 3"},
+		{Codigo: RegraLimiteProduto, Mensagem: "o pedido pode ter no máximo 1 unidades de Coca-cola"},
+	}}
+
+	assert.Equal(t, "o pedido pode ter no máximo 2 itens, mas tem 3; o pedido pode ter no máximo 1 unidades de Coca-cola", err.Error())
+}
+
+func TestLimitesProdutoParse(t *testing.T) {
+	tests := []struct {
+		valor    string
+		esperado map[int]int
+		erro     bool
+	}{
+		{valor: "", esperado: map[int]int{}},
+		{valor: "12:2", esperado: map[int]int{12: 2}},
+		{valor: " 12 : 2 , 15:0 ,", esperado: map[int]int{12: 2, 15: 0}},
+		{valor: "12", erro: true},
+		{valor: "x:2", erro: true},
+		{valor: "12:-1", erro: true},
+		{valor: "0:1", erro: true},
+	}
+
+	for _, tt := range tests {
+		limites, err := LimitesProdutoParse(tt.valor)
+		if tt.erro {
+			assert.Error(t, err, tt.valor)
+			continue
+		}
+		assert.NoError(t, err, tt.valor)
+		assert.Equal(t, tt.esperado, limites, tt.valor)
+	}
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TamanhoMaximoAutor é o maior nome de autor que o histórico de versões guarda
+const TamanhoMaximoAutor = 60
+
+var (
+	ErrSemVersaoVigente = errors.New("o produto não tinha versão registrada na data informada")
+	ErrAutorMuitoLongo  = fmt.Errorf("o autor da alteração deve ter no máximo %d caracteres", TamanhoMaximoAutor)
+)
+
+// VersaoProduto registra como o produto ficou após cada alteração do catálogo, a partir de quando e por quem
+type VersaoProduto struct {
+	ID                  int        `json:"idVersao"`
+	ProdutoID           int        `json:"idProduto"`
+	Nome                string     `json:"nomeProduto"`
+	Categoria           CatProduto `json:"categoriaProduto"`
+	Descricao           string     `json:"descricaoProduto"`
+	Preco               Money      `json:"precoProduto"`
+	TempoPreparoMinutos int        `json:"tempoPreparoMinutos"`
+	Autor               string     `json:"autor"`
+	VigenteDesde        time.Time  `json:"vigenteDesde"`
+}
+
+func VersaoProdutoNew(produto Produto, autor string, agora time.Time) (*VersaoProduto, error) {
+	if produto.ID <= 0 {
+		return nil, errors.New("a versão precisa de um produto cadastrado")
+	}
+	if strings.TrimSpace(autor) == "" {
+		return nil, errors.New("o autor da alteração é obrigatório")
+	}
+	if utf8.RuneCountInString(autor) > TamanhoMaximoAutor {
+		return nil, ErrAutorMuitoLongo
+	}
+
+	return &VersaoProduto{
+		ProdutoID:           produto.ID,
+		Nome:                produto.Nome,
+		Categoria:           produto.Categoria,
+		Descricao:           produto.Descricao,
+		Preco:               produto.Preco,
+		TempoPreparoMinutos: produto.TempoPreparoMinutos,
+		Autor:               autor,
+		VigenteDesde:        agora,
+	}, nil
+}
+
+// CamposAlterados lista os campos que mudaram em relação à versão anterior; nil na primeira versão
+func (v VersaoProduto) CamposAlterados(anterior *VersaoProduto) []string {
+	if anterior == nil {
+		return nil
+	}
+
+	campos := []string{}
+	if v.Nome != anterior.Nome {
+		campos = append(campos, "nome")
+	}
+	if v.Categoria != anterior.Categoria {
+		campos = append(campos, "categoria")
+	}
+	if v.Descricao != anterior.Descricao {
+		campos = append(campos, "descricao")
+	}
+	if v.Preco != anterior.Preco {
+		campos = append(campos, "preco")
+	}
+	if v.TempoPreparoMinutos != anterior.TempoPreparoMinutos {
+		campos = append(campos, "tempoPreparo")
+	}
+	return campos
+}
+
+// VersaoVigente devolve a versão em vigor no instante informado, dentre versões em ordem cronológica
+func VersaoVigente(versoes []VersaoProduto, em time.Time) (*VersaoProduto, error) {
+	var vigente *VersaoProduto
+	for i := range versoes {
+		if versoes[i].VigenteDesde.After(em) {
+			break
+		}
+		vigente = &versoes[i]
+	}
+
+	if vigente == nil {
+		return nil, ErrSemVersaoVigente
+	}
+	return vigente, nil
+}
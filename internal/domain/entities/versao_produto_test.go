@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersaoProdutoNew(t *testing.T) {
+	agora := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	versao, err := VersaoProdutoNew(xSalada, "gerente", agora)
+	assert.NoError(t, err)
+	assert.Equal(t, xSalada.ID, versao.ProdutoID)
+	assert.Equal(t, xSalada.Preco, versao.Preco)
+	assert.Equal(t, "gerente", versao.Autor)
+	assert.Equal(t, agora, versao.VigenteDesde)
+
+	_, err = VersaoProdutoNew(xSalada, " ", agora)
+	assert.Error(t, err)
+
+	_, err = VersaoProdutoNew(Produto{Nome: "Sem ID"}, "gerente", agora)
+	assert.Error(t, err)
+
+	// O limite conta caracteres, não bytes
+	_, err = VersaoProdutoNew(xSalada, strings.Repeat("é", TamanhoMaximoAutor), agora)
+	assert.NoError(t, err)
+	_, err = VersaoProdutoNew(xSalada, strings.Repeat("a", TamanhoMaximoAutor+1), agora)
+	assert.ErrorIs(t, err, ErrAutorMuitoLongo)
+}
+
+func TestVersaoProduto_CamposAlterados(t *testing.T) {
+	agora := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	v1, _ := VersaoProdutoNew(xSalada, "gerente", agora)
+
+	editado := xSalada
+	editado.Preco = Reais(24.90)
+	editado.Descricao = "Nova receita"
+	v2, _ := VersaoProdutoNew(editado, "gerente", agora.Add(time.Hour))
+
+	assert.Nil(t, v1.CamposAlterados(nil))
+	assert.Equal(t, []string{"descricao", "preco"}, v2.CamposAlterados(v1))
+	assert.Empty(t, v1.CamposAlterados(v1))
+}
+
+func TestVersaoVigente(t *testing.T) {
+	inicio := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	versoes := []VersaoProduto{
+		{ID: 1, Preco: Reais(20), VigenteDesde: inicio},
+		{ID: 2, Preco: Reais(22), VigenteDesde: inicio.Add(24 * time.Hour)},
+		{ID: 3, Preco: Reais(25), VigenteDesde: inicio.Add(48 * time.Hour)},
+	}
+
+	_, err := VersaoVigente(versoes, inicio.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrSemVersaoVigente)
+
+	casos := []struct {
+		em    time.Time
+		preco Money
+	}{
+		{inicio, Reais(20)},
+		{inicio.Add(23 * time.Hour), Reais(20)},
+		{inicio.Add(24 * time.Hour), Reais(22)},
+		{inicio.Add(100 * time.Hour), Reais(25)},
+	}
+	for _, caso := range casos {
+		versao, err := VersaoVigente(versoes, caso.em)
+		assert.NoError(t, err)
+		assert.Equal(t, caso.preco, versao.Preco, "em %s", caso.em)
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+)
+
+// CategoriaRepository define a interface para operações de dados das categorias do cardápio.
+// Renomear uma categoria atualiza os produtos, combos e cupons que a usam.
+type CategoriaRepository interface {
+	AdicionarCategoria(c context.Context, categoria *entities.Categoria) error
+	BuscarCategoriaPorId(c context.Context, id int) (*entities.Categoria, error)
+	BuscarCategoriaPorNome(c context.Context, nome string) (*entities.Categoria, error)
+	BuscarCategoriaPorSlug(c context.Context, slug string) (*entities.Categoria, error)
+	// ListarCategorias devolve todas as categorias, ativas ou não, na ordem de exibição
+	ListarCategorias(c context.Context) ([]*entities.Categoria, error)
+	EditarCategoria(c context.Context, categoria *entities.Categoria) error
+	RemoverCategoria(c context.Context, id int) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+)
+
+// CupomRepository define a interface para operações de dados dos cupons de desconto.
+// O uso do cupom é contabilizado pelo PedidoRepository ao gravar o pedido, na mesma transação.
+type CupomRepository interface {
+	AdicionarCupom(c context.Context, cupom *entities.Cupom) error
+	BuscarCupomPorCodigo(c context.Context, codigo string) (*entities.Cupom, error)
+	ListarCupons(c context.Context) ([]*entities.Cupom, error)
+	EditarCupom(c context.Context, cupom *entities.Cupom) error
+	RemoverCupom(c context.Context, codigo string) error
+}
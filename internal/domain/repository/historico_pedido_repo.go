@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+)
+
+// HistoricoPedidoRepository define a interface para o histórico de status dos pedidos
+type HistoricoPedidoRepository interface {
+	RegistrarAlteracao(c context.Context, alteracao *entities.AlteracaoPedido) error
+	// ListarHistorico retorna as alterações do pedido em ordem cronológica
+	ListarHistorico(c context.Context, pedidoID int) ([]entities.AlteracaoPedido, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+)
+
+// ImagemProdutoRepository define a interface para operações de dados das imagens dos produtos.
+// Os arquivos ficam no armazenamento de imagens; aqui ficam apenas as chaves e URLs de cada variante.
+type ImagemProdutoRepository interface {
+	ListarImagens(c context.Context, produtoID int) ([]entities.ImagemProduto, error)
+	// SalvarImagens substitui todas as variantes gravadas para o produto
+	SalvarImagens(c context.Context, produtoID int, imagens []entities.ImagemProduto) error
+	RemoverImagens(c context.Context, produtoID int) error
+}
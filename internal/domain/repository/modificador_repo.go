@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+)
+
+// ModificadorRepository define a interface para operações de dados dos grupos de modificadores dos produtos
+type ModificadorRepository interface {
+	AdicionarGrupo(c context.Context, grupo *entities.GrupoModificador) error
+	ListarGruposPorProduto(c context.Context, produtoID int) ([]entities.GrupoModificador, error)
+	RemoverGrupo(c context.Context, grupoID int) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"time"
+)
+
+// OutboxRepository guarda os eventos de domínio até o relay publicá-los
+type OutboxRepository interface {
+	// RegistrarEvento grava o evento; dentro de Transacao.Executar, junto com a alteração que o gerou
+	RegistrarEvento(c context.Context, evento *entities.EventoOutbox) error
+	// ListarPendentes retorna os eventos pendentes com tentativa prevista até agora, os mais antigos primeiro.
+	// Dentro de uma transação, os eventos ficam reservados para quem os listou até o fim dela.
+	ListarPendentes(c context.Context, agora time.Time, limite int) ([]*entities.EventoOutbox, error)
+	// AtualizarEvento grava o status, as tentativas e o último erro do evento
+	AtualizarEvento(c context.Context, evento *entities.EventoOutbox) error
+	BuscarEvento(c context.Context, id int64) (*entities.EventoOutbox, error)
+	// ListarNaoPublicados retorna os eventos pendentes ou que falharam, os mais antigos primeiro
+	ListarNaoPublicados(c context.Context) ([]*entities.EventoOutbox, error)
+}
@@ -10,7 +10,18 @@ import (
 type PedidoRepository interface {
 	CriarPedido(c context.Context, pedido *entities.Pedido) error
 	BuscarPedido(c context.Context, pedidoID int) (*entities.Pedido, error)
-	AtualizarStatusPedido(c context.Context, pedidoID int, status string, UltimaAtualizacao time.Time) error
+	// BuscarPedidoPorCodigo encontra o pedido pelo código de retirada gerado no dia informado
+	BuscarPedidoPorCodigo(c context.Context, codigo string, dia time.Time) (*entities.Pedido, error)
+	// AtualizarStatusPedido grava o novo status apenas se o pedido ainda estiver no status atual informado;
+	// caso contrário devolve entities.ErrConflitoStatus
+	AtualizarStatusPedido(c context.Context, pedidoID int, statusAtual, novoStatus string, UltimaAtualizacao time.Time) error
 	AtualizarStatusPagamento(c context.Context, pedidoID int, statusPagamento string, UltimaAtualizacao time.Time) error
-	ListarTodosOsPedidos(c context.Context) ([]*entities.Pedido, error)
+	// ConsultarPedidos retorna a página de pedidos que atendem aos filtros, na ordenação e a partir do cursor da consulta
+	ConsultarPedidos(c context.Context, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error)
+	// ListarFilaCozinha retorna os pedidos recebidos ou em preparação, por ordem de chegada
+	ListarFilaCozinha(c context.Context) ([]*entities.Pedido, error)
+	// CancelarPedido grava o cancelamento do pedido e devolve ao estoque as unidades reservadas por ele
+	CancelarPedido(c context.Context, pedido *entities.Pedido) error
+	// AtualizarPrevisoes grava a previsão de pronto recalculada de cada pedido
+	AtualizarPrevisoes(c context.Context, pedidos []*entities.Pedido) error
 }
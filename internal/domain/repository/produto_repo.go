@@ -8,8 +8,15 @@ import (
 type ProdutoRepository interface {
 	AdicionarProduto(c context.Context, produto *entities.Produto) error
 	BuscarProdutoPorId(c context.Context, id int) (*entities.Produto, error)
-	ListarTodosOsProdutos(c context.Context) ([]*entities.Produto, error)
+	// ConsultarProdutos retorna a página do cardápio que atende aos filtros, na ordenação e a partir do cursor da consulta
+	ConsultarProdutos(c context.Context, consulta entities.ConsultaProdutos) (*entities.Pagina[*entities.Produto], error)
 	EditarProduto(c context.Context, produto *entities.Produto) error
-	RemoverProduto(c context.Context, id int) error
+	// ArquivarProduto retira o produto do cardápio; ele continua disponível para os pedidos já feitos
+	ArquivarProduto(c context.Context, produto *entities.Produto) error
+	RestaurarProduto(c context.Context, produto *entities.Produto) error
 	ListarPorCategoria(c context.Context, categoria string) ([]*entities.Produto, error)
+	// AtualizarDisponibilidade grava apenas a disponibilidade manual do produto, sem tocar no estoque
+	AtualizarDisponibilidade(c context.Context, id int, esgotado bool) error
+	// DefinirEstoque grava apenas o estoque do produto, sem tocar na disponibilidade manual
+	DefinirEstoque(c context.Context, id int, estoque *int) error
 }
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// Transacao executa as operações de vários repositórios como uma só: ou todas são gravadas, ou nenhuma.
+// Os repositórios chamados com o contexto recebido por fn participam da transação.
+type Transacao interface {
+	Executar(c context.Context, fn func(c context.Context) error) error
+}
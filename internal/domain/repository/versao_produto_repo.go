@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"time"
+)
+
+// VersaoProdutoRepository define a interface para o histórico de versões dos produtos do catálogo
+type VersaoProdutoRepository interface {
+	RegistrarVersao(c context.Context, versao *entities.VersaoProduto) error
+	// ListarVersoes retorna as versões do produto em ordem cronológica
+	ListarVersoes(c context.Context, produtoID int) ([]entities.VersaoProduto, error)
+	// BuscarVersaoEm retorna a versão do produto em vigor no instante informado
+	BuscarVersaoEm(c context.Context, produtoID int, em time.Time) (*entities.VersaoProduto, error)
+}
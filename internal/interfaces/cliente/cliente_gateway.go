@@ -0,0 +1,14 @@
+// internal/interfaces/cliente/cliente_gateway.go
+package cliente
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+)
+
+// ClienteGateway consulta o serviço de clientes, dono do cadastro de clientes
+type ClienteGateway interface {
+	// BuscarCliente encontra o cliente pelo CPF ou pelo identificador da referência.
+	// Retorna entities.ErrClienteNaoEncontrado quando o serviço não conhece o cliente.
+	BuscarCliente(ctx context.Context, ref entities.ReferenciaCliente) (*entities.Cliente, error)
+}
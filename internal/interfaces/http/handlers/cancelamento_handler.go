@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	_ "lanchonete/docs"
+	"lanchonete/internal/application/presenters"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CancelamentoHandler struct {
+	PedidoCancelarUseCase usecases.PedidoCancelarUseCase
+}
+
+func NewCancelamentoHandler(pedidoCancelarUseCase usecases.PedidoCancelarUseCase) *CancelamentoHandler {
+	return &CancelamentoHandler{
+		PedidoCancelarUseCase: pedidoCancelarUseCase,
+	}
+}
+
+// CancelamentoRequest informa quem está cancelando o pedido e por quê
+type CancelamentoRequest struct {
+	Ator   string `json:"ator" binding:"required" example:"Cliente"` // Cliente ou Loja
+	Motivo string `json:"motivo" binding:"required" example:"Desisti do pedido"`
+}
+
+// CancelarPedido godoc
+// @Summary Cancela um pedido
+// @Description O cliente pode cancelar até o pedido entrar em preparação; a loja, até ele ficar pronto.
+// @Description O estoque reservado volta ao catálogo e o evento pedido_cancelado informa se há reembolso a fazer.
+// @Tags pedido
+// @Router /pedidos/{nroPedido}/cancelar [post]
+// @Accept  json
+// @Produce  json
+// @Param nroPedido path string true "Número do pedido"
+// @Param cancelamento body CancelamentoRequest true "Responsável e motivo"
+// @Success 200 {object} presenters.PedidoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse "O pedido não pode ser cancelado por esse ator no status atual"
+func (ch *CancelamentoHandler) CancelarPedido(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("nroPedido"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Número do pedido inválido"})
+		return
+	}
+
+	var req CancelamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	pedido, err := ch.PedidoCancelarUseCase.Run(comOrigemHTTP(c), id, req.Ator, req.Motivo)
+	if err != nil {
+		c.JSON(statusErroCancelamento(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewPedidoDTO(pedido))
+}
+
+func statusErroCancelamento(err error) int {
+	var naoPermitido *entities.CancelamentoNaoPermitidoError
+	switch {
+	case errors.Is(err, entities.ErrPedidoNaoEncontrado):
+		return http.StatusNotFound
+	case errors.As(err, &naoPermitido), isConflitoDeStatus(err):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrAtorCancelamentoInvalido), errors.Is(err, entities.ErrMotivoCancelamento):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockPedidoCancelarUseCase struct{ mock.Mock }
+
+func (m *MockPedidoCancelarUseCase) Run(c context.Context, pedidoID int, ator, motivo string) (*entities.Pedido, error) {
+	args := m.Called(c, pedidoID, ator, motivo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Pedido), args.Error(1)
+}
+
+func novoContextoCancelamento(id, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodPost, "/pedidos/"+id+"/cancelar", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "nroPedido", Value: id}}
+	return c, w
+}
+
+func TestCancelamentoHandler_CancelarPedido(t *testing.T) {
+	mockUC := new(MockPedidoCancelarUseCase)
+	handler := NewCancelamentoHandler(mockUC)
+
+	cancelado := &entities.Pedido{
+		ID:              1,
+		Status:          entities.Cancelado,
+		StatusPagamento: entities.PagamentoPago,
+		Cancelamento: &entities.Cancelamento{
+			Ator:                entities.CanceladoPelaLoja,
+			Motivo:              "faltou pão",
+			CanceladoEm:         time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+			ReembolsoNecessario: true,
+		},
+	}
+	mockUC.On("Run", mock.Anything, 1, "Loja", "faltou pão").Return(cancelado, nil)
+
+	c, w := novoContextoCancelamento("1", `{"ator":"Loja","motivo":"faltou pão"}`)
+	handler.CancelarPedido(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reembolsoNecessario":true`)
+	assert.Contains(t, w.Body.String(), `"status":"Cancelado"`)
+}
+
+func TestCancelamentoHandler_CancelarPedido_Erros(t *testing.T) {
+	mockUC := new(MockPedidoCancelarUseCase)
+	handler := NewCancelamentoHandler(mockUC)
+
+	mockUC.On("Run", mock.Anything, 2, "Cliente", "demorou").
+		Return(nil, &entities.CancelamentoNaoPermitidoError{Ator: entities.CanceladoPeloCliente, Status: entities.EmPreparacao})
+	mockUC.On("Run", mock.Anything, 3, "Loja", "erro").
+		Return(nil, &entities.TransicaoStatusError{De: entities.Finalizado, Para: entities.Cancelado})
+	mockUC.On("Run", mock.Anything, 4, "Cozinha", "erro").Return(nil, entities.ErrAtorCancelamentoInvalido)
+	mockUC.On("Run", mock.Anything, 5, "Loja", "erro").Return(nil, fmt.Errorf("não foi possível cancelar o pedido: %w", entities.ErrPedidoNaoEncontrado))
+
+	casos := []struct {
+		id     string
+		body   string
+		status int
+	}{
+		{"2", `{"ator":"Cliente","motivo":"demorou"}`, http.StatusConflict},
+		{"3", `{"ator":"Loja","motivo":"erro"}`, http.StatusConflict},
+		{"4", `{"ator":"Cozinha","motivo":"erro"}`, http.StatusBadRequest},
+		{"5", `{"ator":"Loja","motivo":"erro"}`, http.StatusNotFound},
+		{"6", `{"ator":"Loja"}`, http.StatusBadRequest},
+		{"abc", `{"ator":"Loja","motivo":"erro"}`, http.StatusBadRequest},
+	}
+
+	for _, caso := range casos {
+		c, w := novoContextoCancelamento(caso.id, caso.body)
+		handler.CancelarPedido(c)
+		assert.Equal(t, caso.status, w.Code, "pedido %s", caso.id)
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	_ "lanchonete/docs"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CategoriaHandler struct {
+	CategoriaIncluirUseCase     usecases.CategoriaIncluirUseCase
+	CategoriaListarTodasUseCase usecases.CategoriaListarTodasUseCase
+	CategoriaEditarUseCase      usecases.CategoriaEditarUseCase
+	CategoriaRemoverUseCase     usecases.CategoriaRemoverUseCase
+}
+
+func NewCategoriaHandler(categoriaIncluirUseCase usecases.CategoriaIncluirUseCase,
+	categoriaListarTodasUseCase usecases.CategoriaListarTodasUseCase,
+	categoriaEditarUseCase usecases.CategoriaEditarUseCase,
+	categoriaRemoverUseCase usecases.CategoriaRemoverUseCase) *CategoriaHandler {
+	return &CategoriaHandler{
+		CategoriaIncluirUseCase:     categoriaIncluirUseCase,
+		CategoriaListarTodasUseCase: categoriaListarTodasUseCase,
+		CategoriaEditarUseCase:      categoriaEditarUseCase,
+		CategoriaRemoverUseCase:     categoriaRemoverUseCase,
+	}
+}
+
+// CategoriaRequest descreve uma categoria do cardápio; o slug é gerado a partir do nome
+type CategoriaRequest struct {
+	Nome  string `json:"nome" example:"Café da manhã"`
+	Ordem int    `json:"ordem" example:"6"`
+	// Ativa é verdadeiro quando omitido
+	Ativa               *bool `json:"ativa,omitempty" example:"true"`
+	ObrigatoriaNoPedido bool  `json:"obrigatoriaNoPedido" example:"false"`
+	// TempoPreparoMinutos é o tempo de preparo padrão dos produtos da categoria; na edição, zero mantém o atual
+	TempoPreparoMinutos int `json:"tempoPreparoMinutos" example:"5"`
+}
+
+func (r CategoriaRequest) ativa() bool {
+	return r.Ativa == nil || *r.Ativa
+}
+
+// CategoriaIncluir godoc
+// @Summary Cria uma categoria
+// @Description Cadastra uma categoria do cardápio com a ordem de exibição, se todo pedido precisa de um item dela e o tempo de preparo padrão dos seus produtos
+// @Tags categoria
+// @Router /categorias [post]
+// @Accept  json
+// @Produce  json
+// @Param categoria body CategoriaRequest true "Categoria"
+// @Success 201 {object} entities.Categoria
+// @Failure 400 {object} response.ErrorResponse
+func (ch *CategoriaHandler) CategoriaIncluir(c *gin.Context) {
+	var req CategoriaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	categoria, err := ch.CategoriaIncluirUseCase.Run(c, req.Nome, req.Ordem, req.ativa(), req.ObrigatoriaNoPedido, req.TempoPreparoMinutos)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoria)
+}
+
+// CategoriaListarTodas godoc
+// @Summary Lista as categorias
+// @Description Lista as categorias na ordem de exibição do cardápio
+// @Tags categoria
+// @Router /categorias [get]
+// @Accept  json
+// @Produce  json
+// @Param incluirInativas query bool false "Inclui as categorias desativadas"
+// @Success 200 {object} []entities.Categoria
+// @Failure 500 {object} response.ErrorResponse
+func (ch *CategoriaHandler) CategoriaListarTodas(c *gin.Context) {
+	incluirInativas, _ := strconv.ParseBool(c.Query("incluirInativas"))
+
+	categorias, err := ch.CategoriaListarTodasUseCase.Run(c, incluirInativas)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, categorias)
+}
+
+// CategoriaEditar godoc
+// @Summary Edita uma categoria
+// @Description Altera nome, ordem e indicadores da categoria; renomear atualiza os produtos, combos e cupons dela
+// @Tags categoria
+// @Router /categorias/{id} [put]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID da categoria"
+// @Param categoria body CategoriaRequest true "Categoria"
+// @Success 200 {object} entities.Categoria
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse "A categoria Combo não pode ser renomeada"
+func (ch *CategoriaHandler) CategoriaEditar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID da categoria inválido"})
+		return
+	}
+
+	var req CategoriaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	categoria, err := ch.CategoriaEditarUseCase.Run(c, id, req.Nome, req.Ordem, req.ativa(), req.ObrigatoriaNoPedido, req.TempoPreparoMinutos)
+	if err != nil {
+		c.JSON(statusErroCategoria(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, categoria)
+}
+
+// CategoriaRemover godoc
+// @Summary Remove uma categoria
+// @Description Remove uma categoria sem produtos, combos ou cupons; para tirá-la do cardápio, desative-a
+// @Tags categoria
+// @Router /categorias/{id} [delete]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID da categoria"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse "A categoria está em uso ou é reservada"
+func (ch *CategoriaHandler) CategoriaRemover(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID da categoria inválido"})
+		return
+	}
+
+	if err := ch.CategoriaRemoverUseCase.Run(c, id); err != nil {
+		c.JSON(statusErroCategoria(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Categoria removida com sucesso",
+	})
+}
+
+func statusErroCategoria(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrCategoriaNaoEncontrada):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrCategoriaReservada), errors.Is(err, entities.ErrCategoriaEmUso):
+		return http.StatusConflict
+	case strings.Contains(err.Error(), "categoria inválida"), strings.Contains(err.Error(), "já existe uma categoria"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockCategoriaIncluirUseCase struct{ mock.Mock }
+
+func (m *MockCategoriaIncluirUseCase) Run(c context.Context, nome string, ordem int, ativa, obrigatoriaNoPedido bool, tempoPreparoMinutos int) (*entities.Categoria, error) {
+	args := m.Called(c, nome, ordem, ativa, obrigatoriaNoPedido, tempoPreparoMinutos)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Categoria), args.Error(1)
+}
+
+type MockCategoriaListarTodasUseCase struct{ mock.Mock }
+
+func (m *MockCategoriaListarTodasUseCase) Run(c context.Context, incluirInativas bool) ([]*entities.Categoria, error) {
+	args := m.Called(c, incluirInativas)
+	return args.Get(0).([]*entities.Categoria), args.Error(1)
+}
+
+type MockCategoriaEditarUseCase struct{ mock.Mock }
+
+func (m *MockCategoriaEditarUseCase) Run(c context.Context, id int, nome string, ordem int, ativa, obrigatoriaNoPedido bool, tempoPreparoMinutos int) (*entities.Categoria, error) {
+	args := m.Called(c, id, nome, ordem, ativa, obrigatoriaNoPedido, tempoPreparoMinutos)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Categoria), args.Error(1)
+}
+
+type MockCategoriaRemoverUseCase struct{ mock.Mock }
+
+func (m *MockCategoriaRemoverUseCase) Run(c context.Context, id int) error {
+	args := m.Called(c, id)
+	return args.Error(0)
+}
+
+func TestNewCategoriaHandler(t *testing.T) {
+	mockIncluir := new(MockCategoriaIncluirUseCase)
+	mockListar := new(MockCategoriaListarTodasUseCase)
+	mockEditar := new(MockCategoriaEditarUseCase)
+	mockRemover := new(MockCategoriaRemoverUseCase)
+
+	handler := NewCategoriaHandler(mockIncluir, mockListar, mockEditar, mockRemover)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockIncluir, handler.CategoriaIncluirUseCase)
+	assert.Equal(t, mockListar, handler.CategoriaListarTodasUseCase)
+	assert.Equal(t, mockEditar, handler.CategoriaEditarUseCase)
+	assert.Equal(t, mockRemover, handler.CategoriaRemoverUseCase)
+}
+
+func TestCategoriaHandler_CategoriaIncluir(t *testing.T) {
+	mockUC := new(MockCategoriaIncluirUseCase)
+	handler := &CategoriaHandler{CategoriaIncluirUseCase: mockUC}
+
+	criada := &entities.Categoria{ID: 6, Nome: "Café da manhã", Slug: "cafe-da-manha", Ordem: 6, Ativa: true}
+	mockUC.On("Run", mock.Anything, "Café da manhã", 6, true, false, 5).Return(criada, nil)
+
+	c, w := novoContextoCupom(http.MethodPost, "/categorias", `{"nome":"Café da manhã","ordem":6,"tempoPreparoMinutos":5}`, nil)
+	handler.CategoriaIncluir(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"slug":"cafe-da-manha"`)
+	mockUC.AssertExpectations(t)
+}
+
+func TestCategoriaHandler_CategoriaIncluir_Invalida(t *testing.T) {
+	mockUC := new(MockCategoriaIncluirUseCase)
+	handler := &CategoriaHandler{CategoriaIncluirUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, "Bebida", 0, false, false, 0).Return(nil, errors.New("já existe uma categoria com o nome Bebida"))
+
+	c, w := novoContextoCupom(http.MethodPost, "/categorias", `{"nome":"Bebida","ativa":false}`, nil)
+	handler.CategoriaIncluir(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCategoriaHandler_CategoriaListarTodas(t *testing.T) {
+	mockUC := new(MockCategoriaListarTodasUseCase)
+	handler := &CategoriaHandler{CategoriaListarTodasUseCase: mockUC}
+
+	categorias := []*entities.Categoria{{ID: 1, Nome: entities.Lanche, Slug: "lanche", Ordem: 1, Ativa: true, ObrigatoriaNoPedido: true}}
+	mockUC.On("Run", mock.Anything, true).Return(categorias, nil)
+
+	c, w := novoContextoCupom(http.MethodGet, "/categorias?incluirInativas=true", "", nil)
+	handler.CategoriaListarTodas(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"obrigatoriaNoPedido":true`)
+}
+
+func TestCategoriaHandler_CategoriaEditar(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Sucesso", nil, http.StatusOK},
+		{"Não encontrada", fmt.Errorf("não foi possível atualizar a categoria: %w", entities.ErrCategoriaNaoEncontrada), http.StatusNotFound},
+		{"Combo reservado", entities.ErrCategoriaReservada, http.StatusConflict},
+		{"Dados inválidos", errors.New("atualização de categoria inválida: o nome da categoria precisa ter letras ou números"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := new(MockCategoriaEditarUseCase)
+			handler := &CategoriaHandler{CategoriaEditarUseCase: mockUC}
+
+			var editada *entities.Categoria
+			if tt.err == nil {
+				editada = &entities.Categoria{ID: 3, Nome: "Bebidas", Slug: "bebidas", Ordem: 2, Ativa: true}
+			}
+			mockUC.On("Run", mock.Anything, 3, "Bebidas", 2, true, false, 0).Return(editada, tt.err)
+
+			c, w := novoContextoCupom(http.MethodPut, "/categorias/3", `{"nome":"Bebidas","ordem":2}`, gin.Params{{Key: "id", Value: "3"}})
+			handler.CategoriaEditar(c)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestCategoriaHandler_CategoriaRemover(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		err    error
+		status int
+	}{
+		{"Sucesso", "6", nil, http.StatusOK},
+		{"Em uso", "1", entities.ErrCategoriaEmUso, http.StatusConflict},
+		{"Não encontrada", "99", entities.ErrCategoriaNaoEncontrada, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := new(MockCategoriaRemoverUseCase)
+			handler := &CategoriaHandler{CategoriaRemoverUseCase: mockUC}
+
+			var id int
+			fmt.Sscan(tt.id, &id)
+			mockUC.On("Run", mock.Anything, id).Return(tt.err)
+
+			c, w := novoContextoCupom(http.MethodDelete, "/categorias/"+tt.id, "", gin.Params{{Key: "id", Value: tt.id}})
+			handler.CategoriaRemover(c)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+
+	c, w := novoContextoCupom(http.MethodDelete, "/categorias/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+	(&CategoriaHandler{}).CategoriaRemover(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
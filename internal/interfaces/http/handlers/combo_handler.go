@@ -0,0 +1,56 @@
+package handler
+
+import (
+	_ "lanchonete/docs"
+	"lanchonete/internal/application/presenters"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ComboHandler struct {
+	ComboIncluirUseCase usecases.ComboIncluirUseCase
+}
+
+func NewComboHandler(comboIncluirUseCase usecases.ComboIncluirUseCase) *ComboHandler {
+	return &ComboHandler{
+		ComboIncluirUseCase: comboIncluirUseCase,
+	}
+}
+
+// ComboIncluir godoc
+// @Summary Cria um combo
+// @Description Cria um combo com preço próprio. Cada componente é um produto fixo ({"produto": {"idProduto": 1}}) ou uma categoria a ser escolhida no pedido ({"categoria": "Bebida"})
+// @Tags produto
+// @Router /produto/combo [post]
+// @Accept  json
+// @Produce  json
+// @Param combo body entities.Produto true "Combo com seus componentes"
+// @Param X-Usuario header string false "Quem está alterando o catálogo, registrado no histórico do produto"
+// @Success 201 {object} presenters.ProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+func (ch *ComboHandler) ComboIncluir(c *gin.Context) {
+	var combo entities.Produto
+
+	if err := c.ShouldBindJSON(&combo); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	ctx, err := comAutor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	criado, err := ch.ComboIncluirUseCase.Run(ctx, combo.Nome, combo.Descricao, combo.Preco, combo.Componentes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, presenters.NewProdutoDTO(criado))
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockComboIncluirUseCase struct{ mock.Mock }
+
+func (m *MockComboIncluirUseCase) Run(c context.Context, nome, descricao string, preco entities.Money, componentes []entities.ComponenteCombo) (*entities.Produto, error) {
+	args := m.Called(c, nome, descricao, preco, componentes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Produto), args.Error(1)
+}
+
+func TestNewComboHandler(t *testing.T) {
+	mockIncluir := new(MockComboIncluirUseCase)
+
+	handler := NewComboHandler(mockIncluir)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockIncluir, handler.ComboIncluirUseCase)
+}
+
+func TestComboHandler_ComboIncluir(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockComboIncluirUseCase)
+	handler := &ComboHandler{ComboIncluirUseCase: mockUC}
+
+	componentes := []entities.ComponenteCombo{
+		{Produto: &entities.Produto{ID: 1}, Quantidade: 1},
+		{Categoria: entities.Bebida, Quantidade: 1},
+	}
+	criado := &entities.Produto{ID: 11, Nome: "Combo X-Salada", Categoria: entities.Combo, Preco: entities.Reais(26), Componentes: componentes}
+	mockUC.On("Run", mock.Anything, "Combo X-Salada", "X-Salada e bebida", entities.Reais(26), componentes).Return(criado, nil)
+
+	body := `{"nomeProduto":"Combo X-Salada","descricaoProduto":"X-Salada e bebida","precoProduto":26,
+		"componentes":[{"produto":{"idProduto":1},"quantidade":1},{"categoria":"Bebida","quantidade":1}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/produto/combo", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.ComboIncluir(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"categoria":"Combo"`)
+	assert.Contains(t, w.Body.String(), `"componentes"`)
+	mockUC.AssertExpectations(t)
+}
+
+func TestComboHandler_ComboIncluir_Invalido(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockComboIncluirUseCase)
+	handler := &ComboHandler{ComboIncluirUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, "Combo", "", entities.Reais(26), []entities.ComponenteCombo(nil)).
+		Return(nil, errors.New("criação de combo inválida: um combo precisa ter ao menos dois componentes"))
+
+	req, _ := http.NewRequest(http.MethodPost, "/produto/combo", bytes.NewBufferString(`{"nomeProduto":"Combo","precoProduto":26}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.ComboIncluir(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "ao menos dois componentes")
+}
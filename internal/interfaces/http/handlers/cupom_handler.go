@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	_ "lanchonete/docs"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CupomHandler struct {
+	CupomIncluirUseCase         usecases.CupomIncluirUseCase
+	CupomBuscarPorCodigoUseCase usecases.CupomBuscarPorCodigoUseCase
+	CupomListarTodosUseCase     usecases.CupomListarTodosUseCase
+	CupomEditarUseCase          usecases.CupomEditarUseCase
+	CupomRemoverUseCase         usecases.CupomRemoverUseCase
+}
+
+func NewCupomHandler(cupomIncluirUseCase usecases.CupomIncluirUseCase,
+	cupomBuscarPorCodigoUseCase usecases.CupomBuscarPorCodigoUseCase,
+	cupomListarTodosUseCase usecases.CupomListarTodosUseCase,
+	cupomEditarUseCase usecases.CupomEditarUseCase,
+	cupomRemoverUseCase usecases.CupomRemoverUseCase) *CupomHandler {
+	return &CupomHandler{
+		CupomIncluirUseCase:         cupomIncluirUseCase,
+		CupomBuscarPorCodigoUseCase: cupomBuscarPorCodigoUseCase,
+		CupomListarTodosUseCase:     cupomListarTodosUseCase,
+		CupomEditarUseCase:          cupomEditarUseCase,
+		CupomRemoverUseCase:         cupomRemoverUseCase,
+	}
+}
+
+// CupomIncluir godoc
+// @Summary Cria um cupom de desconto
+// @Description Cria um cupom percentual, de valor fixo ou compre e ganhe, opcionalmente restrito a uma categoria, com valor mínimo, validade e limite de usos
+// @Tags cupom
+// @Router /cupons [post]
+// @Accept  json
+// @Produce  json
+// @Param cupom body entities.Cupom true "Cupom"
+// @Success 201 {object} entities.Cupom
+// @Failure 400 {object} response.ErrorResponse
+func (ch *CupomHandler) CupomIncluir(c *gin.Context) {
+	var cupom entities.Cupom
+	if err := c.ShouldBindJSON(&cupom); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	criado, err := ch.CupomIncluirUseCase.Run(c, cupom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, criado)
+}
+
+// CupomBuscarPorCodigo godoc
+// @Summary Busca um cupom
+// @Description Busca um cupom pelo código
+// @Tags cupom
+// @Router /cupons/{codigo} [get]
+// @Accept  json
+// @Produce  json
+// @Param codigo path string true "Código do cupom"
+// @Success 200 {object} entities.Cupom
+// @Failure 404 {object} response.ErrorResponse
+func (ch *CupomHandler) CupomBuscarPorCodigo(c *gin.Context) {
+	cupom, err := ch.CupomBuscarPorCodigoUseCase.Run(c, c.Param("codigo"))
+	if err != nil {
+		c.JSON(statusErroCupom(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cupom)
+}
+
+// CupomListarTodos godoc
+// @Summary Lista os cupons
+// @Description Lista todos os cupons cadastrados com seus usos
+// @Tags cupom
+// @Router /cupons [get]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} []entities.Cupom
+// @Failure 500 {object} response.ErrorResponse
+func (ch *CupomHandler) CupomListarTodos(c *gin.Context) {
+	cupons, err := ch.CupomListarTodosUseCase.Run(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cupons)
+}
+
+// CupomEditar godoc
+// @Summary Edita um cupom
+// @Description Altera as regras de um cupom; o código e os usos já feitos são mantidos
+// @Tags cupom
+// @Router /cupons/{codigo} [put]
+// @Accept  json
+// @Produce  json
+// @Param codigo path string true "Código do cupom"
+// @Param cupom body entities.Cupom true "Cupom"
+// @Success 200 {object} entities.Cupom
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (ch *CupomHandler) CupomEditar(c *gin.Context) {
+	var cupom entities.Cupom
+	if err := c.ShouldBindJSON(&cupom); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	editado, err := ch.CupomEditarUseCase.Run(c, c.Param("codigo"), cupom)
+	if err != nil {
+		status := statusErroCupom(err)
+		if status == http.StatusInternalServerError && strings.Contains(err.Error(), "atualização de cupom inválida") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, editado)
+}
+
+// CupomRemover godoc
+// @Summary Remove um cupom
+// @Description Remove um cupom pelo código
+// @Tags cupom
+// @Router /cupons/{codigo} [delete]
+// @Accept  json
+// @Produce  json
+// @Param codigo path string true "Código do cupom"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+func (ch *CupomHandler) CupomRemover(c *gin.Context) {
+	err := ch.CupomRemoverUseCase.Run(c, c.Param("codigo"))
+	if err != nil {
+		c.JSON(statusErroCupom(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Cupom removido com sucesso",
+	})
+}
+
+func statusErroCupom(err error) int {
+	if errors.Is(err, entities.ErrCupomNaoEncontrado) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockCupomIncluirUseCase struct{ mock.Mock }
+
+func (m *MockCupomIncluirUseCase) Run(c context.Context, cupom entities.Cupom) (*entities.Cupom, error) {
+	args := m.Called(c, cupom)
+	return args.Get(0).(*entities.Cupom), args.Error(1)
+}
+
+type MockCupomBuscarPorCodigoUseCase struct{ mock.Mock }
+
+func (m *MockCupomBuscarPorCodigoUseCase) Run(c context.Context, codigo string) (*entities.Cupom, error) {
+	args := m.Called(c, codigo)
+	return args.Get(0).(*entities.Cupom), args.Error(1)
+}
+
+type MockCupomListarTodosUseCase struct{ mock.Mock }
+
+func (m *MockCupomListarTodosUseCase) Run(c context.Context) ([]*entities.Cupom, error) {
+	args := m.Called(c)
+	return args.Get(0).([]*entities.Cupom), args.Error(1)
+}
+
+type MockCupomEditarUseCase struct{ mock.Mock }
+
+func (m *MockCupomEditarUseCase) Run(c context.Context, codigo string, cupom entities.Cupom) (*entities.Cupom, error) {
+	args := m.Called(c, codigo, cupom)
+	return args.Get(0).(*entities.Cupom), args.Error(1)
+}
+
+type MockCupomRemoverUseCase struct{ mock.Mock }
+
+func (m *MockCupomRemoverUseCase) Run(c context.Context, codigo string) error {
+	args := m.Called(c, codigo)
+	return args.Error(0)
+}
+
+func novoContextoCupom(method, url, body string, params gin.Params) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	return c, w
+}
+
+func TestNewCupomHandler(t *testing.T) {
+	mockIncluir := new(MockCupomIncluirUseCase)
+	mockBuscar := new(MockCupomBuscarPorCodigoUseCase)
+	mockListar := new(MockCupomListarTodosUseCase)
+	mockEditar := new(MockCupomEditarUseCase)
+	mockRemover := new(MockCupomRemoverUseCase)
+
+	handler := NewCupomHandler(mockIncluir, mockBuscar, mockListar, mockEditar, mockRemover)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockIncluir, handler.CupomIncluirUseCase)
+	assert.Equal(t, mockBuscar, handler.CupomBuscarPorCodigoUseCase)
+	assert.Equal(t, mockListar, handler.CupomListarTodosUseCase)
+	assert.Equal(t, mockEditar, handler.CupomEditarUseCase)
+	assert.Equal(t, mockRemover, handler.CupomRemoverUseCase)
+}
+
+func TestCupomHandler_CupomIncluir(t *testing.T) {
+	mockUC := new(MockCupomIncluirUseCase)
+	handler := &CupomHandler{CupomIncluirUseCase: mockUC}
+
+	entrada := entities.Cupom{Codigo: "natal10", Tipo: entities.DescontoPercentual, Percentual: 10}
+	mockUC.On("Run", mock.Anything, entrada).Return(&entities.Cupom{ID: 1, Codigo: "NATAL10", Tipo: entities.DescontoPercentual, Percentual: 10}, nil)
+
+	c, w := novoContextoCupom(http.MethodPost, "/cupons", `{"codigo":"natal10","tipo":"Percentual","percentual":10}`, nil)
+	handler.CupomIncluir(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"codigo":"NATAL10"`)
+	mockUC.AssertExpectations(t)
+}
+
+func TestCupomHandler_CupomIncluir_Invalido(t *testing.T) {
+	mockUC := new(MockCupomIncluirUseCase)
+	handler := &CupomHandler{CupomIncluirUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, mock.Anything).Return((*entities.Cupom)(nil), errors.New("criação de cupom inválida: tipo de desconto inválido"))
+
+	c, w := novoContextoCupom(http.MethodPost, "/cupons", `{"codigo":"X"}`, nil)
+	handler.CupomIncluir(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCupomHandler_CupomBuscarPorCodigo(t *testing.T) {
+	mockUC := new(MockCupomBuscarPorCodigoUseCase)
+	handler := &CupomHandler{CupomBuscarPorCodigoUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, "NATAL10").Return(&entities.Cupom{ID: 1, Codigo: "NATAL10"}, nil)
+	mockUC.On("Run", mock.Anything, "NADA").Return((*entities.Cupom)(nil), fmt.Errorf("não foi possível buscar o cupom: %w", entities.ErrCupomNaoEncontrado))
+
+	c, w := novoContextoCupom(http.MethodGet, "/cupons/NATAL10", "", gin.Params{{Key: "codigo", Value: "NATAL10"}})
+	handler.CupomBuscarPorCodigo(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	c, w = novoContextoCupom(http.MethodGet, "/cupons/NADA", "", gin.Params{{Key: "codigo", Value: "NADA"}})
+	handler.CupomBuscarPorCodigo(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCupomHandler_CupomListarTodos(t *testing.T) {
+	mockUC := new(MockCupomListarTodosUseCase)
+	handler := &CupomHandler{CupomListarTodosUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything).Return([]*entities.Cupom{{ID: 1, Codigo: "A"}, {ID: 2, Codigo: "B"}}, nil)
+
+	c, w := novoContextoCupom(http.MethodGet, "/cupons", "", nil)
+	handler.CupomListarTodos(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"codigo":"B"`)
+}
+
+func TestCupomHandler_CupomEditar(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Sucesso", nil, http.StatusOK},
+		{"Não encontrado", fmt.Errorf("cupom não cadastrado: %w", entities.ErrCupomNaoEncontrado), http.StatusNotFound},
+		{"Inválido", errors.New("atualização de cupom inválida: o percentual de desconto deve estar entre 1 e 100"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := new(MockCupomEditarUseCase)
+			handler := &CupomHandler{CupomEditarUseCase: mockUC}
+
+			var retorno *entities.Cupom
+			if tt.err == nil {
+				retorno = &entities.Cupom{ID: 1, Codigo: "NATAL10", Tipo: entities.DescontoPercentual, Percentual: 15}
+			}
+			mockUC.On("Run", mock.Anything, "NATAL10", entities.Cupom{Tipo: entities.DescontoPercentual, Percentual: 15}).Return(retorno, tt.err)
+
+			c, w := novoContextoCupom(http.MethodPut, "/cupons/NATAL10", `{"tipo":"Percentual","percentual":15}`, gin.Params{{Key: "codigo", Value: "NATAL10"}})
+			handler.CupomEditar(c)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestCupomHandler_CupomRemover(t *testing.T) {
+	mockUC := new(MockCupomRemoverUseCase)
+	handler := &CupomHandler{CupomRemoverUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, "NATAL10").Return(nil)
+	mockUC.On("Run", mock.Anything, "NADA").Return(fmt.Errorf("não foi possível remover o cupom: %w", entities.ErrCupomNaoEncontrado))
+
+	c, w := novoContextoCupom(http.MethodDelete, "/cupons/NATAL10", "", gin.Params{{Key: "codigo", Value: "NATAL10"}})
+	handler.CupomRemover(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	c, w = novoContextoCupom(http.MethodDelete, "/cupons/NADA", "", gin.Params{{Key: "codigo", Value: "NADA"}})
+	handler.CupomRemover(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
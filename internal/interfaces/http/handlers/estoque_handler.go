@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	_ "lanchonete/docs"
+	"lanchonete/internal/application/presenters"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EstoqueHandler struct {
+	ProdutoAtualizarDisponibilidadeUseCase usecases.ProdutoAtualizarDisponibilidadeUseCase
+	ProdutoAjustarEstoqueUseCase           usecases.ProdutoAjustarEstoqueUseCase
+}
+
+func NewEstoqueHandler(produtoAtualizarDisponibilidadeUseCase usecases.ProdutoAtualizarDisponibilidadeUseCase,
+	produtoAjustarEstoqueUseCase usecases.ProdutoAjustarEstoqueUseCase) *EstoqueHandler {
+	return &EstoqueHandler{
+		ProdutoAtualizarDisponibilidadeUseCase: produtoAtualizarDisponibilidadeUseCase,
+		ProdutoAjustarEstoqueUseCase:           produtoAjustarEstoqueUseCase,
+	}
+}
+
+// DisponibilidadeRequest liga ou desliga manualmente a venda de um produto
+type DisponibilidadeRequest struct {
+	Esgotado bool `json:"esgotado"`
+}
+
+// EstoqueRequest define o estoque do produto; null deixa de controlar o estoque
+type EstoqueRequest struct {
+	Estoque *int `json:"estoque"`
+}
+
+// AtualizarDisponibilidade godoc
+// @Summary Marca um produto como esgotado ou disponível
+// @Description Tira o produto do cardápio ou o devolve, independentemente do estoque
+// @Tags estoque
+// @Router /produto/{id}/disponibilidade [put]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Param disponibilidade body DisponibilidadeRequest true "Disponibilidade"
+// @Success 200 {object} presenters.ProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (eh *EstoqueHandler) AtualizarDisponibilidade(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	var req DisponibilidadeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	produto, err := eh.ProdutoAtualizarDisponibilidadeUseCase.Run(c, id, req.Esgotado)
+	if err != nil {
+		c.JSON(statusErroEstoque(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewProdutoDTO(produto))
+}
+
+// AjustarEstoque godoc
+// @Summary Ajusta o estoque de um produto
+// @Description Define a quantidade em estoque do produto; estoque nulo deixa de controlar o estoque
+// @Tags estoque
+// @Router /produto/{id}/estoque [put]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Param estoque body EstoqueRequest true "Estoque"
+// @Success 200 {object} presenters.ProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (eh *EstoqueHandler) AjustarEstoque(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	var req EstoqueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	produto, err := eh.ProdutoAjustarEstoqueUseCase.Run(c, id, req.Estoque)
+	if err != nil {
+		c.JSON(statusErroEstoque(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewProdutoDTO(produto))
+}
+
+// statusErroEstoque traduz os erros dos casos de uso de estoque em status HTTP
+func statusErroEstoque(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrProdutoNaoEncontrado):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrEstoqueNegativo):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
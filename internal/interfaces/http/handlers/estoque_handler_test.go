@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockProdutoAtualizarDisponibilidadeUseCase struct{ mock.Mock }
+
+func (m *MockProdutoAtualizarDisponibilidadeUseCase) Run(c context.Context, id int, esgotado bool) (*entities.Produto, error) {
+	args := m.Called(c, id, esgotado)
+	return args.Get(0).(*entities.Produto), args.Error(1)
+}
+
+type MockProdutoAjustarEstoqueUseCase struct{ mock.Mock }
+
+func (m *MockProdutoAjustarEstoqueUseCase) Run(c context.Context, id int, estoque *int) (*entities.Produto, error) {
+	args := m.Called(c, id, estoque)
+	return args.Get(0).(*entities.Produto), args.Error(1)
+}
+
+func novoContextoEstoque(url, body string, id string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	return c, w
+}
+
+func TestNewEstoqueHandler(t *testing.T) {
+	mockDisponibilidade := new(MockProdutoAtualizarDisponibilidadeUseCase)
+	mockEstoque := new(MockProdutoAjustarEstoqueUseCase)
+
+	handler := NewEstoqueHandler(mockDisponibilidade, mockEstoque)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockDisponibilidade, handler.ProdutoAtualizarDisponibilidadeUseCase)
+	assert.Equal(t, mockEstoque, handler.ProdutoAjustarEstoqueUseCase)
+}
+
+func TestEstoqueHandler_AtualizarDisponibilidade(t *testing.T) {
+	mockUC := new(MockProdutoAtualizarDisponibilidadeUseCase)
+	handler := &EstoqueHandler{ProdutoAtualizarDisponibilidadeUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, 1, true).Return(&entities.Produto{ID: 1, Nome: "X-Salada", Esgotado: true}, nil)
+	mockUC.On("Run", mock.Anything, 99, true).Return((*entities.Produto)(nil), fmt.Errorf("não foi possível buscar o produto: %w", entities.ErrProdutoNaoEncontrado))
+
+	c, w := novoContextoEstoque("/produto/1/disponibilidade", `{"esgotado":true}`, "1")
+	handler.AtualizarDisponibilidade(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"esgotado":true`)
+
+	c, w = novoContextoEstoque("/produto/99/disponibilidade", `{"esgotado":true}`, "99")
+	handler.AtualizarDisponibilidade(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	c, w = novoContextoEstoque("/produto/abc/disponibilidade", `{"esgotado":true}`, "abc")
+	handler.AtualizarDisponibilidade(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEstoqueHandler_AjustarEstoque(t *testing.T) {
+	mockUC := new(MockProdutoAjustarEstoqueUseCase)
+	handler := &EstoqueHandler{ProdutoAjustarEstoqueUseCase: mockUC}
+
+	cinco := 5
+	negativo := -1
+	mockUC.On("Run", mock.Anything, 2, &cinco).Return(&entities.Produto{ID: 2, Nome: "Coca-cola", Estoque: &cinco}, nil)
+	mockUC.On("Run", mock.Anything, 2, &negativo).Return((*entities.Produto)(nil), fmt.Errorf("ajuste de estoque inválido: %w", entities.ErrEstoqueNegativo))
+	mockUC.On("Run", mock.Anything, 2, (*int)(nil)).Return(&entities.Produto{ID: 2, Nome: "Coca-cola"}, nil)
+
+	c, w := novoContextoEstoque("/produto/2/estoque", `{"estoque":5}`, "2")
+	handler.AjustarEstoque(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"estoque":5`)
+
+	c, w = novoContextoEstoque("/produto/2/estoque", `{"estoque":-1}`, "2")
+	handler.AjustarEstoque(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	c, w = novoContextoEstoque("/produto/2/estoque", `{"estoque":null}`, "2")
+	handler.AjustarEstoque(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"estoque"`)
+}
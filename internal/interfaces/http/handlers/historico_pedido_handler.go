@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	_ "lanchonete/docs"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// comOrigemHTTP devolve o contexto da requisição marcado como origem das mudanças de status do pedido
+func comOrigemHTTP(c *gin.Context) context.Context {
+	return usecases.ComOrigem(c, entities.OrigemHTTP)
+}
+
+type HistoricoPedidoHandler struct {
+	PedidoHistoricoUseCase usecases.PedidoHistoricoUseCase
+}
+
+func NewHistoricoPedidoHandler(pedidoHistoricoUseCase usecases.PedidoHistoricoUseCase) *HistoricoPedidoHandler {
+	return &HistoricoPedidoHandler{
+		PedidoHistoricoUseCase: pedidoHistoricoUseCase,
+	}
+}
+
+// Historico godoc
+// @Summary Histórico de status de um pedido
+// @Description Lista as mudanças de status e de status de pagamento do pedido, com o instante e a origem de cada uma (http, sqs, agendador)
+// @Tags pedido
+// @Router /pedidos/{nroPedido}/historico [get]
+// @Produce  json
+// @Param nroPedido path string true "Número do pedido"
+// @Success 200 {array} entities.AlteracaoPedido
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (hh *HistoricoPedidoHandler) Historico(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("nroPedido"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Número do pedido inválido"})
+		return
+	}
+
+	historico, err := hh.PedidoHistoricoUseCase.Run(c, id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, entities.ErrPedidoNaoEncontrado) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, historico)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockPedidoHistoricoUseCase struct{ mock.Mock }
+
+func (m *MockPedidoHistoricoUseCase) Run(c context.Context, pedidoID int) ([]entities.AlteracaoPedido, error) {
+	args := m.Called(c, pedidoID)
+	return args.Get(0).([]entities.AlteracaoPedido), args.Error(1)
+}
+
+func novoContextoHistoricoPedido(nroPedido string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/pedidos/"+nroPedido+"/historico", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "nroPedido", Value: nroPedido}}
+	return c, w
+}
+
+func TestHistoricoPedidoHandler_Historico(t *testing.T) {
+	mockUC := new(MockPedidoHistoricoUseCase)
+	handler := NewHistoricoPedidoHandler(mockUC)
+
+	inicio := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	historico := []entities.AlteracaoPedido{
+		{ID: 1, PedidoID: 42, Campo: entities.AlteracaoStatus, Para: "Pendente", Origem: entities.OrigemHTTP, AlteradoEm: inicio},
+		{ID: 2, PedidoID: 42, Campo: entities.AlteracaoPagamento, De: "Pendente", Para: "Pago", Origem: entities.OrigemConsumidorSQS, AlteradoEm: inicio.Add(time.Minute)},
+	}
+	mockUC.On("Run", mock.Anything, 42).Return(historico, nil)
+	mockUC.On("Run", mock.Anything, 7).Return([]entities.AlteracaoPedido(nil), entities.ErrPedidoNaoEncontrado)
+
+	c, w := novoContextoHistoricoPedido("42")
+	handler.Historico(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"origem":"sqs"`)
+	assert.Contains(t, w.Body.String(), `"de":"Pendente","para":"Pago"`)
+
+	c, w = novoContextoHistoricoPedido("7")
+	handler.Historico(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	c, w = novoContextoHistoricoPedido("abc")
+	handler.Historico(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestComOrigemHTTP(t *testing.T) {
+	c, _ := novoContextoHistoricoPedido("1")
+	assert.Equal(t, entities.OrigemHTTP, usecases.OrigemDe(comOrigemHTTP(c)))
+}
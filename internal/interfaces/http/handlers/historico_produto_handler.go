@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	_ "lanchonete/docs"
+	"lanchonete/internal/application/presenters"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderAutor identifica quem está alterando o catálogo, para o histórico de versões dos produtos
+const HeaderAutor = "X-Usuario"
+
+// comAutor devolve o contexto da requisição marcado com o autor informado no cabeçalho, recusando um
+// autor maior do que o histórico guarda
+func comAutor(c *gin.Context) (context.Context, error) {
+	autor := strings.TrimSpace(c.GetHeader(HeaderAutor))
+	if utf8.RuneCountInString(autor) > entities.TamanhoMaximoAutor {
+		return nil, fmt.Errorf("cabeçalho %s inválido: %w", HeaderAutor, entities.ErrAutorMuitoLongo)
+	}
+	return usecases.ComAutor(c, autor), nil
+}
+
+type HistoricoProdutoHandler struct {
+	ProdutoHistoricoUseCase usecases.ProdutoHistoricoUseCase
+	ProdutoVersaoEmUseCase  usecases.ProdutoVersaoEmUseCase
+}
+
+func NewHistoricoProdutoHandler(produtoHistoricoUseCase usecases.ProdutoHistoricoUseCase,
+	produtoVersaoEmUseCase usecases.ProdutoVersaoEmUseCase) *HistoricoProdutoHandler {
+	return &HistoricoProdutoHandler{
+		ProdutoHistoricoUseCase: produtoHistoricoUseCase,
+		ProdutoVersaoEmUseCase:  produtoVersaoEmUseCase,
+	}
+}
+
+// Historico godoc
+// @Summary Histórico de alterações de um produto
+// @Description Lista todas as versões do produto, com o autor, a data e os campos alterados em cada uma
+// @Tags produto
+// @Router /produto/{id}/historico [get]
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Success 200 {array} presenters.VersaoProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (hh *HistoricoProdutoHandler) Historico(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	versoes, err := hh.ProdutoHistoricoUseCase.Run(c, id)
+	if err != nil {
+		c.JSON(statusErroHistorico(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewHistoricoProdutoDTO(versoes))
+}
+
+// VersaoEm godoc
+// @Summary Versão de um produto em uma data
+// @Description Informa como o produto estava, e quanto custava, no instante informado
+// @Tags produto
+// @Router /produto/{id}/historico/versao [get]
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Param em query string true "Instante no formato RFC 3339, ex.: 2025-03-10T12:00:00-03:00"
+// @Success 200 {object} presenters.VersaoProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (hh *HistoricoProdutoHandler) VersaoEm(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	em, err := time.Parse(time.RFC3339, c.Query("em"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "informe o instante em no formato RFC 3339"})
+		return
+	}
+
+	versao, err := hh.ProdutoVersaoEmUseCase.Run(c, id, em)
+	if err != nil {
+		c.JSON(statusErroHistorico(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewVersaoProdutoDTO(*versao, nil))
+}
+
+func statusErroHistorico(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "não encontrado"), errors.Is(err, entities.ErrSemVersaoVigente):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+	"lanchonete/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockProdutoHistoricoUseCase struct{ mock.Mock }
+
+func (m *MockProdutoHistoricoUseCase) Run(c context.Context, id int) ([]entities.VersaoProduto, error) {
+	args := m.Called(c, id)
+	return args.Get(0).([]entities.VersaoProduto), args.Error(1)
+}
+
+type MockProdutoVersaoEmUseCase struct{ mock.Mock }
+
+func (m *MockProdutoVersaoEmUseCase) Run(c context.Context, id int, em time.Time) (*entities.VersaoProduto, error) {
+	args := m.Called(c, id, em)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.VersaoProduto), args.Error(1)
+}
+
+func novoContextoHistorico(url, id string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	return c, w
+}
+
+func TestHistoricoProdutoHandler_Historico(t *testing.T) {
+	mockUC := new(MockProdutoHistoricoUseCase)
+	handler := NewHistoricoProdutoHandler(mockUC, nil)
+
+	inicio := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	versoes := []entities.VersaoProduto{
+		{ID: 1, ProdutoID: 1, Nome: "X-Salada", Preco: entities.Reais(22.5), Autor: "gerente", VigenteDesde: inicio},
+		{ID: 2, ProdutoID: 1, Nome: "X-Salada", Preco: entities.Reais(24.0), Autor: "caixa", VigenteDesde: inicio.Add(time.Hour)},
+	}
+	mockUC.On("Run", mock.Anything, 1).Return(versoes, nil)
+	mockUC.On("Run", mock.Anything, 2).Return([]entities.VersaoProduto(nil), errors.New("produto não existe no banco de dados: produto não encontrado"))
+
+	c, w := novoContextoHistorico("/produto/1/historico", "1")
+	handler.Historico(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"autor":"caixa"`)
+	assert.Contains(t, w.Body.String(), `"camposAlterados":["preco"]`)
+
+	c, w = novoContextoHistorico("/produto/2/historico", "2")
+	handler.Historico(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHistoricoProdutoHandler_VersaoEm(t *testing.T) {
+	mockUC := new(MockProdutoVersaoEmUseCase)
+	handler := NewHistoricoProdutoHandler(nil, mockUC)
+
+	em := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	mockUC.On("Run", mock.Anything, 1, em).Return(&entities.VersaoProduto{ID: 1, ProdutoID: 1, Preco: entities.Reais(22.5)}, nil)
+	mockUC.On("Run", mock.Anything, 2, em).Return(nil, entities.ErrSemVersaoVigente)
+
+	c, w := novoContextoHistorico("/produto/1/historico/versao?em=2025-03-10T12:00:00Z", "1")
+	handler.VersaoEm(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"precoProduto":22.5`)
+
+	c, w = novoContextoHistorico("/produto/2/historico/versao?em=2025-03-10T12:00:00Z", "2")
+	handler.VersaoEm(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	c, w = novoContextoHistorico("/produto/1/historico/versao?em=ontem", "1")
+	handler.VersaoEm(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestComAutor_LeCabecalho(t *testing.T) {
+	c, _ := novoContextoHistorico("/produto/editar", "1")
+	c.Request.Header.Set(HeaderAutor, " gerente ")
+	ctx, err := comAutor(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "gerente", usecases.AutorDe(ctx))
+
+	c, _ = novoContextoHistorico("/produto/editar", "1")
+	ctx, err = comAutor(c)
+	assert.NoError(t, err)
+	assert.Equal(t, usecases.AutorDesconhecido, usecases.AutorDe(ctx))
+
+	// O histórico guarda até entities.TamanhoMaximoAutor caracteres
+	c, _ = novoContextoHistorico("/produto/editar", "1")
+	c.Request.Header.Set(HeaderAutor, strings.Repeat("a", entities.TamanhoMaximoAutor+1))
+	_, err = comAutor(c)
+	assert.ErrorIs(t, err, entities.ErrAutorMuitoLongo)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	_ "lanchonete/docs"
+	"lanchonete/internal/application/presenters"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImagemProdutoHandler struct {
+	ProdutoImagemEnviarUseCase  usecases.ProdutoImagemEnviarUseCase
+	ProdutoImagemRemoverUseCase usecases.ProdutoImagemRemoverUseCase
+}
+
+func NewImagemProdutoHandler(produtoImagemEnviarUseCase usecases.ProdutoImagemEnviarUseCase,
+	produtoImagemRemoverUseCase usecases.ProdutoImagemRemoverUseCase) *ImagemProdutoHandler {
+	return &ImagemProdutoHandler{
+		ProdutoImagemEnviarUseCase:  produtoImagemEnviarUseCase,
+		ProdutoImagemRemoverUseCase: produtoImagemRemoverUseCase,
+	}
+}
+
+// EnviarImagem godoc
+// @Summary Envia a imagem de um produto
+// @Description Recebe um JPEG ou PNG de até 5 MB, gera as variantes média e miniatura e substitui a imagem anterior
+// @Tags produto
+// @Router /produto/{id}/imagem [post]
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Param imagem formData file true "Arquivo da imagem"
+// @Success 200 {object} presenters.ProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 415 {object} response.ErrorResponse
+func (ih *ImagemProdutoHandler) EnviarImagem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	arquivo, err := c.FormFile("imagem")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "envie o arquivo no campo 'imagem'"})
+		return
+	}
+	if arquivo.Size > entities.TamanhoMaximoImagem {
+		c.JSON(http.StatusRequestEntityTooLarge, response.ErrorResponse{Message: entities.ErrImagemGrande.Error()})
+		return
+	}
+
+	f, err := arquivo.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+	defer f.Close()
+
+	conteudo, err := io.ReadAll(io.LimitReader(f, entities.TamanhoMaximoImagem+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	// O tipo declarado pelo cliente não é confiável; vale o que o conteúdo do arquivo indica
+	contentType := http.DetectContentType(conteudo)
+
+	produto, err := ih.ProdutoImagemEnviarUseCase.Run(c, id, conteudo, contentType)
+	if err != nil {
+		c.JSON(statusErroImagem(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewProdutoDTO(produto))
+}
+
+// RemoverImagem godoc
+// @Summary Remove a imagem de um produto
+// @Description Apaga a imagem do produto e todas as suas variantes
+// @Tags produto
+// @Router /produto/{id}/imagem [delete]
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Success 200 {object} presenters.ProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (ih *ImagemProdutoHandler) RemoverImagem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	produto, err := ih.ProdutoImagemRemoverUseCase.Run(c, id)
+	if err != nil {
+		c.JSON(statusErroImagem(err), response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewProdutoDTO(produto))
+}
+
+// statusErroImagem traduz os erros dos casos de uso de imagem em status HTTP
+func statusErroImagem(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "produto não encontrado"), errors.Is(err, entities.ErrProdutoSemFoto):
+		return http.StatusNotFound
+	case errors.Is(err, entities.ErrProdutoArquivado):
+		return http.StatusConflict
+	case errors.Is(err, entities.ErrImagemGrande), errors.Is(err, entities.ErrDimensoesImagem):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, entities.ErrFormatoImagem):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, entities.ErrImagemVazia):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockProdutoImagemEnviarUseCase struct{ mock.Mock }
+
+func (m *MockProdutoImagemEnviarUseCase) Run(c context.Context, id int, conteudo []byte, contentType string) (*entities.Produto, error) {
+	args := m.Called(c, id, conteudo, contentType)
+	return args.Get(0).(*entities.Produto), args.Error(1)
+}
+
+type MockProdutoImagemRemoverUseCase struct{ mock.Mock }
+
+func (m *MockProdutoImagemRemoverUseCase) Run(c context.Context, id int) (*entities.Produto, error) {
+	args := m.Called(c, id)
+	return args.Get(0).(*entities.Produto), args.Error(1)
+}
+
+// novoContextoImagem monta um envio multipart com o arquivo no campo informado
+func novoContextoImagem(t *testing.T, campo string, conteudo []byte, id string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(campo, "foto.png")
+	assert.NoError(t, err)
+	_, err = part.Write(conteudo)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/produto/"+id+"/imagem", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	return c, w
+}
+
+func pngTeste(t *testing.T) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func TestNewImagemProdutoHandler(t *testing.T) {
+	mockEnviar := new(MockProdutoImagemEnviarUseCase)
+	mockRemover := new(MockProdutoImagemRemoverUseCase)
+
+	handler := NewImagemProdutoHandler(mockEnviar, mockRemover)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockEnviar, handler.ProdutoImagemEnviarUseCase)
+	assert.Equal(t, mockRemover, handler.ProdutoImagemRemoverUseCase)
+}
+
+func TestImagemProdutoHandler_EnviarImagem(t *testing.T) {
+	arquivo := pngTeste(t)
+	produto := &entities.Produto{ID: 1, Nome: "X-Salada", Imagens: []entities.ImagemProduto{
+		{Variante: entities.ImagemMiniatura, URL: "http://imagens/produtos/1/miniatura-1.png"},
+	}}
+
+	mockUC := new(MockProdutoImagemEnviarUseCase)
+	handler := &ImagemProdutoHandler{ProdutoImagemEnviarUseCase: mockUC}
+	mockUC.On("Run", mock.Anything, 1, arquivo, "image/png").Return(produto, nil)
+	mockUC.On("Run", mock.Anything, 2, mock.Anything, "image/png").Return((*entities.Produto)(nil), entities.ErrProdutoArquivado)
+	mockUC.On("Run", mock.Anything, 3, mock.Anything, "image/png").Return((*entities.Produto)(nil), entities.ErrDimensoesImagem)
+	mockUC.On("Run", mock.Anything, 99, mock.Anything, "image/png").Return((*entities.Produto)(nil), errors.New("produto não existe no banco de dados: produto não encontrado"))
+	mockUC.On("Run", mock.Anything, 1, mock.Anything, "text/plain; charset=utf-8").Return((*entities.Produto)(nil), entities.ErrFormatoImagem)
+
+	c, w := novoContextoImagem(t, "imagem", arquivo, "1")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"miniatura":"http://imagens/produtos/1/miniatura-1.png"`)
+
+	c, w = novoContextoImagem(t, "imagem", arquivo, "2")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	c, w = novoContextoImagem(t, "imagem", arquivo, "99")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	c, w = novoContextoImagem(t, "imagem", []byte("não sou uma imagem"), "1")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	c, w = novoContextoImagem(t, "arquivo", arquivo, "1")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	c, w = novoContextoImagem(t, "imagem", make([]byte, entities.TamanhoMaximoImagem+1), "1")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	c, w = novoContextoImagem(t, "imagem", arquivo, "3")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	c, w = novoContextoImagem(t, "imagem", arquivo, "abc")
+	handler.EnviarImagem(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestImagemProdutoHandler_RemoverImagem(t *testing.T) {
+	mockUC := new(MockProdutoImagemRemoverUseCase)
+	handler := &ImagemProdutoHandler{ProdutoImagemRemoverUseCase: mockUC}
+	mockUC.On("Run", mock.Anything, 1).Return(&entities.Produto{ID: 1, Nome: "X-Salada"}, nil)
+	mockUC.On("Run", mock.Anything, 2).Return((*entities.Produto)(nil), entities.ErrProdutoSemFoto)
+
+	novoContexto := func(id string) (*gin.Context, *httptest.ResponseRecorder) {
+		gin.SetMode(gin.TestMode)
+		req, _ := http.NewRequest(http.MethodDelete, "/produto/"+id+"/imagem", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		return c, w
+	}
+
+	c, w := novoContexto("1")
+	handler.RemoverImagem(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"imagens"`)
+
+	c, w = novoContexto("2")
+	handler.RemoverImagem(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	c, w = novoContexto("abc")
+	handler.RemoverImagem(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paginacaoQuery lê os parâmetros comuns às listagens paginadas: ?ordem=-total&cursor=...&limite=50
+func paginacaoQuery(c *gin.Context) (entities.Ordenacao, *entities.Cursor, int, error) {
+	ordem := entities.OrdenacaoParse(c.Query("ordem"))
+
+	cursor, err := entities.CursorParse(c.Query("cursor"))
+	if err != nil {
+		return ordem, nil, 0, err
+	}
+
+	limite := 0
+	if valor := strings.TrimSpace(c.Query("limite")); valor != "" {
+		if limite, err = strconv.Atoi(valor); err != nil || limite <= 0 {
+			return ordem, nil, 0, entities.ErrLimiteInvalido
+		}
+	}
+	return ordem, cursor, limite, nil
+}
+
+// instanteQuery lê uma data (2006-01-02, no fuso da lanchonete) ou um instante RFC 3339. Uma data no
+// fim do período vale até o último instante do dia.
+func instanteQuery(c *gin.Context, chave string, fimDoPeriodo bool) (*time.Time, error) {
+	valor := strings.TrimSpace(c.Query(chave))
+	if valor == "" {
+		return nil, nil
+	}
+
+	if dia, err := time.ParseInLocation("2006-01-02", valor, time.Local); err == nil {
+		if fimDoPeriodo {
+			dia = dia.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return &dia, nil
+	}
+	instante, err := time.Parse(time.RFC3339, valor)
+	if err != nil {
+		return nil, fmt.Errorf("%s inválido: use AAAA-MM-DD ou RFC 3339", chave)
+	}
+	return &instante, nil
+}
+
+// precoQuery lê um valor em reais, como 25.90
+func precoQuery(c *gin.Context, chave string) (*entities.Money, error) {
+	valor := strings.TrimSpace(c.Query(chave))
+	if valor == "" {
+		return nil, nil
+	}
+	preco, err := entities.MoneyParse(valor)
+	if err != nil {
+		return nil, fmt.Errorf("%s inválido: %w", chave, err)
+	}
+	return &preco, nil
+}
+
+// isConsultaInvalida identifica os erros de filtro, ordenação ou paginação informados pelo cliente
+func isConsultaInvalida(err error) bool {
+	for _, alvo := range []error{
+		entities.ErrCursorInvalido,
+		entities.ErrOrdemInvalida,
+		entities.ErrLimiteInvalido,
+		entities.ErrPeriodoInvalido,
+		entities.ErrFaixaPreco,
+		entities.ErrStatusInvalido,
+		entities.ErrStatusPagamentoInvalido,
+	} {
+		if errors.Is(err, alvo) {
+			return true
+		}
+	}
+	return false
+}
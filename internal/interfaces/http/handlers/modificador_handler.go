@@ -0,0 +1,120 @@
+package handler
+
+import (
+	_ "lanchonete/docs"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModificadorHandler struct {
+	GrupoModificadorIncluirUseCase          usecases.GrupoModificadorIncluirUseCase
+	GrupoModificadorListarPorProdutoUseCase usecases.GrupoModificadorListarPorProdutoUseCase
+	GrupoModificadorRemoverUseCase          usecases.GrupoModificadorRemoverUseCase
+}
+
+func NewModificadorHandler(grupoModificadorIncluirUseCase usecases.GrupoModificadorIncluirUseCase,
+	grupoModificadorListarPorProdutoUseCase usecases.GrupoModificadorListarPorProdutoUseCase,
+	grupoModificadorRemoverUseCase usecases.GrupoModificadorRemoverUseCase) *ModificadorHandler {
+	return &ModificadorHandler{
+		GrupoModificadorIncluirUseCase:          grupoModificadorIncluirUseCase,
+		GrupoModificadorListarPorProdutoUseCase: grupoModificadorListarPorProdutoUseCase,
+		GrupoModificadorRemoverUseCase:          grupoModificadorRemoverUseCase,
+	}
+}
+
+// IncluirGrupoModificador godoc
+// @Summary Cria um grupo de modificadores
+// @Description Cria um grupo de modificadores (ex: "Adicionais") com suas opções e acréscimos de preço para um produto
+// @Tags modificador
+// @Router /produto/{id}/modificadores [post]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Param grupo body entities.GrupoModificador true "Grupo de modificadores"
+// @Success 201 {object} entities.GrupoModificador
+// @Failure 400 {object} response.ErrorResponse
+func (mh *ModificadorHandler) IncluirGrupoModificador(c *gin.Context) {
+	produtoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	var grupo entities.GrupoModificador
+	if err := c.ShouldBindJSON(&grupo); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	criado, err := mh.GrupoModificadorIncluirUseCase.Run(c, produtoID, grupo.Nome, grupo.MinSelecoes, grupo.MaxSelecoes, grupo.Opcoes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, criado)
+}
+
+// ListarGruposModificador godoc
+// @Summary Lista os modificadores de um produto
+// @Description Lista os grupos de modificadores de um produto com suas opções
+// @Tags modificador
+// @Router /produto/{id}/modificadores [get]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Success 200 {object} []entities.GrupoModificador
+// @Failure 400 {object} response.ErrorResponse
+func (mh *ModificadorHandler) ListarGruposModificador(c *gin.Context) {
+	produtoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	grupos, err := mh.GrupoModificadorListarPorProdutoUseCase.Run(c, produtoID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, grupos)
+}
+
+// RemoverGrupoModificador godoc
+// @Summary Remove um grupo de modificadores
+// @Description Remove um grupo de modificadores e suas opções
+// @Tags modificador
+// @Router /produto/modificadores/{idGrupo} [delete]
+// @Accept  json
+// @Produce  json
+// @Param idGrupo path int true "ID do grupo de modificadores"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 404 {object} response.ErrorResponse
+func (mh *ModificadorHandler) RemoverGrupoModificador(c *gin.Context) {
+	grupoID, err := strconv.Atoi(c.Param("idGrupo"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do grupo inválido"})
+		return
+	}
+
+	err = mh.GrupoModificadorRemoverUseCase.Run(c, grupoID)
+	if err != nil {
+		if strings.Contains(err.Error(), "não encontrado") {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SuccessResponse{
+		Message: "Grupo de modificadores removido com sucesso",
+	})
+}
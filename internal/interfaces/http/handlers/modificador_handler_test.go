@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockGrupoModificadorIncluirUseCase struct{ mock.Mock }
+
+func (m *MockGrupoModificadorIncluirUseCase) Run(c context.Context, produtoID int, nome string, minSelecoes int, maxSelecoes int, opcoes []entities.Modificador) (*entities.GrupoModificador, error) {
+	args := m.Called(c, produtoID, nome, minSelecoes, maxSelecoes, opcoes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.GrupoModificador), args.Error(1)
+}
+
+type MockGrupoModificadorListarPorProdutoUseCase struct{ mock.Mock }
+
+func (m *MockGrupoModificadorListarPorProdutoUseCase) Run(c context.Context, produtoID int) ([]entities.GrupoModificador, error) {
+	args := m.Called(c, produtoID)
+	return args.Get(0).([]entities.GrupoModificador), args.Error(1)
+}
+
+type MockGrupoModificadorRemoverUseCase struct{ mock.Mock }
+
+func (m *MockGrupoModificadorRemoverUseCase) Run(c context.Context, grupoID int) error {
+	args := m.Called(c, grupoID)
+	return args.Error(0)
+}
+
+func TestNewModificadorHandler(t *testing.T) {
+	mockIncluir := new(MockGrupoModificadorIncluirUseCase)
+	mockListar := new(MockGrupoModificadorListarPorProdutoUseCase)
+	mockRemover := new(MockGrupoModificadorRemoverUseCase)
+
+	handler := NewModificadorHandler(mockIncluir, mockListar, mockRemover)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockIncluir, handler.GrupoModificadorIncluirUseCase)
+	assert.Equal(t, mockListar, handler.GrupoModificadorListarPorProdutoUseCase)
+	assert.Equal(t, mockRemover, handler.GrupoModificadorRemoverUseCase)
+}
+
+func TestModificadorHandler_IncluirGrupoModificador(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockGrupoModificadorIncluirUseCase)
+	handler := &ModificadorHandler{GrupoModificadorIncluirUseCase: mockUC}
+
+	grupo := entities.GrupoModificador{
+		Nome:        "Adicionais",
+		MaxSelecoes: 2,
+		Opcoes:      []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Reais(4.0)}},
+	}
+	criado := grupo
+	criado.ID = 1
+	criado.ProdutoID = 1
+	mockUC.On("Run", mock.Anything, 1, "Adicionais", 0, 2, grupo.Opcoes).Return(&criado, nil)
+
+	body, _ := json.Marshal(grupo)
+	req, _ := http.NewRequest(http.MethodPost, "/produto/1/modificadores", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	handler.IncluirGrupoModificador(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"idGrupo":1`)
+	mockUC.AssertExpectations(t)
+}
+
+func TestModificadorHandler_IncluirGrupoModificador_Invalido(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockGrupoModificadorIncluirUseCase)
+	handler := &ModificadorHandler{GrupoModificadorIncluirUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, 1, "", 0, 0, []entities.Modificador(nil)).
+		Return(nil, errors.New("falha ao criar grupo de modificadores: o nome do grupo de modificadores é obrigatório"))
+
+	req, _ := http.NewRequest(http.MethodPost, "/produto/1/modificadores", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	handler.IncluirGrupoModificador(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "nome do grupo")
+}
+
+func TestModificadorHandler_ListarGruposModificador(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockGrupoModificadorListarPorProdutoUseCase)
+	handler := &ModificadorHandler{GrupoModificadorListarPorProdutoUseCase: mockUC}
+
+	mockUC.On("Run", mock.Anything, 1).Return([]entities.GrupoModificador{{ID: 1, ProdutoID: 1, Nome: "Adicionais"}}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/produto/1/modificadores", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	handler.ListarGruposModificador(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Adicionais")
+}
+
+func TestModificadorHandler_RemoverGrupoModificador(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Sucesso", nil, http.StatusOK},
+		{"Não encontrado", errors.New("grupo de modificadores não encontrado"), http.StatusNotFound},
+		{"Erro de banco", errors.New("erro de conexão"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := new(MockGrupoModificadorRemoverUseCase)
+			handler := &ModificadorHandler{GrupoModificadorRemoverUseCase: mockUC}
+			mockUC.On("Run", mock.Anything, 5).Return(tt.err)
+
+			req, _ := http.NewRequest(http.MethodDelete, "/produto/modificadores/5", nil)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "idGrupo", Value: "5"}}
+
+			handler.RemoverGrupoModificador(c)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
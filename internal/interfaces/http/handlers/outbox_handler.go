@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	_ "lanchonete/docs"
+	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
+	"lanchonete/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutboxHandler struct {
+	OutboxListarTravadosUseCase usecases.OutboxListarTravadosUseCase
+	OutboxReprocessarUseCase    usecases.OutboxReprocessarUseCase
+}
+
+func NewOutboxHandler(outboxListarTravadosUseCase usecases.OutboxListarTravadosUseCase, outboxReprocessarUseCase usecases.OutboxReprocessarUseCase) *OutboxHandler {
+	return &OutboxHandler{
+		OutboxListarTravadosUseCase: outboxListarTravadosUseCase,
+		OutboxReprocessarUseCase:    outboxReprocessarUseCase,
+	}
+}
+
+// ListarTravados godoc
+// @Summary Eventos travados na outbox
+// @Description Lista os eventos que esgotaram as tentativas de publicação ou que esperam publicação há mais que OUTBOX_ALERTA, com o último erro de cada um
+// @Tags admin
+// @Router /admin/outbox/travados [get]
+// @Produce  json
+// @Success 200 {array} entities.EventoOutbox
+// @Failure 500 {object} response.ErrorResponse
+func (oh *OutboxHandler) ListarTravados(c *gin.Context) {
+	eventos, err := oh.OutboxListarTravadosUseCase.Run(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, eventos)
+}
+
+// Reprocessar godoc
+// @Summary Reprocessar evento da outbox
+// @Description Devolve um evento não publicado ao relay, com as tentativas zeradas
+// @Tags admin
+// @Router /admin/outbox/{id}/reprocessar [post]
+// @Produce  json
+// @Param id path int true "ID do evento"
+// @Success 200 {object} entities.EventoOutbox
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+func (oh *OutboxHandler) Reprocessar(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do evento inválido"})
+		return
+	}
+
+	evento, err := oh.OutboxReprocessarUseCase.Run(c, id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, entities.ErrEventoJaPublicado):
+			status = http.StatusConflict
+		case strings.Contains(err.Error(), "evento não encontrado"):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, evento)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"lanchonete/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock UseCases ---
+type MockOutboxListarTravadosUseCase struct{ mock.Mock }
+
+func (m *MockOutboxListarTravadosUseCase) Run(c context.Context) ([]*entities.EventoOutbox, error) {
+	args := m.Called(c)
+	return args.Get(0).([]*entities.EventoOutbox), args.Error(1)
+}
+
+type MockOutboxReprocessarUseCase struct{ mock.Mock }
+
+func (m *MockOutboxReprocessarUseCase) Run(c context.Context, id int64) (*entities.EventoOutbox, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.EventoOutbox), args.Error(1)
+}
+
+func novoContextoOutbox(method, id string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(method, "/admin/outbox", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	return c, w
+}
+
+func TestOutboxHandler_ListarTravados(t *testing.T) {
+	mockListar := new(MockOutboxListarTravadosUseCase)
+	handler := NewOutboxHandler(mockListar, new(MockOutboxReprocessarUseCase))
+
+	criadoEm := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	eventos := []*entities.EventoOutbox{
+		{ID: 3, Fila: entities.FilaPedidos, Tipo: "pedido_criado", Payload: []byte(`{"id_pedido":7}`), Status: entities.EventoFalhou, Tentativas: 10, UltimoErro: "fila indisponível", CriadoEm: criadoEm},
+	}
+	mockListar.On("Run", mock.Anything).Return(eventos, nil).Once()
+
+	c, w := novoContextoOutbox(http.MethodGet, "")
+	handler.ListarTravados(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"payload":{"id_pedido":7}`)
+	assert.Contains(t, w.Body.String(), `"ultimo_erro":"fila indisponível"`)
+
+	mockListar.On("Run", mock.Anything).Return([]*entities.EventoOutbox(nil), errors.New("db fora"))
+	c, w = novoContextoOutbox(http.MethodGet, "")
+	handler.ListarTravados(c)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestOutboxHandler_Reprocessar(t *testing.T) {
+	mockReprocessar := new(MockOutboxReprocessarUseCase)
+	handler := NewOutboxHandler(new(MockOutboxListarTravadosUseCase), mockReprocessar)
+
+	mockReprocessar.On("Run", mock.Anything, int64(3)).Return(&entities.EventoOutbox{ID: 3, Status: entities.EventoPendente}, nil)
+	mockReprocessar.On("Run", mock.Anything, int64(4)).Return(nil, entities.ErrEventoJaPublicado)
+	mockReprocessar.On("Run", mock.Anything, int64(5)).Return(nil, errors.New("evento não encontrado"))
+
+	tests := []struct {
+		id     string
+		status int
+	}{
+		{"3", http.StatusOK},
+		{"4", http.StatusConflict},
+		{"5", http.StatusNotFound},
+		{"abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		c, w := novoContextoOutbox(http.MethodPost, tt.id)
+		handler.Reprocessar(c)
+		assert.Equal(t, tt.status, w.Code, "id %s", tt.id)
+	}
+}
@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	_ "lanchonete/docs"
+	"lanchonete/internal/application/presenters"
 	"lanchonete/internal/domain/entities"
 	response "lanchonete/internal/interfaces/http/responses"
 	"lanchonete/usecases"
@@ -20,6 +22,8 @@ type PedidoHandler struct {
 	PedidoAtualizarStatusPagamentoUseCase usecases.PedidoAtualizarStatusPagamentoUseCase
 	ProdutoBuscarPorIdUseCase             usecases.ProdutoBuscaPorIdUseCase
 	PedidoListarTodosUseCase              usecases.PedidoListarTodosUseCase
+	PedidoListarPorClienteUseCase         usecases.PedidoListarPorClienteUseCase
+	PedidoBuscarPorCodigoUseCase          usecases.PedidoBuscarPorCodigoUseCase
 }
 
 func NewPedidoHandler(pedidoIncluirUseCase usecases.PedidoIncluirUseCase,
@@ -27,7 +31,9 @@ func NewPedidoHandler(pedidoIncluirUseCase usecases.PedidoIncluirUseCase,
 	pedidoAtualizarStatusUsecase usecases.PedidoAtualizarStatusUseCase,
 	pedidoAtualizarStatusPagamentoUseCase usecases.PedidoAtualizarStatusPagamentoUseCase,
 	produtoBuscarPorIdUseCase usecases.ProdutoBuscaPorIdUseCase,
-	pedidoListarTodosUseCase usecases.PedidoListarTodosUseCase) *PedidoHandler {
+	pedidoListarTodosUseCase usecases.PedidoListarTodosUseCase,
+	pedidoListarPorClienteUseCase usecases.PedidoListarPorClienteUseCase,
+	pedidoBuscarPorCodigoUseCase usecases.PedidoBuscarPorCodigoUseCase) *PedidoHandler {
 	return &PedidoHandler{
 		PedidoIncluirUseCase:                  pedidoIncluirUseCase,
 		PedidoBuscarPorIdUseCase:              pedidoBuscarPorIdUseCase,
@@ -35,19 +41,26 @@ func NewPedidoHandler(pedidoIncluirUseCase usecases.PedidoIncluirUseCase,
 		PedidoAtualizarStatusPagamentoUseCase: pedidoAtualizarStatusPagamentoUseCase,
 		ProdutoBuscarPorIdUseCase:             produtoBuscarPorIdUseCase,
 		PedidoListarTodosUseCase:              pedidoListarTodosUseCase,
+		PedidoListarPorClienteUseCase:         pedidoListarPorClienteUseCase,
+		PedidoBuscarPorCodigoUseCase:          pedidoBuscarPorCodigoUseCase,
 	}
 }
 
 // CriarPedido godoc
 // @Summary Cria um pedido
-// @Description Cria um pedido
+// @Description Cria um pedido. O cliente pode ser identificado pelo CPF ou pelo identificador no serviço de clientes,
+// @Description em "cliente"; nesse caso o nome cadastrado substitui cliente_nome.
 // @Tags pedido
 // @Router /pedidos [post]
 // @Accept  json
 // @Produce  json
 // @Param pedido body entities.Pedido true "Pedido"
-// @Success 200 {object} response.SuccessResponse
+// @Success 200 {object} response.PedidoCriadoResponse "Pedido criado, com o código de retirada e os avisos de alérgenos dos produtos"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ProdutosIndisponiveisResponse "Produtos esgotados ou sem estoque"
+// @Failure 422 {object} response.RegrasPedidoResponse "Pedido fora das regras da loja, com todas as violações"
+// @Failure 422 {object} response.ErrorResponse "Cupom recusado ou cliente não encontrado"
+// @Failure 503 {object} response.ErrorResponse "Serviço de clientes indisponível"
 func (h *PedidoHandler) CriarPedido(r *gin.Context) {
 	var pedido entities.Pedido
 	fmt.Println("Handler Criando pedido", pedido)
@@ -58,33 +71,74 @@ func (h *PedidoHandler) CriarPedido(r *gin.Context) {
 		return
 	}
 
-	// Substituir o array de produtos com os dados completos do banco
-	produtosCompletos := []entities.Produto{}
+	// Substituir o produto de cada item com os dados completos do banco
+	itensCompletos := []entities.ItemPedido{}
 
-	for _, produto := range pedido.Produtos {
-		pBanco, err := h.ProdutoBuscarPorIdUseCase.Run(r, produto.ID)
+	for _, item := range pedido.Itens {
+		pBanco, err := h.ProdutoBuscarPorIdUseCase.Run(r, item.Produto.ID)
 		if err != nil {
 			r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Produto não Cadastrado!"})
 			return
 		}
-		produtosCompletos = append(produtosCompletos, *pBanco)
+
+		// Produtos escolhidos para as posições livres de um combo
+		var escolhas []entities.ItemCombo
+		for _, escolha := range item.Componentes {
+			eBanco, err := h.ProdutoBuscarPorIdUseCase.Run(r, escolha.Produto.ID)
+			if err != nil {
+				r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Produto não Cadastrado!"})
+				return
+			}
+			escolhas = append(escolhas, entities.ItemCombo{Produto: *eBanco})
+		}
+
+		itensCompletos = append(itensCompletos, entities.ItemPedido{
+			Produto:       *pBanco,
+			Quantidade:    item.Quantidade,
+			Modificadores: item.Modificadores,
+			Componentes:   escolhas,
+		})
 	}
 
-	// Chamar PedidoNew com os produtos completos
-	ped, err := h.PedidoIncluirUseCase.Run(r, pedido.ClienteNome, produtosCompletos, pedido.Personalizacao)
+	// Chamar PedidoNew com os itens completos
+	ped, err := h.PedidoIncluirUseCase.Run(comOrigemHTTP(r), pedido.ClienteNome, pedido.Cliente, itensCompletos, pedido.Personalizacao, pedido.Cupom)
 	if err != nil {
+		var indisponiveis *entities.ProdutosIndisponiveisError
+		if errors.As(err, &indisponiveis) {
+			r.JSON(http.StatusConflict, response.ProdutosIndisponiveisResponse{Message: err.Error(), Produtos: indisponiveis.Produtos})
+			return
+		}
+		var violadas *entities.RegrasPedidoError
+		if errors.As(err, &violadas) {
+			r.JSON(http.StatusUnprocessableEntity, response.RegrasPedidoResponse{Message: err.Error(), Violacoes: violadas.Violacoes})
+			return
+		}
+		if entities.CupomRecusado(err) || errors.Is(err, entities.ErrClienteNaoEncontrado) {
+			r.JSON(http.StatusUnprocessableEntity, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+		if errors.Is(err, entities.ErrCPFInvalido) || errors.Is(err, entities.ErrClienteSemReferencia) || errors.Is(err, entities.ErrQuantidadeExcedida) {
+			r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+		if errors.Is(err, entities.ErrServicoClientesIndisponivel) {
+			r.JSON(http.StatusServiceUnavailable, response.ErrorResponse{Message: err.Error()})
+			return
+		}
 		r.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	r.JSON(http.StatusOK, response.SuccessResponse{
-		Message: "Pedido criado com sucesso" + strconv.Itoa(ped.ID),
+	r.JSON(http.StatusOK, response.PedidoCriadoResponse{
+		Message:         "Pedido criado com sucesso" + strconv.Itoa(ped.ID),
+		CodigoRetirada:  ped.CodigoRetirada,
+		AvisosAlergenos: ped.AvisosAlergenos,
 	})
 }
 
 // BuscarPedido godoc
 // @Summary Busca um pedido
-// @Description Busca um pedido
+// @Description Busca um pedido, com o tempo que ele passou em cada status
 // @Tags pedido
 // @Router /pedidos/{ID} [get]
 // @Accept  json
@@ -109,9 +163,36 @@ func (h *PedidoHandler) BuscarPedido(r *gin.Context) {
 
 }
 
+// BuscarPedidoPorCodigo godoc
+// @Summary Busca um pedido pelo código de retirada
+// @Description Busca o pedido de hoje com o código chamado no balcão, como A-017. Os códigos recomeçam todo dia.
+// @Tags pedido
+// @Router /pedidos/codigo/{codigo} [get]
+// @Produce  json
+// @Param codigo path string true "Código de retirada, com ou sem hífen"
+// @Success 200 {object} entities.Pedido
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+func (h *PedidoHandler) BuscarPedidoPorCodigo(r *gin.Context) {
+	pedido, err := h.PedidoBuscarPorCodigoUseCase.Run(r, r.Param("codigo"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, entities.ErrCodigoRetiradaInvalido):
+			status = http.StatusBadRequest
+		case errors.Is(err, entities.ErrPedidoNaoEncontrado):
+			status = http.StatusNotFound
+		}
+		r.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	r.JSON(http.StatusOK, pedido)
+}
+
 // AtualizarPedido godoc
 // @Summary Atualiza um pedido a partir de sua Identificação
-// @Description Atualizar um pedido
+// @Description Atualizar um pedido; para cancelar, use POST /pedidos/{nroPedido}/cancelar
 // @Tags pedido
 // @Router /pedidos/{nroPedido}/status/{status} [put]
 // @Accept  json
@@ -120,6 +201,7 @@ func (h *PedidoHandler) BuscarPedido(r *gin.Context) {
 // @Param status path string true "Novo Status do pedido"
 // @Success 200 {object} entities.Pedido
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 func (h *PedidoHandler) AtualizarStatusPedido(r *gin.Context) {
 	nroPedido := r.Param("nroPedido")
 	id, err := strconv.Atoi(nroPedido)
@@ -132,9 +214,13 @@ func (h *PedidoHandler) AtualizarStatusPedido(r *gin.Context) {
 	status := r.Param("status")
 	fmt.Printf("Atualizando pedido ID: %d para status: '%s'\n", id, status)
 
-	err = h.PedidoAtualizarStatusUseCase.Run(r, id, status)
+	err = h.PedidoAtualizarStatusUseCase.Run(comOrigemHTTP(r), id, status)
 	if err != nil {
 		fmt.Printf("Erro ao atualizar status: %v\n", err)
+		if isConflitoDeStatus(err) {
+			r.JSON(http.StatusConflict, response.ErrorResponse{Message: err.Error()})
+			return
+		}
 		r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
 		return
 	}
@@ -145,6 +231,33 @@ func (h *PedidoHandler) AtualizarStatusPedido(r *gin.Context) {
 	})
 }
 
+// ProximosStatusPedido godoc
+// @Summary Lista os próximos status permitidos de um pedido
+// @Description Retorna o status atual do pedido e os status aceitos por PUT /pedidos/{nroPedido}/status/{status}
+// @Tags pedido
+// @Router /pedidos/{nroPedido}/status [get]
+// @Accept  json
+// @Produce  json
+// @Param nroPedido path string true "Número do pedido"
+// @Success 200 {object} presenters.ProximosStatusDTO
+// @Failure 400 {object} response.ErrorResponse
+func (h *PedidoHandler) ProximosStatusPedido(r *gin.Context) {
+	nroPedido := r.Param("nroPedido")
+	id, err := strconv.Atoi(nroPedido)
+	if err != nil {
+		r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "Número do pedido inválido"})
+		return
+	}
+
+	pedido, err := h.PedidoBuscarPorIdUseCase.Run(r, id)
+	if err != nil {
+		r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	r.JSON(http.StatusOK, presenters.NewProximosStatusDTO(pedido))
+}
+
 // AtualizarStatusPagamento godoc
 // @Summary Atualiza o status de pagamento de um pedido
 // @Description Atualizar o status de pagamento de um pedido
@@ -156,6 +269,7 @@ func (h *PedidoHandler) AtualizarStatusPedido(r *gin.Context) {
 // @Param statusPagamento path string true "Novo Status de pagamento (Pendente, Pago, Recusado, Cancelado)"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 func (h *PedidoHandler) AtualizarStatusPagamento(r *gin.Context) {
 	nroPedido := r.Param("nroPedido")
 	id, err := strconv.Atoi(nroPedido)
@@ -168,9 +282,13 @@ func (h *PedidoHandler) AtualizarStatusPagamento(r *gin.Context) {
 	statusPagamento := r.Param("statusPagamento")
 	fmt.Printf("Atualizando status de pagamento do pedido ID: %d para status: '%s'\n", id, statusPagamento)
 
-	err = h.PedidoAtualizarStatusPagamentoUseCase.Run(r, id, statusPagamento)
+	err = h.PedidoAtualizarStatusPagamentoUseCase.Run(comOrigemHTTP(r), id, statusPagamento)
 	if err != nil {
 		fmt.Printf("Erro ao atualizar status de pagamento: %v\n", err)
+		if isConflitoDeStatus(err) {
+			r.JSON(http.StatusConflict, response.ErrorResponse{Message: err.Error()})
+			return
+		}
 		r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
 		return
 	}
@@ -181,21 +299,113 @@ func (h *PedidoHandler) AtualizarStatusPagamento(r *gin.Context) {
 	})
 }
 
-// ProdutoListarTodos godoc
-// @Summary Lista todos os pedidos no banco
-// @Description Lista todos os pedidos presentes no banco
+// ListarTodosOsPedidos godoc
+// @Summary Lista os pedidos, em páginas
+// @Description Lista os pedidos que atendem aos filtros. A resposta traz o total de pedidos encontrados e, quando há mais pedidos, o next_cursor a enviar no parâmetro cursor para pedir a próxima página.
 // @Tags pedido
 // @Router /pedidos/listartodos [GET]
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} []entities.Pedido
+// @Param status query string false "Status do pedido, ex.: Recebido"
+// @Param statusPagamento query string false "Status do pagamento, ex.: Pago"
+// @Param de query string false "Pedidos feitos a partir desta data (AAAA-MM-DD) ou instante (RFC 3339)"
+// @Param ate query string false "Pedidos feitos até esta data (AAAA-MM-DD, inclusive) ou instante (RFC 3339)"
+// @Param clienteNome query string false "Parte do nome do cliente"
+// @Param ordem query string false "criado_em (padrão) ou total; prefixo - para ordem decrescente, ex.: -total"
+// @Param cursor query string false "next_cursor da página anterior"
+// @Param limite query int false "Pedidos por página, de 1 a 100 (padrão 20)"
+// @Success 200 {object} response.ListaResponse[entities.Pedido]
 // @Failure 400 {object} response.ErrorResponse
 func (h *PedidoHandler) ListarTodosOsPedidos(r *gin.Context) {
-	pedidos, err := h.PedidoListarTodosUseCase.Run(r)
+	consulta, err := consultaPedidos(r)
 	if err != nil {
-		r.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	pagina, err := h.PedidoListarTodosUseCase.Run(r, consulta)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if isConsultaInvalida(err) {
+			status = http.StatusBadRequest
+		}
+		r.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	r.JSON(http.StatusOK, response.NewListaResponse(pagina, func(p *entities.Pedido) *entities.Pedido { return p }))
+}
+
+// consultaPedidos lê da query string os filtros, a ordenação e a página da listagem de pedidos
+func consultaPedidos(r *gin.Context) (entities.ConsultaPedidos, error) {
+	consulta := entities.ConsultaPedidos{
+		Status:          entities.StatusPedido(r.Query("status")),
+		StatusPagamento: entities.StatusPagamento(r.Query("statusPagamento")),
+		ClienteNome:     r.Query("clienteNome"),
+	}
+
+	var err error
+	if consulta.De, err = instanteQuery(r, "de", false); err != nil {
+		return consulta, err
+	}
+	if consulta.Ate, err = instanteQuery(r, "ate", true); err != nil {
+		return consulta, err
+	}
+	consulta.Ordem, consulta.Cursor, consulta.Limite, err = paginacaoQuery(r)
+	return consulta, err
+}
+
+// ListarPedidos godoc
+// @Summary Lista os pedidos, em páginas
+// @Description Lista os pedidos; com cliente, apenas os pedidos do cliente identificado pelo CPF ou pelo identificador no serviço de clientes. Com ou sem cliente, responde como /pedidos/listartodos, em páginas e com os mesmos filtros.
+// @Tags pedido
+// @Router /pedidos [GET]
+// @Produce  json
+// @Param cliente query string false "CPF, com ou sem pontuação, ou identificador do cliente"
+// @Param status query string false "Status do pedido, ex.: Recebido"
+// @Param statusPagamento query string false "Status do pagamento, ex.: Pago"
+// @Param de query string false "Pedidos feitos a partir desta data (AAAA-MM-DD) ou instante (RFC 3339)"
+// @Param ate query string false "Pedidos feitos até esta data (AAAA-MM-DD, inclusive) ou instante (RFC 3339)"
+// @Param clienteNome query string false "Parte do nome do cliente"
+// @Param ordem query string false "criado_em (padrão) ou total; prefixo - para ordem decrescente, ex.: -total"
+// @Param cursor query string false "next_cursor da página anterior"
+// @Param limite query int false "Pedidos por página, de 1 a 100 (padrão 20)"
+// @Success 200 {object} response.ListaResponse[entities.Pedido]
+// @Failure 400 {object} response.ErrorResponse
+func (h *PedidoHandler) ListarPedidos(r *gin.Context) {
+	cliente := r.Query("cliente")
+	if cliente == "" {
+		h.ListarTodosOsPedidos(r)
 		return
 	}
 
-	r.JSON(http.StatusOK, pedidos)
+	consulta, err := consultaPedidos(r)
+	if err != nil {
+		r.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	pagina, err := h.PedidoListarPorClienteUseCase.Run(r, cliente, consulta)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, entities.ErrClienteSemReferencia) || isConsultaInvalida(err) {
+			status = http.StatusBadRequest
+		}
+		r.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	r.JSON(http.StatusOK, response.NewListaResponse(pagina, func(p *entities.Pedido) *entities.Pedido { return p }))
+}
+
+// isConflitoDeStatus identifica erros de regra do ciclo de vida do pedido, respondidos com 409
+func isConflitoDeStatus(err error) bool {
+	var transicaoErr *entities.TransicaoStatusError
+	var transicaoPagamentoErr *entities.TransicaoPagamentoError
+	return errors.As(err, &transicaoErr) ||
+		errors.As(err, &transicaoPagamentoErr) ||
+		errors.Is(err, entities.ErrPagamentoNaoConfirmado) ||
+		errors.Is(err, entities.ErrCancelamentoViaStatus) ||
+		errors.Is(err, entities.ErrConflitoStatus) ||
+		errors.Is(err, entities.ErrPagamentoPedidoCancelado)
 }
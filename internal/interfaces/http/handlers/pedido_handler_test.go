@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"lanchonete/internal/domain/entities"
 
@@ -18,8 +21,8 @@ import (
 // --- Mock UseCases ---
 type MockPedidoIncluirUseCase struct{ mock.Mock }
 
-func (m *MockPedidoIncluirUseCase) Run(ctx context.Context, clienteNome string, produtos []entities.Produto, personalizacao *string) (*entities.Pedido, error) {
-	args := m.Called(ctx, clienteNome, produtos, personalizacao)
+func (m *MockPedidoIncluirUseCase) Run(ctx context.Context, clienteNome string, cliente *entities.ReferenciaCliente, itens []entities.ItemPedido, personalizacao *string, cupom *string) (*entities.Pedido, error) {
+	args := m.Called(ctx, clienteNome, cliente, itens, personalizacao, cupom)
 	return args.Get(0).(*entities.Pedido), args.Error(1)
 }
 
@@ -53,9 +56,28 @@ func (m *MockProdutoBuscarPorIdUseCase) Run(ctx context.Context, id int) (*entit
 
 type MockPedidoListarTodosUseCase struct{ mock.Mock }
 
-func (m *MockPedidoListarTodosUseCase) Run(ctx context.Context) ([]*entities.Pedido, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*entities.Pedido), args.Error(1)
+func (m *MockPedidoListarTodosUseCase) Run(ctx context.Context, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error) {
+	args := m.Called(ctx, consulta)
+	pagina, _ := args.Get(0).(*entities.Pagina[*entities.Pedido])
+	return pagina, args.Error(1)
+}
+
+type MockPedidoListarPorClienteUseCase struct{ mock.Mock }
+
+func (m *MockPedidoListarPorClienteUseCase) Run(ctx context.Context, cliente string, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error) {
+	args := m.Called(ctx, cliente, consulta)
+	pagina, _ := args.Get(0).(*entities.Pagina[*entities.Pedido])
+	return pagina, args.Error(1)
+}
+
+type MockPedidoBuscarPorCodigoUseCase struct{ mock.Mock }
+
+func (m *MockPedidoBuscarPorCodigoUseCase) Run(ctx context.Context, codigo string) (*entities.Pedido, error) {
+	args := m.Called(ctx, codigo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Pedido), args.Error(1)
 }
 
 // --- Teste do Construtor ---
@@ -67,6 +89,8 @@ func TestNewPedidoHandler(t *testing.T) {
 	mockAtualizarStatusPagamento := new(MockPedidoAtualizarStatusPagamentoUseCase)
 	mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
 	mockListarTodos := new(MockPedidoListarTodosUseCase)
+	mockListarPorCliente := new(MockPedidoListarPorClienteUseCase)
+	mockBuscarPorCodigo := new(MockPedidoBuscarPorCodigoUseCase)
 
 	// Testar construtor
 	handler := NewPedidoHandler(
@@ -76,6 +100,8 @@ func TestNewPedidoHandler(t *testing.T) {
 		mockAtualizarStatusPagamento,
 		mockProdutoBuscar,
 		mockListarTodos,
+		mockListarPorCliente,
+		mockBuscarPorCodigo,
 	)
 
 	// Verificações
@@ -86,6 +112,8 @@ func TestNewPedidoHandler(t *testing.T) {
 	assert.Equal(t, mockAtualizarStatusPagamento, handler.PedidoAtualizarStatusPagamentoUseCase)
 	assert.Equal(t, mockProdutoBuscar, handler.ProdutoBuscarPorIdUseCase)
 	assert.Equal(t, mockListarTodos, handler.PedidoListarTodosUseCase)
+	assert.Equal(t, mockListarPorCliente, handler.PedidoListarPorClienteUseCase)
+	assert.Equal(t, mockBuscarPorCodigo, handler.PedidoBuscarPorCodigoUseCase)
 }
 
 // --- Testes dos Métodos ---
@@ -106,31 +134,33 @@ func TestPedidoHandler_CriarPedido(t *testing.T) {
 		Nome:      "Hamburger",
 		Categoria: "Lanche",
 		Descricao: "Hamburger clássico",
-		Preco:     15.0,
+		Preco:     entities.Reais(15.0),
 	}
 
-	// Pedido de entrada com apenas o ID do produto
+	// Pedido de entrada com apenas o ID do produto e a quantidade
 	personalizacao := "Sem cebola"
 	pedidoRequest := entities.Pedido{
 		ClienteNome: "João Silva",
-		Produtos: []entities.Produto{
-			{ID: 1}, // Apenas ID será enviado
+		Itens: []entities.ItemPedido{
+			{Produto: entities.Produto{ID: 1}, Quantidade: 2}, // Apenas ID e quantidade serão enviados
 		},
 		Personalizacao: &personalizacao,
 	}
+	itensCompletos := []entities.ItemPedido{{Produto: *produtoCompleto, Quantidade: 2}}
 
 	// Pedido que será retornado pelo use case
 	pedidoRetorno := &entities.Pedido{
 		ID:             123,
+		CodigoRetirada: "A-017",
 		ClienteNome:    "João Silva",
-		Produtos:       []entities.Produto{*produtoCompleto},
+		Itens:          itensCompletos,
 		Status:         entities.Pendente,
 		Personalizacao: &personalizacao,
 	}
 
 	// Mocks
 	mockProdutoBuscar.On("Run", mock.Anything, 1).Return(produtoCompleto, nil)
-	mockPedidoIncluir.On("Run", mock.Anything, "João Silva", []entities.Produto{*produtoCompleto}, &personalizacao).
+	mockPedidoIncluir.On("Run", mock.Anything, "João Silva", (*entities.ReferenciaCliente)(nil), itensCompletos, &personalizacao, (*string)(nil)).
 		Return(pedidoRetorno, nil)
 
 	// Preparar request
@@ -147,10 +177,184 @@ func TestPedidoHandler_CriarPedido(t *testing.T) {
 	// Verificações
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "Pedido criado com sucesso")
+	assert.Contains(t, w.Body.String(), `"codigoRetirada":"A-017"`)
+	mockProdutoBuscar.AssertExpectations(t)
+	mockPedidoIncluir.AssertExpectations(t)
+}
+
+func TestPedidoHandler_CriarPedido_ComModificadores(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockPedidoIncluir := new(MockPedidoIncluirUseCase)
+	mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
+
+	handler := &PedidoHandler{
+		PedidoIncluirUseCase:      mockPedidoIncluir,
+		ProdutoBuscarPorIdUseCase: mockProdutoBuscar,
+	}
+
+	produtoCompleto := &entities.Produto{ID: 1, Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)}
+	itensCompletos := []entities.ItemPedido{{
+		Produto:       *produtoCompleto,
+		Quantidade:    1,
+		Modificadores: []entities.Modificador{{ID: 1}, {ID: 3}},
+	}}
+
+	mockProdutoBuscar.On("Run", mock.Anything, 1).Return(produtoCompleto, nil)
+	mockPedidoIncluir.On("Run", mock.Anything, "Maria", (*entities.ReferenciaCliente)(nil), itensCompletos, (*string)(nil), (*string)(nil)).
+		Return(&entities.Pedido{ID: 7, Itens: itensCompletos}, nil)
+
+	// Os modificadores chegam apenas com o ID; nome e preço vêm do catálogo
+	body := `{"cliente_nome":"Maria","itens":[{"produto":{"idProduto":1},"quantidade":1,"modificadores":[{"idModificador":1},{"idModificador":3}]}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/pedidos", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CriarPedido(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockPedidoIncluir.AssertExpectations(t)
+}
+
+func TestPedidoHandler_CriarPedido_ComboComEscolha(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockPedidoIncluir := new(MockPedidoIncluirUseCase)
+	mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
+
+	handler := &PedidoHandler{
+		PedidoIncluirUseCase:      mockPedidoIncluir,
+		ProdutoBuscarPorIdUseCase: mockProdutoBuscar,
+	}
+
+	combo := &entities.Produto{ID: 11, Nome: "Combo X-Salada", Categoria: entities.Combo, Preco: entities.Reais(42)}
+	fanta := &entities.Produto{ID: 9, Nome: "Fanta", Categoria: entities.Bebida, Preco: entities.Reais(5.5)}
+	itensCompletos := []entities.ItemPedido{{
+		Produto:     *combo,
+		Quantidade:  1,
+		Componentes: []entities.ItemCombo{{Produto: *fanta}},
+	}}
+
+	mockProdutoBuscar.On("Run", mock.Anything, 11).Return(combo, nil)
+	mockProdutoBuscar.On("Run", mock.Anything, 9).Return(fanta, nil)
+	mockPedidoIncluir.On("Run", mock.Anything, "Maria", (*entities.ReferenciaCliente)(nil), itensCompletos, (*string)(nil), (*string)(nil)).
+		Return(&entities.Pedido{ID: 8, Itens: itensCompletos}, nil)
+
+	// A escolha da bebida do combo chega apenas com o ID do produto
+	body := `{"cliente_nome":"Maria","itens":[{"produto":{"idProduto":11},"quantidade":1,"componentes":[{"produto":{"idProduto":9}}]}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/pedidos", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CriarPedido(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
 	mockProdutoBuscar.AssertExpectations(t)
 	mockPedidoIncluir.AssertExpectations(t)
 }
 
+func TestPedidoHandler_CriarPedido_CupomRecusado(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockPedidoIncluir := new(MockPedidoIncluirUseCase)
+	mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
+
+	handler := &PedidoHandler{
+		PedidoIncluirUseCase:      mockPedidoIncluir,
+		ProdutoBuscarPorIdUseCase: mockProdutoBuscar,
+	}
+
+	produto := &entities.Produto{ID: 1, Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)}
+	cupom := "MIN50"
+	mockProdutoBuscar.On("Run", mock.Anything, 1).Return(produto, nil)
+	mockPedidoIncluir.On("Run", mock.Anything, "Maria", (*entities.ReferenciaCliente)(nil), mock.Anything, (*string)(nil), &cupom).
+		Return((*entities.Pedido)(nil), entities.ErrCupomValorMinimo)
+
+	body := `{"cliente_nome":"Maria","cupom":"MIN50","itens":[{"produto":{"idProduto":1},"quantidade":1}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/pedidos", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CriarPedido(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "valor mínimo do cupom")
+	mockPedidoIncluir.AssertExpectations(t)
+}
+
+func TestPedidoHandler_CriarPedido_ProdutosIndisponiveis(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockPedidoIncluir := new(MockPedidoIncluirUseCase)
+	mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
+
+	handler := &PedidoHandler{
+		PedidoIncluirUseCase:      mockPedidoIncluir,
+		ProdutoBuscarPorIdUseCase: mockProdutoBuscar,
+	}
+
+	produto := &entities.Produto{ID: 1, Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5), Esgotado: true}
+	mockProdutoBuscar.On("Run", mock.Anything, 1).Return(produto, nil)
+	mockPedidoIncluir.On("Run", mock.Anything, "Maria", (*entities.ReferenciaCliente)(nil), mock.Anything, (*string)(nil), (*string)(nil)).
+		Return((*entities.Pedido)(nil), &entities.ProdutosIndisponiveisError{Produtos: []string{"X-Salada"}})
+
+	body := `{"cliente_nome":"Maria","itens":[{"produto":{"idProduto":1},"quantidade":1}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/pedidos", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CriarPedido(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"message":"produtos indisponíveis: X-Salada","produtos":["X-Salada"]}`, w.Body.String())
+}
+
+func TestPedidoHandler_CriarPedido_RegrasViolada(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockPedidoIncluir := new(MockPedidoIncluirUseCase)
+	mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
+
+	handler := &PedidoHandler{
+		PedidoIncluirUseCase:      mockPedidoIncluir,
+		ProdutoBuscarPorIdUseCase: mockProdutoBuscar,
+	}
+
+	produto := &entities.Produto{ID: 2, Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6)}
+	mockProdutoBuscar.On("Run", mock.Anything, 2).Return(produto, nil)
+	mockPedidoIncluir.On("Run", mock.Anything, "Maria", (*entities.ReferenciaCliente)(nil), mock.Anything, (*string)(nil), (*string)(nil)).
+		Return((*entities.Pedido)(nil), &entities.RegrasPedidoError{Violacoes: []entities.ViolacaoRegra{
+			{Codigo: entities.RegraCategoriaObrigatoria, Mensagem: "o pedido precisa ter ao menos um item da categoria Lanche"},
+			{Codigo: entities.RegraMaximoItens, Mensagem: "o pedido pode ter no máximo 20 itens, mas tem 25"},
+		}})
+
+	body := `{"cliente_nome":"Maria","itens":[{"produto":{"idProduto":2},"quantidade":25}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/pedidos", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.CriarPedido(c)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{
+		"message": "o pedido precisa ter ao menos um item da categoria Lanche; o pedido pode ter no máximo 20 itens, mas tem 25",
+		"violacoes": [
+			{"codigo": "CATEGORIA_OBRIGATORIA", "mensagem": "o pedido precisa ter ao menos um item da categoria Lanche"},
+			{"codigo": "MAXIMO_ITENS", "mensagem": "o pedido pode ter no máximo 20 itens, mas tem 25"}
+		]
+	}`, w.Body.String())
+}
+
 func TestPedidoHandler_BuscarPedido(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -253,7 +457,7 @@ func TestPedidoHandler_ListarTodosOsPedidos(t *testing.T) {
 		},
 	}
 
-	mockListar.On("Run", mock.Anything).Return(pedidos, nil)
+	mockListar.On("Run", mock.Anything, entities.ConsultaPedidos{}).Return(&entities.Pagina[*entities.Pedido]{Itens: pedidos, Total: 2}, nil)
 
 	req, _ := http.NewRequest(http.MethodGet, "/pedidos/listartodos", nil)
 	w := httptest.NewRecorder()
@@ -265,6 +469,64 @@ func TestPedidoHandler_ListarTodosOsPedidos(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "João Silva")
 	assert.Contains(t, w.Body.String(), "Maria Santos")
+	assert.Contains(t, w.Body.String(), `"total":2`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":null`)
+	mockListar.AssertExpectations(t)
+}
+
+func TestPedidoHandler_ListarTodosOsPedidos_Filtros(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockListar := new(MockPedidoListarTodosUseCase)
+	handler := &PedidoHandler{PedidoListarTodosUseCase: mockListar}
+
+	de := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	ate := time.Date(2026, 3, 31, 23, 59, 59, 999999999, time.Local)
+	cursor := entities.Cursor{Ordem: "-total", Valor: "4500", ID: 9}
+	esperada := entities.ConsultaPedidos{
+		Status:          entities.Recebido,
+		StatusPagamento: entities.PagamentoPago,
+		De:              &de,
+		Ate:             &ate,
+		ClienteNome:     "Ana",
+		Ordem:           entities.Ordenacao{Campo: entities.OrdemPedidoTotal, Decrescente: true},
+		Cursor:          &cursor,
+		Limite:          10,
+	}
+	mockListar.On("Run", mock.Anything, esperada).Return(&entities.Pagina[*entities.Pedido]{
+		Itens:         []*entities.Pedido{{ID: 8, ClienteNome: "Ana Souza"}},
+		Total:         12,
+		ProximoCursor: "proxima",
+	}, nil)
+	mockListar.On("Run", mock.Anything, entities.ConsultaPedidos{Ordem: entities.Ordenacao{Campo: "cliente"}}).
+		Return(nil, fmt.Errorf("%w: \"cliente\"", entities.ErrOrdemInvalida))
+
+	executar := func(url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		handler.ListarTodosOsPedidos(c)
+		return w
+	}
+
+	w := executar("/pedidos/listartodos?status=Recebido&statusPagamento=Pago&de=2026-03-01&ate=2026-03-31&clienteNome=Ana&ordem=-total&limite=10&cursor=" + cursor.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Ana Souza")
+	assert.Contains(t, w.Body.String(), `"total":12`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":"proxima"`)
+
+	w = executar("/pedidos/listartodos?ordem=cliente")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	for _, url := range []string{
+		"/pedidos/listartodos?cursor=invalido!",
+		"/pedidos/listartodos?limite=zero",
+		"/pedidos/listartodos?de=ontem",
+	} {
+		w = executar(url)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
 	mockListar.AssertExpectations(t)
 }
 
@@ -325,3 +587,290 @@ func TestPedidoHandler_AtualizarStatusPagamento_InvalidID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "Número do pedido inválido")
 }
+
+func TestPedidoHandler_AtualizarStatusPedido_TransicaoInvalida(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAtualizar := new(MockPedidoAtualizarStatusUseCase)
+	handler := &PedidoHandler{
+		PedidoAtualizarStatusUseCase: mockAtualizar,
+	}
+
+	transicaoErr := &entities.TransicaoStatusError{De: entities.Finalizado, Para: entities.Pendente}
+	mockAtualizar.On("Run", mock.Anything, 1, "Pendente").Return(transicaoErr)
+
+	req, _ := http.NewRequest(http.MethodPut, "/pedidos/1/status/Pendente", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "nroPedido", Value: "1"},
+		{Key: "status", Value: "Pendente"},
+	}
+	c.Request = req
+
+	handler.AtualizarStatusPedido(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "transição de status inválida")
+	mockAtualizar.AssertExpectations(t)
+}
+
+func TestPedidoHandler_AtualizarStatusPedido_StatusInvalido(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAtualizar := new(MockPedidoAtualizarStatusUseCase)
+	handler := &PedidoHandler{
+		PedidoAtualizarStatusUseCase: mockAtualizar,
+	}
+
+	mockAtualizar.On("Run", mock.Anything, 1, "Xpto").Return(errors.New("status inválido"))
+
+	req, _ := http.NewRequest(http.MethodPut, "/pedidos/1/status/Xpto", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "nroPedido", Value: "1"},
+		{Key: "status", Value: "Xpto"},
+	}
+	c.Request = req
+
+	handler.AtualizarStatusPedido(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockAtualizar.AssertExpectations(t)
+}
+
+func TestPedidoHandler_ProximosStatusPedido(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockBuscar := new(MockPedidoBuscarPorIdUseCase)
+	handler := &PedidoHandler{
+		PedidoBuscarPorIdUseCase: mockBuscar,
+	}
+
+	pedido := &entities.Pedido{
+		ID:              1,
+		Status:          entities.Recebido,
+		StatusPagamento: entities.PagamentoPago,
+	}
+
+	mockBuscar.On("Run", mock.Anything, 1).Return(pedido, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/pedidos/1/status", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "nroPedido", Value: "1"}}
+	c.Request = req
+
+	handler.ProximosStatusPedido(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":1,"statusAtual":"Recebido","proximosStatus":["Em preparação"]}`, w.Body.String())
+	mockBuscar.AssertExpectations(t)
+}
+
+func TestPedidoHandler_AtualizarStatusPedido_CancelamentoViaStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAtualizar := new(MockPedidoAtualizarStatusUseCase)
+	handler := &PedidoHandler{
+		PedidoAtualizarStatusUseCase: mockAtualizar,
+	}
+
+	mockAtualizar.On("Run", mock.Anything, 1, "Cancelado").Return(entities.ErrCancelamentoViaStatus)
+
+	req, _ := http.NewRequest(http.MethodPut, "/pedidos/1/status/Cancelado", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "nroPedido", Value: "1"},
+		{Key: "status", Value: "Cancelado"},
+	}
+	c.Request = req
+
+	handler.AtualizarStatusPedido(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockAtualizar.AssertExpectations(t)
+}
+
+func TestPedidoHandler_AtualizarStatusPedido_PagamentoNaoConfirmado(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAtualizar := new(MockPedidoAtualizarStatusUseCase)
+	handler := &PedidoHandler{
+		PedidoAtualizarStatusUseCase: mockAtualizar,
+	}
+
+	mockAtualizar.On("Run", mock.Anything, 1, "Em preparação").Return(entities.ErrPagamentoNaoConfirmado)
+
+	req, _ := http.NewRequest(http.MethodPut, "/pedidos/1/status/Em preparação", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "nroPedido", Value: "1"},
+		{Key: "status", Value: "Em preparação"},
+	}
+	c.Request = req
+
+	handler.AtualizarStatusPedido(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockAtualizar.AssertExpectations(t)
+}
+
+func TestPedidoHandler_AtualizarStatusPagamento_TransicaoInvalida(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockAtualizarPagamento := new(MockPedidoAtualizarStatusPagamentoUseCase)
+	handler := &PedidoHandler{
+		PedidoAtualizarStatusPagamentoUseCase: mockAtualizarPagamento,
+	}
+
+	transicaoErr := &entities.TransicaoPagamentoError{De: entities.PagamentoRecusado, Para: entities.PagamentoPago}
+	mockAtualizarPagamento.On("Run", mock.Anything, 1, "Pago").Return(transicaoErr)
+
+	req, _ := http.NewRequest(http.MethodPut, "/pedidos/1/pagamento/Pago", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "nroPedido", Value: "1"},
+		{Key: "statusPagamento", Value: "Pago"},
+	}
+	c.Request = req
+
+	handler.AtualizarStatusPagamento(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "transição de status de pagamento inválida")
+	mockAtualizarPagamento.AssertExpectations(t)
+}
+
+func TestPedidoHandler_CriarPedido_ClienteIdentificado(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	produto := &entities.Produto{ID: 1, Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)}
+	casos := []struct {
+		nome   string
+		cpf    string
+		erro   error
+		status int
+	}{
+		{"identificado", "52998224725", nil, http.StatusOK},
+		{"cpf inválido", "123", entities.ErrCPFInvalido, http.StatusBadRequest},
+		{"não cadastrado", "11144477735", fmt.Errorf("não foi possível identificar o cliente: %w", entities.ErrClienteNaoEncontrado), http.StatusUnprocessableEntity},
+		{"serviço fora do ar", "52998224725", fmt.Errorf("não foi possível identificar o cliente: %w", entities.ErrServicoClientesIndisponivel), http.StatusServiceUnavailable},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			mockPedidoIncluir := new(MockPedidoIncluirUseCase)
+			mockProdutoBuscar := new(MockProdutoBuscarPorIdUseCase)
+			handler := &PedidoHandler{
+				PedidoIncluirUseCase:      mockPedidoIncluir,
+				ProdutoBuscarPorIdUseCase: mockProdutoBuscar,
+			}
+
+			mockProdutoBuscar.On("Run", mock.Anything, 1).Return(produto, nil)
+			pedido := &entities.Pedido{ID: 5, ClienteNome: "Ana Souza"}
+			if caso.erro != nil {
+				pedido = nil
+			}
+			mockPedidoIncluir.On("Run", mock.Anything, "Ana", &entities.ReferenciaCliente{CPF: caso.cpf}, mock.Anything, (*string)(nil), (*string)(nil)).
+				Return(pedido, caso.erro)
+
+			body := `{"cliente_nome":"Ana","cliente":{"cpf":"` + caso.cpf + `"},"itens":[{"produto":{"idProduto":1},"quantidade":1}]}`
+			req, _ := http.NewRequest(http.MethodPost, "/pedidos", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.CriarPedido(c)
+
+			assert.Equal(t, caso.status, w.Code)
+			mockPedidoIncluir.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPedidoHandler_ListarPedidos_PorCliente(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockListar := new(MockPedidoListarTodosUseCase)
+	mockPorCliente := new(MockPedidoListarPorClienteUseCase)
+	handler := &PedidoHandler{
+		PedidoListarTodosUseCase:      mockListar,
+		PedidoListarPorClienteUseCase: mockPorCliente,
+	}
+
+	mockPorCliente.On("Run", mock.Anything, "529.982.247-25", entities.ConsultaPedidos{Limite: 1}).Return(&entities.Pagina[*entities.Pedido]{
+		Itens:         []*entities.Pedido{{ID: 1, ClienteNome: "Ana Souza", Cliente: &entities.ReferenciaCliente{CPF: "52998224725"}}},
+		Total:         3,
+		ProximoCursor: "proxima",
+	}, nil)
+	mockPorCliente.On("Run", mock.Anything, " ", entities.ConsultaPedidos{}).Return(nil, entities.ErrClienteSemReferencia)
+	mockListar.On("Run", mock.Anything, entities.ConsultaPedidos{}).
+		Return(&entities.Pagina[*entities.Pedido]{Itens: []*entities.Pedido{{ID: 2, ClienteNome: "Balcão"}}, Total: 1}, nil)
+
+	executar := func(url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		handler.ListarPedidos(c)
+		return w
+	}
+
+	// Com ou sem cliente, a resposta vem no mesmo envelope paginado
+	w := executar("/pedidos?cliente=529.982.247-25&limite=1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Ana Souza")
+	assert.Contains(t, w.Body.String(), `"next_cursor":"proxima"`)
+	assert.Contains(t, w.Body.String(), `"total":3`)
+	assert.NotContains(t, w.Body.String(), "Balcão")
+
+	w = executar("/pedidos?cliente=%20")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = executar("/pedidos?cliente=37&cursor=%25%25")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = executar("/pedidos")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Balcão")
+	assert.Contains(t, w.Body.String(), `"total":1`)
+	mockPorCliente.AssertExpectations(t)
+}
+
+func TestPedidoHandler_BuscarPedidoPorCodigo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockBuscarPorCodigo := new(MockPedidoBuscarPorCodigoUseCase)
+	handler := &PedidoHandler{PedidoBuscarPorCodigoUseCase: mockBuscarPorCodigo}
+
+	mockBuscarPorCodigo.On("Run", mock.Anything, "a17").Return(&entities.Pedido{ID: 42, CodigoRetirada: "A-017", Status: entities.Pronto}, nil)
+	mockBuscarPorCodigo.On("Run", mock.Anything, "B-001").Return(nil, entities.ErrPedidoNaoEncontrado)
+	mockBuscarPorCodigo.On("Run", mock.Anything, "xyz").Return(nil, entities.ErrCodigoRetiradaInvalido)
+
+	executar := func(codigo string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/pedidos/codigo/"+codigo, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "codigo", Value: codigo}}
+		handler.BuscarPedidoPorCodigo(c)
+		return w
+	}
+
+	w := executar("a17")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"codigo_retirada":"A-017"`)
+
+	w = executar("B-001")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = executar("xyz")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockBuscarPorCodigo.AssertExpectations(t)
+}
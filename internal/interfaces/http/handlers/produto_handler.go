@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	_ "lanchonete/docs"
 	"lanchonete/internal/application/presenters"
@@ -21,6 +22,7 @@ type ProdutoHandler struct {
 	ProdutoEditarUseCase             usecases.ProdutoEditarUseCase
 	ProdutoRemoverUseCase            usecases.ProdutoRemoverUseCase
 	ProdutoListarPorCategoriaUseCase usecases.ProdutoListarPorCategoriaUseCase
+	ProdutoRestaurarUseCase          usecases.ProdutoRestaurarUseCase
 }
 
 func NewProdutoHandler(produtoIncluirUseCase usecases.ProdutoIncluirUseCase,
@@ -28,7 +30,8 @@ func NewProdutoHandler(produtoIncluirUseCase usecases.ProdutoIncluirUseCase,
 	produtoListarTodosUseCase usecases.ProdutoListarTodosUseCase,
 	produtoEditarUseCase usecases.ProdutoEditarUseCase,
 	produtoRemoverUseCase usecases.ProdutoRemoverUseCase,
-	produtoListarPorCategoriaUseCase usecases.ProdutoListarPorCategoriaUseCase) *ProdutoHandler {
+	produtoListarPorCategoriaUseCase usecases.ProdutoListarPorCategoriaUseCase,
+	produtoRestaurarUseCase usecases.ProdutoRestaurarUseCase) *ProdutoHandler {
 	return &ProdutoHandler{
 		ProdutoIncluirUseCase:            produtoIncluirUseCase,
 		ProdutoBuscarPorIdUseCase:        produtoBuscarPorIdUseCase,
@@ -36,6 +39,7 @@ func NewProdutoHandler(produtoIncluirUseCase usecases.ProdutoIncluirUseCase,
 		ProdutoListarTodosUseCase:        produtoListarTodosUseCase,
 		ProdutoRemoverUseCase:            produtoRemoverUseCase,
 		ProdutoListarPorCategoriaUseCase: produtoListarPorCategoriaUseCase,
+		ProdutoRestaurarUseCase:          produtoRestaurarUseCase,
 	}
 }
 
@@ -47,6 +51,7 @@ func NewProdutoHandler(produtoIncluirUseCase usecases.ProdutoIncluirUseCase,
 // @Accept  json
 // @Produce  json
 // @Param produto body entities.Produto true "Produto"
+// @Param X-Usuario header string false "Quem está alterando o catálogo, registrado no histórico do produto"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
 func (ph *ProdutoHandler) ProdutoIncluir(c *gin.Context) {
@@ -60,7 +65,13 @@ func (ph *ProdutoHandler) ProdutoIncluir(c *gin.Context) {
 		return
 	}
 
-	prd, err := ph.ProdutoIncluirUseCase.Run(c, produto.Nome, string(produto.Categoria), produto.Descricao, produto.Preco)
+	ctx, err := comAutor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	prd, err := ph.ProdutoIncluirUseCase.Run(ctx, produto.Nome, string(produto.Categoria), produto.Descricao, produto.Preco, produto.TempoPreparoMinutos, produto.InformacoesDieteticas)
 	fmt.Println("Entrando no if erro Handler")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
@@ -98,28 +109,62 @@ func (ph *ProdutoHandler) ProdutoBuscarPorId(c *gin.Context) {
 }
 
 // ProdutoListarTodos godoc
-// @Summary Lista todos os produtos no banco
-// @Description Lista todos os produtos cadastrados
+// @Summary Lista os produtos do cardápio, em páginas
+// @Description Lista os produtos que atendem aos filtros. A resposta traz o total de produtos encontrados e, quando há mais produtos, o next_cursor a enviar no parâmetro cursor para pedir a próxima página.
 // @Tags produto
 // @Router /produtos [GET]
 // @Accept  json
 // @Produce  json
-// @Success 200 {object} []presenters.ProdutoDTO
+// @Param incluirIndisponiveis query bool false "Inclui produtos esgotados ou sem estoque"
+// @Param tag query []string false "Apenas produtos com todas as tags de dieta: vegano, vegetariano, sem-gluten" collectionFormat(multi)
+// @Param sem query []string false "Apenas produtos sem os alérgenos, ex.: lactose, gluten" collectionFormat(multi)
+// @Param categoria query string false "Nome da categoria, ex.: Lanche"
+// @Param precoMin query number false "Preço mínimo, ex.: 10.00"
+// @Param precoMax query number false "Preço máximo, ex.: 25.90"
+// @Param ordem query string false "nome (padrão) ou preco; prefixo - para ordem decrescente, ex.: -preco"
+// @Param cursor query string false "next_cursor da página anterior"
+// @Param limite query int false "Produtos por página, de 1 a 100 (padrão 20)"
+// @Success 200 {object} response.ListaResponse[presenters.ProdutoDTO]
 // @Failure 400 {object} response.ErrorResponse
 func (ph *ProdutoHandler) ProdutoListarTodos(c *gin.Context) {
-	produtos, err := ph.ProdutoListarTodosUseCase.Run(c)
+	consulta, err := consultaProdutos(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	// Convert to DTOs
-	var produtosDTO []*presenters.ProdutoDTO
-	for _, produto := range produtos {
-		produtosDTO = append(produtosDTO, presenters.NewProdutoDTO(produto))
+	pagina, err := ph.ProdutoListarTodosUseCase.Run(c, consulta)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if isConsultaInvalida(err) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, response.ErrorResponse{Message: err.Error()})
+		return
 	}
 
-	c.JSON(http.StatusOK, produtosDTO)
+	c.JSON(http.StatusOK, response.NewListaResponse(pagina, presenters.NewProdutoDTO))
+}
+
+// consultaProdutos lê da query string os filtros, a ordenação e a página da listagem do cardápio
+func consultaProdutos(c *gin.Context) (entities.ConsultaProdutos, error) {
+	consulta := entities.ConsultaProdutos{
+		Categoria:            entities.CatProduto(strings.TrimSpace(c.Query("categoria"))),
+		IncluirIndisponiveis: incluirIndisponiveis(c),
+	}
+
+	var err error
+	if consulta.Dieta, err = filtroDieta(c); err != nil {
+		return consulta, err
+	}
+	if consulta.PrecoMin, err = precoQuery(c, "precoMin"); err != nil {
+		return consulta, err
+	}
+	if consulta.PrecoMax, err = precoQuery(c, "precoMax"); err != nil {
+		return consulta, err
+	}
+	consulta.Ordem, consulta.Cursor, consulta.Limite, err = paginacaoQuery(c)
+	return consulta, err
 }
 
 // EditarProduto godoc
@@ -129,6 +174,7 @@ func (ph *ProdutoHandler) ProdutoListarTodos(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param produto body entities.Produto true "Produto"
+// @Param X-Usuario header string false "Quem está alterando o catálogo, registrado no histórico do produto"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Router /produto/editar [put]
@@ -143,7 +189,13 @@ func (ph *ProdutoHandler) ProdutoEditar(c *gin.Context) {
 		return
 	}
 
-	prd, err := ph.ProdutoEditarUseCase.Run(c, produto.ID, produto.Nome, string(produto.Categoria), produto.Descricao, produto.Preco)
+	ctx, err := comAutor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	prd, err := ph.ProdutoEditarUseCase.Run(ctx, produto.ID, produto.Nome, string(produto.Categoria), produto.Descricao, produto.Preco, produto.TempoPreparoMinutos, produto.InformacoesDieteticas)
 	if err != nil {
 		fmt.Println("Entrando no segundo erro")
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
@@ -157,8 +209,8 @@ func (ph *ProdutoHandler) ProdutoEditar(c *gin.Context) {
 }
 
 // RemoverProduto godoc
-// @Summary Remove um produto
-// @Description Remove um produto
+// @Summary Arquiva um produto
+// @Description Retira o produto do cardápio; os pedidos já feitos continuam com o produto
 // @Tags produto
 // @Router /produto/delete/{id} [DELETE]
 // @Accept  json
@@ -166,43 +218,91 @@ func (ph *ProdutoHandler) ProdutoEditar(c *gin.Context) {
 // @Param id path int true "ID do produto"
 // @Success 200 {object} response.SuccessResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse "Produto já arquivado"
 func (ph *ProdutoHandler) ProdutoRemover(c *gin.Context) {
 	id := c.Param("id")
 
 	idnt, _ := strconv.Atoi(id)
 	err := ph.ProdutoRemoverUseCase.Run(c, idnt)
 	if err != nil {
-		if strings.Contains(err.Error(), "produto não encontrado") {
+		if errors.Is(err, entities.ErrProdutoNaoEncontrado) {
 			c.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
 			return
 		}
 
+		if errors.Is(err, entities.ErrProdutoArquivado) {
+			c.JSON(http.StatusConflict, response.ErrorResponse{Message: err.Error()})
+			return
+		}
+
 		// Outros erros (erro no banco, etc)
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, response.SuccessResponse{
-		Message: "Produto removido com sucesso",
+		Message: "Produto arquivado com sucesso",
 	})
 }
 
+// ProdutoRestaurar godoc
+// @Summary Restaura um produto arquivado
+// @Description Devolve ao cardápio um produto arquivado
+// @Tags produto
+// @Router /produto/{id}/restaurar [PUT]
+// @Accept  json
+// @Produce  json
+// @Param id path int true "ID do produto"
+// @Success 200 {object} presenters.ProdutoDTO
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse "Produto não está arquivado"
+func (ph *ProdutoHandler) ProdutoRestaurar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Message: "ID do produto inválido"})
+		return
+	}
+
+	produto, err := ph.ProdutoRestaurarUseCase.Run(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, entities.ErrProdutoNaoEncontrado):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, entities.ErrProdutoNaoArquivado):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.NewProdutoDTO(produto))
+}
+
 // ProdutoListarPorCategoria godoc
 // @Summary Lista os produtos por categoria
-// @Description Lista todos os produtos por categoria
+// @Description Lista todos os produtos da categoria identificada pelo slug; uma categoria inativa não tem produtos no cardápio
 // @Tags produto
 // @Router /produtos/{categoria} [GET]
 // @Accept  json
 // @Produce  json
-// @Param categoria path string true "Categoria de produtos"
+// @Param categoria path string true "Slug da categoria, ex.: cafe-da-manha"
+// @Param incluirIndisponiveis query bool false "Inclui produtos esgotados, sem estoque ou de categorias inativas"
 // @Success 200 {object} []presenters.ProdutoDTO
-// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 func (ph *ProdutoHandler) ProdutoListarPorCategoria(c *gin.Context) {
-	categoria := c.Param("categoria")
+	slug := c.Param("categoria")
 
-	produtos, err := ph.ProdutoListarPorCategoriaUseCase.Run(c, categoria)
+	produtos, err := ph.ProdutoListarPorCategoriaUseCase.Run(c, slug, incluirIndisponiveis(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Message: err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, entities.ErrCategoriaNaoEncontrada) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.ErrorResponse{Message: err.Error()})
 		return
 	}
 
@@ -214,3 +314,42 @@ func (ph *ProdutoHandler) ProdutoListarPorCategoria(c *gin.Context) {
 
 	c.JSON(http.StatusOK, produtosDTO)
 }
+
+// incluirIndisponiveis lê o filtro do cardápio; por padrão apenas produtos disponíveis são listados
+func incluirIndisponiveis(c *gin.Context) bool {
+	incluir, _ := strconv.ParseBool(c.Query("incluirIndisponiveis"))
+	return incluir
+}
+
+// filtroDieta lê os filtros de dieta do cardápio. Cada parâmetro pode ser repetido ou separado por vírgulas:
+// ?tag=vegano&sem=lactose,gluten
+func filtroDieta(c *gin.Context) (entities.FiltroDieta, error) {
+	var filtro entities.FiltroDieta
+	for _, valor := range valoresQuery(c, "tag") {
+		tag, err := entities.ParseTagDieta(valor)
+		if err != nil {
+			return filtro, err
+		}
+		filtro.Tags = append(filtro.Tags, tag)
+	}
+	for _, valor := range valoresQuery(c, "sem") {
+		alergeno, err := entities.ParseAlergeno(valor)
+		if err != nil {
+			return filtro, err
+		}
+		filtro.SemAlergenos = append(filtro.SemAlergenos, alergeno)
+	}
+	return filtro, nil
+}
+
+func valoresQuery(c *gin.Context, chave string) []string {
+	var valores []string
+	for _, parametro := range c.QueryArray(chave) {
+		for _, valor := range strings.Split(parametro, ",") {
+			if valor = strings.TrimSpace(valor); valor != "" {
+				valores = append(valores, valor)
+			}
+		}
+	}
+	return valores
+}
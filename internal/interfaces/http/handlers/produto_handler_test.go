@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"lanchonete/internal/application/presenters"
 	"lanchonete/internal/domain/entities"
+	response "lanchonete/internal/interfaces/http/responses"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -18,8 +22,8 @@ import (
 // --- Mock UseCases ---
 type MockProdutoIncluirUseCase struct{ mock.Mock }
 
-func (m *MockProdutoIncluirUseCase) Run(c context.Context, identificacao string, nome, categoria string, preco float32) (*entities.Produto, error) {
-	args := m.Called(c, identificacao, nome, categoria, preco)
+func (m *MockProdutoIncluirUseCase) Run(c context.Context, identificacao string, nome, categoria string, preco entities.Money, tempoPreparoMinutos int, dieta entities.InformacoesDieteticas) (*entities.Produto, error) {
+	args := m.Called(c, identificacao, nome, categoria, preco, tempoPreparoMinutos, dieta)
 	return args.Get(0).(*entities.Produto), args.Error(1)
 }
 
@@ -32,15 +36,16 @@ func (m *MockProdutoBuscaPorIdUseCase) Run(c context.Context, id int) (*entities
 
 type MockProdutoListarTodosUseCase struct{ mock.Mock }
 
-func (m *MockProdutoListarTodosUseCase) Run(c context.Context) ([]*entities.Produto, error) {
-	args := m.Called(c)
-	return args.Get(0).([]*entities.Produto), args.Error(1)
+func (m *MockProdutoListarTodosUseCase) Run(c context.Context, consulta entities.ConsultaProdutos) (*entities.Pagina[*entities.Produto], error) {
+	args := m.Called(c, consulta)
+	pagina, _ := args.Get(0).(*entities.Pagina[*entities.Produto])
+	return pagina, args.Error(1)
 }
 
 type MockProdutoEditarUseCase struct{ mock.Mock }
 
-func (m *MockProdutoEditarUseCase) Run(c context.Context, id int, nome, categoria, descricao string, preco float32) (*entities.Produto, error) {
-	args := m.Called(c, id, nome, categoria, descricao, preco)
+func (m *MockProdutoEditarUseCase) Run(c context.Context, id int, nome, categoria, descricao string, preco entities.Money, tempoPreparoMinutos int, dieta entities.InformacoesDieteticas) (*entities.Produto, error) {
+	args := m.Called(c, id, nome, categoria, descricao, preco, tempoPreparoMinutos, dieta)
 	return args.Get(0).(*entities.Produto), args.Error(1)
 }
 
@@ -53,11 +58,21 @@ func (m *MockProdutoRemoverUseCase) Run(c context.Context, id int) error {
 
 type MockProdutoListarPorCategoriaUseCase struct{ mock.Mock }
 
-func (m *MockProdutoListarPorCategoriaUseCase) Run(c context.Context, categoria string) ([]*entities.Produto, error) {
-	args := m.Called(c, categoria)
+func (m *MockProdutoListarPorCategoriaUseCase) Run(c context.Context, categoria string, incluirIndisponiveis bool) ([]*entities.Produto, error) {
+	args := m.Called(c, categoria, incluirIndisponiveis)
 	return args.Get(0).([]*entities.Produto), args.Error(1)
 }
 
+type MockProdutoRestaurarUseCase struct{ mock.Mock }
+
+func (m *MockProdutoRestaurarUseCase) Run(c context.Context, id int) (*entities.Produto, error) {
+	args := m.Called(c, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Produto), args.Error(1)
+}
+
 // --- Teste do Construtor (IMPORTANTE) ---
 func TestNewProdutoHandler(t *testing.T) {
 	// Mocks dos use cases
@@ -67,6 +82,7 @@ func TestNewProdutoHandler(t *testing.T) {
 	mockEditar := new(MockProdutoEditarUseCase)
 	mockRemover := new(MockProdutoRemoverUseCase)
 	mockListarCategoria := new(MockProdutoListarPorCategoriaUseCase)
+	mockRestaurar := new(MockProdutoRestaurarUseCase)
 
 	// Testar construtor
 	handler := NewProdutoHandler(
@@ -76,6 +92,7 @@ func TestNewProdutoHandler(t *testing.T) {
 		mockEditar,
 		mockRemover,
 		mockListarCategoria,
+		mockRestaurar,
 	)
 
 	// Verificações
@@ -86,6 +103,7 @@ func TestNewProdutoHandler(t *testing.T) {
 	assert.Equal(t, mockEditar, handler.ProdutoEditarUseCase)
 	assert.Equal(t, mockRemover, handler.ProdutoRemoverUseCase)
 	assert.Equal(t, mockListarCategoria, handler.ProdutoListarPorCategoriaUseCase)
+	assert.Equal(t, mockRestaurar, handler.ProdutoRestaurarUseCase)
 }
 
 // --- Testes dos Métodos ---
@@ -97,12 +115,13 @@ func TestProdutoHandler_ProdutoIncluir(t *testing.T) {
 	}
 
 	prod := entities.Produto{
-		Nome:      "Coca-Cola",
-		Categoria: "Bebida",
-		Descricao: "Refrigerante",
-		Preco:     5.0,
+		Nome:                "Coca-Cola",
+		Categoria:           "Bebida",
+		Descricao:           "Refrigerante",
+		Preco:               entities.Reais(5.0),
+		TempoPreparoMinutos: 2,
 	}
-	mockUC.On("Run", mock.Anything, prod.Nome, string(prod.Categoria), prod.Descricao, prod.Preco).
+	mockUC.On("Run", mock.Anything, prod.Nome, string(prod.Categoria), prod.Descricao, prod.Preco, 2, entities.InformacoesDieteticas{}).
 		Return(&prod, nil)
 
 	body, _ := json.Marshal(prod)
@@ -146,7 +165,7 @@ func TestProdutoHandler_ProdutoListarTodos(t *testing.T) {
 	}
 
 	prods := []*entities.Produto{{Nome: "Coca-Cola"}}
-	mockUC.On("Run", mock.Anything).Return(prods, nil)
+	mockUC.On("Run", mock.Anything, entities.ConsultaProdutos{}).Return(&entities.Pagina[*entities.Produto]{Itens: prods, Total: 1}, nil)
 
 	req, _ := http.NewRequest(http.MethodGet, "/produtos", nil)
 	w := httptest.NewRecorder()
@@ -158,6 +177,113 @@ func TestProdutoHandler_ProdutoListarTodos(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Coca-Cola")
 }
 
+func TestProdutoHandler_ProdutoIncluir_InformacoesDieteticas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoIncluirUseCase)
+	handler := &ProdutoHandler{ProdutoIncluirUseCase: mockUC}
+
+	dieta := entities.InformacoesDieteticas{
+		Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoLactose},
+		Nutricao:  &entities.InformacaoNutricional{PorcaoGramas: 200, Calorias: 520},
+	}
+	mockUC.On("Run", mock.Anything, "X-Bacon", "Lanche", "Pão, bacon e queijo", entities.Reais(25), 0, dieta).
+		Return(&entities.Produto{Nome: "X-Bacon"}, nil)
+
+	body := `{"nomeProduto":"X-Bacon","categoriaProduto":"Lanche","descricaoProduto":"Pão, bacon e queijo","precoProduto":25,
+		"alergenos":["gluten","lactose"],"informacaoNutricional":{"porcaoGramas":200,"kcal":520}}`
+	req, _ := http.NewRequest(http.MethodPost, "/produto", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.ProdutoIncluir(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUC.AssertExpectations(t)
+}
+
+func TestProdutoHandler_ProdutoListarTodos_FiltroDieta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoListarTodosUseCase)
+	handler := &ProdutoHandler{ProdutoListarTodosUseCase: mockUC}
+
+	filtro := entities.FiltroDieta{
+		Tags:         []entities.TagDieta{entities.TagVegano, entities.TagSemGluten},
+		SemAlergenos: []entities.Alergeno{entities.AlergenoLactose, entities.AlergenoSoja},
+	}
+	mockUC.On("Run", mock.Anything, entities.ConsultaProdutos{Dieta: filtro}).
+		Return(&entities.Pagina[*entities.Produto]{Itens: []*entities.Produto{{Nome: "Batata-frita"}}, Total: 1}, nil)
+
+	novoContexto := func(url string) (*gin.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		return c, w
+	}
+
+	c, w := novoContexto("/produtos?tag=vegano&tag=sem%20gl%C3%BAten&sem=lactose,soja")
+	handler.ProdutoListarTodos(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Batata-frita")
+
+	c, w = novoContexto("/produtos?tag=paleo")
+	handler.ProdutoListarTodos(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "tag de dieta desconhecida")
+
+	c, w = novoContexto("/produtos?sem=pimenta")
+	handler.ProdutoListarTodos(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProdutoHandler_ProdutoListarTodos_Paginada(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoListarTodosUseCase)
+	handler := &ProdutoHandler{ProdutoListarTodosUseCase: mockUC}
+
+	min, max := entities.Reais(5), entities.Reais(20.5)
+	mockUC.On("Run", mock.Anything, entities.ConsultaProdutos{
+		Categoria:            entities.Bebida,
+		PrecoMin:             &min,
+		PrecoMax:             &max,
+		IncluirIndisponiveis: true,
+		Ordem:                entities.Ordenacao{Campo: entities.OrdemProdutoPreco},
+		Limite:               2,
+	}).Return(&entities.Pagina[*entities.Produto]{
+		Itens:         []*entities.Produto{{ID: 9, Nome: "Fanta"}, {ID: 10, Nome: "Sprite"}},
+		Total:         3,
+		ProximoCursor: "cursor-da-proxima",
+	}, nil)
+	mockUC.On("Run", mock.Anything, entities.ConsultaProdutos{PrecoMin: &max, PrecoMax: &min}).Return(nil, entities.ErrFaixaPreco)
+
+	executar := func(url string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		handler.ProdutoListarTodos(c)
+		return w
+	}
+
+	w := executar("/produtos?categoria=Bebida&precoMin=5&precoMax=20.50&incluirIndisponiveis=true&ordem=preco&limite=2")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var lista response.ListaResponse[presenters.ProdutoDTO]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &lista))
+	assert.Len(t, lista.Itens, 2)
+	assert.Equal(t, 3, lista.Total)
+	if assert.NotNil(t, lista.ProximoCursor) {
+		assert.Equal(t, "cursor-da-proxima", *lista.ProximoCursor)
+	}
+
+	w = executar("/produtos?precoMin=20.50&precoMax=5")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = executar("/produtos?precoMax=barato")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUC.AssertExpectations(t)
+}
+
 func TestProdutoHandler_ProdutoEditar(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockUC := new(MockProdutoEditarUseCase)
@@ -170,9 +296,9 @@ func TestProdutoHandler_ProdutoEditar(t *testing.T) {
 		Nome:      "Coca-Cola",
 		Categoria: "Bebida",
 		Descricao: "Refrigerante",
-		Preco:     5.0,
+		Preco:     entities.Reais(5.0),
 	}
-	mockUC.On("Run", mock.Anything, 1, prod.Nome, string(prod.Categoria), prod.Descricao, prod.Preco).
+	mockUC.On("Run", mock.Anything, 1, prod.Nome, string(prod.Categoria), prod.Descricao, prod.Preco, 0, entities.InformacoesDieteticas{}).
 		Return(&prod, nil)
 
 	body, _ := json.Marshal(prod)
@@ -187,6 +313,26 @@ func TestProdutoHandler_ProdutoEditar(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Produto editado com sucesso")
 }
 
+func TestProdutoHandler_ProdutoEditar_AutorMuitoLongo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoEditarUseCase)
+	handler := &ProdutoHandler{
+		ProdutoEditarUseCase: mockUC,
+	}
+
+	body, _ := json.Marshal(entities.Produto{ID: 1, Nome: "Coca-Cola"})
+	req, _ := http.NewRequest(http.MethodPost, "/produto/editar", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderAutor, strings.Repeat("a", entities.TamanhoMaximoAutor+1))
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	handler.ProdutoEditar(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUC.AssertNotCalled(t, "Run")
+}
+
 func TestProdutoHandler_ProdutoRemover(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockUC := new(MockProdutoRemoverUseCase)
@@ -204,7 +350,78 @@ func TestProdutoHandler_ProdutoRemover(t *testing.T) {
 
 	handler.ProdutoRemover(c)
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "Produto removido com sucesso")
+	assert.Contains(t, w.Body.String(), "Produto arquivado com sucesso")
+}
+
+func TestProdutoHandler_ProdutoRemover_JaArquivado(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoRemoverUseCase)
+	handler := &ProdutoHandler{
+		ProdutoRemoverUseCase: mockUC,
+	}
+
+	mockUC.On("Run", mock.Anything, 1).Return(entities.ErrProdutoArquivado)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/produto/1", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Request = req
+
+	handler.ProdutoRemover(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestProdutoHandler_ProdutoRemover_NaoEncontrado(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoRemoverUseCase)
+	handler := &ProdutoHandler{
+		ProdutoRemoverUseCase: mockUC,
+	}
+
+	mockUC.On("Run", mock.Anything, 1).Return(fmt.Errorf("produto não existe no banco de dados: %w", entities.ErrProdutoNaoEncontrado))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/produto/1", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Request = req
+
+	handler.ProdutoRemover(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestProdutoHandler_ProdutoRestaurar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoRestaurarUseCase)
+	handler := &ProdutoHandler{
+		ProdutoRestaurarUseCase: mockUC,
+	}
+
+	mockUC.On("Run", mock.Anything, 1).Return(&entities.Produto{ID: 1, Nome: "Coca-Cola", Categoria: "Bebida"}, nil)
+	mockUC.On("Run", mock.Anything, 2).Return(nil, entities.ErrProdutoNaoArquivado)
+	mockUC.On("Run", mock.Anything, 3).Return(nil, fmt.Errorf("produto não existe no banco de dados: %w", entities.ErrProdutoNaoEncontrado))
+
+	casos := []struct {
+		id     string
+		status int
+	}{
+		{"1", http.StatusOK},
+		{"2", http.StatusConflict},
+		{"3", http.StatusNotFound},
+		{"abc", http.StatusBadRequest},
+	}
+
+	for _, caso := range casos {
+		req, _ := http.NewRequest(http.MethodPut, "/produto/"+caso.id+"/restaurar", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: caso.id}}
+		c.Request = req
+
+		handler.ProdutoRestaurar(c)
+		assert.Equal(t, caso.status, w.Code, "id %s", caso.id)
+	}
 }
 
 func TestProdutoHandler_ProdutoListarPorCategoria(t *testing.T) {
@@ -215,15 +432,35 @@ func TestProdutoHandler_ProdutoListarPorCategoria(t *testing.T) {
 	}
 
 	prods := []*entities.Produto{{Nome: "Coca-Cola", Categoria: "Bebida"}}
-	mockUC.On("Run", mock.Anything, "Bebida").Return(prods, nil)
+	mockUC.On("Run", mock.Anything, "bebida", false).Return(prods, nil)
 
-	req, _ := http.NewRequest(http.MethodGet, "/produtos/Bebida", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/produtos/bebida", nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	c.Params = gin.Params{{Key: "categoria", Value: "Bebida"}}
+	c.Params = gin.Params{{Key: "categoria", Value: "bebida"}}
 	c.Request = req
 
 	handler.ProdutoListarPorCategoria(c)
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "Coca-Cola")
 }
+
+func TestProdutoHandler_ProdutoListarPorCategoria_NaoEncontrada(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUC := new(MockProdutoListarPorCategoriaUseCase)
+	handler := &ProdutoHandler{
+		ProdutoListarPorCategoriaUseCase: mockUC,
+	}
+
+	mockUC.On("Run", mock.Anything, "pizzas", false).Return([]*entities.Produto(nil), fmt.Errorf("não foi possível listar produtos: %w", entities.ErrCategoriaNaoEncontrada))
+
+	req, _ := http.NewRequest(http.MethodGet, "/produtos/pizzas", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "categoria", Value: "pizzas"}}
+	c.Request = req
+
+	handler.ProdutoListarPorCategoria(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "categoria não encontrada")
+}
@@ -0,0 +1,27 @@
+package response
+
+import "lanchonete/internal/domain/entities"
+
+// ListaResponse é o envelope das listagens paginadas: os itens da página, quantos itens atendem aos
+// filtros em todas as páginas e o cursor para pedir a próxima página, nulo na última
+type ListaResponse[T any] struct {
+	Itens         []T     `json:"itens"`
+	Total         int     `json:"total"`
+	ProximoCursor *string `json:"next_cursor"`
+}
+
+// NewListaResponse monta o envelope convertendo cada item da página para o formato da resposta
+func NewListaResponse[E, T any](pagina *entities.Pagina[E], converter func(E) T) ListaResponse[T] {
+	lista := ListaResponse[T]{
+		Itens: make([]T, 0, len(pagina.Itens)),
+		Total: pagina.Total,
+	}
+	for _, item := range pagina.Itens {
+		lista.Itens = append(lista.Itens, converter(item))
+	}
+	if pagina.ProximoCursor != "" {
+		cursor := pagina.ProximoCursor
+		lista.ProximoCursor = &cursor
+	}
+	return lista
+}
@@ -0,0 +1,11 @@
+package response
+
+import "lanchonete/internal/domain/entities"
+
+// PedidoCriadoResponse confirma a criação do pedido, informa o código de retirada chamado no balcão
+// e alerta sobre os alérgenos dos produtos escolhidos
+type PedidoCriadoResponse struct {
+	Message         string                   `json:"message"`
+	CodigoRetirada  string                   `json:"codigoRetirada"`
+	AvisosAlergenos []entities.AvisoAlergeno `json:"avisosAlergenos,omitempty"`
+}
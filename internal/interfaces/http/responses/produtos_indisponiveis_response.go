@@ -0,0 +1,7 @@
+package response
+
+// ProdutosIndisponiveisResponse lista os produtos que impediram a criação do pedido
+type ProdutosIndisponiveisResponse struct {
+	Message  string   `json:"message"`
+	Produtos []string `json:"produtos"`
+}
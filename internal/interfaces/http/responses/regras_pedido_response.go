@@ -0,0 +1,9 @@
+package response
+
+import "lanchonete/internal/domain/entities"
+
+// RegrasPedidoResponse lista todas as regras da loja que o pedido não cumpre
+type RegrasPedidoResponse struct {
+	Message   string                   `json:"message"`
+	Violacoes []entities.ViolacaoRegra `json:"violacoes"`
+}
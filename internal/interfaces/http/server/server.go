@@ -2,10 +2,14 @@ package server
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"lanchonete/bootstrap"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/interfaces/cliente"
 	handler "lanchonete/internal/interfaces/http/handlers"
+	"lanchonete/internal/interfaces/storage"
 	"lanchonete/usecases"
 
 	"github.com/gin-contrib/cors"
@@ -13,7 +17,8 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
-	sqspublisher "lanchonete/infra/publisher"
+	clientegateway "lanchonete/infra/cliente"
+	imagestorage "lanchonete/infra/storage"
 )
 
 type Server struct {
@@ -30,7 +35,7 @@ func NewServer(app *bootstrap.App) *Server {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", handler.HeaderAutor},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 	}))
@@ -47,20 +52,40 @@ func (s *Server) SetupRoutes() {
 
 		api := s.router.Group("")
 
-		// 1. Criar o publisher (você pode obter a URL da env, por exemplo)
-		produtoPublisher, err := sqspublisher.NewSQSPublisher(s.app.Env.ProdutoQueueURL)
+		// Armazenamento das imagens dos produtos
+		imageStorage, err := s.novoImageStorage()
 		if err != nil {
-			panic(fmt.Sprintf("Erro ao criar o publisher: %v", err))
+			panic(fmt.Sprintf("Erro ao criar o armazenamento de imagens: %v", err))
 		}
 
+		// Categorias do cardápio
+		categoriaRepo := s.app.CategoriaRepository
+		categoriaHandler := handler.NewCategoriaHandler(
+			usecases.NewCategoriaIncluirUseCase(categoriaRepo),
+			usecases.NewCategoriaListarTodasUseCase(categoriaRepo),
+			usecases.NewCategoriaEditarUseCase(categoriaRepo),
+			usecases.NewCategoriaRemoverUseCase(categoriaRepo),
+		)
+		api.POST("/categorias", categoriaHandler.CategoriaIncluir)
+		api.GET("/categorias", categoriaHandler.CategoriaListarTodas)
+		api.PUT("/categorias/:id", categoriaHandler.CategoriaEditar)
+		api.DELETE("/categorias/:id", categoriaHandler.CategoriaRemover)
+
+		// Eventos gravados na mesma transação das alterações; o relay da outbox os publica nas filas
+		transacao := s.app.Transacao
+		outboxRepo := s.app.OutboxRepository
+
 		// Produto
 		produtoRepo := s.app.ProdutoRepository
-		produtoIncluir := usecases.NewProdutoIncluirUseCase(produtoRepo, produtoPublisher)
-		produtoEditar := usecases.NewProdutoEditarUseCase(produtoRepo, produtoPublisher)
-		produtoRemover := usecases.NewProdutoRemoverUseCase(produtoRepo, produtoPublisher)
+		versaoProdutoRepo := s.app.VersaoProdutoRepository
+		produtoIncluir := usecases.NewProdutoIncluirUseCase(produtoRepo, categoriaRepo, versaoProdutoRepo, transacao, outboxRepo)
+		produtoEditar := usecases.NewProdutoEditarUseCase(produtoRepo, categoriaRepo, versaoProdutoRepo, transacao, outboxRepo)
+		imagemProdutoRepo := s.app.ImagemProdutoRepository
+		produtoRemover := usecases.NewProdutoRemoverUseCase(produtoRepo, transacao, outboxRepo)
 		produtoBuscar := usecases.NewProdutoBuscaPorIdUseCase(produtoRepo)
 		produtoListarTodos := usecases.NewProdutoListarTodosUseCase(produtoRepo)
-		produtoListarPorCategoria := usecases.NewProdutoListarPorCategoriaUseCase(produtoRepo)
+		produtoListarPorCategoria := usecases.NewProdutoListarPorCategoriaUseCase(produtoRepo, categoriaRepo)
+		produtoRestaurar := usecases.NewProdutoRestaurarUseCase(produtoRepo, transacao, outboxRepo)
 
 		produtoHandler := handler.NewProdutoHandler(
 			produtoIncluir,
@@ -69,6 +94,7 @@ func (s *Server) SetupRoutes() {
 			produtoEditar,
 			produtoRemover,
 			produtoListarPorCategoria,
+			produtoRestaurar,
 		)
 		api.POST("/produto", produtoHandler.ProdutoIncluir)
 		api.GET("/produto/:id", produtoHandler.ProdutoBuscarPorId)
@@ -76,20 +102,66 @@ func (s *Server) SetupRoutes() {
 		api.GET("/produtos/:categoria", produtoHandler.ProdutoListarPorCategoria)
 		api.PUT("/produto/editar", produtoHandler.ProdutoEditar)
 		api.DELETE("/produto/delete/:id", produtoHandler.ProdutoRemover)
+		api.PUT("/produto/:id/restaurar", produtoHandler.ProdutoRestaurar)
 
-		// 1. Criar o publisher (você pode obter a URL da env, por exemplo)
-		pedidoPublisher, err := sqspublisher.NewSQSPublisher(s.app.Env.PedidoQueueURL)
-		if err != nil {
-			panic(fmt.Sprintf("Erro ao criar o publisher: %v", err))
-		}
+		// Histórico de versões do produto
+		historicoHandler := handler.NewHistoricoProdutoHandler(
+			usecases.NewProdutoHistoricoUseCase(produtoRepo, versaoProdutoRepo),
+			usecases.NewProdutoVersaoEmUseCase(produtoRepo, versaoProdutoRepo),
+		)
+		api.GET("/produto/:id/historico", historicoHandler.Historico)
+		api.GET("/produto/:id/historico/versao", historicoHandler.VersaoEm)
+
+		// Imagens do produto
+		imagemHandler := handler.NewImagemProdutoHandler(
+			usecases.NewProdutoImagemEnviarUseCase(produtoRepo, imagemProdutoRepo, imageStorage, transacao, outboxRepo),
+			usecases.NewProdutoImagemRemoverUseCase(produtoRepo, imagemProdutoRepo, imageStorage, transacao, outboxRepo),
+		)
+		api.POST("/produto/:id/imagem", imagemHandler.EnviarImagem)
+		api.DELETE("/produto/:id/imagem", imagemHandler.RemoverImagem)
+
+		// Combos
+		comboHandler := handler.NewComboHandler(usecases.NewComboIncluirUseCase(produtoRepo, categoriaRepo, versaoProdutoRepo, transacao, outboxRepo))
+		api.POST("/produto/combo", comboHandler.ComboIncluir)
+
+		// Disponibilidade e estoque de produto
+		estoqueHandler := handler.NewEstoqueHandler(
+			usecases.NewProdutoAtualizarDisponibilidadeUseCase(produtoRepo, transacao, outboxRepo),
+			usecases.NewProdutoAjustarEstoqueUseCase(produtoRepo, transacao, outboxRepo),
+		)
+		api.PUT("/produto/:id/disponibilidade", estoqueHandler.AtualizarDisponibilidade)
+		api.PUT("/produto/:id/estoque", estoqueHandler.AjustarEstoque)
+
+		// Modificadores de produto
+		modificadorRepo := s.app.ModificadorRepository
+		modificadorHandler := handler.NewModificadorHandler(
+			usecases.NewGrupoModificadorIncluirUseCase(produtoRepo, modificadorRepo),
+			usecases.NewGrupoModificadorListarPorProdutoUseCase(modificadorRepo),
+			usecases.NewGrupoModificadorRemoverUseCase(modificadorRepo),
+		)
+		api.POST("/produto/:id/modificadores", modificadorHandler.IncluirGrupoModificador)
+		api.GET("/produto/:id/modificadores", modificadorHandler.ListarGruposModificador)
+		api.DELETE("/produto/modificadores/:idGrupo", modificadorHandler.RemoverGrupoModificador)
 
 		// Pedido
 		pedidoRepo := s.app.PedidoRepository
-		pedidoIncluir := usecases.NewPedidoIncluirUseCase(pedidoRepo, pedidoPublisher)
-		pedidoBuscar := usecases.NewPedidoBuscarPorIdUseCase(pedidoRepo)
-		pedidoAtualizar := usecases.NewPedidoAtualizarStatusUseCase(pedidoRepo, pedidoPublisher)
-		pedidoAtualizarPagamento := usecases.NewPedidoAtualizarStatusPagamentoUseCase(pedidoRepo)
+		cupomRepo := s.app.CupomRepository
+		historicoPedidoRepo := s.app.HistoricoPedidoRepository
+		clienteGateway, err := s.novoClienteGateway()
+		if err != nil {
+			panic(fmt.Sprintf("Erro ao criar o acesso ao serviço de clientes: %v", err))
+		}
+		regrasPedido, err := s.novasRegrasPedido()
+		if err != nil {
+			panic(fmt.Sprintf("Erro ao carregar as regras dos pedidos: %v", err))
+		}
+		pedidoIncluir := usecases.NewPedidoIncluirUseCase(pedidoRepo, cupomRepo, categoriaRepo, historicoPedidoRepo, clienteGateway, regrasPedido, transacao, outboxRepo)
+		pedidoBuscar := usecases.NewPedidoBuscarPorIdUseCase(pedidoRepo, historicoPedidoRepo)
+		pedidoAtualizar := usecases.NewPedidoAtualizarStatusUseCase(pedidoRepo, historicoPedidoRepo, transacao, outboxRepo)
+		pedidoAtualizarPagamento := usecases.NewPedidoAtualizarStatusPagamentoUseCase(pedidoRepo, historicoPedidoRepo, transacao, outboxRepo)
 		pedidoListarTodos := usecases.NewPedidoListarTodosUseCase(pedidoRepo)
+		pedidoListarPorCliente := usecases.NewPedidoListarPorClienteUseCase(pedidoRepo)
+		pedidoBuscarPorCodigo := usecases.NewPedidoBuscarPorCodigoUseCase(pedidoRepo, historicoPedidoRepo)
 		produtoBuscaPorId := usecases.NewProdutoBuscaPorIdUseCase(produtoRepo)
 
 		pedidoHandler := handler.NewPedidoHandler(
@@ -99,13 +171,48 @@ func (s *Server) SetupRoutes() {
 			pedidoAtualizarPagamento,
 			produtoBuscaPorId,
 			pedidoListarTodos,
+			pedidoListarPorCliente,
+			pedidoBuscarPorCodigo,
 		)
 		api.POST("/pedidos", pedidoHandler.CriarPedido)
+		api.GET("/pedidos", pedidoHandler.ListarPedidos)
 		api.GET("/pedidos/:nroPedido", pedidoHandler.BuscarPedido)
+		api.GET("/pedidos/codigo/:codigo", pedidoHandler.BuscarPedidoPorCodigo)
+		api.GET("/pedidos/:nroPedido/status", pedidoHandler.ProximosStatusPedido)
 		api.PUT("/pedidos/:nroPedido/status/:status", pedidoHandler.AtualizarStatusPedido)
 		api.PUT("/pedidos/:nroPedido/pagamento/:statusPagamento", pedidoHandler.AtualizarStatusPagamento)
 		api.GET("/pedidos/listartodos", pedidoHandler.ListarTodosOsPedidos)
 
+		// Histórico de status do pedido
+		historicoPedidoHandler := handler.NewHistoricoPedidoHandler(usecases.NewPedidoHistoricoUseCase(pedidoRepo, historicoPedidoRepo))
+		api.GET("/pedidos/:nroPedido/historico", historicoPedidoHandler.Historico)
+
+		// Cancelamento de pedido
+		cancelamentoHandler := handler.NewCancelamentoHandler(usecases.NewPedidoCancelarUseCase(pedidoRepo, historicoPedidoRepo, transacao, outboxRepo))
+		api.POST("/pedidos/:nroPedido/cancelar", cancelamentoHandler.CancelarPedido)
+
+		// Cupons
+		cupomHandler := handler.NewCupomHandler(
+			usecases.NewCupomIncluirUseCase(cupomRepo, categoriaRepo),
+			usecases.NewCupomBuscarPorCodigoUseCase(cupomRepo),
+			usecases.NewCupomListarTodosUseCase(cupomRepo),
+			usecases.NewCupomEditarUseCase(cupomRepo, categoriaRepo),
+			usecases.NewCupomRemoverUseCase(cupomRepo),
+		)
+		api.POST("/cupons", cupomHandler.CupomIncluir)
+		api.GET("/cupons", cupomHandler.CupomListarTodos)
+		api.GET("/cupons/:codigo", cupomHandler.CupomBuscarPorCodigo)
+		api.PUT("/cupons/:codigo", cupomHandler.CupomEditar)
+		api.DELETE("/cupons/:codigo", cupomHandler.CupomRemover)
+
+		// Administração da outbox
+		outboxHandler := handler.NewOutboxHandler(
+			usecases.NewOutboxListarTravadosUseCase(outboxRepo, s.app.Env.OutboxAlerta),
+			usecases.NewOutboxReprocessarUseCase(outboxRepo),
+		)
+		api.GET("/admin/outbox/travados", outboxHandler.ListarTravados)
+		api.POST("/admin/outbox/:id/reprocessar", outboxHandler.Reprocessar)
+
 		// Health check e Swagger
 		api.GET("/health", func(c *gin.Context) {
 			c.JSON(200, gin.H{"status": "ok"})
@@ -114,6 +221,62 @@ func (s *Server) SetupRoutes() {
 	})
 }
 
+// novoImageStorage escolhe onde gravar as imagens dos produtos. No armazenamento local, a própria
+// aplicação serve os arquivos em /imagens.
+func (s *Server) novoImageStorage() (storage.ImageStorage, error) {
+	env := s.app.Env
+	if env.ImageStorage == "s3" {
+		return imagestorage.NewS3ImageStorage(env.S3Bucket, env.S3Endpoint, env.S3PublicURL)
+	}
+
+	local, err := imagestorage.NewLocalImageStorage(env.ImageDir, env.ImageBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	s.router.Static("/imagens", env.ImageDir)
+	return local, nil
+}
+
+// novoClienteGateway escolhe como consultar o serviço de clientes. Sem CLIENTE_SERVICE_URL, usa um
+// cadastro em memória, vazio, para rodar a aplicação sem o serviço.
+func (s *Server) novoClienteGateway() (cliente.ClienteGateway, error) {
+	env := s.app.Env
+	if env.ClienteURL == "" {
+		fmt.Println("⚠️ CLIENTE_SERVICE_URL não definida: usando clientes em memória")
+		return clientegateway.NewMemoriaClienteGateway(), nil
+	}
+	return clientegateway.NewHTTPClienteGateway(env.ClienteURL, env.ClienteTimeout)
+}
+
+// novasRegrasPedido monta as regras dos pedidos a partir das variáveis PEDIDO_*. Sem nenhuma delas,
+// valem só as regras de sempre: ao menos um produto e os itens das categorias obrigatórias.
+func (s *Server) novasRegrasPedido() (entities.RegrasPedido, error) {
+	env := s.app.Env
+	config := entities.ConfigRegrasPedido{MaximoItens: env.PedidoMaximoItens}
+
+	if strings.TrimSpace(env.PedidoValorMaximo) != "" {
+		valorMaximo, err := entities.MoneyParse(env.PedidoValorMaximo)
+		if err != nil {
+			return nil, fmt.Errorf("PEDIDO_VALOR_MAXIMO: %w", err)
+		}
+		config.ValorMaximo = valorMaximo
+	}
+
+	limites, err := entities.LimitesProdutoParse(env.PedidoLimites)
+	if err != nil {
+		return nil, fmt.Errorf("PEDIDO_LIMITES_PRODUTO: %w", err)
+	}
+	config.LimitesProduto = limites
+
+	for _, categoria := range strings.Split(env.PedidoAvulsas, ",") {
+		if categoria = strings.TrimSpace(categoria); categoria != "" {
+			config.CategoriasAvulsas = append(config.CategoriasAvulsas, entities.CatProduto(categoria))
+		}
+	}
+
+	return entities.RegrasPedidoNew(config), nil
+}
+
 func (s *Server) Start() error {
 	s.SetupRoutes()
 	return s.router.Run(s.app.Env.ServerAddress)
@@ -0,0 +1,11 @@
+// internal/interfaces/storage/image_storage.go
+package storage
+
+import "context"
+
+// ImageStorage guarda os arquivos de imagem do catálogo e devolve a URL pública de cada um
+type ImageStorage interface {
+	Save(ctx context.Context, key string, content []byte, contentType string) (url string, err error)
+	// Delete apaga o arquivo; apagar uma chave inexistente não é erro
+	Delete(ctx context.Context, key string) error
+}
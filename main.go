@@ -11,7 +11,11 @@ import (
 	"lanchonete/bootstrap"
 	_ "lanchonete/docs"
 	queue "lanchonete/infra/consumer"
+	"lanchonete/infra/outbox"
+	sqspublisher "lanchonete/infra/publisher"
+	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/interfaces/http/server"
+	"lanchonete/internal/interfaces/publisher"
 	"lanchonete/usecases"
 )
 
@@ -29,6 +33,14 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Subcomando de migração do esquema: lanchonete migrate [up | down [n] | version]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := bootstrap.Migrar(ctx, os.Args[2:]); err != nil {
+			log.Fatalf("Erro ao migrar o banco: %v", err)
+		}
+		return
+	}
+
 	// Initialize application
 	app, err := bootstrap.NewApp(ctx)
 	if err != nil {
@@ -52,10 +64,27 @@ func main() {
 		log.Fatalf("Erro ao inicializar consumidor SQS: %v", err)
 	}
 
-	// Cria use-case e inicia consumo
-	pagamentoUseCase := usecases.NewPedidoAtualizarStatusPagamentoUseCase(app.PedidoRepository)
+	// Use-case de pagamento; os eventos vão para a outbox, na transação do pedido
+	pagamentoUseCase := usecases.NewPedidoAtualizarStatusPagamentoUseCase(app.PedidoRepository, app.HistoricoPedidoRepository, app.Transacao, app.OutboxRepository)
 	sqsConsumer.StartConsumingPagamento(app.Env.PagamentoQueueURL, pagamentoUseCase)
 
+	// Relay da outbox: publica nas filas os eventos gravados junto com produtos e pedidos
+	pedidoPublisher, err := sqspublisher.NewSQSPublisher(app.Env.PedidoQueueURL)
+	if err != nil {
+		log.Fatalf("Erro ao criar o publisher: %v", err)
+	}
+	produtoPublisher, err := sqspublisher.NewSQSPublisher(app.Env.ProdutoQueueURL)
+	if err != nil {
+		log.Fatalf("Erro ao criar o publisher: %v", err)
+	}
+	politica := entities.PoliticaRetentativaPadrao
+	politica.MaxTentativas = app.Env.OutboxTentativas
+	relayUseCase := usecases.NewOutboxRelayUseCase(app.Transacao, app.OutboxRepository, map[string]publisher.EventPublisher{
+		entities.FilaProdutos: produtoPublisher,
+		entities.FilaPedidos:  pedidoPublisher,
+	}, politica, 100)
+	outbox.NewRelay(relayUseCase, app.Env.OutboxIntervalo).Start(ctx)
+
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -0,0 +1,227 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/infra/database/memoria"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"testing"
+)
+
+// bancoTeste reúne os repositórios em memória usados nos testes dos casos de uso. Eles compartilham as
+// tabelas e passam pela mesma suíte de conformidade das implementações MySQL, então os testes veem as
+// mesmas regras de gravação, baixa de estoque e erros que o serviço em produção.
+type bancoTeste struct {
+	t             *testing.T
+	Banco         *memoria.Banco
+	Pedidos       repository.PedidoRepository
+	Produtos      repository.ProdutoRepository
+	Categorias    repository.CategoriaRepository
+	Modificadores repository.ModificadorRepository
+	Cupons        repository.CupomRepository
+	Versoes       repository.VersaoProdutoRepository
+	Imagens       repository.ImagemProdutoRepository
+	Historico     repository.HistoricoPedidoRepository
+	Transacao     repository.Transacao
+}
+
+// novoBancoTeste cria o banco em memória com as categorias do cardápio inicial
+func novoBancoTeste(t *testing.T) *bancoTeste {
+	t.Helper()
+	banco := memoria.NewBanco()
+	b := &bancoTeste{
+		t:             t,
+		Banco:         banco,
+		Pedidos:       memoria.NewPedidoMemoriaRepository(banco),
+		Produtos:      memoria.NewProdutoMemoriaRepository(banco),
+		Categorias:    memoria.NewCategoriaMemoriaRepository(banco),
+		Modificadores: memoria.NewModificadorMemoriaRepository(banco),
+		Cupons:        memoria.NewCupomMemoriaRepository(banco),
+		Versoes:       memoria.NewVersaoProdutoMemoriaRepository(banco),
+		Imagens:       memoria.NewImagemProdutoMemoriaRepository(banco),
+		Historico:     memoria.NewHistoricoPedidoMemoriaRepository(banco),
+		Transacao:     memoria.NewTransacaoMemoria(banco),
+	}
+	for _, categoria := range novoMockCategorias().Categorias {
+		nova := *categoria
+		if err := b.Categorias.AdicionarCategoria(context.Background(), &nova); err != nil {
+			t.Fatalf("erro ao criar a categoria %s: %v", nova.Nome, err)
+		}
+	}
+	return b
+}
+
+// produto grava o produto e devolve a cópia com o ID gerado
+func (b *bancoTeste) produto(produto entities.Produto) *entities.Produto {
+	b.t.Helper()
+	b.gravarProdutos(&produto)
+	return &produto
+}
+
+// gravarProdutos grava os produtos na ordem, preenchendo em cada um o ID gerado. As imagens e o
+// arquivamento ficam em tabelas próprias e são gravados depois do produto, como faz o serviço.
+func (b *bancoTeste) gravarProdutos(produtos ...*entities.Produto) {
+	b.t.Helper()
+	ctx := context.Background()
+	for _, produto := range produtos {
+		if err := b.Produtos.AdicionarProduto(ctx, produto); err != nil {
+			b.t.Fatalf("erro ao gravar o produto %s: %v", produto.Nome, err)
+		}
+		if len(produto.Imagens) > 0 {
+			if err := b.Imagens.SalvarImagens(ctx, produto.ID, produto.Imagens); err != nil {
+				b.t.Fatalf("erro ao gravar as imagens do produto %s: %v", produto.Nome, err)
+			}
+		}
+		if produto.ArquivadoEm != nil {
+			if err := b.Produtos.ArquivarProduto(ctx, produto); err != nil {
+				b.t.Fatalf("erro ao arquivar o produto %s: %v", produto.Nome, err)
+			}
+		}
+	}
+}
+
+// categoria grava uma categoria além das do cardápio inicial
+func (b *bancoTeste) categoria(categoria entities.Categoria) {
+	b.t.Helper()
+	if err := b.Categorias.AdicionarCategoria(context.Background(), &categoria); err != nil {
+		b.t.Fatalf("erro ao gravar a categoria %s: %v", categoria.Nome, err)
+	}
+}
+
+// pedido grava o pedido, baixando o estoque dos produtos, e devolve a cópia com o ID gerado
+func (b *bancoTeste) pedido(pedido entities.Pedido) *entities.Pedido {
+	b.t.Helper()
+	if err := b.Pedidos.CriarPedido(context.Background(), &pedido); err != nil {
+		b.t.Fatalf("erro ao gravar o pedido de %s: %v", pedido.ClienteNome, err)
+	}
+	return &pedido
+}
+
+// cupom grava o cupom
+func (b *bancoTeste) cupom(cupom entities.Cupom) {
+	b.t.Helper()
+	if err := b.Cupons.AdicionarCupom(context.Background(), &cupom); err != nil {
+		b.t.Fatalf("erro ao gravar o cupom %s: %v", cupom.Codigo, err)
+	}
+}
+
+// grupo grava o grupo de modificadores e devolve a cópia com os IDs gerados
+func (b *bancoTeste) grupo(grupo entities.GrupoModificador) *entities.GrupoModificador {
+	b.t.Helper()
+	if err := b.Modificadores.AdicionarGrupo(context.Background(), &grupo); err != nil {
+		b.t.Fatalf("erro ao gravar o grupo %s: %v", grupo.Nome, err)
+	}
+	return &grupo
+}
+
+// gruposDe lista os grupos de modificadores gravados para o produto
+func (b *bancoTeste) gruposDe(produtoID int) []entities.GrupoModificador {
+	b.t.Helper()
+	grupos, err := b.Modificadores.ListarGruposPorProduto(context.Background(), produtoID)
+	if err != nil {
+		b.t.Fatalf("erro ao listar os grupos do produto %d: %v", produtoID, err)
+	}
+	return grupos
+}
+
+// produtos lista os produtos do cardápio, inclusive os indisponíveis; os arquivados ficam de fora
+func (b *bancoTeste) produtos() []*entities.Produto {
+	b.t.Helper()
+	consulta := entities.ConsultaProdutos{IncluirIndisponiveis: true, Limite: entities.LimiteMaximo}
+	if err := consulta.Validar(); err != nil {
+		b.t.Fatalf("consulta de produtos inválida: %v", err)
+	}
+	pagina, err := b.Produtos.ConsultarProdutos(context.Background(), consulta)
+	if err != nil {
+		b.t.Fatalf("erro ao listar os produtos: %v", err)
+	}
+	return pagina.Itens
+}
+
+// buscarPedido relê o pedido gravado
+func (b *bancoTeste) buscarPedido(id int) *entities.Pedido {
+	b.t.Helper()
+	pedido, err := b.Pedidos.BuscarPedido(context.Background(), id)
+	if err != nil {
+		b.t.Fatalf("erro ao buscar o pedido %d: %v", id, err)
+	}
+	return pedido
+}
+
+// pedidos lista os pedidos gravados, na ordem de criação
+func (b *bancoTeste) pedidos() []*entities.Pedido {
+	b.t.Helper()
+	consulta := entities.ConsultaPedidos{Limite: entities.LimiteMaximo}
+	if err := consulta.Validar(); err != nil {
+		b.t.Fatalf("consulta de pedidos inválida: %v", err)
+	}
+	pagina, err := b.Pedidos.ConsultarPedidos(context.Background(), consulta)
+	if err != nil {
+		b.t.Fatalf("erro ao listar os pedidos: %v", err)
+	}
+	return pagina.Itens
+}
+
+// buscarProduto relê o produto gravado
+func (b *bancoTeste) buscarProduto(id int) *entities.Produto {
+	b.t.Helper()
+	produto, err := b.Produtos.BuscarProdutoPorId(context.Background(), id)
+	if err != nil {
+		b.t.Fatalf("erro ao buscar o produto %d: %v", id, err)
+	}
+	return produto
+}
+
+// estoqueDe relê o estoque do produto gravado
+func (b *bancoTeste) estoqueDe(produto *entities.Produto) int {
+	b.t.Helper()
+	estoque := b.buscarProduto(produto.ID).Estoque
+	if estoque == nil {
+		b.t.Fatalf("esperado que o produto %d controlasse o estoque", produto.ID)
+	}
+	return *estoque
+}
+
+// previsoesComFalha repassa tudo ao repositório em memória, mas falha ao gravar as previsões da fila da cozinha
+type previsoesComFalha struct {
+	repository.PedidoRepository
+	err error
+}
+
+func (p previsoesComFalha) AtualizarPrevisoes(ctx context.Context, pedidos []*entities.Pedido) error {
+	return p.err
+}
+
+// filaComFalha repassa tudo ao repositório em memória, mas falha ao carregar a fila da cozinha
+type filaComFalha struct {
+	repository.PedidoRepository
+	err error
+}
+
+func (f filaComFalha) ListarFilaCozinha(ctx context.Context) ([]*entities.Pedido, error) {
+	return nil, f.err
+}
+
+// imagensComFalha repassa tudo ao repositório em memória, mas falha ao gravar as imagens
+type imagensComFalha struct {
+	repository.ImagemProdutoRepository
+	err error
+}
+
+func (i imagensComFalha) SalvarImagens(ctx context.Context, produtoID int, imagens []entities.ImagemProduto) error {
+	return i.err
+}
+
+// modificadoresComFalha repassa tudo ao repositório em memória, mas falha ao gravar e ao listar os grupos
+type modificadoresComFalha struct {
+	repository.ModificadorRepository
+	err error
+}
+
+func (m modificadoresComFalha) AdicionarGrupo(ctx context.Context, grupo *entities.GrupoModificador) error {
+	return m.err
+}
+
+func (m modificadoresComFalha) ListarGruposPorProduto(ctx context.Context, produtoID int) ([]entities.GrupoModificador, error) {
+	return nil, m.err
+}
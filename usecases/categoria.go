@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// categoriaCadastrada busca a categoria pelo nome gravado nos produtos; nil quando ela não existe
+func categoriaCadastrada(c context.Context, categoriaGateway repository.CategoriaRepository, nome entities.CatProduto) (*entities.Categoria, error) {
+	categoria, err := categoriaGateway.BuscarCategoriaPorNome(c, string(nome))
+	if errors.Is(err, entities.ErrCategoriaNaoEncontrada) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível consultar a categoria: %w", err)
+	}
+	return categoria, nil
+}
+
+// exigirCategoria confirma que a categoria usada por um combo ou cupom está cadastrada
+func exigirCategoria(c context.Context, categoriaGateway repository.CategoriaRepository, nome entities.CatProduto) error {
+	categoria, err := categoriaCadastrada(c, categoriaGateway, nome)
+	if err != nil {
+		return err
+	}
+	if categoria == nil {
+		return fmt.Errorf("%w: %s", entities.ErrCategoriaInvalida, nome)
+	}
+	return nil
+}
+
+// verificarSlugLivre impede duas categorias com o mesmo slug, que ficariam com a mesma URL
+func verificarSlugLivre(c context.Context, categoriaGateway repository.CategoriaRepository, categoria *entities.Categoria) error {
+	existente, err := categoriaGateway.BuscarCategoriaPorSlug(c, categoria.Slug)
+	if errors.Is(err, entities.ErrCategoriaNaoEncontrada) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("não foi possível consultar a categoria: %w", err)
+	}
+	if existente.ID != categoria.ID {
+		return fmt.Errorf("já existe uma categoria com o nome %s", existente.Nome)
+	}
+	return nil
+}
+
+func valoresCategorias(categorias []*entities.Categoria) []entities.Categoria {
+	valores := make([]entities.Categoria, 0, len(categorias))
+	for _, categoria := range categorias {
+		valores = append(valores, *categoria)
+	}
+	return valores
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CategoriaEditarUseCase interface {
+	Run(ctx context.Context, id int, nome string, ordem int, ativa, obrigatoriaNoPedido bool, tempoPreparoMinutos int) (*entities.Categoria, error)
+}
+
+type categoriaEditarUseCase struct {
+	categoriaGateway repository.CategoriaRepository
+}
+
+func NewCategoriaEditarUseCase(categoriaGateway repository.CategoriaRepository) CategoriaEditarUseCase {
+	return &categoriaEditarUseCase{
+		categoriaGateway: categoriaGateway,
+	}
+}
+
+func (cuc *categoriaEditarUseCase) Run(c context.Context, id int, nome string, ordem int, ativa bool, obrigatoriaNoPedido bool, tempoPreparoMinutos int) (*entities.Categoria, error) {
+	atual, err := cuc.categoriaGateway.BuscarCategoriaPorId(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível atualizar a categoria: %w", err)
+	}
+
+	if nome == "" {
+		nome = string(atual.Nome)
+	}
+
+	if tempoPreparoMinutos == 0 {
+		tempoPreparoMinutos = atual.TempoPreparoMinutos
+	}
+
+	categoria, err := entities.CategoriaNew(nome, ordem, ativa, obrigatoriaNoPedido)
+	if err != nil {
+		return nil, fmt.Errorf("atualização de categoria inválida: %w", err)
+	}
+	if err := categoria.DefinirTempoPreparo(tempoPreparoMinutos); err != nil {
+		return nil, fmt.Errorf("atualização de categoria inválida: %w", err)
+	}
+	categoria.ID = atual.ID
+
+	if atual.Reservada() && categoria.Nome != atual.Nome {
+		return nil, entities.ErrCategoriaReservada
+	}
+
+	if err := verificarSlugLivre(c, cuc.categoriaGateway, categoria); err != nil {
+		return nil, err
+	}
+
+	err = cuc.categoriaGateway.EditarCategoria(c, categoria)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível atualizar a categoria: %w", err)
+	}
+
+	return categoria, nil
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoriaEditar_Run_Sucesso(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaEditarUseCase(repo)
+
+	categoria, err := useCase.Run(context.Background(), 3, "Bebidas geladas", 2, true, true, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bebidas-geladas", categoria.Slug)
+	assert.True(t, categoria.ObrigatoriaNoPedido)
+	assert.Equal(t, entities.CatProduto("Bebidas geladas"), repo.Categorias[2].Nome)
+}
+
+func TestCategoriaEditar_Run_MantemNome(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaEditarUseCase(repo)
+
+	categoria, err := useCase.Run(context.Background(), 1, "", 1, true, false, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Lanche, categoria.Nome)
+	assert.Equal(t, 10, categoria.TempoPreparoMinutos, "zero mantém o tempo de preparo atual")
+	assert.False(t, repo.Categorias[0].ObrigatoriaNoPedido, "o lanche deixa de ser obrigatório")
+}
+
+func TestCategoriaEditar_Run_NomeDeOutraCategoria(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaEditarUseCase(repo)
+
+	_, err := useCase.Run(context.Background(), 3, "Sobremesa", 3, true, false, 0)
+
+	assert.ErrorContains(t, err, "já existe uma categoria com o nome Sobremesa")
+}
+
+func TestCategoriaEditar_Run_ComboReservado(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaEditarUseCase(repo)
+
+	_, err := useCase.Run(context.Background(), 5, "Promoções", 5, true, false, 0)
+	assert.ErrorIs(t, err, entities.ErrCategoriaReservada)
+
+	categoria, err := useCase.Run(context.Background(), 5, "", 0, true, false, 0)
+	assert.NoError(t, err, "a ordem do combo pode mudar")
+	assert.Equal(t, 0, categoria.Ordem)
+}
+
+func TestCategoriaEditar_Run_NaoEncontrada(t *testing.T) {
+	useCase := NewCategoriaEditarUseCase(novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), 99, "Salgados", 1, true, false, 0)
+
+	assert.ErrorIs(t, err, entities.ErrCategoriaNaoEncontrada)
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CategoriaIncluirUseCase interface {
+	Run(ctx context.Context, nome string, ordem int, ativa, obrigatoriaNoPedido bool, tempoPreparoMinutos int) (*entities.Categoria, error)
+}
+
+type categoriaIncluirUseCase struct {
+	categoriaGateway repository.CategoriaRepository
+}
+
+func NewCategoriaIncluirUseCase(categoriaGateway repository.CategoriaRepository) CategoriaIncluirUseCase {
+	return &categoriaIncluirUseCase{
+		categoriaGateway: categoriaGateway,
+	}
+}
+
+func (cuc *categoriaIncluirUseCase) Run(c context.Context, nome string, ordem int, ativa bool, obrigatoriaNoPedido bool, tempoPreparoMinutos int) (*entities.Categoria, error) {
+	categoria, err := entities.CategoriaNew(nome, ordem, ativa, obrigatoriaNoPedido)
+	if err != nil {
+		return nil, fmt.Errorf("criação de categoria inválida: %w", err)
+	}
+	if err := categoria.DefinirTempoPreparo(tempoPreparoMinutos); err != nil {
+		return nil, fmt.Errorf("criação de categoria inválida: %w", err)
+	}
+
+	if err := verificarSlugLivre(c, cuc.categoriaGateway, categoria); err != nil {
+		return nil, err
+	}
+
+	err = cuc.categoriaGateway.AdicionarCategoria(c, categoria)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível criar a categoria: %w", err)
+	}
+
+	return categoria, nil
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockCategoriaRepository implements repository.CategoriaRepository for testing
+type MockCategoriaRepository struct {
+	Categorias []*entities.Categoria
+	// EmUso marca as categorias que têm produtos, combos ou cupons
+	EmUso map[int]bool
+	Err   error
+}
+
+// novoMockCategorias devolve o cadastro com as categorias do cardápio inicial
+func novoMockCategorias() *MockCategoriaRepository {
+	return &MockCategoriaRepository{Categorias: []*entities.Categoria{
+		{ID: 1, Nome: entities.Lanche, Slug: "lanche", Ordem: 1, Ativa: true, ObrigatoriaNoPedido: true, TempoPreparoMinutos: 10},
+		{ID: 2, Nome: entities.Acompanhamento, Slug: "acompanhamento", Ordem: 2, Ativa: true, TempoPreparoMinutos: 6},
+		{ID: 3, Nome: entities.Bebida, Slug: "bebida", Ordem: 3, Ativa: true, TempoPreparoMinutos: 1},
+		{ID: 4, Nome: entities.Sobremesa, Slug: "sobremesa", Ordem: 4, Ativa: true, TempoPreparoMinutos: 3},
+		{ID: 5, Nome: entities.Combo, Slug: "combo", Ordem: 5, Ativa: true},
+	}}
+}
+
+func (m *MockCategoriaRepository) AdicionarCategoria(ctx context.Context, categoria *entities.Categoria) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	categoria.ID = len(m.Categorias) + 1
+	m.Categorias = append(m.Categorias, categoria)
+	return nil
+}
+
+func (m *MockCategoriaRepository) buscar(encontrou func(*entities.Categoria) bool) (*entities.Categoria, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	for _, categoria := range m.Categorias {
+		if encontrou(categoria) {
+			copia := *categoria
+			return &copia, nil
+		}
+	}
+	return nil, entities.ErrCategoriaNaoEncontrada
+}
+
+func (m *MockCategoriaRepository) BuscarCategoriaPorId(ctx context.Context, id int) (*entities.Categoria, error) {
+	return m.buscar(func(c *entities.Categoria) bool { return c.ID == id })
+}
+
+func (m *MockCategoriaRepository) BuscarCategoriaPorNome(ctx context.Context, nome string) (*entities.Categoria, error) {
+	return m.buscar(func(c *entities.Categoria) bool { return string(c.Nome) == nome })
+}
+
+func (m *MockCategoriaRepository) BuscarCategoriaPorSlug(ctx context.Context, slug string) (*entities.Categoria, error) {
+	return m.buscar(func(c *entities.Categoria) bool { return c.Slug == slug })
+}
+
+func (m *MockCategoriaRepository) ListarCategorias(ctx context.Context) ([]*entities.Categoria, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Categorias, nil
+}
+
+func (m *MockCategoriaRepository) EditarCategoria(ctx context.Context, categoria *entities.Categoria) error {
+	for i, c := range m.Categorias {
+		if c.ID == categoria.ID {
+			m.Categorias[i] = categoria
+			return nil
+		}
+	}
+	return entities.ErrCategoriaNaoEncontrada
+}
+
+func (m *MockCategoriaRepository) RemoverCategoria(ctx context.Context, id int) error {
+	if m.EmUso[id] {
+		return entities.ErrCategoriaEmUso
+	}
+	for i, c := range m.Categorias {
+		if c.ID == id {
+			m.Categorias = append(m.Categorias[:i], m.Categorias[i+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrCategoriaNaoEncontrada
+}
+
+func TestCategoriaIncluir_Run_Sucesso(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaIncluirUseCase(repo)
+
+	categoria, err := useCase.Run(context.Background(), "Café da manhã", 0, true, false, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, categoria.ID)
+	assert.Equal(t, 4, categoria.TempoPreparoMinutos)
+	assert.Equal(t, "cafe-da-manha", categoria.Slug)
+	assert.Len(t, repo.Categorias, 6)
+}
+
+func TestCategoriaIncluir_Run_NomeDuplicado(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaIncluirUseCase(repo)
+
+	_, err := useCase.Run(context.Background(), "BEBIDA", 7, true, false, 0)
+
+	assert.ErrorContains(t, err, "já existe uma categoria com o nome Bebida")
+	assert.Len(t, repo.Categorias, 5)
+}
+
+func TestCategoriaIncluir_Run_Invalida(t *testing.T) {
+	repo := novoMockCategorias()
+	useCase := NewCategoriaIncluirUseCase(repo)
+
+	_, err := useCase.Run(context.Background(), " ", 1, true, false, 0)
+
+	assert.ErrorContains(t, err, "criação de categoria inválida")
+}
+
+func TestCategoriaIncluir_Run_ErroRepositorio(t *testing.T) {
+	repo := &MockCategoriaRepository{Err: errors.New("falha no banco")}
+	useCase := NewCategoriaIncluirUseCase(repo)
+
+	_, err := useCase.Run(context.Background(), "Salgados", 1, true, false, 0)
+
+	assert.ErrorContains(t, err, "falha no banco")
+}
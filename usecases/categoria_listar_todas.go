@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CategoriaListarTodasUseCase interface {
+	Run(ctx context.Context, incluirInativas bool) ([]*entities.Categoria, error)
+}
+
+type categoriaListarTodasUseCase struct {
+	categoriaGateway repository.CategoriaRepository
+}
+
+func NewCategoriaListarTodasUseCase(categoriaGateway repository.CategoriaRepository) CategoriaListarTodasUseCase {
+	return &categoriaListarTodasUseCase{
+		categoriaGateway: categoriaGateway,
+	}
+}
+
+func (cuc *categoriaListarTodasUseCase) Run(c context.Context, incluirInativas bool) ([]*entities.Categoria, error) {
+	categorias, err := cuc.categoriaGateway.ListarCategorias(c)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível listar as categorias: %w", err)
+	}
+	if incluirInativas {
+		return categorias, nil
+	}
+
+	ativas := make([]*entities.Categoria, 0, len(categorias))
+	for _, categoria := range categorias {
+		if categoria.Ativa {
+			ativas = append(ativas, categoria)
+		}
+	}
+	return ativas, nil
+}
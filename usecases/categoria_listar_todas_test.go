@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoriaListarTodas_Run(t *testing.T) {
+	repo := novoMockCategorias()
+	repo.Categorias[3].Ativa = false
+	useCase := NewCategoriaListarTodasUseCase(repo)
+
+	ativas, err := useCase.Run(context.Background(), false)
+	assert.NoError(t, err)
+	assert.Len(t, ativas, 4)
+	for _, categoria := range ativas {
+		assert.True(t, categoria.Ativa)
+	}
+
+	todas, err := useCase.Run(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Len(t, todas, 5)
+}
+
+func TestCategoriaListarTodas_Run_Erro(t *testing.T) {
+	useCase := NewCategoriaListarTodasUseCase(&MockCategoriaRepository{Err: errors.New("falha no banco")})
+
+	_, err := useCase.Run(context.Background(), false)
+
+	assert.ErrorContains(t, err, "não foi possível listar as categorias")
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CategoriaRemoverUseCase interface {
+	Run(ctx context.Context, id int) error
+}
+
+type categoriaRemoverUseCase struct {
+	categoriaGateway repository.CategoriaRepository
+}
+
+func NewCategoriaRemoverUseCase(categoriaGateway repository.CategoriaRepository) CategoriaRemoverUseCase {
+	return &categoriaRemoverUseCase{
+		categoriaGateway: categoriaGateway,
+	}
+}
+
+// Run remove uma categoria sem produtos, combos ou cupons; para tirá-la do cardápio basta desativá-la
+func (cuc *categoriaRemoverUseCase) Run(c context.Context, id int) error {
+	categoria, err := cuc.categoriaGateway.BuscarCategoriaPorId(c, id)
+	if err != nil {
+		return fmt.Errorf("não foi possível remover a categoria: %w", err)
+	}
+	if categoria.Reservada() {
+		return entities.ErrCategoriaReservada
+	}
+
+	err = cuc.categoriaGateway.RemoverCategoria(c, id)
+	if err != nil {
+		return fmt.Errorf("não foi possível remover a categoria: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoriaRemover_Run(t *testing.T) {
+	repo := novoMockCategorias()
+	repo.EmUso = map[int]bool{1: true}
+	useCase := NewCategoriaRemoverUseCase(repo)
+
+	assert.NoError(t, useCase.Run(context.Background(), 4))
+	assert.Len(t, repo.Categorias, 4)
+
+	assert.ErrorIs(t, useCase.Run(context.Background(), 1), entities.ErrCategoriaEmUso)
+	assert.ErrorIs(t, useCase.Run(context.Background(), 5), entities.ErrCategoriaReservada)
+	assert.ErrorIs(t, useCase.Run(context.Background(), 99), entities.ErrCategoriaNaoEncontrada)
+	assert.Len(t, repo.Categorias, 4)
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type ComboIncluirUseCase interface {
+	Run(ctx context.Context, nome, descricao string, preco entities.Money, componentes []entities.ComponenteCombo) (*entities.Produto, error)
+}
+
+type comboIncluirUseCase struct {
+	produtoRepository   repository.ProdutoRepository
+	categoriaRepository repository.CategoriaRepository
+	versaoRepository    repository.VersaoProdutoRepository
+	transacao           repository.Transacao
+	outboxRepository    repository.OutboxRepository
+}
+
+func NewComboIncluirUseCase(produtoRepository repository.ProdutoRepository, categoriaRepository repository.CategoriaRepository, versaoRepository repository.VersaoProdutoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) ComboIncluirUseCase {
+	return &comboIncluirUseCase{
+		produtoRepository:   produtoRepository,
+		categoriaRepository: categoriaRepository,
+		versaoRepository:    versaoRepository,
+		transacao:           transacao,
+		outboxRepository:    outboxRepository,
+	}
+}
+
+func (cuc *comboIncluirUseCase) Run(c context.Context, nome string, descricao string, preco entities.Money, componentes []entities.ComponenteCombo) (*entities.Produto, error) {
+	// Os componentes fixos chegam apenas com o ID; completar com os dados do catálogo
+	completos := make([]entities.ComponenteCombo, 0, len(componentes))
+	for _, componente := range componentes {
+		if componente.Produto != nil {
+			produto, err := cuc.produtoRepository.BuscarProdutoPorId(c, componente.Produto.ID)
+			if err != nil {
+				return nil, fmt.Errorf("produto %d do combo não existe: %w", componente.Produto.ID, err)
+			}
+			if produto.Arquivado() {
+				return nil, fmt.Errorf("produto %s do combo está arquivado", produto.Nome)
+			}
+			componente.Produto = produto
+		} else if componente.Categoria != "" && componente.Categoria != entities.Combo {
+			if err := exigirCategoria(c, cuc.categoriaRepository, componente.Categoria); err != nil {
+				return nil, fmt.Errorf("criação de combo inválida: %w", err)
+			}
+		}
+		completos = append(completos, componente)
+	}
+
+	combo, err := entities.ComboNew(nome, descricao, preco, completos)
+	if err != nil {
+		return nil, fmt.Errorf("criação de combo inválida: %w", err)
+	}
+
+	err = cuc.transacao.Executar(c, func(c context.Context) error {
+		if err := cuc.produtoRepository.AdicionarProduto(c, combo); err != nil {
+			return fmt.Errorf("não foi possível criar combo: %w", err)
+		}
+
+		if err := registrarVersao(c, cuc.versaoRepository, *combo); err != nil {
+			return err
+		}
+
+		// ✨ Evento para o SQS, publicado pelo relay da outbox
+		payload := map[string]interface{}{
+			"id_produto":  combo.ID,
+			"nome":        combo.Nome,
+			"categoria":   combo.Categoria,
+			"descricao":   combo.Descricao,
+			"preco":       combo.Preco,
+			"componentes": serializeComponentesCombo(combo.Componentes),
+		}
+		return registrarEvento(c, cuc.outboxRepository, entities.FilaProdutos, "produto_criado", payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return combo, nil
+}
+
+func serializeComponentesCombo(componentes []entities.ComponenteCombo) []map[string]interface{} {
+	lista := []map[string]interface{}{}
+	for _, componente := range componentes {
+		item := map[string]interface{}{"quantidade": componente.Quantidade}
+		if componente.Produto != nil {
+			item["id_produto"] = componente.Produto.ID
+			item["nome"] = componente.Produto.Nome
+		} else {
+			item["categoria"] = componente.Categoria
+		}
+		lista = append(lista, item)
+	}
+	return lista
+}
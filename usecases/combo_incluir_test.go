@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComboIncluir_Run_Sucesso(t *testing.T) {
+	banco := novoBancoTeste(t)
+	lanche := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	outbox := &MockOutboxRepository{}
+	useCase := NewComboIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, outbox)
+
+	componentes := []entities.ComponenteCombo{
+		{Produto: &entities.Produto{ID: lanche.ID}, Quantidade: 1},
+		{Categoria: entities.Bebida, Quantidade: 1},
+	}
+	combo, err := useCase.Run(context.Background(), "Combo X-Salada", "X-Salada e bebida", entities.Reais(26), componentes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, lanche.ID+1, combo.ID)
+	assert.Equal(t, entities.Combo, combo.Categoria)
+	assert.Equal(t, "X-Salada", combo.Componentes[0].Produto.Nome, "componente completado com os dados do catálogo")
+	assert.Equal(t, []string{"produto_criado"}, outbox.Tipos())
+}
+
+func TestComboIncluir_Run_ComponenteInexistente(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewComboIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	componentes := []entities.ComponenteCombo{
+		{Produto: &entities.Produto{ID: 99}},
+		{Categoria: entities.Bebida},
+	}
+	_, err := useCase.Run(context.Background(), "Combo", "", entities.Reais(26), componentes)
+
+	assert.ErrorContains(t, err, "produto 99 do combo não existe")
+	assert.Empty(t, banco.produtos())
+}
+
+func TestComboIncluir_Run_ComboInvalido(t *testing.T) {
+	banco := novoBancoTeste(t)
+	lanche := banco.produto(entities.Produto{Nome: "Combo antigo", Categoria: entities.Combo, Preco: entities.Reais(30)})
+	useCase := NewComboIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	componentes := []entities.ComponenteCombo{
+		{Produto: &entities.Produto{ID: lanche.ID}},
+		{Categoria: entities.Bebida},
+	}
+	_, err := useCase.Run(context.Background(), "Combo", "", entities.Reais(26), componentes)
+
+	assert.ErrorContains(t, err, "criação de combo inválida")
+	assert.Len(t, banco.produtos(), 1)
+}
+
+func TestComboIncluir_Run_CategoriaNaoCadastrada(t *testing.T) {
+	banco := novoBancoTeste(t)
+	lanche := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	useCase := NewComboIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	componentes := []entities.ComponenteCombo{
+		{Produto: &entities.Produto{ID: lanche.ID}},
+		{Categoria: "Pizza"},
+	}
+	_, err := useCase.Run(context.Background(), "Combo", "", entities.Reais(26), componentes)
+
+	assert.ErrorIs(t, err, entities.ErrCategoriaInvalida)
+	assert.Len(t, banco.produtos(), 1)
+}
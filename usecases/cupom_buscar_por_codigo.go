@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CupomBuscarPorCodigoUseCase interface {
+	Run(ctx context.Context, codigo string) (*entities.Cupom, error)
+}
+
+type cupomBuscarPorCodigoUseCase struct {
+	cupomGateway repository.CupomRepository
+}
+
+func NewCupomBuscarPorCodigoUseCase(cupomGateway repository.CupomRepository) CupomBuscarPorCodigoUseCase {
+	return &cupomBuscarPorCodigoUseCase{
+		cupomGateway: cupomGateway,
+	}
+}
+
+func (cuc *cupomBuscarPorCodigoUseCase) Run(c context.Context, codigo string) (*entities.Cupom, error) {
+	cupom, err := cuc.cupomGateway.BuscarCupomPorCodigo(c, entities.NormalizarCodigoCupom(codigo))
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível buscar o cupom: %w", err)
+	}
+	return cupom, nil
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCupomBuscarPorCodigo_Run_Sucesso(t *testing.T) {
+	repo := &MockCupomRepository{Cupons: []*entities.Cupom{{ID: 1, Codigo: "NATAL10"}}}
+	useCase := NewCupomBuscarPorCodigoUseCase(repo)
+
+	cupom, err := useCase.Run(context.Background(), " natal10")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cupom.ID)
+}
+
+func TestCupomBuscarPorCodigo_Run_NaoEncontrado(t *testing.T) {
+	useCase := NewCupomBuscarPorCodigoUseCase(&MockCupomRepository{})
+
+	_, err := useCase.Run(context.Background(), "NATAL10")
+
+	assert.ErrorContains(t, err, "cupom não encontrado")
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CupomEditarUseCase interface {
+	Run(ctx context.Context, codigo string, cupom entities.Cupom) (*entities.Cupom, error)
+}
+
+type cupomEditarUseCase struct {
+	cupomGateway     repository.CupomRepository
+	categoriaGateway repository.CategoriaRepository
+}
+
+func NewCupomEditarUseCase(cupomGateway repository.CupomRepository, categoriaGateway repository.CategoriaRepository) CupomEditarUseCase {
+	return &cupomEditarUseCase{
+		cupomGateway:     cupomGateway,
+		categoriaGateway: categoriaGateway,
+	}
+}
+
+func (cuc *cupomEditarUseCase) Run(c context.Context, codigo string, dados entities.Cupom) (*entities.Cupom, error) {
+	atual, err := cuc.cupomGateway.BuscarCupomPorCodigo(c, entities.NormalizarCodigoCupom(codigo))
+	if err != nil {
+		return nil, fmt.Errorf("cupom não cadastrado: %w", err)
+	}
+
+	// O código identifica o cupom e os usos já feitos não podem ser apagados na edição
+	dados.Codigo = atual.Codigo
+	cupom, err := entities.CupomNew(dados)
+	if err != nil {
+		return nil, fmt.Errorf("atualização de cupom inválida: %w", err)
+	}
+
+	if cupom.Categoria != "" {
+		if err := exigirCategoria(c, cuc.categoriaGateway, cupom.Categoria); err != nil {
+			return nil, fmt.Errorf("atualização de cupom inválida: %w", err)
+		}
+	}
+	cupom.ID = atual.ID
+	cupom.Usos = atual.Usos
+
+	err = cuc.cupomGateway.EditarCupom(c, cupom)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível atualizar o cupom: %w", err)
+	}
+
+	return cupom, nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCupomEditar_Run_MantemCodigoEUsos(t *testing.T) {
+	repo := &MockCupomRepository{Cupons: []*entities.Cupom{
+		{ID: 7, Codigo: "NATAL10", Tipo: entities.DescontoPercentual, Percentual: 10, LimiteUsos: 100, Usos: 42},
+	}}
+	useCase := NewCupomEditarUseCase(repo, novoMockCategorias())
+
+	cupom, err := useCase.Run(context.Background(), "natal10", entities.Cupom{
+		Codigo: "OUTRO", Tipo: entities.DescontoPercentual, Percentual: 15, LimiteUsos: 200,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, cupom.ID)
+	assert.Equal(t, "NATAL10", cupom.Codigo)
+	assert.Equal(t, 15, cupom.Percentual)
+	assert.Equal(t, 42, cupom.Usos)
+	assert.Equal(t, cupom, repo.Cupons[0])
+}
+
+func TestCupomEditar_Run_NaoEncontrado(t *testing.T) {
+	useCase := NewCupomEditarUseCase(&MockCupomRepository{}, novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), "NATAL10", entities.Cupom{Tipo: entities.DescontoPercentual, Percentual: 15})
+
+	assert.ErrorContains(t, err, "cupom não encontrado")
+}
+
+func TestCupomEditar_Run_Invalido(t *testing.T) {
+	repo := &MockCupomRepository{Cupons: []*entities.Cupom{{ID: 7, Codigo: "NATAL10", Tipo: entities.DescontoPercentual, Percentual: 10}}}
+	useCase := NewCupomEditarUseCase(repo, novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), "NATAL10", entities.Cupom{Tipo: entities.DescontoPercentual, Percentual: 150})
+
+	assert.ErrorContains(t, err, "atualização de cupom inválida")
+	assert.Equal(t, 10, repo.Cupons[0].Percentual)
+}
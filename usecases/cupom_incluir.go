@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CupomIncluirUseCase interface {
+	Run(ctx context.Context, cupom entities.Cupom) (*entities.Cupom, error)
+}
+
+type cupomIncluirUseCase struct {
+	cupomGateway     repository.CupomRepository
+	categoriaGateway repository.CategoriaRepository
+}
+
+func NewCupomIncluirUseCase(cupomGateway repository.CupomRepository, categoriaGateway repository.CategoriaRepository) CupomIncluirUseCase {
+	return &cupomIncluirUseCase{
+		cupomGateway:     cupomGateway,
+		categoriaGateway: categoriaGateway,
+	}
+}
+
+func (cuc *cupomIncluirUseCase) Run(c context.Context, dados entities.Cupom) (*entities.Cupom, error) {
+	cupom, err := entities.CupomNew(dados)
+	if err != nil {
+		return nil, fmt.Errorf("criação de cupom inválida: %w", err)
+	}
+
+	if cupom.Categoria != "" {
+		if err := exigirCategoria(c, cuc.categoriaGateway, cupom.Categoria); err != nil {
+			return nil, fmt.Errorf("criação de cupom inválida: %w", err)
+		}
+	}
+
+	if _, err := cuc.cupomGateway.BuscarCupomPorCodigo(c, cupom.Codigo); err == nil {
+		return nil, fmt.Errorf("já existe um cupom com o código %s", cupom.Codigo)
+	}
+
+	err = cuc.cupomGateway.AdicionarCupom(c, cupom)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível criar o cupom: %w", err)
+	}
+
+	return cupom, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockCupomRepository implements repository.CupomRepository for testing
+type MockCupomRepository struct {
+	Cupons []*entities.Cupom
+	Err    error
+}
+
+func (m *MockCupomRepository) AdicionarCupom(ctx context.Context, cupom *entities.Cupom) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	cupom.ID = len(m.Cupons) + 1
+	m.Cupons = append(m.Cupons, cupom)
+	return nil
+}
+
+func (m *MockCupomRepository) BuscarCupomPorCodigo(ctx context.Context, codigo string) (*entities.Cupom, error) {
+	for _, c := range m.Cupons {
+		if c.Codigo == codigo {
+			return c, nil
+		}
+	}
+	return nil, errors.New("cupom não encontrado")
+}
+
+func (m *MockCupomRepository) ListarCupons(ctx context.Context) ([]*entities.Cupom, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Cupons, nil
+}
+
+func (m *MockCupomRepository) EditarCupom(ctx context.Context, cupom *entities.Cupom) error {
+	for i, c := range m.Cupons {
+		if c.Codigo == cupom.Codigo {
+			m.Cupons[i] = cupom
+			return nil
+		}
+	}
+	return errors.New("cupom não encontrado")
+}
+
+func (m *MockCupomRepository) RemoverCupom(ctx context.Context, codigo string) error {
+	for i, c := range m.Cupons {
+		if c.Codigo == codigo {
+			m.Cupons = append(m.Cupons[:i], m.Cupons[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cupom não encontrado")
+}
+
+func TestCupomIncluir_Run_Sucesso(t *testing.T) {
+	repo := &MockCupomRepository{}
+	useCase := NewCupomIncluirUseCase(repo, novoMockCategorias())
+
+	cupom, err := useCase.Run(context.Background(), entities.Cupom{Codigo: "natal10", Tipo: entities.DescontoPercentual, Percentual: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cupom.ID)
+	assert.Equal(t, "NATAL10", cupom.Codigo)
+	assert.Len(t, repo.Cupons, 1)
+}
+
+func TestCupomIncluir_Run_CodigoDuplicado(t *testing.T) {
+	repo := &MockCupomRepository{Cupons: []*entities.Cupom{{ID: 1, Codigo: "NATAL10"}}}
+	useCase := NewCupomIncluirUseCase(repo, novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), entities.Cupom{Codigo: "Natal10", Tipo: entities.DescontoPercentual, Percentual: 15})
+
+	assert.ErrorContains(t, err, "já existe um cupom com o código NATAL10")
+	assert.Len(t, repo.Cupons, 1)
+}
+
+func TestCupomIncluir_Run_Invalido(t *testing.T) {
+	repo := &MockCupomRepository{}
+	useCase := NewCupomIncluirUseCase(repo, novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), entities.Cupom{Codigo: "X", Tipo: entities.DescontoPercentual, Percentual: 0})
+
+	assert.ErrorContains(t, err, "criação de cupom inválida")
+	assert.Empty(t, repo.Cupons)
+}
+
+func TestCupomIncluir_Run_ErroRepositorio(t *testing.T) {
+	repo := &MockCupomRepository{Err: errors.New("erro de banco")}
+	useCase := NewCupomIncluirUseCase(repo, novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), entities.Cupom{Codigo: "X", Tipo: entities.DescontoValorFixo, Valor: entities.Reais(5)})
+
+	assert.ErrorContains(t, err, "erro de banco")
+}
+
+func TestCupomIncluir_Run_CategoriaNaoCadastrada(t *testing.T) {
+	repo := &MockCupomRepository{}
+	useCase := NewCupomIncluirUseCase(repo, novoMockCategorias())
+
+	_, err := useCase.Run(context.Background(), entities.Cupom{Codigo: "PIZZA", Tipo: entities.DescontoPercentual, Percentual: 10, Categoria: "Pizza"})
+
+	assert.ErrorIs(t, err, entities.ErrCategoriaInvalida)
+	assert.Empty(t, repo.Cupons)
+}
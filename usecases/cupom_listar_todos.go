@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CupomListarTodosUseCase interface {
+	Run(ctx context.Context) ([]*entities.Cupom, error)
+}
+
+type cupomListarTodosUseCase struct {
+	cupomGateway repository.CupomRepository
+}
+
+func NewCupomListarTodosUseCase(cupomGateway repository.CupomRepository) CupomListarTodosUseCase {
+	return &cupomListarTodosUseCase{
+		cupomGateway: cupomGateway,
+	}
+}
+
+func (cuc *cupomListarTodosUseCase) Run(c context.Context) ([]*entities.Cupom, error) {
+	cupons, err := cuc.cupomGateway.ListarCupons(c)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível listar os cupons: %w", err)
+	}
+	return cupons, nil
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCupomListarTodos_Run_Sucesso(t *testing.T) {
+	repo := &MockCupomRepository{Cupons: []*entities.Cupom{{ID: 1, Codigo: "A"}, {ID: 2, Codigo: "B"}}}
+	useCase := NewCupomListarTodosUseCase(repo)
+
+	cupons, err := useCase.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, cupons, 2)
+}
+
+func TestCupomListarTodos_Run_Erro(t *testing.T) {
+	useCase := NewCupomListarTodosUseCase(&MockCupomRepository{Err: errors.New("erro de banco")})
+
+	_, err := useCase.Run(context.Background())
+
+	assert.ErrorContains(t, err, "não foi possível listar os cupons")
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type CupomRemoverUseCase interface {
+	Run(ctx context.Context, codigo string) error
+}
+
+type cupomRemoverUseCase struct {
+	cupomGateway repository.CupomRepository
+}
+
+func NewCupomRemoverUseCase(cupomGateway repository.CupomRepository) CupomRemoverUseCase {
+	return &cupomRemoverUseCase{
+		cupomGateway: cupomGateway,
+	}
+}
+
+func (cuc *cupomRemoverUseCase) Run(c context.Context, codigo string) error {
+	err := cuc.cupomGateway.RemoverCupom(c, entities.NormalizarCodigoCupom(codigo))
+	if err != nil {
+		return fmt.Errorf("não foi possível remover o cupom: %w", err)
+	}
+	return nil
+}
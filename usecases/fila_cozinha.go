@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+// recalcularFilaCozinha recalcula e grava a previsão de todos os pedidos na fila da cozinha;
+// deve ser chamado sempre que um pedido entra ou sai da fila
+func recalcularFilaCozinha(c context.Context, pedidoGateway repository.PedidoRepository) error {
+	fila, err := pedidoGateway.ListarFilaCozinha(c)
+	if err != nil {
+		return fmt.Errorf("não foi possível carregar a fila da cozinha: %w", err)
+	}
+
+	entities.RecalcularFila(fila, time.Now())
+
+	if err := pedidoGateway.AtualizarPrevisoes(c, fila); err != nil {
+		return fmt.Errorf("não foi possível atualizar as previsões da fila: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type GrupoModificadorIncluirUseCase interface {
+	Run(ctx context.Context, produtoID int, nome string, minSelecoes int, maxSelecoes int, opcoes []entities.Modificador) (*entities.GrupoModificador, error)
+}
+
+type grupoModificadorIncluirUseCase struct {
+	produtoGateway     repository.ProdutoRepository
+	modificadorGateway repository.ModificadorRepository
+}
+
+func NewGrupoModificadorIncluirUseCase(produtoGateway repository.ProdutoRepository, modificadorGateway repository.ModificadorRepository) GrupoModificadorIncluirUseCase {
+	return &grupoModificadorIncluirUseCase{
+		produtoGateway:     produtoGateway,
+		modificadorGateway: modificadorGateway,
+	}
+}
+
+func (gmuc *grupoModificadorIncluirUseCase) Run(c context.Context, produtoID int, nome string, minSelecoes int, maxSelecoes int, opcoes []entities.Modificador) (*entities.GrupoModificador, error) {
+	_, err := gmuc.produtoGateway.BuscarProdutoPorId(c, produtoID)
+	if err != nil {
+		return nil, fmt.Errorf("produto não existe no banco de dados: %w", err)
+	}
+
+	grupo, err := entities.GrupoModificadorNew(produtoID, nome, minSelecoes, maxSelecoes, opcoes)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar grupo de modificadores: %w", err)
+	}
+
+	err = gmuc.modificadorGateway.AdicionarGrupo(c, grupo)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao incluir grupo de modificadores: %w", err)
+	}
+
+	return grupo, nil
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrupoModificadorIncluir_Run_Sucesso(t *testing.T) {
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	useCase := NewGrupoModificadorIncluirUseCase(banco.Produtos, banco.Modificadores)
+
+	opcoes := []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Reais(4.0)}}
+	grupo, err := useCase.Run(context.Background(), produto.ID, "Adicionais", 0, 1, opcoes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, grupo.ID)
+	assert.Equal(t, produto.ID, grupo.ProdutoID)
+	assert.Equal(t, 1, grupo.Opcoes[0].ID)
+	assert.Len(t, banco.gruposDe(produto.ID), 1)
+}
+
+func TestGrupoModificadorIncluir_Run_ProdutoInexistente(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewGrupoModificadorIncluirUseCase(banco.Produtos, banco.Modificadores)
+
+	opcoes := []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Reais(4.0)}}
+	_, err := useCase.Run(context.Background(), 99, "Adicionais", 0, 1, opcoes)
+
+	assert.ErrorContains(t, err, "produto não existe")
+	assert.Empty(t, banco.gruposDe(99))
+}
+
+func TestGrupoModificadorIncluir_Run_GrupoInvalido(t *testing.T) {
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	useCase := NewGrupoModificadorIncluirUseCase(banco.Produtos, banco.Modificadores)
+
+	_, err := useCase.Run(context.Background(), produto.ID, "Adicionais", 2, 1, nil)
+
+	assert.ErrorContains(t, err, "falha ao criar grupo de modificadores")
+	assert.Empty(t, banco.gruposDe(produto.ID))
+}
+
+func TestGrupoModificadorIncluir_Run_ErroRepositorio(t *testing.T) {
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	modificadores := modificadoresComFalha{ModificadorRepository: banco.Modificadores, err: errors.New("erro de banco")}
+	useCase := NewGrupoModificadorIncluirUseCase(banco.Produtos, modificadores)
+
+	opcoes := []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Reais(4.0)}}
+	_, err := useCase.Run(context.Background(), produto.ID, "Adicionais", 0, 1, opcoes)
+
+	assert.ErrorContains(t, err, "erro de banco")
+}
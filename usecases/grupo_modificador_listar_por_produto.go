@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type GrupoModificadorListarPorProdutoUseCase interface {
+	Run(ctx context.Context, produtoID int) ([]entities.GrupoModificador, error)
+}
+
+type grupoModificadorListarPorProdutoUseCase struct {
+	modificadorGateway repository.ModificadorRepository
+}
+
+func NewGrupoModificadorListarPorProdutoUseCase(modificadorGateway repository.ModificadorRepository) GrupoModificadorListarPorProdutoUseCase {
+	return &grupoModificadorListarPorProdutoUseCase{
+		modificadorGateway: modificadorGateway,
+	}
+}
+
+func (gmuc *grupoModificadorListarPorProdutoUseCase) Run(c context.Context, produtoID int) ([]entities.GrupoModificador, error) {
+	grupos, err := gmuc.modificadorGateway.ListarGruposPorProduto(c, produtoID)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível listar os modificadores do produto: %w", err)
+	}
+	return grupos, nil
+}
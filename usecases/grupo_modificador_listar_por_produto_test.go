@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrupoModificadorListarPorProduto_Run_Sucesso(t *testing.T) {
+	banco := novoBancoTeste(t)
+	lanche := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	bebida := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6)})
+	banco.grupo(entities.GrupoModificador{ProdutoID: lanche.ID, Nome: "Adicionais", MaxSelecoes: 1, Opcoes: []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Reais(4.0)}}})
+	banco.grupo(entities.GrupoModificador{ProdutoID: bebida.ID, Nome: "Gelo", MaxSelecoes: 1, Opcoes: []entities.Modificador{{Nome: "Sem gelo"}}})
+	useCase := NewGrupoModificadorListarPorProdutoUseCase(banco.Modificadores)
+
+	grupos, err := useCase.Run(context.Background(), lanche.ID)
+
+	assert.NoError(t, err)
+	assert.Len(t, grupos, 1)
+	assert.Equal(t, "Adicionais", grupos[0].Nome)
+}
+
+func TestGrupoModificadorListarPorProduto_Run_Erro(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewGrupoModificadorListarPorProdutoUseCase(modificadoresComFalha{ModificadorRepository: banco.Modificadores, err: errors.New("erro de banco")})
+
+	_, err := useCase.Run(context.Background(), 1)
+
+	assert.ErrorContains(t, err, "não foi possível listar os modificadores do produto")
+}
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/repository"
+)
+
+type GrupoModificadorRemoverUseCase interface {
+	Run(ctx context.Context, grupoID int) error
+}
+
+type grupoModificadorRemoverUseCase struct {
+	modificadorGateway repository.ModificadorRepository
+}
+
+func NewGrupoModificadorRemoverUseCase(modificadorGateway repository.ModificadorRepository) GrupoModificadorRemoverUseCase {
+	return &grupoModificadorRemoverUseCase{
+		modificadorGateway: modificadorGateway,
+	}
+}
+
+func (gmuc *grupoModificadorRemoverUseCase) Run(c context.Context, grupoID int) error {
+	err := gmuc.modificadorGateway.RemoverGrupo(c, grupoID)
+	if err != nil {
+		return fmt.Errorf("não foi possível remover o grupo de modificadores: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrupoModificadorRemover_Run_Sucesso(t *testing.T) {
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	grupo := banco.grupo(entities.GrupoModificador{ProdutoID: produto.ID, Nome: "Adicionais", MaxSelecoes: 1, Opcoes: []entities.Modificador{{Nome: "Bacon extra", Preco: entities.Reais(4.0)}}})
+	useCase := NewGrupoModificadorRemoverUseCase(banco.Modificadores)
+
+	err := useCase.Run(context.Background(), grupo.ID)
+
+	assert.NoError(t, err)
+	assert.Empty(t, banco.gruposDe(produto.ID))
+}
+
+func TestGrupoModificadorRemover_Run_NaoEncontrado(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewGrupoModificadorRemoverUseCase(banco.Modificadores)
+
+	err := useCase.Run(context.Background(), 99)
+
+	assert.ErrorContains(t, err, "grupo de modificadores não encontrado")
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type chaveOrigem struct{}
+
+// ComOrigem associa ao contexto de onde vem a mudança de status do pedido, para o histórico do pedido
+func ComOrigem(ctx context.Context, origem entities.OrigemAlteracao) context.Context {
+	return context.WithValue(ctx, chaveOrigem{}, origem)
+}
+
+// OrigemDe informa de onde vem a mudança de status do pedido, ou OrigemDesconhecida
+func OrigemDe(ctx context.Context) entities.OrigemAlteracao {
+	if origem, ok := ctx.Value(chaveOrigem{}).(entities.OrigemAlteracao); ok && origem != "" {
+		return origem
+	}
+	return entities.OrigemDesconhecida
+}
+
+// statusAnteriores guarda os status do pedido antes de uma mudança, para comparar depois de persistida
+type statusAnteriores struct {
+	status          entities.StatusPedido
+	statusPagamento entities.StatusPagamento
+}
+
+func statusDe(pedido *entities.Pedido) statusAnteriores {
+	return statusAnteriores{status: pedido.Status, statusPagamento: pedido.StatusPagamento}
+}
+
+// registrarMudancas grava no histórico os status do pedido que mudaram desde os anteriores. Deve ser
+// chamado dentro de Transacao.Executar, para que a mudança e o histórico sejam gravados juntos.
+func registrarMudancas(c context.Context, historicoGateway repository.HistoricoPedidoRepository, anteriores statusAnteriores, pedido *entities.Pedido) error {
+	if pedido.StatusPagamento != anteriores.statusPagamento {
+		if err := registrarAlteracao(c, historicoGateway, pedido, entities.AlteracaoPagamento, string(anteriores.statusPagamento), string(pedido.StatusPagamento)); err != nil {
+			return err
+		}
+	}
+	if pedido.Status != anteriores.status {
+		return registrarAlteracao(c, historicoGateway, pedido, entities.AlteracaoStatus, string(anteriores.status), string(pedido.Status))
+	}
+	return nil
+}
+
+func registrarAlteracao(c context.Context, historicoGateway repository.HistoricoPedidoRepository, pedido *entities.Pedido, campo entities.CampoAlteracao, de, para string) error {
+	alteracao, err := entities.AlteracaoPedidoNew(pedido.ID, campo, de, para, OrigemDe(c), pedido.UltimaAtualizacao)
+	if err == nil {
+		err = historicoGateway.RegistrarAlteracao(c, alteracao)
+	}
+	if err != nil {
+		return fmt.Errorf("não foi possível registrar o histórico do pedido %d: %w", pedido.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/interfaces/storage"
+)
+
+// apagarArquivosImagens remove do armazenamento os arquivos de imagens que já não estão gravadas
+// para o produto. Uma falha aqui só deixa um arquivo órfão, então é registrada e não interrompe a operação.
+func apagarArquivosImagens(c context.Context, imageStorage storage.ImageStorage, imagens []entities.ImagemProduto) {
+	for _, imagem := range imagens {
+		if err := imageStorage.Delete(c, imagem.Chave); err != nil {
+			fmt.Println("⚠️ Falha ao apagar arquivo de imagem do produto:", imagem.Chave, err)
+		}
+	}
+}
+
+// eventoProdutoEditado monta o evento com o estado atual do produto, incluindo as URLs das imagens
+func eventoProdutoEditado(c context.Context, produto *entities.Produto) map[string]interface{} {
+	return map[string]interface{}{
+		"id_produto":    produto.ID,
+		"nome":          produto.Nome,
+		"categoria":     produto.Categoria,
+		"descricao":     produto.Descricao,
+		"preco":         produto.Preco,
+		"tempo_preparo": produto.TempoPreparoMinutos,
+		"alergenos":     produto.Alergenos,
+		"tags_dieta":    produto.TagsDieta,
+		"imagens":       entities.URLsImagens(produto.Imagens),
+		"autor":         AutorDe(c),
+	}
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"lanchonete/internal/domain/entities"
+)
+
+// varianteGerada é um arquivo pronto para o armazenamento, ainda sem chave nem URL
+type varianteGerada struct {
+	variante entities.VarianteImagem
+	conteudo []byte
+	largura  int
+	altura   int
+}
+
+// gerarVariantes decodifica a imagem enviada e produz a original e uma cópia reduzida para cada
+// largura em entities.LarguraVariante, no mesmo formato. Imagens menores não são ampliadas. As
+// dimensões são conferidas pelo cabeçalho antes da decodificação, que aloca a imagem inteira.
+func gerarVariantes(conteudo []byte, extensao string) ([]varianteGerada, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(conteudo))
+	if err != nil {
+		return nil, fmt.Errorf("%w: o arquivo não pôde ser lido como imagem", entities.ErrFormatoImagem)
+	}
+	if err := entities.ValidarDimensoesImagem(config.Width, config.Height); err != nil {
+		return nil, err
+	}
+
+	original, _, err := image.Decode(bytes.NewReader(conteudo))
+	if err != nil {
+		return nil, fmt.Errorf("%w: o arquivo não pôde ser lido como imagem", entities.ErrFormatoImagem)
+	}
+
+	limites := original.Bounds()
+	variantes := []varianteGerada{{
+		variante: entities.ImagemOriginal,
+		conteudo: conteudo,
+		largura:  limites.Dx(),
+		altura:   limites.Dy(),
+	}}
+
+	for _, variante := range []entities.VarianteImagem{entities.ImagemMedia, entities.ImagemMiniatura} {
+		reduzida := redimensionar(original, entities.LarguraVariante[variante])
+		codificada, err := codificarImagem(reduzida, extensao)
+		if err != nil {
+			return nil, err
+		}
+		variantes = append(variantes, varianteGerada{
+			variante: variante,
+			conteudo: codificada,
+			largura:  reduzida.Bounds().Dx(),
+			altura:   reduzida.Bounds().Dy(),
+		})
+	}
+	return variantes, nil
+}
+
+// redimensionar reduz a imagem para a largura informada, mantendo a proporção. Cada pixel do
+// resultado é a média dos pixels de origem que ele cobre, o que evita o serrilhado nas miniaturas.
+func redimensionar(origem image.Image, largura int) image.Image {
+	limites := origem.Bounds()
+	if limites.Dx() <= largura {
+		return origem
+	}
+
+	altura := limites.Dy() * largura / limites.Dx()
+	if altura < 1 {
+		altura = 1
+	}
+
+	destino := image.NewNRGBA(image.Rect(0, 0, largura, altura))
+	for y := 0; y < altura; y++ {
+		y0 := limites.Min.Y + y*limites.Dy()/altura
+		y1 := limites.Min.Y + (y+1)*limites.Dy()/altura
+		for x := 0; x < largura; x++ {
+			x0 := limites.Min.X + x*limites.Dx()/largura
+			x1 := limites.Min.X + (x+1)*limites.Dx()/largura
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(origem.At(sx, sy)).(color.NRGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			destino.Set(x, y, color.NRGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return destino
+}
+
+func codificarImagem(img image.Image, extensao string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch extensao {
+	case "png":
+		err = png.Encode(&buf, img)
+	default:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar variante da imagem: %w", err)
+	}
+	return buf.Bytes(), nil
+}
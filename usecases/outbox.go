@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+// registrarEvento grava o evento na outbox. Chamado dentro de Transacao.Executar, o evento só
+// existe se a alteração que o gerou for confirmada, e o relay o publica depois.
+func registrarEvento(c context.Context, outboxRepository repository.OutboxRepository, fila, tipo string, payload map[string]interface{}) error {
+	evento, err := entities.EventoOutboxNew(fila, tipo, payload, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := outboxRepository.RegistrarEvento(c, evento); err != nil {
+		return fmt.Errorf("não foi possível registrar o evento %s: %w", tipo, err)
+	}
+	return nil
+}
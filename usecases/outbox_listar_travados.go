@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+type OutboxListarTravadosUseCase interface {
+	Run(ctx context.Context) ([]*entities.EventoOutbox, error)
+}
+
+type outboxListarTravadosUseCase struct {
+	outboxRepository repository.OutboxRepository
+	limite           time.Duration
+}
+
+// NewOutboxListarTravadosUseCase considera travado o evento que falhou ou que espera publicação há mais que limite
+func NewOutboxListarTravadosUseCase(outboxRepository repository.OutboxRepository, limite time.Duration) OutboxListarTravadosUseCase {
+	return &outboxListarTravadosUseCase{
+		outboxRepository: outboxRepository,
+		limite:           limite,
+	}
+}
+
+func (oltuc *outboxListarTravadosUseCase) Run(c context.Context) ([]*entities.EventoOutbox, error) {
+	eventos, err := oltuc.outboxRepository.ListarNaoPublicados(c)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível listar os eventos da outbox: %w", err)
+	}
+
+	agora := time.Now()
+	travados := []*entities.EventoOutbox{}
+	for _, evento := range eventos {
+		if evento.Travado(agora, oltuc.limite) {
+			travados = append(travados, evento)
+		}
+	}
+	return travados, nil
+}
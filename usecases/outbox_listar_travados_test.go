@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestOutboxListarTravadosUseCase_Run(t *testing.T) {
+	agora := time.Now()
+	recente := novoEventoOutbox(t, entities.FilaProdutos, "produto_criado", agora)
+	antigo := novoEventoOutbox(t, entities.FilaProdutos, "produto_editado", agora.Add(-time.Hour))
+	falhou := novoEventoOutbox(t, entities.FilaPedidos, "pedido_criado", agora)
+	falhou.Status = entities.EventoFalhou
+	publicado := novoEventoOutbox(t, entities.FilaPedidos, "pedido_cancelado", agora.Add(-time.Hour))
+	publicado.MarcarPublicado(agora)
+
+	outbox := &MockOutboxRepository{Eventos: []*entities.EventoOutbox{recente, antigo, falhou, publicado}}
+	useCase := NewOutboxListarTravadosUseCase(outbox, 5*time.Minute)
+
+	travados, err := useCase.Run(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(travados) != 2 || travados[0] != antigo || travados[1] != falhou {
+		t.Errorf("expected the old and the failed events, got %+v", travados)
+	}
+}
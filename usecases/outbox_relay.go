@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"lanchonete/internal/interfaces/publisher"
+	"time"
+)
+
+type OutboxRelayUseCase interface {
+	// Run publica um lote de eventos pendentes e informa quantos chegaram à fila
+	Run(ctx context.Context) (int, error)
+}
+
+type outboxRelayUseCase struct {
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
+	publishers       map[string]publisher.EventPublisher
+	politica         entities.PoliticaRetentativa
+	lote             int
+}
+
+// NewOutboxRelayUseCase recebe um publisher por fila (entities.FilaProdutos, entities.FilaPedidos)
+func NewOutboxRelayUseCase(transacao repository.Transacao, outboxRepository repository.OutboxRepository, publishers map[string]publisher.EventPublisher, politica entities.PoliticaRetentativa, lote int) OutboxRelayUseCase {
+	return &outboxRelayUseCase{
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
+		publishers:       publishers,
+		politica:         politica,
+		lote:             lote,
+	}
+}
+
+// Run publica um lote em três passos, sem segurar transação durante a publicação: reserva os eventos
+// numa transação curta, publica fora dela e grava o resultado noutra transação curta. A reserva impede
+// que outra instância publique os mesmos eventos. Se o relay parar depois de publicar, o evento volta
+// quando a reserva vence e é publicado de novo: a entrega é pelo menos uma vez.
+func (oruc *outboxRelayUseCase) Run(c context.Context) (int, error) {
+	eventos, err := oruc.reservar(c)
+	if err != nil {
+		return 0, err
+	}
+	if len(eventos) == 0 {
+		return 0, nil
+	}
+
+	publicados := 0
+	for _, evento := range eventos {
+		if err := oruc.publicar(evento); err != nil {
+			evento.RegistrarFalha(err, time.Now(), oruc.politica)
+			if evento.Status == entities.EventoFalhou {
+				fmt.Println("⚠️ Evento da outbox esgotou as tentativas de publicação:", evento.ID, evento.Tipo, err)
+			}
+		} else {
+			evento.MarcarPublicado(time.Now())
+			publicados++
+		}
+	}
+
+	err = oruc.transacao.Executar(c, func(c context.Context) error {
+		for _, evento := range eventos {
+			if err := oruc.outboxRepository.AtualizarEvento(c, evento); err != nil {
+				return fmt.Errorf("não foi possível atualizar o evento %d: %w", evento.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return publicados, nil
+}
+
+// reservar lista o lote de eventos pendentes e os reserva para este relay
+func (oruc *outboxRelayUseCase) reservar(c context.Context) ([]*entities.EventoOutbox, error) {
+	var eventos []*entities.EventoOutbox
+	err := oruc.transacao.Executar(c, func(c context.Context) error {
+		agora := time.Now()
+		pendentes, err := oruc.outboxRepository.ListarPendentes(c, agora, oruc.lote)
+		if err != nil {
+			return fmt.Errorf("não foi possível carregar os eventos pendentes: %w", err)
+		}
+
+		for _, evento := range pendentes {
+			evento.Reservar(agora, entities.PrazoReservaEvento)
+			if err := oruc.outboxRepository.AtualizarEvento(c, evento); err != nil {
+				return fmt.Errorf("não foi possível reservar o evento %d: %w", evento.ID, err)
+			}
+		}
+		eventos = pendentes
+		return nil
+	})
+	return eventos, err
+}
+
+func (oruc *outboxRelayUseCase) publicar(evento *entities.EventoOutbox) error {
+	eventPublisher, ok := oruc.publishers[evento.Fila]
+	if !ok {
+		return fmt.Errorf("nenhum publisher configurado para a fila %q", evento.Fila)
+	}
+	return eventPublisher.Publish(evento.Tipo, evento.Payload)
+}
@@ -0,0 +1,251 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/interfaces/publisher"
+	"testing"
+	"time"
+)
+
+// MockTransacao implements repository.Transacao for testing
+type MockTransacao struct {
+	Confirmadas int
+	Desfeitas   int
+	// Aberta indica que fn está rodando dentro da transação
+	Aberta bool
+}
+
+func (m *MockTransacao) Executar(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Aberta = true
+	err := fn(ctx)
+	m.Aberta = false
+	if err != nil {
+		m.Desfeitas++
+		return err
+	}
+	m.Confirmadas++
+	return nil
+}
+
+// MockOutboxRepository implements repository.OutboxRepository for testing
+type MockOutboxRepository struct {
+	Eventos []*entities.EventoOutbox
+	Err     error
+}
+
+func (m *MockOutboxRepository) RegistrarEvento(ctx context.Context, evento *entities.EventoOutbox) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	evento.ID = int64(len(m.Eventos) + 1)
+	m.Eventos = append(m.Eventos, evento)
+	return nil
+}
+
+func (m *MockOutboxRepository) ListarPendentes(ctx context.Context, agora time.Time, limite int) ([]*entities.EventoOutbox, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	pendentes := []*entities.EventoOutbox{}
+	for _, e := range m.Eventos {
+		if e.Status == entities.EventoPendente && !e.ProximaTentativa.After(agora) && len(pendentes) < limite {
+			pendentes = append(pendentes, e)
+		}
+	}
+	return pendentes, nil
+}
+
+func (m *MockOutboxRepository) AtualizarEvento(ctx context.Context, evento *entities.EventoOutbox) error {
+	return m.Err
+}
+
+func (m *MockOutboxRepository) BuscarEvento(ctx context.Context, id int64) (*entities.EventoOutbox, error) {
+	for _, e := range m.Eventos {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, errors.New("evento não encontrado")
+}
+
+func (m *MockOutboxRepository) ListarNaoPublicados(ctx context.Context) ([]*entities.EventoOutbox, error) {
+	naoPublicados := []*entities.EventoOutbox{}
+	for _, e := range m.Eventos {
+		if e.Status != entities.EventoPublicado {
+			naoPublicados = append(naoPublicados, e)
+		}
+	}
+	return naoPublicados, nil
+}
+
+// Tipos lista os tipos dos eventos registrados, na ordem
+func (m *MockOutboxRepository) Tipos() []string {
+	tipos := []string{}
+	for _, e := range m.Eventos {
+		tipos = append(tipos, e.Tipo)
+	}
+	return tipos
+}
+
+// Payload decodifica os dados do i-ésimo evento registrado, como o relay os publica
+func (m *MockOutboxRepository) Payload(t *testing.T, i int) map[string]interface{} {
+	t.Helper()
+	if i >= len(m.Eventos) {
+		t.Fatalf("evento %d não registrado, eventos: %v", i, m.Tipos())
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(m.Eventos[i].Payload, &payload); err != nil {
+		t.Fatalf("payload inválido: %v", err)
+	}
+	return payload
+}
+
+type MockEventPublisherOutbox struct {
+	Eventos []string
+	Err     error
+}
+
+func (m *MockEventPublisherOutbox) Publish(eventType string, payload interface{}) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Eventos = append(m.Eventos, eventType)
+	return nil
+}
+
+func novoEventoOutbox(t *testing.T, fila, tipo string, agora time.Time) *entities.EventoOutbox {
+	evento, err := entities.EventoOutboxNew(fila, tipo, map[string]interface{}{"id": 1}, agora)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return evento
+}
+
+func TestOutboxRelayUseCase_Run_PublicaNaFilaDoEvento(t *testing.T) {
+	agora := time.Now()
+	outbox := &MockOutboxRepository{}
+	outbox.RegistrarEvento(context.Background(), novoEventoOutbox(t, entities.FilaProdutos, "produto_criado", agora))
+	outbox.RegistrarEvento(context.Background(), novoEventoOutbox(t, entities.FilaPedidos, "pedido_criado", agora))
+	futuro := novoEventoOutbox(t, entities.FilaPedidos, "pedido_cancelado", agora)
+	futuro.ProximaTentativa = agora.Add(time.Hour)
+	outbox.RegistrarEvento(context.Background(), futuro)
+
+	produtos := &MockEventPublisherOutbox{}
+	pedidos := &MockEventPublisherOutbox{}
+	transacao := &MockTransacao{}
+	useCase := NewOutboxRelayUseCase(transacao, outbox, map[string]publisher.EventPublisher{
+		entities.FilaProdutos: produtos,
+		entities.FilaPedidos:  pedidos,
+	}, entities.PoliticaRetentativaPadrao, 10)
+
+	publicados, err := useCase.Run(context.Background())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if publicados != 2 {
+		t.Errorf("expected 2 eventos publicados, got %d", publicados)
+	}
+	if len(produtos.Eventos) != 1 || produtos.Eventos[0] != "produto_criado" {
+		t.Errorf("unexpected eventos na fila de produtos %v", produtos.Eventos)
+	}
+	if len(pedidos.Eventos) != 1 || pedidos.Eventos[0] != "pedido_criado" {
+		t.Errorf("unexpected eventos na fila de pedidos %v", pedidos.Eventos)
+	}
+	if outbox.Eventos[0].Status != entities.EventoPublicado || outbox.Eventos[2].Status != entities.EventoPendente {
+		t.Errorf("unexpected status %s e %s", outbox.Eventos[0].Status, outbox.Eventos[2].Status)
+	}
+	if transacao.Confirmadas != 2 {
+		t.Errorf("expected one transaction to reserve the batch and one to record the results, got %d", transacao.Confirmadas)
+	}
+}
+
+// publisherForaDaTransacao falha o teste se a publicação acontecer com a transação aberta
+type publisherForaDaTransacao struct {
+	t         *testing.T
+	transacao *MockTransacao
+	outbox    *MockOutboxRepository
+	Eventos   []string
+}
+
+func (p *publisherForaDaTransacao) Publish(eventType string, payload interface{}) error {
+	if p.transacao.Aberta {
+		p.t.Errorf("expected %s to be published outside the transaction", eventType)
+	}
+	// Enquanto publica, o evento reservado não aparece para outra instância do relay
+	if pendentes, _ := p.outbox.ListarPendentes(context.Background(), time.Now(), 10); len(pendentes) != 0 {
+		p.t.Errorf("expected the reserved events to be hidden from other relays, got %d", len(pendentes))
+	}
+	p.Eventos = append(p.Eventos, eventType)
+	return nil
+}
+
+func TestOutboxRelayUseCase_Run_PublicaForaDaTransacao(t *testing.T) {
+	outbox := &MockOutboxRepository{}
+	outbox.RegistrarEvento(context.Background(), novoEventoOutbox(t, entities.FilaPedidos, "pedido_criado", time.Now()))
+	transacao := &MockTransacao{}
+	pedidos := &publisherForaDaTransacao{t: t, transacao: transacao, outbox: outbox}
+	useCase := NewOutboxRelayUseCase(transacao, outbox, map[string]publisher.EventPublisher{entities.FilaPedidos: pedidos}, entities.PoliticaRetentativaPadrao, 10)
+
+	publicados, err := useCase.Run(context.Background())
+
+	if err != nil || publicados != 1 || len(pedidos.Eventos) != 1 {
+		t.Fatalf("expected 1 evento publicado, got %d %v (%v)", publicados, pedidos.Eventos, err)
+	}
+	if outbox.Eventos[0].Status != entities.EventoPublicado {
+		t.Errorf("expected evento publicado, got %s", outbox.Eventos[0].Status)
+	}
+}
+
+func TestOutboxRelayUseCase_Run_FalhaAgendaNovaTentativa(t *testing.T) {
+	agora := time.Now()
+	outbox := &MockOutboxRepository{}
+	outbox.RegistrarEvento(context.Background(), novoEventoOutbox(t, entities.FilaProdutos, "produto_criado", agora))
+	outbox.RegistrarEvento(context.Background(), novoEventoOutbox(t, entities.FilaPedidos, "pedido_criado", agora))
+
+	politica := entities.PoliticaRetentativa{MaxTentativas: 2, Espera: time.Minute, EsperaMaxima: time.Hour}
+	produtos := &MockEventPublisherOutbox{Err: errors.New("fila indisponível")}
+	useCase := NewOutboxRelayUseCase(&MockTransacao{}, outbox, map[string]publisher.EventPublisher{
+		entities.FilaProdutos: produtos,
+	}, politica, 10)
+
+	publicados, err := useCase.Run(context.Background())
+
+	if err != nil {
+		t.Fatalf("a falha de publicação não deveria interromper o lote: %v", err)
+	}
+	if publicados != 0 {
+		t.Errorf("expected nenhum evento publicado, got %d", publicados)
+	}
+	for _, e := range outbox.Eventos {
+		if e.Status != entities.EventoPendente || e.Tentativas != 1 || !e.ProximaTentativa.After(agora) || e.UltimoErro == "" {
+			t.Errorf("expected nova tentativa agendada, got %+v", e)
+		}
+	}
+
+	// Na próxima rodada nada está vencido; depois da espera o evento esgota as tentativas
+	for _, e := range outbox.Eventos {
+		e.ProximaTentativa = agora
+	}
+	if _, err := useCase.Run(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if outbox.Eventos[0].Status != entities.EventoFalhou {
+		t.Errorf("expected evento falhou, got %s", outbox.Eventos[0].Status)
+	}
+}
+
+func TestOutboxRelayUseCase_Run_ErroNoRepositorio(t *testing.T) {
+	transacao := &MockTransacao{}
+	useCase := NewOutboxRelayUseCase(transacao, &MockOutboxRepository{Err: errors.New("db fora")}, nil, entities.PoliticaRetentativaPadrao, 10)
+
+	if _, err := useCase.Run(context.Background()); err == nil {
+		t.Error("expected error")
+	}
+	if transacao.Desfeitas != 1 {
+		t.Errorf("expected transaction rollback, got %d", transacao.Desfeitas)
+	}
+}
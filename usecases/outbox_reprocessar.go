@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+type OutboxReprocessarUseCase interface {
+	Run(ctx context.Context, id int64) (*entities.EventoOutbox, error)
+}
+
+type outboxReprocessarUseCase struct {
+	outboxRepository repository.OutboxRepository
+}
+
+func NewOutboxReprocessarUseCase(outboxRepository repository.OutboxRepository) OutboxReprocessarUseCase {
+	return &outboxReprocessarUseCase{outboxRepository: outboxRepository}
+}
+
+// Run devolve um evento travado ao relay, que volta a tentar publicá-lo na próxima rodada
+func (oruc *outboxReprocessarUseCase) Run(c context.Context, id int64) (*entities.EventoOutbox, error) {
+	evento, err := oruc.outboxRepository.BuscarEvento(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := evento.Reprocessar(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := oruc.outboxRepository.AtualizarEvento(c, evento); err != nil {
+		return nil, err
+	}
+	return evento, nil
+}
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestOutboxReprocessarUseCase_Run(t *testing.T) {
+	agora := time.Now()
+	falhou := novoEventoOutbox(t, entities.FilaPedidos, "pedido_criado", agora.Add(-time.Hour))
+	falhou.ID = 1
+	falhou.Status = entities.EventoFalhou
+	falhou.Tentativas = 10
+	publicado := novoEventoOutbox(t, entities.FilaPedidos, "pedido_cancelado", agora)
+	publicado.ID = 2
+	publicado.MarcarPublicado(agora)
+
+	useCase := NewOutboxReprocessarUseCase(&MockOutboxRepository{Eventos: []*entities.EventoOutbox{falhou, publicado}})
+
+	evento, err := useCase.Run(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if evento.Status != entities.EventoPendente || evento.Tentativas != 0 || evento.ProximaTentativa.After(time.Now()) {
+		t.Errorf("expected evento pronto para o relay, got %+v", evento)
+	}
+
+	if _, err := useCase.Run(context.Background(), 2); !errors.Is(err, entities.ErrEventoJaPublicado) {
+		t.Errorf("expected ErrEventoJaPublicado, got %v", err)
+	}
+	if _, err := useCase.Run(context.Background(), 99); err == nil || err.Error() != "evento não encontrado" {
+		t.Errorf("expected 'evento não encontrado', got %v", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
-	"lanchonete/internal/interfaces/publisher"
 )
 
 type PedidoAtualizarStatusUseCase interface {
@@ -12,14 +11,18 @@ type PedidoAtualizarStatusUseCase interface {
 }
 
 type pedidoAtualizarStatusUseCase struct {
-	pedidoGateway  repository.PedidoRepository
-	eventPublisher publisher.EventPublisher
+	pedidoGateway    repository.PedidoRepository
+	historicoGateway repository.HistoricoPedidoRepository
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
 }
 
-func NewPedidoAtualizarStatusUseCase(pedidoGateway repository.PedidoRepository, publisher publisher.EventPublisher) PedidoAtualizarStatusUseCase {
+func NewPedidoAtualizarStatusUseCase(pedidoGateway repository.PedidoRepository, historicoGateway repository.HistoricoPedidoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) PedidoAtualizarStatusUseCase {
 	return &pedidoAtualizarStatusUseCase{
-		pedidoGateway:  pedidoGateway,
-		eventPublisher: publisher,
+		pedidoGateway:    pedidoGateway,
+		historicoGateway: historicoGateway,
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
 	}
 }
 
@@ -30,23 +33,46 @@ func (pduc *pedidoAtualizarStatusUseCase) Run(c context.Context, pedidoID int, s
 		return err
 	}
 
-	err = pedido.UpdateStatus(entities.StatusPedido(status))
-	if err != nil {
-		return err
+	novoStatus := entities.StatusPedido(status)
+
+	// Cancelar exige motivo e responsável e devolve o estoque; isso é feito pelo cancelamento do pedido
+	if novoStatus == entities.Cancelado {
+		return entities.ErrCancelamentoViaStatus
 	}
 
-	err = pduc.pedidoGateway.AtualizarStatusPedido(c, pedidoID, status, pedido.UltimaAtualizacao)
+	// Status de cozinha só podem ser atingidos com o pagamento confirmado
+	if novoStatus.ExigePagamento() && pedido.StatusPagamento != entities.PagamentoPago {
+		return entities.ErrPagamentoNaoConfirmado
+	}
 
+	anteriores := statusDe(pedido)
+	err = pedido.UpdateStatus(novoStatus)
 	if err != nil {
 		return err
 	}
 
-	// ✨ Publicar evento no SQS
-	payload := map[string]interface{}{
-		"id_pedido":     pedidoID,
-		"status":        status,
-		"atualizado_em": pedido.UltimaAtualizacao,
-	}
+	return pduc.transacao.Executar(c, func(c context.Context) error {
+		err := pduc.pedidoGateway.AtualizarStatusPedido(c, pedidoID, string(anteriores.status), status, pedido.UltimaAtualizacao)
+		if err != nil {
+			return err
+		}
+
+		if err := registrarMudancas(c, pduc.historicoGateway, anteriores, pedido); err != nil {
+			return err
+		}
+
+		// A mudança de status pode alterar a fila da cozinha e, com ela, a previsão dos demais pedidos
+		if err := recalcularFilaCozinha(c, pduc.pedidoGateway); err != nil {
+			return err
+		}
 
-	return pduc.eventPublisher.Publish("pedido_status_atualizado", payload)
+		// ✨ Evento de status, publicado pelo relay da outbox
+		payload := map[string]interface{}{
+			"id_pedido":       pedidoID,
+			"codigo_retirada": pedido.CodigoRetirada,
+			"status":          status,
+			"atualizado_em":   pedido.UltimaAtualizacao,
+		}
+		return registrarEvento(c, pduc.outboxRepository, entities.FilaPedidos, "pedido_status_atualizado", payload)
+	})
 }
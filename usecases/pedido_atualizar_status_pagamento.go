@@ -2,7 +2,11 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
+	"time"
 )
 
 type PedidoAtualizarStatusPagamentoUseCase interface {
@@ -10,12 +14,18 @@ type PedidoAtualizarStatusPagamentoUseCase interface {
 }
 
 type pedidoAtualizarStatusPagamentoUseCase struct {
-	pedidoGateway repository.PedidoRepository
+	pedidoGateway    repository.PedidoRepository
+	historicoGateway repository.HistoricoPedidoRepository
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
 }
 
-func NewPedidoAtualizarStatusPagamentoUseCase(pedidoGateway repository.PedidoRepository) PedidoAtualizarStatusPagamentoUseCase {
+func NewPedidoAtualizarStatusPagamentoUseCase(pedidoGateway repository.PedidoRepository, historicoGateway repository.HistoricoPedidoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) PedidoAtualizarStatusPagamentoUseCase {
 	return &pedidoAtualizarStatusPagamentoUseCase{
-		pedidoGateway: pedidoGateway,
+		pedidoGateway:    pedidoGateway,
+		historicoGateway: historicoGateway,
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
 	}
 }
 
@@ -27,16 +37,119 @@ func (pduc *pedidoAtualizarStatusPagamentoUseCase) Run(c context.Context, pedido
 	}
 
 	// Validar o status de pagamento usando o método da entidade
-	err = pedido.UpdateStatusPagamento(statusPagamento)
+	novoStatusPagamento := entities.StatusPagamento(statusPagamento)
+	anteriores := statusDe(pedido)
+	err = pedido.UpdateStatusPagamento(novoStatusPagamento)
+	if errors.Is(err, entities.ErrPagamentoPedidoCancelado) {
+		// O pedido foi cancelado antes de o pagamento ser confirmado: o valor recebido precisa voltar ao cliente
+		if errEstorno := pduc.solicitarEstorno(c, pedido); errEstorno != nil {
+			return errEstorno
+		}
+	}
 	if err != nil {
 		return err
 	}
 
-	// Atualizar no banco de dados
-	err = pduc.pedidoGateway.AtualizarStatusPagamento(c, pedidoID, statusPagamento, pedido.UltimaAtualizacao)
+	// Aplicar ao pedido o efeito do novo status de pagamento antes de persistir qualquer mudança
+	novoStatusPedido, err := statusPedidoAposPagamento(pedido, novoStatusPagamento)
 	if err != nil {
 		return err
 	}
 
+	// O pagamento e o status do pedido são gravados juntos: se o pedido mudou de status nesse meio
+	// tempo, o novo status de pagamento também não é gravado
+	return pduc.transacao.Executar(c, func(c context.Context) error {
+		err := pduc.pedidoGateway.AtualizarStatusPagamento(c, pedidoID, statusPagamento, pedido.UltimaAtualizacao)
+		if err != nil {
+			return err
+		}
+
+		switch novoStatusPedido {
+		case "":
+		case entities.Cancelado:
+			// O cancelamento devolve o estoque e sai da fila da cozinha
+			if err := cancelarPedido(c, pduc.pedidoGateway, pedido); err != nil {
+				return err
+			}
+		default:
+			err := pduc.pedidoGateway.AtualizarStatusPedido(c, pedidoID, string(anteriores.status), string(novoStatusPedido), pedido.UltimaAtualizacao)
+			if err != nil {
+				return err
+			}
+			// Pedido pago entra na fila da cozinha
+			if err := recalcularFilaCozinha(c, pduc.pedidoGateway); err != nil {
+				return err
+			}
+		}
+
+		if err := registrarMudancas(c, pduc.historicoGateway, anteriores, pedido); err != nil {
+			return err
+		}
+
+		if novoStatusPedido == "" {
+			return nil
+		}
+		if novoStatusPedido == entities.Cancelado {
+			if err := avisarCancelamento(c, pduc.outboxRepository, pedido); err != nil {
+				return err
+			}
+		}
+
+		// ✨ Evento de status, publicado pelo relay da outbox
+		payload := map[string]interface{}{
+			"id_pedido":        pedidoID,
+			"codigo_retirada":  pedido.CodigoRetirada,
+			"status":           novoStatusPedido,
+			"status_pagamento": novoStatusPagamento,
+			"atualizado_em":    pedido.UltimaAtualizacao,
+		}
+		return registrarEvento(c, pduc.outboxRepository, entities.FilaPedidos, "pedido_status_atualizado", payload)
+	})
+}
+
+// solicitarEstorno grava na outbox o pedido de devolução, ao cliente, de um pagamento confirmado para
+// um pedido já cancelado; o relay avisa o serviço de pagamento
+func (pduc *pedidoAtualizarStatusPagamentoUseCase) solicitarEstorno(c context.Context, pedido *entities.Pedido) error {
+	payload := map[string]interface{}{
+		"id_pedido":       pedido.ID,
+		"codigo_retirada": pedido.CodigoRetirada,
+		"valor_reembolso": pedido.Total,
+		"motivo":          "pagamento confirmado após o cancelamento do pedido",
+	}
+
+	err := pduc.transacao.Executar(c, func(c context.Context) error {
+		return registrarEvento(c, pduc.outboxRepository, entities.FilaPedidos, "pagamento_estorno_solicitado", payload)
+	})
+	if err != nil {
+		return fmt.Errorf("não foi possível solicitar o estorno do pedido %d: %w", pedido.ID, err)
+	}
 	return nil
 }
+
+// statusPedidoAposPagamento aplica a política entre pagamento e pedido: o pagamento confirmado
+// promove um pedido pendente para Recebido e o pagamento recusado ou cancelado cancela o pedido,
+// registrando o serviço de pagamento como responsável pelo cancelamento.
+// Retorna o novo status do pedido, ou vazio quando o pedido não muda.
+func statusPedidoAposPagamento(pedido *entities.Pedido, statusPagamento entities.StatusPagamento) (entities.StatusPedido, error) {
+	var novoStatus entities.StatusPedido
+	switch {
+	case statusPagamento == entities.PagamentoPago && pedido.Status == entities.Pendente:
+		novoStatus = entities.Recebido
+	case statusPagamento.CancelaPedido() && pedido.Status != entities.Cancelado:
+		motivo := "pagamento recusado"
+		if statusPagamento == entities.PagamentoCancelado {
+			motivo = "pagamento cancelado"
+		}
+		if err := pedido.Cancelar(entities.CanceladoPeloPagamento, motivo, time.Now()); err != nil {
+			return "", err
+		}
+		return entities.Cancelado, nil
+	default:
+		return "", nil
+	}
+
+	if err := pedido.UpdateStatus(novoStatus); err != nil {
+		return "", err
+	}
+	return novoStatus, nil
+}
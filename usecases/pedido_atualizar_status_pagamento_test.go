@@ -5,112 +5,71 @@ import (
 	"errors"
 	"lanchonete/internal/domain/entities"
 	"testing"
-	"time"
 )
 
-// MockPedidoRepositoryAtualizarPagamento implements repository.PedidoRepository for testing
-type MockPedidoRepositoryAtualizarPagamento struct {
-	Pedidos []*entities.Pedido
-}
-
-func (m *MockPedidoRepositoryAtualizarPagamento) CriarPedido(ctx context.Context, pedido *entities.Pedido) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryAtualizarPagamento) BuscarPedido(ctx context.Context, id int) (*entities.Pedido, error) {
-	for _, p := range m.Pedidos {
-		if p.ID == id {
-			return p, nil
-		}
-	}
-	return nil, errors.New("pedido não encontrado")
-}
-
-func (m *MockPedidoRepositoryAtualizarPagamento) AtualizarStatusPedido(ctx context.Context, pedidoID int, status string, ultimaAtualizacao time.Time) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryAtualizarPagamento) ListarTodosOsPedidos(ctx context.Context) ([]*entities.Pedido, error) {
-	return nil, nil
-}
-
-func (m *MockPedidoRepositoryAtualizarPagamento) AtualizarStatusPagamento(ctx context.Context, pedidoID int, statusPagamento string, ultimaAtualizacao time.Time) error {
-	for _, p := range m.Pedidos {
-		if p.ID == pedidoID {
-			p.StatusPagamento = statusPagamento
-			p.UltimaAtualizacao = ultimaAtualizacao
-			return nil
-		}
-	}
-	return errors.New("pedido não encontrado")
-}
-
 func TestPedidoAtualizarStatusPagamentoUseCase_Run_Success(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarPagamento{}
-	useCase := NewPedidoAtualizarStatusPagamentoUseCase(mockRepo)
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:              1,
+	pedido := banco.pedido(entities.Pedido{
 		ClienteNome:     "João Silva",
-		StatusPagamento: "Pendente",
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+		Status:          entities.Pendente,
+		StatusPagamento: entities.PagamentoPendente,
+	})
 
 	// Test
-	err := useCase.Run(context.Background(), 1, "Pago")
+	err := useCase.Run(context.Background(), pedido.ID, "Pago")
 
 	// Assertions
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if mockRepo.Pedidos[0].StatusPagamento != "Pago" {
-		t.Errorf("expected StatusPagamento 'Pago', got %s", mockRepo.Pedidos[0].StatusPagamento)
+	if banco.buscarPedido(pedido.ID).StatusPagamento != entities.PagamentoPago {
+		t.Errorf("expected StatusPagamento 'Pago', got %s", banco.buscarPedido(pedido.ID).StatusPagamento)
 	}
 }
 
 func TestPedidoAtualizarStatusPagamentoUseCase_Run_AllValidStatuses(t *testing.T) {
-	validStatuses := []string{"Pendente", "Pago", "Recusado", "Cancelado"}
+	validStatuses := []string{"Pago", "Recusado", "Cancelado"}
 
 	for _, status := range validStatuses {
-		mockRepo := &MockPedidoRepositoryAtualizarPagamento{}
-		useCase := NewPedidoAtualizarStatusPagamentoUseCase(mockRepo)
+		banco := novoBancoTeste(t)
+		useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 		// Setup pedido no repositório
-		pedido := &entities.Pedido{
-			ID:              1,
+		pedido := banco.pedido(entities.Pedido{
 			ClienteNome:     "João Silva",
-			StatusPagamento: "Pendente",
-		}
-		mockRepo.Pedidos = []*entities.Pedido{pedido}
+			Status:          entities.Pendente,
+			StatusPagamento: entities.PagamentoPendente,
+		})
 
 		// Test
-		err := useCase.Run(context.Background(), 1, status)
+		err := useCase.Run(context.Background(), pedido.ID, status)
 
 		// Assertions
 		if err != nil {
 			t.Errorf("expected no error for status '%s', got %v", status, err)
 		}
-		if mockRepo.Pedidos[0].StatusPagamento != status {
-			t.Errorf("expected StatusPagamento '%s', got %s", status, mockRepo.Pedidos[0].StatusPagamento)
+		if string(banco.buscarPedido(pedido.ID).StatusPagamento) != status {
+			t.Errorf("expected StatusPagamento '%s', got %s", status, banco.buscarPedido(pedido.ID).StatusPagamento)
 		}
 	}
 }
 
 func TestPedidoAtualizarStatusPagamentoUseCase_Run_InvalidStatus(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarPagamento{}
-	useCase := NewPedidoAtualizarStatusPagamentoUseCase(mockRepo)
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:              1,
+	pedido := banco.pedido(entities.Pedido{
 		ClienteNome:     "João Silva",
-		StatusPagamento: "Pendente",
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+		Status:          entities.Pendente,
+		StatusPagamento: entities.PagamentoPendente,
+	})
 
 	// Test com status inválido
-	err := useCase.Run(context.Background(), 1, "StatusInvalido")
+	err := useCase.Run(context.Background(), pedido.ID, "StatusInvalido")
 
 	// Assertions
 	if err == nil {
@@ -119,8 +78,8 @@ func TestPedidoAtualizarStatusPagamentoUseCase_Run_InvalidStatus(t *testing.T) {
 }
 
 func TestPedidoAtualizarStatusPagamentoUseCase_Run_PedidoNotFound(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarPagamento{}
-	useCase := NewPedidoAtualizarStatusPagamentoUseCase(mockRepo)
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	// Test sem pedidos no repositório
 	err := useCase.Run(context.Background(), 999, "Pago")
@@ -131,34 +90,197 @@ func TestPedidoAtualizarStatusPagamentoUseCase_Run_PedidoNotFound(t *testing.T)
 	}
 }
 
-func TestPedidoAtualizarStatusPagamentoUseCase_Run_PaymentFlow(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarPagamento{}
-	useCase := NewPedidoAtualizarStatusPagamentoUseCase(mockRepo)
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_PagoPromovePedido(t *testing.T) {
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:              1,
+	pedido := banco.pedido(entities.Pedido{
 		ClienteNome:     "João Silva",
-		StatusPagamento: "Pendente",
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+		Status:          entities.Pendente,
+		StatusPagamento: entities.PagamentoPendente,
+	})
 
-	// Test typical payment flow: Pendente -> Pago
-	err := useCase.Run(context.Background(), 1, "Pago")
+	// Test: pagamento confirmado promove o pedido para Recebido
+	err := useCase.Run(context.Background(), pedido.ID, "Pago")
 	if err != nil {
 		t.Fatalf("expected no error for 'Pago', got %v", err)
 	}
-	if mockRepo.Pedidos[0].StatusPagamento != "Pago" {
-		t.Errorf("expected StatusPagamento 'Pago', got %s", mockRepo.Pedidos[0].StatusPagamento)
+	if banco.buscarPedido(pedido.ID).Status != entities.Recebido {
+		t.Errorf("expected Status 'Recebido', got %s", banco.buscarPedido(pedido.ID).Status)
 	}
+	if len(mockOutbox.Tipos()) != 1 || mockOutbox.Tipos()[0] != "pedido_status_atualizado" {
+		t.Errorf("expected 'pedido_status_atualizado' event, got %v", mockOutbox.Tipos())
+	}
+}
 
-	// Test refusal flow: reset to Pendente -> Recusado
-	mockRepo.Pedidos[0].StatusPagamento = "Pendente"
-	err = useCase.Run(context.Background(), 1, "Recusado")
-	if err != nil {
-		t.Fatalf("expected no error for 'Recusado', got %v", err)
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_RecusadoCancelaPedido(t *testing.T) {
+	for _, status := range []string{"Recusado", "Cancelado"} {
+		banco := novoBancoTeste(t)
+		mockOutbox := &MockOutboxRepository{}
+		useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+		// Setup pedido no repositório
+		pedido := banco.pedido(entities.Pedido{
+			ClienteNome:     "João Silva",
+			Status:          entities.Pendente,
+			StatusPagamento: entities.PagamentoPendente,
+		})
+
+		// Test: pagamento recusado ou cancelado cancela o pedido
+		err := useCase.Run(context.Background(), pedido.ID, status)
+		if err != nil {
+			t.Fatalf("expected no error for '%s', got %v", status, err)
+		}
+		if banco.buscarPedido(pedido.ID).Status != entities.Cancelado {
+			t.Errorf("expected Status 'Cancelado' after '%s', got %s", status, banco.buscarPedido(pedido.ID).Status)
+		}
+		cancelamento := banco.buscarPedido(pedido.ID).Cancelamento
+		if cancelamento == nil || cancelamento.Ator != entities.CanceladoPeloPagamento || cancelamento.ReembolsoNecessario {
+			t.Errorf("expected cancellation by the payment service without refund, got %+v", cancelamento)
+		}
+		if len(mockOutbox.Tipos()) != 2 || mockOutbox.Tipos()[0] != "pedido_cancelado" {
+			t.Errorf("expected 'pedido_cancelado' and 'pedido_status_atualizado' events, got %v", mockOutbox.Tipos())
+		}
+	}
+}
+
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_CancelamentoComPedidoPronto(t *testing.T) {
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+	// Setup pedido pago e já pronto no repositório
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome:     "João Silva",
+		Status:          entities.Pronto,
+		StatusPagamento: entities.PagamentoPago,
+	})
+
+	// Test: pedido pronto não pode mais ser cancelado pelo pagamento
+	err := useCase.Run(context.Background(), pedido.ID, "Cancelado")
+
+	var transicaoErr *entities.TransicaoStatusError
+	if !errors.As(err, &transicaoErr) {
+		t.Fatalf("expected TransicaoStatusError, got %v", err)
+	}
+	if len(mockOutbox.Tipos()) != 0 {
+		t.Errorf("expected no events, got %v", mockOutbox.Tipos())
 	}
-	if mockRepo.Pedidos[0].StatusPagamento != "Recusado" {
-		t.Errorf("expected StatusPagamento 'Recusado', got %s", mockRepo.Pedidos[0].StatusPagamento)
+}
+
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_TransicaoInvalida(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	// Setup pedido com pagamento recusado no repositório
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome:     "João Silva",
+		Status:          entities.Cancelado,
+		StatusPagamento: entities.PagamentoRecusado,
+	})
+
+	// Test: pagamento recusado não pode ser confirmado depois
+	err := useCase.Run(context.Background(), pedido.ID, "Pago")
+
+	var transicaoErr *entities.TransicaoPagamentoError
+	if !errors.As(err, &transicaoErr) {
+		t.Fatalf("expected TransicaoPagamentoError, got %v", err)
+	}
+}
+
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_RegistraHistorico(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.Pendente, StatusPagamento: entities.PagamentoPendente})
+	banco.pedido(entities.Pedido{Status: entities.Pendente, StatusPagamento: entities.PagamentoPendente})
+	mockHistorico := &MockHistoricoPedidoRepository{}
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, mockHistorico, &MockTransacao{}, &MockOutboxRepository{})
+	ctx := ComOrigem(context.Background(), entities.OrigemConsumidorSQS)
+
+	if err := useCase.Run(ctx, 1, "Pago"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := useCase.Run(ctx, 2, "Recusado"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	esperadas := []struct {
+		pedido   int
+		campo    entities.CampoAlteracao
+		de, para string
+	}{
+		{1, entities.AlteracaoPagamento, "Pendente", "Pago"},
+		{1, entities.AlteracaoStatus, "Pendente", "Recebido"},
+		{2, entities.AlteracaoPagamento, "Pendente", "Recusado"},
+		{2, entities.AlteracaoStatus, "Pendente", "Cancelado"},
+	}
+	if len(mockHistorico.Alteracoes) != len(esperadas) {
+		t.Fatalf("expected %d alterações, got %+v", len(esperadas), mockHistorico.Alteracoes)
+	}
+	for i, e := range esperadas {
+		a := mockHistorico.Alteracoes[i]
+		if a.PedidoID != e.pedido || a.Campo != e.campo || a.De != e.de || a.Para != e.para || a.Origem != entities.OrigemConsumidorSQS {
+			t.Errorf("alteração %d: expected %+v, got %+v", i, e, a)
+		}
+	}
+}
+
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_PagoAposCancelamento(t *testing.T) {
+	banco := novoBancoTeste(t)
+	pedido, _ := novoPedidoParaCancelar(banco, entities.Pendente, entities.PagamentoPendente)
+	cancelar := NewPedidoCancelarUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+	if _, err := cancelar.Run(context.Background(), pedido.ID, "Cliente", "desisti"); err != nil {
+		t.Fatalf("expected no error cancelling the order, got %v", err)
+	}
+	mockOutbox := &MockOutboxRepository{}
+	transacao := &MockTransacao{}
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, transacao, mockOutbox)
+
+	// Test: o pagamento confirmado depois do cancelamento é recusado e o estorno é solicitado
+	err := useCase.Run(context.Background(), pedido.ID, "Pago")
+
+	if !errors.Is(err, entities.ErrPagamentoPedidoCancelado) {
+		t.Fatalf("expected ErrPagamentoPedidoCancelado, got %v", err)
+	}
+	if status := banco.buscarPedido(pedido.ID).StatusPagamento; status != entities.PagamentoCancelado {
+		t.Errorf("expected payment status to stay Cancelado, got %s", status)
+	}
+	if transacao.Confirmadas != 1 {
+		t.Errorf("expected only the refund request to be written, got %d transactions", transacao.Confirmadas)
+	}
+	if len(mockOutbox.Tipos()) != 1 || mockOutbox.Tipos()[0] != "pagamento_estorno_solicitado" {
+		t.Errorf("expected pagamento_estorno_solicitado, got %v", mockOutbox.Tipos())
+	}
+}
+
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_GravaNaMesmaTransacao(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.Pendente, StatusPagamento: entities.PagamentoPendente})
+	transacao := &MockTransacao{}
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, transacao, &MockOutboxRepository{})
+
+	if err := useCase.Run(context.Background(), 1, "Pago"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if transacao.Confirmadas != 1 {
+		t.Errorf("expected payment and order status in one transaction, got %d", transacao.Confirmadas)
+	}
+}
+
+func TestPedidoAtualizarStatusPagamentoUseCase_Run_FalhaAoSolicitarEstorno(t *testing.T) {
+	banco := novoBancoTeste(t)
+	pedido, _ := novoPedidoParaCancelar(banco, entities.Pendente, entities.PagamentoPendente)
+	cancelar := NewPedidoCancelarUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+	if _, err := cancelar.Run(context.Background(), pedido.ID, "Cliente", "desisti"); err != nil {
+		t.Fatalf("expected no error cancelling the order, got %v", err)
+	}
+	useCase := NewPedidoAtualizarStatusPagamentoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{Err: errors.New("db offline")})
+
+	// A falha ao gravar o pedido de estorno é devolvida no lugar da recusa do pagamento
+	err := useCase.Run(context.Background(), pedido.ID, "Pago")
+
+	if err == nil || errors.Is(err, entities.ErrPagamentoPedidoCancelado) {
+		t.Fatalf("expected the refund request error, got %v", err)
 	}
 }
@@ -8,62 +8,17 @@ import (
 	"time"
 )
 
-// MockPedidoRepositoryAtualizarStatus implements repository.PedidoRepository for testing
-type MockPedidoRepositoryAtualizarStatus struct {
-	Pedidos []*entities.Pedido
-}
-
-type MockEventPublisherAtualizar struct{}
-
-func (m *MockEventPublisherAtualizar) Publish(eventType string, payload interface{}) error {
-	// apenas retorna nil, simula sucesso
-	return nil
-}
-
-func (m *MockPedidoRepositoryAtualizarStatus) CriarPedido(ctx context.Context, pedido *entities.Pedido) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryAtualizarStatus) BuscarPedido(ctx context.Context, id int) (*entities.Pedido, error) {
-	for _, p := range m.Pedidos {
-		if p.ID == id {
-			return p, nil
-		}
-	}
-	return nil, errors.New("pedido não encontrado")
-}
-
-func (m *MockPedidoRepositoryAtualizarStatus) AtualizarStatusPedido(ctx context.Context, pedidoID int, status string, ultimaAtualizacao time.Time) error {
-	for _, p := range m.Pedidos {
-		if p.ID == pedidoID {
-			p.Status = entities.StatusPedido(status)
-			p.UltimaAtualizacao = ultimaAtualizacao
-			return nil
-		}
-	}
-	return errors.New("pedido não encontrado")
-}
-
-func (m *MockPedidoRepositoryAtualizarStatus) ListarTodosOsPedidos(ctx context.Context) ([]*entities.Pedido, error) {
-	return nil, nil
-}
-
-func (m *MockPedidoRepositoryAtualizarStatus) AtualizarStatusPagamento(ctx context.Context, pedidoID int, statusPagamento string, ultimaAtualizacao time.Time) error {
-	return nil
-}
-
 func TestPedidoAtualizarStatusUseCase_Run_Success(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarStatus{}
-	mockPublisher := &MockEventPublisherAtualizar{}
-	useCase := NewPedidoAtualizarStatusUseCase(mockRepo, mockPublisher)
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:          1,
-		ClienteNome: "João Silva",
-		Status:      entities.Pendente,
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome:     "João Silva",
+		Status:          entities.Pendente,
+		StatusPagamento: entities.PagamentoPago,
+	})
 
 	// Test
 	err := useCase.Run(context.Background(), 1, "Recebido")
@@ -72,26 +27,31 @@ func TestPedidoAtualizarStatusUseCase_Run_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if mockRepo.Pedidos[0].Status != entities.Recebido {
-		t.Errorf("expected status 'Recebido', got %s", mockRepo.Pedidos[0].Status)
+	if banco.buscarPedido(pedido.ID).Status != entities.Recebido {
+		t.Errorf("expected status 'Recebido', got %s", banco.buscarPedido(pedido.ID).Status)
 	}
 }
 
 func TestPedidoAtualizarStatusUseCase_Run_AllValidStatuses(t *testing.T) {
-	validStatuses := []string{"Pendente", "Recebido", "Em preparação", "Pronto", "Finalizado"}
+	// Cada status é testado a partir do status imediatamente anterior
+	anteriores := map[string]entities.StatusPedido{
+		"Recebido":      entities.Pendente,
+		"Em preparação": entities.Recebido,
+		"Pronto":        entities.EmPreparacao,
+		"Finalizado":    entities.Pronto,
+	}
 
-	for _, status := range validStatuses {
-		mockRepo := &MockPedidoRepositoryAtualizarStatus{}
-		mockPublisher := &MockEventPublisherAtualizar{}
-		useCase := NewPedidoAtualizarStatusUseCase(mockRepo, mockPublisher)
+	for status, anterior := range anteriores {
+		banco := novoBancoTeste(t)
+		mockOutbox := &MockOutboxRepository{}
+		useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
 
 		// Setup pedido no repositório
-		pedido := &entities.Pedido{
-			ID:          1,
-			ClienteNome: "João Silva",
-			Status:      entities.Pendente,
-		}
-		mockRepo.Pedidos = []*entities.Pedido{pedido}
+		pedido := banco.pedido(entities.Pedido{
+			ClienteNome:     "João Silva",
+			Status:          anterior,
+			StatusPagamento: entities.PagamentoPago,
+		})
 
 		// Test
 		err := useCase.Run(context.Background(), 1, status)
@@ -100,27 +60,25 @@ func TestPedidoAtualizarStatusUseCase_Run_AllValidStatuses(t *testing.T) {
 		if err != nil {
 			t.Errorf("expected no error for status '%s', got %v", status, err)
 		}
-		if string(mockRepo.Pedidos[0].Status) != status {
-			t.Errorf("expected status '%s', got %s", status, mockRepo.Pedidos[0].Status)
+		if string(banco.buscarPedido(pedido.ID).Status) != status {
+			t.Errorf("expected status '%s', got %s", status, banco.buscarPedido(pedido.ID).Status)
 		}
 	}
 }
 
 func TestPedidoAtualizarStatusUseCase_Run_InvalidStatus(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarStatus{}
-	mockPublisher := &MockEventPublisherAtualizar{}
-	useCase := NewPedidoAtualizarStatusUseCase(mockRepo, mockPublisher)
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:          1,
+	pedido := banco.pedido(entities.Pedido{
 		ClienteNome: "João Silva",
 		Status:      entities.Pendente,
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+	})
 
 	// Test com status inválido
-	err := useCase.Run(context.Background(), 1, "StatusInvalido")
+	err := useCase.Run(context.Background(), pedido.ID, "StatusInvalido")
 
 	// Assertions
 	if err == nil {
@@ -129,9 +87,9 @@ func TestPedidoAtualizarStatusUseCase_Run_InvalidStatus(t *testing.T) {
 }
 
 func TestPedidoAtualizarStatusUseCase_Run_PedidoNotFound(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarStatus{}
-	mockPublisher := &MockEventPublisherAtualizar{}
-	useCase := NewPedidoAtualizarStatusUseCase(mockRepo, mockPublisher)
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
 
 	// Test sem pedidos no repositório
 	err := useCase.Run(context.Background(), 999, "Recebido")
@@ -143,17 +101,16 @@ func TestPedidoAtualizarStatusUseCase_Run_PedidoNotFound(t *testing.T) {
 }
 
 func TestPedidoAtualizarStatusUseCase_Run_StatusProgression(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryAtualizarStatus{}
-	mockPublisher := &MockEventPublisherAtualizar{}
-	useCase := NewPedidoAtualizarStatusUseCase(mockRepo, mockPublisher)
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:          1,
-		ClienteNome: "João Silva",
-		Status:      entities.Pendente,
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome:     "João Silva",
+		Status:          entities.Pendente,
+		StatusPagamento: entities.PagamentoPago,
+	})
 
 	// Test progression: Pendente -> Recebido -> Em preparação -> Pronto -> Finalizado
 	statusProgression := []string{"Recebido", "Em preparação", "Pronto", "Finalizado"}
@@ -163,8 +120,162 @@ func TestPedidoAtualizarStatusUseCase_Run_StatusProgression(t *testing.T) {
 		if err != nil {
 			t.Fatalf("expected no error for status '%s', got %v", status, err)
 		}
-		if string(mockRepo.Pedidos[0].Status) != status {
-			t.Errorf("expected status '%s', got %s", status, mockRepo.Pedidos[0].Status)
+		if string(banco.buscarPedido(pedido.ID).Status) != status {
+			t.Errorf("expected status '%s', got %s", status, banco.buscarPedido(pedido.ID).Status)
 		}
 	}
 }
+
+func TestPedidoAtualizarStatusUseCase_Run_TransicaoInvalida(t *testing.T) {
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+	// Setup pedido já finalizado no repositório
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome: "João Silva",
+		Status:      entities.Finalizado,
+	})
+
+	// Test: pedido finalizado não pode voltar para Pendente
+	err := useCase.Run(context.Background(), 1, "Pendente")
+
+	// Assertions
+	var transicaoErr *entities.TransicaoStatusError
+	if !errors.As(err, &transicaoErr) {
+		t.Fatalf("expected TransicaoStatusError, got %v", err)
+	}
+	if banco.buscarPedido(pedido.ID).Status != entities.Finalizado {
+		t.Errorf("expected status to remain 'Finalizado', got %s", banco.buscarPedido(pedido.ID).Status)
+	}
+}
+
+func TestPedidoAtualizarStatusUseCase_Run_PagamentoNaoConfirmado(t *testing.T) {
+	for _, status := range []string{"Recebido", "Em preparação", "Pronto", "Finalizado"} {
+		banco := novoBancoTeste(t)
+		mockOutbox := &MockOutboxRepository{}
+		useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+		// Setup pedido com pagamento recusado no repositório
+		pedido := banco.pedido(entities.Pedido{
+			ClienteNome:     "João Silva",
+			Status:          entities.Pendente,
+			StatusPagamento: entities.PagamentoRecusado,
+		})
+
+		// Test
+		err := useCase.Run(context.Background(), 1, status)
+
+		// Assertions
+		if !errors.Is(err, entities.ErrPagamentoNaoConfirmado) {
+			t.Errorf("expected ErrPagamentoNaoConfirmado for status '%s', got %v", status, err)
+		}
+		if banco.buscarPedido(pedido.ID).Status != entities.Pendente {
+			t.Errorf("expected status to remain 'Pendente', got %s", banco.buscarPedido(pedido.ID).Status)
+		}
+	}
+}
+
+func TestPedidoAtualizarStatusUseCase_Run_CancelarExigeCancelamento(t *testing.T) {
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+	// Setup pedido com pagamento pendente no repositório
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome:     "João Silva",
+		Status:          entities.Pendente,
+		StatusPagamento: entities.PagamentoPendente,
+	})
+
+	// Test: cancelar exige motivo e responsável, o que a troca de status não informa
+	err := useCase.Run(context.Background(), 1, "Cancelado")
+
+	// Assertions
+	if !errors.Is(err, entities.ErrCancelamentoViaStatus) {
+		t.Fatalf("expected ErrCancelamentoViaStatus, got %v", err)
+	}
+	if banco.buscarPedido(pedido.ID).Status != entities.Pendente {
+		t.Errorf("expected status 'Pendente', got %s", banco.buscarPedido(pedido.ID).Status)
+	}
+}
+
+func TestPedidoAtualizarStatusUseCase_Run_RecalculaFila(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	emPreparo := time.Now().Add(5 * time.Minute)
+	primeiro := banco.pedido(entities.Pedido{Status: entities.EmPreparacao, StatusPagamento: entities.PagamentoPago, TempoEstimado: 10 * time.Minute, PrevisaoPronto: &emPreparo})
+	segundo := banco.pedido(entities.Pedido{Status: entities.Recebido, StatusPagamento: entities.PagamentoPago, TempoEstimado: 6 * time.Minute})
+
+	antes := time.Now()
+	if err := useCase.Run(context.Background(), primeiro.ID, "Pronto"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Com o primeiro pedido pronto, o segundo passa a ser o próximo da cozinha
+	previsao := banco.buscarPedido(segundo.ID).PrevisaoPronto
+	if previsao == nil || previsao.Sub(antes) > 7*time.Minute {
+		t.Errorf("expected second order ready in about 6 minutes, got %v", previsao)
+	}
+}
+
+func TestPedidoAtualizarStatusUseCase_Run_RegistraHistorico(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.Recebido, StatusPagamento: entities.PagamentoPago})
+	mockHistorico := &MockHistoricoPedidoRepository{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, mockHistorico, &MockTransacao{}, &MockOutboxRepository{})
+
+	err := useCase.Run(ComOrigem(context.Background(), entities.OrigemHTTP), 1, "Em preparação")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(mockHistorico.Alteracoes) != 1 {
+		t.Fatalf("expected 1 alteração, got %d", len(mockHistorico.Alteracoes))
+	}
+	alteracao := mockHistorico.Alteracoes[0]
+	if alteracao.Campo != entities.AlteracaoStatus || alteracao.De != "Recebido" || alteracao.Para != "Em preparação" || alteracao.Origem != entities.OrigemHTTP {
+		t.Errorf("unexpected alteração %+v", alteracao)
+	}
+
+	// Transição recusada não entra no histórico
+	_ = useCase.Run(context.Background(), 1, "Finalizado")
+	if len(mockHistorico.Alteracoes) != 1 {
+		t.Errorf("expected no new alteração, got %d", len(mockHistorico.Alteracoes))
+	}
+}
+
+func TestPedidoAtualizarStatusUseCase_Run_FalhaAoRecalcularFila(t *testing.T) {
+	banco := novoBancoTeste(t)
+	pedido := banco.pedido(entities.Pedido{Status: entities.Recebido, StatusPagamento: entities.PagamentoPago})
+	pedidos := previsoesComFalha{PedidoRepository: banco.Pedidos, err: errors.New("conexão recusada")}
+	useCase := NewPedidoAtualizarStatusUseCase(pedidos, banco.Historico, banco.Transacao, &MockOutboxRepository{})
+
+	// A fila recalculada é gravada junto com o status: se ela falha, a mudança toda é desfeita
+	err := useCase.Run(context.Background(), pedido.ID, string(entities.EmPreparacao))
+
+	if err == nil {
+		t.Fatal("expected an error when the kitchen queue cannot be recalculated")
+	}
+	if status := banco.buscarPedido(pedido.ID).Status; status != entities.Recebido {
+		t.Errorf("expected the status change to be rolled back, got %s", status)
+	}
+}
+
+func TestPedidoAtualizarStatusUseCase_Run_FalhaNaOutbox(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.Recebido, StatusPagamento: entities.PagamentoPago})
+	transacao := &MockTransacao{}
+	useCase := NewPedidoAtualizarStatusUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, transacao, &MockOutboxRepository{Err: errors.New("db offline")})
+
+	// O evento é gravado junto com o status: sem o evento, a mudança não é confirmada
+	err := useCase.Run(context.Background(), 1, string(entities.EmPreparacao))
+
+	if err == nil {
+		t.Fatal("expected an error when the event cannot be recorded")
+	}
+	if transacao.Desfeitas != 1 || transacao.Confirmadas != 0 {
+		t.Errorf("expected the status change to be rolled back, got %d commits / %d rollbacks", transacao.Confirmadas, transacao.Desfeitas)
+	}
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+type PedidoBuscarPorCodigoUseCase interface {
+	Run(ctx context.Context, codigo string) (*entities.Pedido, error)
+}
+
+type pedidoBuscarPorCodigoUseCase struct {
+	pedidoRepository    repository.PedidoRepository
+	historicoRepository repository.HistoricoPedidoRepository
+}
+
+func NewPedidoBuscarPorCodigoUseCase(pedidoRepository repository.PedidoRepository, historicoRepository repository.HistoricoPedidoRepository) PedidoBuscarPorCodigoUseCase {
+	return &pedidoBuscarPorCodigoUseCase{
+		pedidoRepository:    pedidoRepository,
+		historicoRepository: historicoRepository,
+	}
+}
+
+// Run busca o pedido de hoje com o código de retirada; códigos de outros dias já foram reaproveitados
+func (pcuc *pedidoBuscarPorCodigoUseCase) Run(c context.Context, codigo string) (*entities.Pedido, error) {
+	codigo, err := entities.NormalizarCodigoRetirada(codigo)
+	if err != nil {
+		return nil, err
+	}
+
+	pedido, err := pcuc.pedidoRepository.BuscarPedidoPorCodigo(c, codigo, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	detalharPedido(c, pcuc.historicoRepository, pedido)
+	return pedido, nil
+}
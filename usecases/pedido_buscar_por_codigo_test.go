@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestPedidoBuscarPorCodigoUseCase_Run_Success(t *testing.T) {
+	// O repositório numera os pedidos do dia: A-001, A-002...
+	banco := novoBancoTeste(t)
+	agora := time.Now()
+	banco.pedido(entities.Pedido{Status: entities.Pendente, UltimaAtualizacao: agora})
+	pedido := banco.pedido(entities.Pedido{Status: entities.Recebido, UltimaAtualizacao: agora})
+	mockHistorico := &MockHistoricoPedidoRepository{Alteracoes: []entities.AlteracaoPedido{
+		{PedidoID: pedido.ID, Campo: entities.AlteracaoStatus, Para: "Recebido", AlteradoEm: agora.Add(-time.Minute)},
+	}}
+	useCase := NewPedidoBuscarPorCodigoUseCase(banco.Pedidos, mockHistorico)
+
+	// O código digitado no balcão é normalizado antes da busca
+	result, err := useCase.Run(context.Background(), "a2")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.ID != pedido.ID || result.CodigoRetirada != "A-002" {
+		t.Errorf("expected pedido %d with code A-002, got %d %s", pedido.ID, result.ID, result.CodigoRetirada)
+	}
+	if len(result.Etapas) != 1 {
+		t.Errorf("expected 1 etapa, got %+v", result.Etapas)
+	}
+}
+
+func TestPedidoBuscarPorCodigoUseCase_Run_Errors(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.Pendente, UltimaAtualizacao: time.Now()})
+	useCase := NewPedidoBuscarPorCodigoUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{})
+
+	_, err := useCase.Run(context.Background(), "17")
+	if !errors.Is(err, entities.ErrCodigoRetiradaInvalido) {
+		t.Errorf("expected ErrCodigoRetiradaInvalido, got %v", err)
+	}
+
+	_, err = useCase.Run(context.Background(), "B-001")
+	if !errors.Is(err, entities.ErrPedidoNaoEncontrado) {
+		t.Errorf("expected ErrPedidoNaoEncontrado, got %v", err)
+	}
+}
@@ -2,8 +2,10 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
+	"time"
 )
 
 type PedidoBuscarPorIdUseCase interface {
@@ -11,12 +13,14 @@ type PedidoBuscarPorIdUseCase interface {
 }
 
 type pedidoBuscarPorIdUseCase struct {
-	pedidoRepository repository.PedidoRepository
+	pedidoRepository    repository.PedidoRepository
+	historicoRepository repository.HistoricoPedidoRepository
 }
 
-func NewPedidoBuscarPorIdUseCase(pedidoRepository repository.PedidoRepository) PedidoBuscarPorIdUseCase {
+func NewPedidoBuscarPorIdUseCase(pedidoRepository repository.PedidoRepository, historicoRepository repository.HistoricoPedidoRepository) PedidoBuscarPorIdUseCase {
 	return &pedidoBuscarPorIdUseCase{
-		pedidoRepository: pedidoRepository,
+		pedidoRepository:    pedidoRepository,
+		historicoRepository: historicoRepository,
 	}
 }
 
@@ -26,5 +30,19 @@ func (pduc *pedidoBuscarPorIdUseCase) Run(c context.Context, pedidoID int) (*ent
 	if err != nil {
 		return nil, err
 	}
+	detalharPedido(c, pduc.historicoRepository, pedido)
 	return pedido, nil
 }
+
+// detalharPedido completa o pedido buscado com os avisos de alérgenos e o tempo em cada etapa
+func detalharPedido(c context.Context, historicoRepository repository.HistoricoPedidoRepository, pedido *entities.Pedido) {
+	pedido.AvisosAlergenos = entities.AvisosAlergenos(pedido.Itens)
+
+	// O detalhe do pedido continua disponível mesmo sem o histórico, apenas sem as etapas
+	historico, err := historicoRepository.ListarHistorico(c, pedido.ID)
+	if err != nil {
+		fmt.Printf("⚠️ Falha ao carregar o histórico do pedido %d: %v\n", pedido.ID, err)
+		return
+	}
+	pedido.Etapas = entities.DuracoesEtapas(historico, time.Now())
+}
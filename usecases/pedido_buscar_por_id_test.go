@@ -8,53 +8,22 @@ import (
 	"time"
 )
 
-// MockPedidoRepositoryBuscar implements repository.PedidoRepository for testing
-type MockPedidoRepositoryBuscar struct {
-	Pedidos []*entities.Pedido
-}
-
-func (m *MockPedidoRepositoryBuscar) CriarPedido(ctx context.Context, pedido *entities.Pedido) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryBuscar) BuscarPedido(ctx context.Context, id int) (*entities.Pedido, error) {
-	for _, p := range m.Pedidos {
-		if p.ID == id {
-			return p, nil
-		}
-	}
-	return nil, errors.New("pedido não encontrado")
-}
-
-func (m *MockPedidoRepositoryBuscar) AtualizarStatusPedido(ctx context.Context, pedidoID int, status string, ultimaAtualizacao time.Time) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryBuscar) ListarTodosOsPedidos(ctx context.Context) ([]*entities.Pedido, error) {
-	return nil, nil
-}
-
-func (m *MockPedidoRepositoryBuscar) AtualizarStatusPagamento(ctx context.Context, pedidoID int, statusPagamento string, ultimaAtualizacao time.Time) error {
-	return nil
-}
-
 func TestPedidoBuscarPorIdUseCase_Run_Success(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryBuscar{}
-	useCase := NewPedidoBuscarPorIdUseCase(mockRepo)
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoBuscarPorIdUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{})
 
 	// Setup pedido no repositório
-	pedido := &entities.Pedido{
-		ID:          1,
+	hamburguer := banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(25.0)})
+	pedido := banco.pedido(entities.Pedido{
 		ClienteNome: "João Silva",
 		Status:      entities.Pendente,
-		Produtos: []entities.Produto{
-			{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Preco: 25.0},
+		Itens: []entities.ItemPedido{
+			{Produto: *hamburguer, Quantidade: 1},
 		},
-	}
-	mockRepo.Pedidos = []*entities.Pedido{pedido}
+	})
 
 	// Test
-	result, err := useCase.Run(context.Background(), 1)
+	result, err := useCase.Run(context.Background(), pedido.ID)
 
 	// Assertions
 	if err != nil {
@@ -63,8 +32,8 @@ func TestPedidoBuscarPorIdUseCase_Run_Success(t *testing.T) {
 	if result == nil {
 		t.Fatal("expected pedido, got nil")
 	}
-	if result.ID != 1 {
-		t.Errorf("expected ID 1, got %d", result.ID)
+	if result.ID != pedido.ID {
+		t.Errorf("expected ID %d, got %d", pedido.ID, result.ID)
 	}
 	if result.ClienteNome != "João Silva" {
 		t.Errorf("expected ClienteNome 'João Silva', got %s", result.ClienteNome)
@@ -72,8 +41,7 @@ func TestPedidoBuscarPorIdUseCase_Run_Success(t *testing.T) {
 }
 
 func TestPedidoBuscarPorIdUseCase_Run_NotFound(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryBuscar{}
-	useCase := NewPedidoBuscarPorIdUseCase(mockRepo)
+	useCase := NewPedidoBuscarPorIdUseCase(novoBancoTeste(t).Pedidos, &MockHistoricoPedidoRepository{})
 
 	// Test
 	result, err := useCase.Run(context.Background(), 999)
@@ -85,14 +53,13 @@ func TestPedidoBuscarPorIdUseCase_Run_NotFound(t *testing.T) {
 	if result != nil {
 		t.Errorf("expected nil result, got %+v", result)
 	}
-	if err.Error() != "pedido não encontrado" {
-		t.Errorf("expected 'pedido não encontrado', got %s", err.Error())
+	if !errors.Is(err, entities.ErrPedidoNaoEncontrado) {
+		t.Errorf("expected ErrPedidoNaoEncontrado, got %v", err)
 	}
 }
 
 func TestPedidoBuscarPorIdUseCase_Run_InvalidID(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryBuscar{}
-	useCase := NewPedidoBuscarPorIdUseCase(mockRepo)
+	useCase := NewPedidoBuscarPorIdUseCase(novoBancoTeste(t).Pedidos, &MockHistoricoPedidoRepository{})
 
 	// Test with invalid ID (0 or negative)
 	testCases := []int{0, -1, -999}
@@ -108,3 +75,59 @@ func TestPedidoBuscarPorIdUseCase_Run_InvalidID(t *testing.T) {
 		}
 	}
 }
+
+func TestPedidoBuscarPorIdUseCase_Run_AvisosAlergenos(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewPedidoBuscarPorIdUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{})
+
+	// Os alérgenos vêm do cadastro atual do produto
+	comGluten := entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoLactose}}
+	xBurguer := banco.produto(entities.Produto{Nome: "X-Burguer", Categoria: entities.Lanche, Preco: entities.Reais(20), InformacoesDieteticas: comGluten})
+	coca := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6)})
+	pedido := banco.pedido(entities.Pedido{
+		ClienteNome: "Maria",
+		Status:      entities.Pendente,
+		Itens: []entities.ItemPedido{
+			{Produto: *xBurguer, Quantidade: 1},
+			{Produto: *coca, Quantidade: 1},
+		},
+	})
+
+	result, err := useCase.Run(context.Background(), pedido.ID)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.AvisosAlergenos) != 2 {
+		t.Fatalf("expected 2 avisos, got %d", len(result.AvisosAlergenos))
+	}
+	if aviso := result.AvisosAlergenos[0]; aviso.Alergeno != entities.AlergenoGluten || aviso.Mensagem != "Contém glúten: X-Burguer" {
+		t.Errorf("unexpected aviso %+v", aviso)
+	}
+}
+
+func TestPedidoBuscarPorIdUseCase_Run_Etapas(t *testing.T) {
+	criadoEm := time.Now().Add(-10 * time.Minute)
+	banco := novoBancoTeste(t)
+	pedido := banco.pedido(entities.Pedido{Status: entities.Recebido})
+	mockHistorico := &MockHistoricoPedidoRepository{Alteracoes: []entities.AlteracaoPedido{
+		{PedidoID: pedido.ID, Campo: entities.AlteracaoStatus, Para: "Pendente", AlteradoEm: criadoEm},
+		{PedidoID: pedido.ID, Campo: entities.AlteracaoStatus, De: "Pendente", Para: "Recebido", AlteradoEm: criadoEm.Add(4 * time.Minute)},
+	}}
+	useCase := NewPedidoBuscarPorIdUseCase(banco.Pedidos, mockHistorico)
+
+	result, err := useCase.Run(context.Background(), pedido.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Etapas) != 2 || result.Etapas[0].Duracao != "00:04:00" || result.Etapas[1].Fim != nil {
+		t.Errorf("unexpected etapas %+v", result.Etapas)
+	}
+
+	// Sem histórico, o pedido ainda é retornado
+	mockHistorico.Err = errors.New("db offline")
+	result, err = useCase.Run(context.Background(), pedido.ID)
+	if err != nil || result == nil {
+		t.Fatalf("expected pedido without etapas, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+type PedidoCancelarUseCase interface {
+	Run(ctx context.Context, pedidoID int, ator, motivo string) (*entities.Pedido, error)
+}
+
+type pedidoCancelarUseCase struct {
+	pedidoGateway    repository.PedidoRepository
+	historicoGateway repository.HistoricoPedidoRepository
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
+}
+
+func NewPedidoCancelarUseCase(pedidoGateway repository.PedidoRepository, historicoGateway repository.HistoricoPedidoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) PedidoCancelarUseCase {
+	return &pedidoCancelarUseCase{
+		pedidoGateway:    pedidoGateway,
+		historicoGateway: historicoGateway,
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
+	}
+}
+
+// Run cancela o pedido em nome do ator, devolve o estoque reservado e avisa o serviço de pagamento pela outbox
+func (pcuc *pedidoCancelarUseCase) Run(c context.Context, pedidoID int, ator, motivo string) (*entities.Pedido, error) {
+	pedido, err := pcuc.pedidoGateway.BuscarPedido(c, pedidoID)
+	if err != nil {
+		return nil, err
+	}
+
+	anteriores := statusDe(pedido)
+	if err := pedido.Cancelar(entities.AtorCancelamento(ator), motivo, time.Now()); err != nil {
+		return nil, err
+	}
+
+	err = pcuc.transacao.Executar(c, func(c context.Context) error {
+		if err := cancelarPedido(c, pcuc.pedidoGateway, pedido); err != nil {
+			return err
+		}
+		if err := registrarMudancas(c, pcuc.historicoGateway, anteriores, pedido); err != nil {
+			return err
+		}
+		return avisarCancelamento(c, pcuc.outboxRepository, pedido)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pedido, nil
+}
+
+// cancelarPedido persiste um pedido já cancelado na entidade, devolvendo o estoque reservado, e
+// tira o pedido da fila da cozinha. Deve ser chamado dentro de Transacao.Executar, para que a fila
+// recalculada seja gravada junto com o cancelamento.
+func cancelarPedido(c context.Context, pedidoGateway repository.PedidoRepository, pedido *entities.Pedido) error {
+	if err := pedidoGateway.CancelarPedido(c, pedido); err != nil {
+		return fmt.Errorf("não foi possível cancelar o pedido: %w", err)
+	}
+	return recalcularFilaCozinha(c, pedidoGateway)
+}
+
+// avisarCancelamento grava pedido_cancelado na outbox, na transação do cancelamento
+func avisarCancelamento(c context.Context, outboxRepository repository.OutboxRepository, pedido *entities.Pedido) error {
+	// ✨ Evento de cancelamento; o serviço de pagamento estorna quando há reembolso a fazer
+	payload := map[string]interface{}{
+		"id_pedido":            pedido.ID,
+		"codigo_retirada":      pedido.CodigoRetirada,
+		"ator":                 pedido.Cancelamento.Ator,
+		"motivo":               pedido.Cancelamento.Motivo,
+		"cancelado_em":         pedido.Cancelamento.CanceladoEm,
+		"reembolso_necessario": pedido.Cancelamento.ReembolsoNecessario,
+		"valor_reembolso":      valorReembolso(pedido),
+		"status_pagamento":     pedido.StatusPagamento,
+	}
+
+	return registrarEvento(c, outboxRepository, entities.FilaPedidos, "pedido_cancelado", payload)
+}
+
+func valorReembolso(pedido *entities.Pedido) entities.Money {
+	if !pedido.Cancelamento.ReembolsoNecessario {
+		return entities.Centavos(0)
+	}
+	return pedido.Total
+}
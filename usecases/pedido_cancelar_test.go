@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+)
+
+// novoPedidoParaCancelar grava um pedido com um lanche sem controle de estoque e uma bebida com 3
+// unidades, das quais o pedido reserva 1
+func novoPedidoParaCancelar(banco *bancoTeste, status entities.StatusPedido, statusPagamento entities.StatusPagamento) (*entities.Pedido, *entities.Produto) {
+	estoque := 3
+	xSalada := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(26.5)})
+	coca := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6), Estoque: &estoque})
+	pedido := banco.pedido(entities.Pedido{
+		Status:          status,
+		StatusPagamento: statusPagamento,
+		Total:           entities.Reais(32.5),
+		Itens: []entities.ItemPedido{
+			{Produto: *xSalada, Quantidade: 2},
+			{Produto: *coca, Quantidade: 1},
+		},
+	})
+	return pedido, coca
+}
+
+func TestPedidoCancelarUseCase_Run_PedidoPago(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	pedidoGravado, coca := novoPedidoParaCancelar(banco, entities.Recebido, entities.PagamentoPago)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoCancelarUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+	// When
+	pedido, err := useCase.Run(context.Background(), pedidoGravado.ID, "Loja", "faltou pão")
+
+	// Then
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pedido.Status != entities.Cancelado {
+		t.Errorf("expected status 'Cancelado', got %s", pedido.Status)
+	}
+	if estoque := banco.estoqueDe(coca); estoque != 3 {
+		t.Errorf("expected the reserved unit to be restored, got %d in stock", estoque)
+	}
+	if len(mockOutbox.Tipos()) != 1 || mockOutbox.Tipos()[0] != "pedido_cancelado" {
+		t.Fatalf("expected 'pedido_cancelado' event, got %v", mockOutbox.Tipos())
+	}
+	payload := mockOutbox.Payload(t, 0)
+	if payload["reembolso_necessario"] != true || payload["valor_reembolso"] != 32.5 {
+		t.Errorf("expected refund of the order total, got %v", payload)
+	}
+}
+
+func TestPedidoCancelarUseCase_Run_PagamentoPendenteSemReembolso(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	pedidoGravado, _ := novoPedidoParaCancelar(banco, entities.Pendente, entities.PagamentoPendente)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoCancelarUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+	// When
+	pedido, err := useCase.Run(context.Background(), pedidoGravado.ID, "Cliente", "desisti")
+
+	// Then
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pedido.StatusPagamento != entities.PagamentoCancelado {
+		t.Errorf("expected payment 'Cancelado', got %s", pedido.StatusPagamento)
+	}
+	if mockOutbox.Payload(t, 0)["reembolso_necessario"] != false {
+		t.Errorf("expected no refund, got %v", mockOutbox.Payload(t, 0))
+	}
+}
+
+func TestPedidoCancelarUseCase_Run_NaoPermitido(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	pedidoGravado, coca := novoPedidoParaCancelar(banco, entities.EmPreparacao, entities.PagamentoPago)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoCancelarUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{}, &MockTransacao{}, mockOutbox)
+
+	// When
+	_, err := useCase.Run(context.Background(), pedidoGravado.ID, "Cliente", "demorou")
+
+	// Then
+	var naoPermitido *entities.CancelamentoNaoPermitidoError
+	if !errors.As(err, &naoPermitido) {
+		t.Fatalf("expected CancelamentoNaoPermitidoError, got %v", err)
+	}
+	if estoque := banco.estoqueDe(coca); estoque != 2 || len(mockOutbox.Tipos()) != 0 {
+		t.Errorf("expected nothing restored or published, got %d in stock / %v", estoque, mockOutbox.Tipos())
+	}
+}
+
+func TestPedidoCancelarUseCase_Run_FalhaNoHistorico(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	pedidoGravado, coca := novoPedidoParaCancelar(banco, entities.Recebido, entities.PagamentoPago)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoCancelarUseCase(banco.Pedidos, &MockHistoricoPedidoRepository{Err: errors.New("db offline")}, banco.Transacao, mockOutbox)
+
+	// When
+	_, err := useCase.Run(context.Background(), pedidoGravado.ID, "Loja", "faltou pão")
+
+	// Then
+	// O histórico é gravado na transação do cancelamento: sem ele, o cancelamento é desfeito e não é avisado
+	if err == nil {
+		t.Fatal("expected the history failure to fail the cancellation")
+	}
+	if len(mockOutbox.Tipos()) != 0 {
+		t.Errorf("expected nothing published, got %v", mockOutbox.Tipos())
+	}
+	if status := banco.buscarPedido(pedidoGravado.ID).Status; status != entities.Recebido || banco.estoqueDe(coca) != 2 {
+		t.Errorf("expected the cancellation to be rolled back, got %s with %d in stock", status, banco.estoqueDe(coca))
+	}
+}
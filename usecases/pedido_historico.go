@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type PedidoHistoricoUseCase interface {
+	Run(ctx context.Context, pedidoID int) ([]entities.AlteracaoPedido, error)
+}
+
+type pedidoHistoricoUseCase struct {
+	pedidoGateway    repository.PedidoRepository
+	historicoGateway repository.HistoricoPedidoRepository
+}
+
+func NewPedidoHistoricoUseCase(pedidoGateway repository.PedidoRepository, historicoGateway repository.HistoricoPedidoRepository) PedidoHistoricoUseCase {
+	return &pedidoHistoricoUseCase{
+		pedidoGateway:    pedidoGateway,
+		historicoGateway: historicoGateway,
+	}
+}
+
+// Run lista as mudanças de status e de pagamento do pedido, da mais antiga para a mais recente
+func (phuc *pedidoHistoricoUseCase) Run(c context.Context, pedidoID int) ([]entities.AlteracaoPedido, error) {
+	if _, err := phuc.pedidoGateway.BuscarPedido(c, pedidoID); err != nil {
+		return nil, err
+	}
+
+	historico, err := phuc.historicoGateway.ListarHistorico(c, pedidoID)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível carregar o histórico do pedido: %w", err)
+	}
+
+	return historico, nil
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+	"time"
+)
+
+// MockHistoricoPedidoRepository implements repository.HistoricoPedidoRepository for testing
+type MockHistoricoPedidoRepository struct {
+	Alteracoes []entities.AlteracaoPedido
+	Err        error
+}
+
+func (m *MockHistoricoPedidoRepository) RegistrarAlteracao(ctx context.Context, alteracao *entities.AlteracaoPedido) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	alteracao.ID = len(m.Alteracoes) + 1
+	m.Alteracoes = append(m.Alteracoes, *alteracao)
+	return nil
+}
+
+func (m *MockHistoricoPedidoRepository) ListarHistorico(ctx context.Context, pedidoID int) ([]entities.AlteracaoPedido, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	historico := []entities.AlteracaoPedido{}
+	for _, a := range m.Alteracoes {
+		if a.PedidoID == pedidoID {
+			historico = append(historico, a)
+		}
+	}
+	return historico, nil
+}
+
+func TestPedidoHistoricoUseCase_Run(t *testing.T) {
+	agora := time.Now()
+	banco := novoBancoTeste(t)
+	pedido := banco.pedido(entities.Pedido{Status: entities.Recebido})
+	mockHistorico := &MockHistoricoPedidoRepository{Alteracoes: []entities.AlteracaoPedido{
+		{PedidoID: pedido.ID, Campo: entities.AlteracaoStatus, Para: "Pendente", Origem: entities.OrigemHTTP, AlteradoEm: agora},
+		{PedidoID: pedido.ID + 1, Campo: entities.AlteracaoStatus, Para: "Pendente", Origem: entities.OrigemHTTP, AlteradoEm: agora},
+		{PedidoID: pedido.ID, Campo: entities.AlteracaoPagamento, De: "Pendente", Para: "Pago", Origem: entities.OrigemConsumidorSQS, AlteradoEm: agora},
+	}}
+	useCase := NewPedidoHistoricoUseCase(banco.Pedidos, mockHistorico)
+
+	historico, err := useCase.Run(context.Background(), pedido.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(historico) != 2 || historico[1].Origem != entities.OrigemConsumidorSQS {
+		t.Errorf("expected the 2 changes of pedido 1, got %+v", historico)
+	}
+
+	if _, err := useCase.Run(context.Background(), 99); err == nil {
+		t.Error("expected error for unknown pedido")
+	}
+
+	mockHistorico.Err = errors.New("db offline")
+	if _, err := useCase.Run(context.Background(), pedido.ID); err == nil {
+		t.Error("expected error when the history cannot be loaded")
+	}
+}
+
+func TestOrigemDe(t *testing.T) {
+	if origem := OrigemDe(context.Background()); origem != entities.OrigemDesconhecida {
+		t.Errorf("expected %s, got %s", entities.OrigemDesconhecida, origem)
+	}
+	ctx := ComOrigem(context.Background(), entities.OrigemAgendador)
+	if origem := OrigemDe(ctx); origem != entities.OrigemAgendador {
+		t.Errorf("expected %s, got %s", entities.OrigemAgendador, origem)
+	}
+}
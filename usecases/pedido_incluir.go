@@ -2,63 +2,188 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
-	"lanchonete/internal/interfaces/publisher"
+	"lanchonete/internal/interfaces/cliente"
+	"time"
 )
 
 type PedidoIncluirUseCase interface {
-	Run(ctx context.Context, clienteNome string, produtos []entities.Produto, personalizacao *string) (*entities.Pedido, error)
+	Run(ctx context.Context, clienteNome string, cliente *entities.ReferenciaCliente, itens []entities.ItemPedido, personalizacao *string, cupom *string) (*entities.Pedido, error)
 }
 
 type pedidoIncluirUseCase struct {
-	pedidoRepository repository.PedidoRepository
-	eventPublisher   publisher.EventPublisher
+	pedidoRepository    repository.PedidoRepository
+	cupomRepository     repository.CupomRepository
+	categoriaRepository repository.CategoriaRepository
+	historicoRepository repository.HistoricoPedidoRepository
+	clienteGateway      cliente.ClienteGateway
+	regras              entities.RegrasPedido
+	transacao           repository.Transacao
+	outboxRepository    repository.OutboxRepository
 }
 
-func NewPedidoIncluirUseCase(pedidoRepository repository.PedidoRepository, publisher publisher.EventPublisher) PedidoIncluirUseCase {
+func NewPedidoIncluirUseCase(pedidoRepository repository.PedidoRepository, cupomRepository repository.CupomRepository, categoriaRepository repository.CategoriaRepository, historicoRepository repository.HistoricoPedidoRepository, clienteGateway cliente.ClienteGateway, regras entities.RegrasPedido, transacao repository.Transacao, outboxRepository repository.OutboxRepository) PedidoIncluirUseCase {
 	return &pedidoIncluirUseCase{
-		pedidoRepository: pedidoRepository,
-		eventPublisher:   publisher,
+		pedidoRepository:    pedidoRepository,
+		cupomRepository:     cupomRepository,
+		categoriaRepository: categoriaRepository,
+		historicoRepository: historicoRepository,
+		clienteGateway:      clienteGateway,
+		regras:              regras,
+		transacao:           transacao,
+		outboxRepository:    outboxRepository,
 	}
 }
 
-func serializeProdutos(produtos []entities.Produto) []map[string]interface{} {
+func serializeItens(itens []entities.ItemPedido) []map[string]interface{} {
 	var lista []map[string]interface{}
-	for _, p := range produtos {
+	for _, item := range itens {
 		lista = append(lista, map[string]interface{}{
-			"id":    p.ID,
-			"nome":  p.Nome,
-			"preco": p.Preco,
+			"id":            item.Produto.ID,
+			"nome":          item.Produto.Nome,
+			"preco":         item.PrecoUnitario,
+			"quantidade":    item.Quantidade,
+			"subtotal":      item.Subtotal,
+			"modificadores": serializeModificadores(item.Modificadores),
+			"componentes":   serializeComponentes(item),
 		})
 	}
 	return lista
 }
 
-func (pduc *pedidoIncluirUseCase) Run(c context.Context, clienteNome string, produtos []entities.Produto, personalizacao *string) (*entities.Pedido, error) {
-	pedido, err := entities.PedidoNew(clienteNome, produtos, personalizacao)
+// serializeComponentes expande um combo nos produtos que a cozinha deve preparar,
+// já multiplicados pela quantidade de combos da linha
+func serializeComponentes(item entities.ItemPedido) []map[string]interface{} {
+	lista := []map[string]interface{}{}
+	for _, c := range item.Componentes {
+		lista = append(lista, map[string]interface{}{
+			"id":         c.Produto.ID,
+			"nome":       c.Produto.Nome,
+			"categoria":  c.Produto.Categoria,
+			"quantidade": c.Quantidade * item.Quantidade,
+		})
+	}
+	return lista
+}
+
+func serializeModificadores(modificadores []entities.Modificador) []map[string]interface{} {
+	lista := []map[string]interface{}{}
+	for _, m := range modificadores {
+		lista = append(lista, map[string]interface{}{
+			"id":    m.ID,
+			"nome":  m.Nome,
+			"preco": m.Preco,
+		})
+	}
+	return lista
+}
+
+func (pduc *pedidoIncluirUseCase) Run(c context.Context, clienteNome string, cliente *entities.ReferenciaCliente, itens []entities.ItemPedido, personalizacao *string, cupom *string) (*entities.Pedido, error) {
+	// O cliente identificado precisa existir no serviço de clientes, que informa o nome cadastrado
+	var referencia *entities.ReferenciaCliente
+	var cadastro *entities.Cliente
+	if cliente != nil {
+		var err error
+		referencia, err = entities.ReferenciaClienteNew(cliente.CPF, cliente.IDExterno)
+		if err != nil {
+			return nil, err
+		}
+		cadastro, err = pduc.clienteGateway.BuscarCliente(c, *referencia)
+		if err != nil {
+			return nil, fmt.Errorf("não foi possível identificar o cliente: %w", err)
+		}
+	}
+
+	// As categorias cadastradas definem quais itens são obrigatórios no pedido e quais saíram do cardápio
+	categorias, err := pduc.categoriaRepository.ListarCategorias(c)
 	if err != nil {
+		return nil, fmt.Errorf("não foi possível carregar as categorias: %w", err)
+	}
+	if err := entities.VerificarCategoriasAtivas(itens, valoresCategorias(categorias)); err != nil {
 		return nil, err
 	}
-	err = pduc.pedidoRepository.CriarPedido(c, pedido)
+
+	pedido, err := entities.PedidoNew(clienteNome, itens, personalizacao)
 	if err != nil {
 		return nil, err
 	}
+	if referencia != nil {
+		pedido.IdentificarCliente(*referencia, *cadastro)
+	}
 
-	// ✨ Publicar evento "pedido_criado"
-	payload := map[string]interface{}{
-		"id_pedido":      pedido.ID,
-		"cliente":        clienteNome,
-		"status":         pedido.Status,
-		"personalizacao": personalizacao,
-		"criado_em":      pedido.UltimaAtualizacao,
-		"produtos":       serializeProdutos(produtos), // transformar []Produto em dados simples
+	if cupom != nil && entities.NormalizarCodigoCupom(*cupom) != "" {
+		cupomAplicado, err := pduc.cupomRepository.BuscarCupomPorCodigo(c, entities.NormalizarCodigoCupom(*cupom))
+		if errors.Is(err, entities.ErrCupomNaoEncontrado) {
+			return nil, fmt.Errorf("%w: %w", entities.ErrCupomInvalido, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("não foi possível buscar o cupom: %w", err)
+		}
+		if err := pedido.AplicarCupom(cupomAplicado, time.Now()); err != nil {
+			return nil, err
+		}
 	}
 
-	err = pduc.eventPublisher.Publish("pedido_criado", payload)
+	// As regras são conferidas depois do cupom porque o valor máximo vale para o total com desconto
+	if err := pduc.regras.Validar(pedido, valoresCategorias(categorias)); err != nil {
+		return nil, err
+	}
+
+	// Previsão provisória: o pedido entra no fim da fila atual da cozinha
+	fila, err := pduc.pedidoRepository.ListarFilaCozinha(c)
 	if err != nil {
-		fmt.Println("⚠️ Falha ao publicar evento de pedido:", err)
+		return nil, fmt.Errorf("não foi possível carregar a fila da cozinha: %w", err)
+	}
+	pedido.PreverPronto(fila, time.Now())
+
+	// O pedido, seu histórico e seus eventos são gravados juntos; o relay publica os eventos depois
+	err = pduc.transacao.Executar(c, func(c context.Context) error {
+		if err := pduc.pedidoRepository.CriarPedido(c, pedido); err != nil {
+			return err
+		}
+
+		// O histórico começa com os status iniciais do pedido
+		if err := registrarMudancas(c, pduc.historicoRepository, statusAnteriores{}, pedido); err != nil {
+			return err
+		}
+
+		// ✨ Evento "pedido_criado"
+		payload := map[string]interface{}{
+			"id_pedido":       pedido.ID,
+			"codigo_retirada": pedido.CodigoRetirada,
+			"cliente":         pedido.ClienteNome,
+			"cliente_ref":     pedido.Cliente.Mascarada(), // o CPF não sai do serviço
+			"status":          pedido.Status,
+			"personalizacao":  personalizacao,
+			"criado_em":       pedido.UltimaAtualizacao,
+			"subtotal":        pedido.Subtotal,
+			"desconto":        pedido.Desconto,
+			"total":           pedido.Total,
+			"cupom":           pedido.Cupom,
+			"tempo_estimado":  entities.FormatarDuracao(pedido.TempoEstimado),
+			"previsao_pronto": pedido.PrevisaoPronto,
+			"produtos":        serializeItens(pedido.Itens), // transformar []ItemPedido em dados simples
+		}
+		if err := registrarEvento(c, pduc.outboxRepository, entities.FilaPedidos, "pedido_criado", payload); err != nil {
+			return err
+		}
+
+		// O repositório devolve nas linhas o estoque restante após a baixa. O aviso vai para a fila de
+		// produtos, a mesma usada pelo ajuste de estoque e de disponibilidade
+		for _, consumo := range entities.ConsumoDeProdutos(pedido.Itens) {
+			if consumo.Produto.ControlaEstoque() && *consumo.Produto.Estoque == 0 {
+				if err := registrarEvento(c, pduc.outboxRepository, entities.FilaProdutos, "produto_indisponivel", eventoProdutoIndisponivel(consumo.Produto)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return pedido, nil
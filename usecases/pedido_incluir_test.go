@@ -4,108 +4,114 @@ import (
 	"context"
 	"errors"
 	"lanchonete/internal/domain/entities"
+	"strings"
 	"testing"
 	"time"
 )
 
-// MockPedidoRepository implements repository.PedidoRepository for testing
-type MockPedidoRepositoryIncluir struct {
-	Pedidos []*entities.Pedido
+// MockCupomRepositoryIncluir implements repository.CupomRepository for testing
+type MockCupomRepositoryIncluir struct {
+	Cupons []*entities.Cupom
+	Err    error
 }
 
-type MockEventPublisherIncluir struct{}
-
-func (m *MockEventPublisherIncluir) Publish(eventType string, payload interface{}) error {
-	// apenas retorna nil, simula sucesso
+func (m *MockCupomRepositoryIncluir) AdicionarCupom(ctx context.Context, cupom *entities.Cupom) error {
 	return nil
 }
 
-func (m *MockPedidoRepositoryIncluir) CriarPedido(ctx context.Context, pedido *entities.Pedido) error {
-	// Simulate duplicate check
-	for _, p := range m.Pedidos {
-		if pedido.ID == 0 {
-			pedido.ID = len(m.Pedidos) + 1
-		}
-
-		if p.ID == pedido.ID {
-			return errors.New("pedido já existe")
+func (m *MockCupomRepositoryIncluir) BuscarCupomPorCodigo(ctx context.Context, codigo string) (*entities.Cupom, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	for _, c := range m.Cupons {
+		if c.Codigo == codigo {
+			return c, nil
 		}
 	}
-	m.Pedidos = append(m.Pedidos, pedido)
+	return nil, entities.ErrCupomNaoEncontrado
+}
+
+func (m *MockCupomRepositoryIncluir) ListarCupons(ctx context.Context) ([]*entities.Cupom, error) {
+	return m.Cupons, nil
+}
+
+func (m *MockCupomRepositoryIncluir) EditarCupom(ctx context.Context, cupom *entities.Cupom) error {
 	return nil
 }
 
-func (m *MockPedidoRepositoryIncluir) BuscarPedido(ctx context.Context, id int) (*entities.Pedido, error) {
-	for _, p := range m.Pedidos {
-		if p.ID == id {
-			return p, nil
-		}
-	}
-	return nil, errors.New("pedido não encontrado")
+func (m *MockCupomRepositoryIncluir) RemoverCupom(ctx context.Context, codigo string) error {
+	return nil
 }
 
-func (m *MockPedidoRepositoryIncluir) AtualizarStatusPedido(ctx context.Context, pedidoID int, status string, ultimaAtualizacao time.Time) error {
-	for _, p := range m.Pedidos {
-		if p.ID == pedidoID {
-			p.Status = entities.StatusPedido(status)
-			p.UltimaAtualizacao = ultimaAtualizacao
-			return nil
-		}
-	}
-	return errors.New("pedido não encontrado")
+// regrasPadrao são as regras aplicadas a todo pedido, sem limites configurados
+var regrasPadrao = entities.RegrasPedidoNew(entities.ConfigRegrasPedido{})
+
+// novoPedidoIncluirUseCase cria o caso de uso sobre o banco em memória, sem cupons, clientes ou regras configuradas
+func novoPedidoIncluirUseCase(banco *bancoTeste) PedidoIncluirUseCase {
+	return NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, &MockTransacao{}, &MockOutboxRepository{})
+}
+
+// novoLancheEBebida grava o X-Salada e a Coca-cola pedidos na maioria dos testes
+func novoLancheEBebida(banco *bancoTeste) (*entities.Produto, *entities.Produto) {
+	xSalada := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	coca := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6.0)})
+	return xSalada, coca
 }
 
-func (m *MockPedidoRepositoryIncluir) ListarTodosOsPedidos(ctx context.Context) ([]*entities.Pedido, error) {
-	return m.Pedidos, nil
+// MockClienteGateway implements cliente.ClienteGateway for testing
+type MockClienteGateway struct {
+	Clientes []entities.Cliente
+	Err      error
 }
 
-func (m *MockPedidoRepositoryIncluir) AtualizarStatusPagamento(ctx context.Context, pedidoID int, statusPagamento string, ultimaAtualizacao time.Time) error {
-	for _, p := range m.Pedidos {
-		if p.ID == pedidoID {
-			p.StatusPagamento = statusPagamento
-			p.UltimaAtualizacao = ultimaAtualizacao
-			return nil
+func (m *MockClienteGateway) BuscarCliente(ctx context.Context, ref entities.ReferenciaCliente) (*entities.Cliente, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	for _, c := range m.Clientes {
+		if (ref.CPF != "" && c.CPF == ref.CPF) || (ref.CPF == "" && c.IDExterno == ref.IDExterno) {
+			cliente := c
+			return &cliente, nil
 		}
 	}
-	return errors.New("pedido não encontrado")
+	return nil, entities.ErrClienteNaoEncontrado
 }
 
 func TestPedidoIncluirUseCase_Run_MultiplePedidos(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryIncluir{}
-	mockPublisher := &MockEventPublisherIncluir{}
-	useCase := NewPedidoIncluirUseCase(mockRepo, mockPublisher)
+	banco := novoBancoTeste(t)
+	useCase := novoPedidoIncluirUseCase(banco)
 
 	// Produtos base
 	produtos := []entities.Produto{
-		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Hamburguer artesanal", Preco: 25.0},
-		{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Batata frita crocante", Preco: 10.0},
-		{Nome: "Refrigerante", Categoria: entities.Bebida, Descricao: "Coca-Cola lata", Preco: 7.5},
+		*banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Hamburguer artesanal", Preco: entities.Reais(25.0)}),
+		*banco.produto(entities.Produto{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Batata frita crocante", Preco: entities.Reais(10.0)}),
+		*banco.produto(entities.Produto{Nome: "Refrigerante", Categoria: entities.Bebida, Descricao: "Coca-Cola lata", Preco: entities.Reais(7.5)}),
 	}
 
 	pedidos := []struct {
 		ClienteNome    string
-		Produtos       []entities.Produto
+		Itens          []entities.ItemPedido
 		Personalizacao *string
 	}{
 		{
 			ClienteNome:    "João",
-			Produtos:       []entities.Produto{produtos[0], produtos[1]},
+			Itens:          []entities.ItemPedido{{Produto: produtos[0], Quantidade: 1}, {Produto: produtos[1], Quantidade: 1}},
 			Personalizacao: nil,
 		},
 		{
 			ClienteNome:    "Maria",
-			Produtos:       []entities.Produto{produtos[0], produtos[2]},
+			Itens:          []entities.ItemPedido{{Produto: produtos[0], Quantidade: 1}, {Produto: produtos[2], Quantidade: 2}},
 			Personalizacao: nil,
 		},
 		{
 			ClienteNome:    "Pedro",
-			Produtos:       []entities.Produto{produtos[0], produtos[1], produtos[2]},
+			Itens:          []entities.ItemPedido{{Produto: produtos[0], Quantidade: 1}, {Produto: produtos[1], Quantidade: 1}, {Produto: produtos[2], Quantidade: 3}},
 			Personalizacao: nil,
 		},
 	}
 
 	for _, p := range pedidos {
-		pedido, err := useCase.Run(context.Background(), p.ClienteNome, p.Produtos, p.Personalizacao)
+		pedido, err := useCase.Run(context.Background(), p.ClienteNome, nil, p.Itens, p.Personalizacao, nil)
 		if err != nil {
 			t.Fatalf("unexpected error for pedido %+v: %v\n", p, err)
 		}
@@ -114,33 +120,33 @@ func TestPedidoIncluirUseCase_Run_MultiplePedidos(t *testing.T) {
 		}
 	}
 
-	if len(mockRepo.Pedidos) != 3 {
-		t.Errorf("expected 3 pedidos in repository, got %d", len(mockRepo.Pedidos))
+	gravados := banco.pedidos()
+	if len(gravados) != 3 {
+		t.Fatalf("expected 3 pedidos in repository, got %d", len(gravados))
 	}
 
 	// Check attributes of each created pedido
-	for i, pedido := range mockRepo.Pedidos {
+	for i, pedido := range gravados {
 		expected := pedidos[i]
 		if pedido.ClienteNome != expected.ClienteNome {
 			t.Errorf("pedido cliente mismatch: got %+v, want %+v", pedido.ClienteNome, expected.ClienteNome)
 		}
-		if len(pedido.Produtos) != len(expected.Produtos) {
-			t.Errorf("pedido produtos count mismatch: got %d, want %d", len(pedido.Produtos), len(expected.Produtos))
+		if len(pedido.Itens) != len(expected.Itens) {
+			t.Errorf("pedido itens count mismatch: got %d, want %d", len(pedido.Itens), len(expected.Itens))
 		}
 	}
 }
 
 func TestPedidoIncluirUseCase_Run_WithPersonalizacao(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryIncluir{}
-	mockPublisher := &MockEventPublisherIncluir{}
-	useCase := NewPedidoIncluirUseCase(mockRepo, mockPublisher)
+	banco := novoBancoTeste(t)
+	useCase := novoPedidoIncluirUseCase(banco)
 
-	produtos := []entities.Produto{
-		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Hamburguer artesanal", Preco: 25.0},
+	itens := []entities.ItemPedido{
+		{Produto: *banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Hamburguer artesanal", Preco: entities.Reais(25.0)}), Quantidade: 1},
 	}
 
 	personalizacao := "Sem cebola e com molho extra"
-	pedido, err := useCase.Run(context.Background(), "João", produtos, &personalizacao)
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, &personalizacao, nil)
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -154,11 +160,9 @@ func TestPedidoIncluirUseCase_Run_WithPersonalizacao(t *testing.T) {
 }
 
 func TestPedidoIncluirUseCase_Run_EmptyProductList(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryIncluir{}
-	mockPublisher := &MockEventPublisherIncluir{}
-	useCase := NewPedidoIncluirUseCase(mockRepo, mockPublisher)
+	useCase := novoPedidoIncluirUseCase(novoBancoTeste(t))
 
-	pedido, err := useCase.Run(context.Background(), "João", []entities.Produto{}, nil)
+	pedido, err := useCase.Run(context.Background(), "João", nil, []entities.ItemPedido{}, nil, nil)
 
 	if err == nil {
 		t.Fatal("expected error for empty product list, got nil")
@@ -167,3 +171,378 @@ func TestPedidoIncluirUseCase_Run_EmptyProductList(t *testing.T) {
 		t.Errorf("expected nil pedido for empty product list, got %+v", pedido)
 	}
 }
+
+func TestPedidoIncluirUseCase_Run_ItemComQuantidade(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := novoPedidoIncluirUseCase(banco)
+	xSalada, coca := novoLancheEBebida(banco)
+
+	itens := []entities.ItemPedido{
+		{Produto: *xSalada, Quantidade: 1},
+		{Produto: *coca, Quantidade: 3},
+	}
+
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pedido.Itens) != 2 {
+		t.Fatalf("expected 2 itens, got %d", len(pedido.Itens))
+	}
+	if pedido.Itens[1].Quantidade != 3 {
+		t.Errorf("expected quantidade 3, got %d", pedido.Itens[1].Quantidade)
+	}
+	if pedido.Total != entities.Reais(40.5) {
+		t.Errorf("expected total 40.50, got %s", pedido.Total)
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_PrevisaoConsideraFila(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.EmPreparacao, TempoEstimado: 10 * time.Minute})
+	banco.pedido(entities.Pedido{Status: entities.Pronto, TempoEstimado: 10 * time.Minute})
+	useCase := novoPedidoIncluirUseCase(banco)
+
+	itens := []entities.ItemPedido{
+		{Produto: *banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5), TempoPreparoMinutos: 8}), Quantidade: 1},
+	}
+
+	antes := time.Now()
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if pedido.TempoEstimado != 8*time.Minute {
+		t.Errorf("Esperado tempo estimado de 8 minutos, recebido %s", pedido.TempoEstimado)
+	}
+	// Apenas o pedido em preparação está à frente: 10 minutos dele mais 8 do novo pedido
+	if pedido.PrevisaoPronto == nil || pedido.PrevisaoPronto.Sub(antes) < 18*time.Minute || pedido.PrevisaoPronto.Sub(antes) > 19*time.Minute {
+		t.Errorf("Esperada previsão em cerca de 18 minutos, recebido %v", pedido.PrevisaoPronto)
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_ComCupom(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.cupom(entities.Cupom{Codigo: "DEZOFF", Tipo: entities.DescontoPercentual, Percentual: 10})
+	useCase := novoPedidoIncluirUseCase(banco)
+	xSalada, coca := novoLancheEBebida(banco)
+
+	itens := []entities.ItemPedido{
+		{Produto: *xSalada, Quantidade: 1},
+		{Produto: *coca, Quantidade: 1},
+	}
+	codigo := " dezoff "
+
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, &codigo)
+
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if pedido.Subtotal != entities.Reais(28.5) || pedido.Desconto != entities.Reais(2.85) || pedido.Total != entities.Reais(25.65) {
+		t.Errorf("Esperado subtotal 28.50, desconto 2.85 e total 25.65, recebido %s, %s e %s", pedido.Subtotal, pedido.Desconto, pedido.Total)
+	}
+	if pedido.Cupom == nil || *pedido.Cupom != "DEZOFF" {
+		t.Errorf("Esperado cupom DEZOFF no pedido, recebido %v", pedido.Cupom)
+	}
+	if cupom, _ := banco.Cupons.BuscarCupomPorCodigo(context.Background(), "DEZOFF"); cupom.Usos != 1 {
+		t.Errorf("Esperado o uso do cupom contado com o pedido, recebido %d", cupom.Usos)
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_CupomRecusado(t *testing.T) {
+	ontem := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		codigo string
+		erro   error
+	}{
+		{"Cupom inexistente", "NAOEXISTE", entities.ErrCupomInvalido},
+		{"Cupom vencido", "VENCIDO", entities.ErrCupomForaDaValidade},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			banco := novoBancoTeste(t)
+			banco.cupom(entities.Cupom{Codigo: "VENCIDO", Tipo: entities.DescontoPercentual, Percentual: 10, ValidoDe: ontem.Add(-time.Hour), ValidoAte: &ontem})
+			useCase := novoPedidoIncluirUseCase(banco)
+			xSalada, _ := novoLancheEBebida(banco)
+			itens := []entities.ItemPedido{{Produto: *xSalada, Quantidade: 1}}
+
+			pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, &tt.codigo)
+
+			if !errors.Is(err, tt.erro) {
+				t.Errorf("Esperado erro %v, recebido %v", tt.erro, err)
+			}
+			if pedido != nil || len(banco.pedidos()) != 0 {
+				t.Error("Pedido com cupom recusado não deveria ser gravado")
+			}
+		})
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_FalhaAoBuscarCupom(t *testing.T) {
+	banco := novoBancoTeste(t)
+	cupons := &MockCupomRepositoryIncluir{Err: errors.New("conexão recusada")}
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, &MockTransacao{}, &MockOutboxRepository{})
+	xSalada, _ := novoLancheEBebida(banco)
+	itens := []entities.ItemPedido{{Produto: *xSalada, Quantidade: 1}}
+	codigo := "DEZOFF"
+
+	// Uma falha do banco não é a recusa do cupom: o cliente recebe um erro do servidor, e não 422
+	_, err := useCase.Run(context.Background(), "João", nil, itens, nil, &codigo)
+
+	if err == nil || entities.CupomRecusado(err) {
+		t.Errorf("Esperado erro de infraestrutura, recebido %v", err)
+	}
+	if len(banco.pedidos()) != 0 {
+		t.Error("Pedido não deveria ser gravado")
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_FalhaAoCarregarFilaCozinha(t *testing.T) {
+	banco := novoBancoTeste(t)
+	pedidos := filaComFalha{PedidoRepository: banco.Pedidos, err: errors.New("conexão recusada")}
+	useCase := NewPedidoIncluirUseCase(pedidos, banco.Cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, banco.Transacao, &MockOutboxRepository{})
+	xSalada, _ := novoLancheEBebida(banco)
+	itens := []entities.ItemPedido{{Produto: *xSalada, Quantidade: 1}}
+
+	// Sem a fila não há previsão de pronto; o pedido não é gravado com uma previsão inventada
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+
+	if err == nil || !strings.Contains(err.Error(), "fila da cozinha") {
+		t.Errorf("Esperado erro ao carregar a fila da cozinha, recebido %v", err)
+	}
+	if pedido != nil || len(banco.pedidos()) != 0 {
+		t.Error("Pedido não deveria ser gravado")
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_CategoriaObrigatoriaConfiguravel(t *testing.T) {
+	// As regras das categorias mudam durante o teste, então o caso de uso as lê do mock
+	banco := novoBancoTeste(t)
+	categorias := novoMockCategorias()
+	categorias.Categorias[0].ObrigatoriaNoPedido = false
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, &MockTransacao{}, &MockOutboxRepository{})
+	_, coca := novoLancheEBebida(banco)
+
+	itens := []entities.ItemPedido{
+		{Produto: *coca, Quantidade: 1},
+	}
+
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+	if err != nil {
+		t.Fatalf("sem categoria obrigatória o pedido só de bebida deveria ser aceito: %v", err)
+	}
+	if pedido == nil {
+		t.Fatal("expected pedido to be created")
+	}
+
+	categorias.Categorias[2].ObrigatoriaNoPedido = true
+	itens[0].Produto.Categoria = entities.Lanche
+	if _, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil); err == nil || !strings.Contains(err.Error(), "categoria Bebida") {
+		t.Errorf("expected error requiring a Bebida, got %v", err)
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_CategoriaInativa(t *testing.T) {
+	banco := novoBancoTeste(t)
+	categorias := novoMockCategorias()
+	categorias.Categorias[2].Ativa = false
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, &MockTransacao{}, &MockOutboxRepository{})
+
+	xSalada, coca := novoLancheEBebida(banco)
+	combo := banco.produto(entities.Produto{Nome: "Combo X-Salada", Categoria: entities.Combo, Preco: entities.Reais(30.0),
+		Componentes: []entities.ComponenteCombo{{Produto: xSalada, Quantidade: 1}, {Produto: coca, Quantidade: 1}}})
+	itens := []entities.ItemPedido{
+		{Produto: *xSalada, Quantidade: 1},
+		{Produto: *combo, Quantidade: 1,
+			Componentes: []entities.ItemCombo{{Produto: *coca, Quantidade: 1}}},
+	}
+
+	// A bebida do combo também saiu do cardápio com a categoria
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+
+	var indisponiveis *entities.ProdutosIndisponiveisError
+	if !errors.As(err, &indisponiveis) {
+		t.Fatalf("Esperado ProdutosIndisponiveisError, recebido %v", err)
+	}
+	if len(indisponiveis.Produtos) != 1 || indisponiveis.Produtos[0] != "Coca-cola" {
+		t.Errorf("Esperado apenas a Coca-cola indisponível, recebido %v", indisponiveis.Produtos)
+	}
+	if pedido != nil || len(banco.pedidos()) != 0 {
+		t.Error("Pedido não deveria ser gravado")
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_IniciaHistorico(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.pedido(entities.Pedido{Status: entities.Pendente})
+	mockHistorico := &MockHistoricoPedidoRepository{}
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, mockHistorico, &MockClienteGateway{}, regrasPadrao, &MockTransacao{}, &MockOutboxRepository{})
+
+	itens := []entities.ItemPedido{
+		{Produto: *banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(25.0)}), Quantidade: 1},
+	}
+	pedido, err := useCase.Run(ComOrigem(context.Background(), entities.OrigemHTTP), "João", nil, itens, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mockHistorico.Alteracoes) != 2 {
+		t.Fatalf("expected status and pagamento to start the history, got %+v", mockHistorico.Alteracoes)
+	}
+	for _, a := range mockHistorico.Alteracoes {
+		if a.PedidoID != pedido.ID || a.De != "" || a.Para != "Pendente" || a.Origem != entities.OrigemHTTP {
+			t.Errorf("unexpected alteração %+v", a)
+		}
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_FalhaNoHistorico(t *testing.T) {
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	mockHistorico := &MockHistoricoPedidoRepository{Err: errors.New("db offline")}
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, mockHistorico, &MockClienteGateway{}, regrasPadrao, banco.Transacao, mockOutbox)
+
+	itens := []entities.ItemPedido{
+		{Produto: *banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(25.0)}), Quantidade: 1},
+	}
+
+	// O histórico é gravado na transação do pedido: sem ele, o pedido e seus eventos são desfeitos
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+
+	if err == nil || !strings.Contains(err.Error(), "histórico do pedido") {
+		t.Fatalf("expected the history failure to fail the order, got %v", err)
+	}
+	if pedido != nil {
+		t.Error("expected no pedido to be returned")
+	}
+	if len(mockOutbox.Tipos()) != 0 {
+		t.Errorf("expected no events before the history is written, got %v", mockOutbox.Tipos())
+	}
+	if len(banco.pedidos()) != 0 {
+		t.Error("expected the order to be rolled back")
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_ClienteIdentificado(t *testing.T) {
+	clientes := &MockClienteGateway{Clientes: []entities.Cliente{
+		{IDExterno: "37", CPF: "52998224725", Nome: "Ana Souza"},
+	}}
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, clientes, regrasPadrao, &MockTransacao{}, mockOutbox)
+	itens := []entities.ItemPedido{
+		{Produto: *banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(25.0)}), Quantidade: 1},
+	}
+
+	pedido, err := useCase.Run(context.Background(), "Ana", &entities.ReferenciaCliente{CPF: "529.982.247-25"}, itens, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pedido.ClienteNome != "Ana Souza" {
+		t.Errorf("expected the registered name, got %s", pedido.ClienteNome)
+	}
+	if pedido.Cliente == nil || pedido.Cliente.CPF != "52998224725" || pedido.Cliente.IDExterno != "37" {
+		t.Errorf("unexpected cliente %+v", pedido.Cliente)
+	}
+	if gravado := banco.buscarPedido(pedido.ID); gravado.Cliente == nil || gravado.Cliente.CPF != "52998224725" {
+		t.Errorf("expected the cliente to be stored with the order, got %+v", gravado.Cliente)
+	}
+
+	// O evento leva o identificador externo e o CPF mascarado, nunca o CPF inteiro
+	payload := string(mockOutbox.Eventos[0].Payload)
+	if strings.Contains(payload, "52998224725") || !strings.Contains(payload, `"cliente_ref":{"cpf":"***.982.247-**","id_externo":"37"}`) {
+		t.Errorf("unexpected cliente_ref in pedido_criado: %s", payload)
+	}
+
+	if _, err := useCase.Run(context.Background(), "Ana", &entities.ReferenciaCliente{CPF: "123.456.789-00"}, itens, nil, nil); !errors.Is(err, entities.ErrCPFInvalido) {
+		t.Errorf("expected ErrCPFInvalido, got %v", err)
+	}
+	if _, err := useCase.Run(context.Background(), "Ana", &entities.ReferenciaCliente{IDExterno: "99"}, itens, nil, nil); !errors.Is(err, entities.ErrClienteNaoEncontrado) {
+		t.Errorf("expected ErrClienteNaoEncontrado, got %v", err)
+	}
+
+	clientes.Err = entities.ErrServicoClientesIndisponivel
+	if _, err := useCase.Run(context.Background(), "Ana", &entities.ReferenciaCliente{IDExterno: "37"}, itens, nil, nil); !errors.Is(err, entities.ErrServicoClientesIndisponivel) {
+		t.Errorf("expected ErrServicoClientesIndisponivel, got %v", err)
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_RegrasConfiguradas(t *testing.T) {
+	banco := novoBancoTeste(t)
+	xSalada, coca := novoLancheEBebida(banco)
+	regras := entities.RegrasPedidoNew(entities.ConfigRegrasPedido{
+		MaximoItens:    3,
+		ValorMaximo:    entities.Reais(50),
+		LimitesProduto: map[int]int{coca.ID: 1},
+	})
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regras, &MockTransacao{}, &MockOutboxRepository{})
+
+	itens := []entities.ItemPedido{
+		{Produto: *xSalada, Quantidade: 2},
+		{Produto: *coca, Quantidade: 2},
+	}
+
+	pedido, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil)
+
+	var violadas *entities.RegrasPedidoError
+	if !errors.As(err, &violadas) {
+		t.Fatalf("expected RegrasPedidoError, got %v", err)
+	}
+	if len(violadas.Violacoes) != 3 {
+		t.Errorf("expected 3 violações, got %+v", violadas.Violacoes)
+	}
+	if pedido != nil || len(banco.pedidos()) != 0 {
+		t.Error("Pedido fora das regras não deveria ser gravado")
+	}
+
+	// Dentro dos limites o pedido é aceito
+	itens[0].Quantidade = 1
+	itens[1].Quantidade = 1
+	if _, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil); err != nil {
+		t.Errorf("expected pedido within the rules to be created, got %v", err)
+	}
+}
+
+func TestPedidoIncluirUseCase_Run_EventosNaOutbox(t *testing.T) {
+	// O pedido leva a última unidade do lanche, que fica indisponível
+	novoPedido := func(banco *bancoTeste) []entities.ItemPedido {
+		estoque := 1
+		xSalada := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5), Estoque: &estoque})
+		return []entities.ItemPedido{{Produto: *xSalada, Quantidade: 1}}
+	}
+
+	banco := novoBancoTeste(t)
+	itens := novoPedido(banco)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, &MockTransacao{}, mockOutbox)
+
+	if _, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tipos := mockOutbox.Tipos()
+	if len(tipos) != 2 || tipos[0] != "pedido_criado" || tipos[1] != "produto_indisponivel" {
+		t.Errorf("expected pedido_criado and produto_indisponivel, got %v", tipos)
+	}
+	filas := map[string]string{"pedido_criado": entities.FilaPedidos, "produto_indisponivel": entities.FilaProdutos}
+	for _, e := range mockOutbox.Eventos {
+		if e.Fila != filas[e.Tipo] || e.Status != entities.EventoPendente {
+			t.Errorf("unexpected evento %+v", e)
+		}
+	}
+
+	// Sem o evento gravado o pedido é desfeito
+	banco = novoBancoTeste(t)
+	itens = novoPedido(banco)
+	useCase = NewPedidoIncluirUseCase(banco.Pedidos, banco.Cupons, banco.Categorias, &MockHistoricoPedidoRepository{}, &MockClienteGateway{}, regrasPadrao, banco.Transacao, &MockOutboxRepository{Err: errors.New("db fora")})
+	if _, err := useCase.Run(context.Background(), "João", nil, itens, nil, nil); err == nil {
+		t.Fatal("expected error when the event cannot be recorded")
+	}
+	if len(banco.pedidos()) != 0 || banco.estoqueDe(&itens[0].Produto) != 1 {
+		t.Error("expected the order and the stock deduction to be rolled back")
+	}
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type PedidoListarPorClienteUseCase interface {
+	Run(ctx context.Context, cliente string, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error)
+}
+
+type pedidoListarPorClienteUseCase struct {
+	pedidoRepo repository.PedidoRepository
+}
+
+func NewPedidoListarPorClienteUseCase(pedidoRepo repository.PedidoRepository) PedidoListarPorClienteUseCase {
+	return &pedidoListarPorClienteUseCase{
+		pedidoRepo: pedidoRepo,
+	}
+}
+
+// Run lista, em páginas e com os demais filtros da consulta, os pedidos do cliente informado pelo CPF,
+// com ou sem pontuação, ou pelo identificador no serviço de clientes
+func (pd *pedidoListarPorClienteUseCase) Run(c context.Context, cliente string, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error) {
+	ref, err := entities.ParseReferenciaCliente(cliente)
+	if err != nil {
+		return nil, err
+	}
+
+	consulta.Cliente = ref
+	if err := consulta.Validar(); err != nil {
+		return nil, err
+	}
+
+	pagina, err := pd.pedidoRepo.ConsultarPedidos(c, consulta)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível listar os pedidos do cliente: %w", err)
+	}
+	for _, pedido := range pagina.Itens {
+		pedido.AvisosAlergenos = entities.AvisosAlergenos(pedido.Itens)
+	}
+	return pagina, nil
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type PedidoListarTodosUseCase interface {
-	Run(ctx context.Context) ([]*entities.Pedido, error)
+	Run(ctx context.Context, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error)
 }
 
 type pedidoListarTodosUseCase struct {
@@ -21,10 +21,17 @@ func NewPedidoListarTodosUseCase(pedidoRepo repository.PedidoRepository) PedidoL
 	}
 }
 
-func (pd *pedidoListarTodosUseCase) Run(c context.Context) ([]*entities.Pedido, error) {
-	pedidos, err := pd.pedidoRepo.ListarTodosOsPedidos(c)
+func (pd *pedidoListarTodosUseCase) Run(c context.Context, consulta entities.ConsultaPedidos) (*entities.Pagina[*entities.Pedido], error) {
+	if err := consulta.Validar(); err != nil {
+		return nil, err
+	}
+
+	pagina, err := pd.pedidoRepo.ConsultarPedidos(c, consulta)
 	if err != nil {
 		return nil, fmt.Errorf("não foi possível listar pedidos: %w", err)
 	}
-	return pedidos, nil
+	for _, pedido := range pagina.Itens {
+		pedido.AvisosAlergenos = entities.AvisosAlergenos(pedido.Itens)
+	}
+	return pagina, nil
 }
@@ -2,106 +2,86 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"lanchonete/infra/database/memoria"
 	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
 	"testing"
-	"time"
 )
 
-// MockPedidoRepositoryListar implements repository.PedidoRepository for testing
-type MockPedidoRepositoryListar struct {
-	Pedidos []*entities.Pedido
-}
-
-func (m *MockPedidoRepositoryListar) CriarPedido(ctx context.Context, pedido *entities.Pedido) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryListar) BuscarPedido(ctx context.Context, id int) (*entities.Pedido, error) {
-	return nil, nil
-}
-
-func (m *MockPedidoRepositoryListar) AtualizarStatusPedido(ctx context.Context, pedidoID int, status string, ultimaAtualizacao time.Time) error {
-	return nil
-}
-
-func (m *MockPedidoRepositoryListar) ListarTodosOsPedidos(ctx context.Context) ([]*entities.Pedido, error) {
-	return m.Pedidos, nil
-}
-
-func (m *MockPedidoRepositoryListar) AtualizarStatusPagamento(ctx context.Context, pedidoID int, statusPagamento string, ultimaAtualizacao time.Time) error {
-	return nil
+// novoPedidoRepositoryListar grava os pedidos no repositório em memória, que filtra, ordena e pagina
+// as listagens como o MySQL. Os IDs são gerados na ordem dos pedidos, a partir de 1.
+func novoPedidoRepositoryListar(t *testing.T, pedidos ...*entities.Pedido) repository.PedidoRepository {
+	repo := memoria.NewPedidoMemoriaRepository(memoria.NewBanco())
+	for _, pedido := range pedidos {
+		if err := repo.CriarPedido(context.Background(), pedido); err != nil {
+			t.Fatalf("erro ao gravar o pedido %d: %v", pedido.ID, err)
+		}
+	}
+	return repo
 }
 
 func TestPedidoListarTodosUseCase_Run_Success(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryListar{}
-	useCase := NewPedidoListarTodosUseCase(mockRepo)
-
 	// Setup pedidos no repositório
-	pedidos := []*entities.Pedido{
-		{ID: 1, ClienteNome: "João", Status: entities.Pendente},
-		{ID: 2, ClienteNome: "Maria", Status: entities.Recebido},
-		{ID: 3, ClienteNome: "Pedro", Status: entities.Pronto},
-	}
-	mockRepo.Pedidos = pedidos
+	useCase := NewPedidoListarTodosUseCase(novoPedidoRepositoryListar(t,
+		&entities.Pedido{ID: 1, ClienteNome: "João", Status: entities.Pendente},
+		&entities.Pedido{ID: 2, ClienteNome: "Maria", Status: entities.Recebido},
+		&entities.Pedido{ID: 3, ClienteNome: "Pedro", Status: entities.Pronto},
+	))
 
 	// Test
-	result, err := useCase.Run(context.Background())
+	result, err := useCase.Run(context.Background(), entities.ConsultaPedidos{})
 
 	// Assertions
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(result) != 3 {
-		t.Errorf("expected 3 pedidos, got %d", len(result))
+	if len(result.Itens) != 3 {
+		t.Errorf("expected 3 pedidos, got %d", len(result.Itens))
 	}
-	if result[0].ClienteNome != "João" {
-		t.Errorf("expected first pedido ClienteNome 'João', got %s", result[0].ClienteNome)
+	if result.Itens[0].ClienteNome != "João" {
+		t.Errorf("expected first pedido ClienteNome 'João', got %s", result.Itens[0].ClienteNome)
 	}
 }
 
 func TestPedidoListarTodosUseCase_Run_Empty(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryListar{}
-	useCase := NewPedidoListarTodosUseCase(mockRepo)
+	useCase := NewPedidoListarTodosUseCase(novoPedidoRepositoryListar(t))
 
 	// Test (repositório vazio)
-	result, err := useCase.Run(context.Background())
+	result, err := useCase.Run(context.Background(), entities.ConsultaPedidos{})
 
 	// Assertions
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(result) != 0 {
-		t.Errorf("expected 0 pedidos, got %d", len(result))
+	if len(result.Itens) != 0 {
+		t.Errorf("expected 0 pedidos, got %d", len(result.Itens))
 	}
 }
 
 func TestPedidoListarTodosUseCase_Run_OrderedByStatus(t *testing.T) {
-	mockRepo := &MockPedidoRepositoryListar{}
-	useCase := NewPedidoListarTodosUseCase(mockRepo)
-
 	// Setup pedidos com diferentes status
-	pedidos := []*entities.Pedido{
-		{ID: 1, ClienteNome: "João", Status: entities.Finalizado},
-		{ID: 2, ClienteNome: "Maria", Status: entities.Pendente},
-		{ID: 3, ClienteNome: "Pedro", Status: entities.EmPreparacao},
-		{ID: 4, ClienteNome: "Ana", Status: entities.Pronto},
-	}
-	mockRepo.Pedidos = pedidos
+	useCase := NewPedidoListarTodosUseCase(novoPedidoRepositoryListar(t,
+		&entities.Pedido{ID: 1, ClienteNome: "João", Status: entities.Finalizado},
+		&entities.Pedido{ID: 2, ClienteNome: "Maria", Status: entities.Pendente},
+		&entities.Pedido{ID: 3, ClienteNome: "Pedro", Status: entities.EmPreparacao},
+		&entities.Pedido{ID: 4, ClienteNome: "Ana", Status: entities.Pronto},
+	))
 
 	// Test
-	result, err := useCase.Run(context.Background())
+	result, err := useCase.Run(context.Background(), entities.ConsultaPedidos{})
 
 	// Assertions
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if len(result) != 4 {
-		t.Errorf("expected 4 pedidos, got %d", len(result))
+	if len(result.Itens) != 4 {
+		t.Errorf("expected 4 pedidos, got %d", len(result.Itens))
 	}
 
 	// Verify all pedidos are returned
 	statusCount := make(map[entities.StatusPedido]int)
-	for _, pedido := range result {
+	for _, pedido := range result.Itens {
 		statusCount[pedido.Status]++
 	}
 
@@ -112,3 +92,67 @@ func TestPedidoListarTodosUseCase_Run_OrderedByStatus(t *testing.T) {
 		t.Errorf("expected 1 EmPreparacao pedido, got %d", statusCount[entities.EmPreparacao])
 	}
 }
+
+func TestPedidoListarTodosUseCase_Run_Filtros(t *testing.T) {
+	useCase := NewPedidoListarTodosUseCase(novoPedidoRepositoryListar(t,
+		&entities.Pedido{ID: 1, ClienteNome: "João", Status: entities.Recebido, StatusPagamento: entities.PagamentoPago},
+		&entities.Pedido{ID: 2, ClienteNome: "Maria", Status: entities.Pendente, StatusPagamento: entities.PagamentoPendente},
+		&entities.Pedido{ID: 3, ClienteNome: "Mariana", Status: entities.Recebido, StatusPagamento: entities.PagamentoPago},
+	))
+
+	result, err := useCase.Run(context.Background(), entities.ConsultaPedidos{Status: entities.Recebido, ClienteNome: "mari"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Itens) != 1 || result.Itens[0].ID != 3 || result.Total != 1 {
+		t.Errorf("expected only pedido 3, got %d pedidos", len(result.Itens))
+	}
+	if result.ProximoCursor != "" {
+		t.Errorf("expected no next cursor, got %q", result.ProximoCursor)
+	}
+}
+
+func TestPedidoListarTodosUseCase_Run_ConsultaInvalida(t *testing.T) {
+	useCase := NewPedidoListarTodosUseCase(novoPedidoRepositoryListar(t))
+
+	_, err := useCase.Run(context.Background(), entities.ConsultaPedidos{Status: "Perdido"})
+	if !errors.Is(err, entities.ErrStatusInvalido) {
+		t.Errorf("expected ErrStatusInvalido, got %v", err)
+	}
+
+	_, err = useCase.Run(context.Background(), entities.ConsultaPedidos{Limite: entities.LimiteMaximo + 1})
+	if !errors.Is(err, entities.ErrLimiteInvalido) {
+		t.Errorf("expected ErrLimiteInvalido, got %v", err)
+	}
+}
+
+func TestPedidoListarPorClienteUseCase_Run(t *testing.T) {
+	useCase := NewPedidoListarPorClienteUseCase(novoPedidoRepositoryListar(t,
+		&entities.Pedido{ID: 1, ClienteNome: "Ana", Cliente: &entities.ReferenciaCliente{CPF: "52998224725", IDExterno: "37"}},
+		&entities.Pedido{ID: 2, ClienteNome: "Balcão"},
+		&entities.Pedido{ID: 3, ClienteNome: "Ana", Cliente: &entities.ReferenciaCliente{IDExterno: "37"}},
+		&entities.Pedido{ID: 4, ClienteNome: "Bruno", Cliente: &entities.ReferenciaCliente{IDExterno: "40"}},
+	))
+
+	porCPF, err := useCase.Run(context.Background(), "529.982.247-25", entities.ConsultaPedidos{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if porCPF.Total != 1 || len(porCPF.Itens) != 1 || porCPF.Itens[0].ID != 1 {
+		t.Errorf("expected pedido 1, got %d pedidos", porCPF.Total)
+	}
+
+	// O filtro por cliente segue a mesma paginação da listagem geral
+	porID, err := useCase.Run(context.Background(), "37", entities.ConsultaPedidos{Limite: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if porID.Total != 2 || len(porID.Itens) != 1 || porID.ProximoCursor == "" {
+		t.Errorf("expected the first of 2 pedidos and a next cursor, got %d of %d", len(porID.Itens), porID.Total)
+	}
+
+	if _, err := useCase.Run(context.Background(), "  ", entities.ConsultaPedidos{}); !errors.Is(err, entities.ErrClienteSemReferencia) {
+		t.Errorf("expected ErrClienteSemReferencia, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type ProdutoAjustarEstoqueUseCase interface {
+	Run(ctx context.Context, id int, estoque *int) (*entities.Produto, error)
+}
+
+type produtoAjustarEstoqueUseCase struct {
+	produtoGateway   repository.ProdutoRepository
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
+}
+
+func NewProdutoAjustarEstoqueUseCase(produtoGateway repository.ProdutoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) ProdutoAjustarEstoqueUseCase {
+	return &produtoAjustarEstoqueUseCase{
+		produtoGateway:   produtoGateway,
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
+	}
+}
+
+func (puc *produtoAjustarEstoqueUseCase) Run(c context.Context, id int, estoque *int) (*entities.Produto, error) {
+	produto, err := puc.produtoGateway.BuscarProdutoPorId(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível buscar o produto: %w", err)
+	}
+
+	estavaDisponivel := produto.Disponivel(1)
+	if err := produto.AjustarEstoque(estoque); err != nil {
+		return nil, fmt.Errorf("ajuste de estoque inválido: %w", err)
+	}
+
+	err = puc.transacao.Executar(c, func(c context.Context) error {
+		// Grava só o estoque, para não desfazer uma mudança de disponibilidade feita desde a leitura
+		if err := puc.produtoGateway.DefinirEstoque(c, id, produto.Estoque); err != nil {
+			return fmt.Errorf("não foi possível ajustar o estoque do produto: %w", err)
+		}
+
+		produto, err = puc.produtoGateway.BuscarProdutoPorId(c, id)
+		if err != nil {
+			return fmt.Errorf("não foi possível buscar o produto: %w", err)
+		}
+
+		if estavaDisponivel && !produto.Disponivel(1) {
+			return registrarProdutoIndisponivel(c, puc.outboxRepository, *produto)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return produto, nil
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+)
+
+func TestProdutoAjustarEstoque_Run(t *testing.T) {
+	banco := novoBancoTeste(t)
+	gravado := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6)})
+	outbox := &MockOutboxRepository{}
+	useCase := NewProdutoAjustarEstoqueUseCase(banco.Produtos, &MockTransacao{}, outbox)
+
+	dez := 10
+	produto, err := useCase.Run(context.Background(), gravado.ID, &dez)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if produto.Estoque == nil || *produto.Estoque != 10 {
+		t.Errorf("Esperado estoque 10, recebido %v", produto.Estoque)
+	}
+	if estoque := banco.estoqueDe(gravado); estoque != 10 {
+		t.Errorf("Esperado estoque 10 gravado, recebido %d", estoque)
+	}
+	if len(outbox.Tipos()) != 0 {
+		t.Errorf("Nenhum evento esperado com estoque positivo, recebido %v", outbox.Tipos())
+	}
+
+	zero := 0
+	if _, err := useCase.Run(context.Background(), gravado.ID, &zero); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(outbox.Tipos()) != 1 || outbox.Tipos()[0] != "produto_indisponivel" {
+		t.Errorf("Esperado evento produto_indisponivel ao zerar o estoque, recebido %v", outbox.Tipos())
+	}
+
+	// Estoque nulo deixa de controlar o estoque e o produto volta ao cardápio
+	produto, err = useCase.Run(context.Background(), gravado.ID, nil)
+	if err != nil || produto.ControlaEstoque() || !produto.Disponivel(1) {
+		t.Errorf("Esperado produto sem controle de estoque, recebido %v (erro %v)", produto.Estoque, err)
+	}
+	if banco.buscarProduto(gravado.ID).ControlaEstoque() {
+		t.Error("Esperado produto gravado sem controle de estoque")
+	}
+}
+
+func TestProdutoAjustarEstoque_Run_Negativo(t *testing.T) {
+	banco := novoBancoTeste(t)
+	gravado := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6)})
+	useCase := NewProdutoAjustarEstoqueUseCase(banco.Produtos, &MockTransacao{}, &MockOutboxRepository{})
+
+	negativo := -3
+	_, err := useCase.Run(context.Background(), gravado.ID, &negativo)
+
+	if !errors.Is(err, entities.ErrEstoqueNegativo) {
+		t.Fatalf("Esperado ErrEstoqueNegativo, recebido %v", err)
+	}
+	if banco.buscarProduto(gravado.ID).ControlaEstoque() {
+		t.Error("Nenhuma gravação esperada com estoque negativo")
+	}
+}
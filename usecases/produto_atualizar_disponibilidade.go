@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type ProdutoAtualizarDisponibilidadeUseCase interface {
+	Run(ctx context.Context, id int, esgotado bool) (*entities.Produto, error)
+}
+
+type produtoAtualizarDisponibilidadeUseCase struct {
+	produtoGateway   repository.ProdutoRepository
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
+}
+
+func NewProdutoAtualizarDisponibilidadeUseCase(produtoGateway repository.ProdutoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) ProdutoAtualizarDisponibilidadeUseCase {
+	return &produtoAtualizarDisponibilidadeUseCase{
+		produtoGateway:   produtoGateway,
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
+	}
+}
+
+func (puc *produtoAtualizarDisponibilidadeUseCase) Run(c context.Context, id int, esgotado bool) (*entities.Produto, error) {
+	produto, err := puc.produtoGateway.BuscarProdutoPorId(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível buscar o produto: %w", err)
+	}
+
+	estavaDisponivel := produto.Disponivel(1)
+
+	err = puc.transacao.Executar(c, func(c context.Context) error {
+		// Grava só a disponibilidade, para não desfazer uma baixa de estoque feita desde a leitura
+		if err := puc.produtoGateway.AtualizarDisponibilidade(c, id, esgotado); err != nil {
+			return fmt.Errorf("não foi possível atualizar a disponibilidade do produto: %w", err)
+		}
+
+		produto, err = puc.produtoGateway.BuscarProdutoPorId(c, id)
+		if err != nil {
+			return fmt.Errorf("não foi possível buscar o produto: %w", err)
+		}
+
+		if estavaDisponivel && !produto.Disponivel(1) {
+			return registrarProdutoIndisponivel(c, puc.outboxRepository, *produto)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return produto, nil
+}
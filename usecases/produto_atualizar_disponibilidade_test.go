@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+)
+
+func TestProdutoAtualizarDisponibilidade_Run_Esgotar(t *testing.T) {
+	banco := novoBancoTeste(t)
+	gravado := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	outbox := &MockOutboxRepository{}
+	useCase := NewProdutoAtualizarDisponibilidadeUseCase(banco.Produtos, &MockTransacao{}, outbox)
+
+	produto, err := useCase.Run(context.Background(), gravado.ID, true)
+
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if !produto.Esgotado || !banco.buscarProduto(gravado.ID).Esgotado {
+		t.Errorf("Esperado produto esgotado e gravado, recebido esgotado=%v", produto.Esgotado)
+	}
+	if len(outbox.Tipos()) != 1 || outbox.Tipos()[0] != "produto_indisponivel" {
+		t.Errorf("Esperado evento produto_indisponivel, recebido %v", outbox.Tipos())
+	}
+
+	// Marcar de novo como esgotado não repete o evento
+	if _, err := useCase.Run(context.Background(), gravado.ID, true); err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(outbox.Tipos()) != 1 {
+		t.Errorf("Esperado um único evento, recebido %v", outbox.Tipos())
+	}
+}
+
+func TestProdutoAtualizarDisponibilidade_Run_ProdutoInexistente(t *testing.T) {
+	banco := novoBancoTeste(t)
+	useCase := NewProdutoAtualizarDisponibilidadeUseCase(banco.Produtos, &MockTransacao{}, &MockOutboxRepository{})
+
+	_, err := useCase.Run(context.Background(), 99, false)
+
+	if !errors.Is(err, entities.ErrProdutoNaoEncontrado) {
+		t.Fatalf("Esperado ErrProdutoNaoEncontrado, recebido %v", err)
+	}
+}
+
+func TestProdutoAtualizarDisponibilidade_Run_PreservaEstoque(t *testing.T) {
+	quatro := 4
+	banco := novoBancoTeste(t)
+	gravado := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5), Estoque: &quatro})
+	useCase := NewProdutoAtualizarDisponibilidadeUseCase(banco.Produtos, &MockTransacao{}, &MockOutboxRepository{})
+
+	produto, err := useCase.Run(context.Background(), gravado.ID, true)
+
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if !produto.Esgotado || produto.Estoque == nil || *produto.Estoque != 4 {
+		t.Errorf("Esperado produto esgotado com o estoque preservado, recebido esgotado=%v estoque=%v", produto.Esgotado, produto.Estoque)
+	}
+	if estoque := banco.estoqueDe(gravado); estoque != 4 {
+		t.Errorf("Esperado estoque 4 gravado, recebido %d", estoque)
+	}
+}
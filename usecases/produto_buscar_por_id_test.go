@@ -2,72 +2,29 @@ package usecases
 
 import (
 	"context"
-	"errors"
 	"lanchonete/internal/domain/entities"
 	"strings"
 	"testing"
 )
 
-// MockProdutoRepositoryBuscar implements repository.ProdutoRepository for testing
-type MockProdutoRepositoryBuscar struct {
-	Produtos []*entities.Produto
-}
-
-func (m *MockProdutoRepositoryBuscar) AdicionarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryBuscar) EditarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryBuscar) RemoverProduto(ctx context.Context, id int) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryBuscar) BuscarProdutoPorId(ctx context.Context, id int) (*entities.Produto, error) {
-	for _, produto := range m.Produtos {
-		if produto.ID == id {
-			return produto, nil
-		}
-	}
-	return nil, errors.New("produto não encontrado")
-}
-
-func (m *MockProdutoRepositoryBuscar) ListarTodosOsProdutos(ctx context.Context) ([]*entities.Produto, error) {
-	return m.Produtos, nil
-}
-
-func (m *MockProdutoRepositoryBuscar) ListarPorCategoria(ctx context.Context, categoria string) ([]*entities.Produto, error) {
-	var produtosFiltrados []*entities.Produto
-	for _, produto := range m.Produtos {
-		if string(produto.Categoria) == categoria {
-			produtosFiltrados = append(produtosFiltrados, produto)
-		}
-	}
-	return produtosFiltrados, nil
-}
-
 func TestProdutoBuscarPorId_Run_Sucesso(t *testing.T) {
 	// Given
 	produto := &entities.Produto{
-		ID:        1,
 		Nome:      "Produto Teste",
 		Categoria: entities.Bebida,
 		Descricao: "Descrição teste",
-		Preco:     10.0,
+		Preco:     entities.Reais(10.0),
 	}
 
-	mockRepo := &MockProdutoRepositoryBuscar{
-		Produtos: []*entities.Produto{produto},
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produto)
 
-	useCase := NewProdutoBuscaPorIdUseCase(mockRepo)
+	useCase := NewProdutoBuscaPorIdUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx, 1)
+	resultado, err := useCase.Run(ctx, produto.ID)
 
 	// Then
 	if err != nil {
@@ -78,8 +35,8 @@ func TestProdutoBuscarPorId_Run_Sucesso(t *testing.T) {
 		t.Fatal("Esperado produto, recebido nil")
 	}
 
-	if resultado.ID != 1 {
-		t.Errorf("Esperado ID 1, recebido %d", resultado.ID)
+	if resultado.ID != produto.ID {
+		t.Errorf("Esperado ID %d, recebido %d", produto.ID, resultado.ID)
 	}
 
 	if resultado.Nome != "Produto Teste" {
@@ -89,11 +46,9 @@ func TestProdutoBuscarPorId_Run_Sucesso(t *testing.T) {
 
 func TestProdutoBuscarPorId_Run_ProdutoNaoEncontrado(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryBuscar{
-		Produtos: []*entities.Produto{},
-	}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoBuscaPorIdUseCase(mockRepo)
+	useCase := NewProdutoBuscaPorIdUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
@@ -116,9 +71,9 @@ func TestProdutoBuscarPorId_Run_ProdutoNaoEncontrado(t *testing.T) {
 
 func TestProdutoBuscarPorId_Run_IdInvalido(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryBuscar{}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoBuscaPorIdUseCase(mockRepo)
+	useCase := NewProdutoBuscaPorIdUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
@@ -142,16 +97,15 @@ func TestProdutoBuscarPorId_Run_IdInvalido(t *testing.T) {
 func TestProdutoBuscarPorId_Run_MultiplosProdutos(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: 10.0},
-		{ID: 3, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
+		{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: entities.Reais(10.0)},
+		{Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
 	}
 
-	mockRepo := &MockProdutoRepositoryBuscar{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoBuscaPorIdUseCase(mockRepo)
+	useCase := NewProdutoBuscaPorIdUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
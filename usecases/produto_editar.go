@@ -5,29 +5,39 @@ import (
 	"fmt"
 	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
-	"lanchonete/internal/interfaces/publisher"
 )
 
 type ProdutoEditarUseCase interface {
-	Run(ctx context.Context, id int, nome, categoria, descricao string, preco float32) (*entities.Produto, error)
+	Run(ctx context.Context, id int, nome, categoria, descricao string, preco entities.Money, tempoPreparoMinutos int, dieta entities.InformacoesDieteticas) (*entities.Produto, error)
 }
 
 type produtoEditarUseCase struct {
-	produtoGateway repository.ProdutoRepository
-	eventPublisher publisher.EventPublisher
+	produtoGateway   repository.ProdutoRepository
+	categoriaGateway repository.CategoriaRepository
+	versaoGateway    repository.VersaoProdutoRepository
+	transacao        repository.Transacao
+	outboxGateway    repository.OutboxRepository
 }
 
 func NewProdutoEditarUseCase(
 	produtoGateway repository.ProdutoRepository,
-	eventPublisher publisher.EventPublisher,
+	categoriaGateway repository.CategoriaRepository,
+	versaoGateway repository.VersaoProdutoRepository,
+	transacao repository.Transacao,
+	outboxGateway repository.OutboxRepository,
 ) ProdutoEditarUseCase {
 	return &produtoEditarUseCase{
-		produtoGateway: produtoGateway,
-		eventPublisher: eventPublisher,
+		produtoGateway:   produtoGateway,
+		categoriaGateway: categoriaGateway,
+		versaoGateway:    versaoGateway,
+		transacao:        transacao,
+		outboxGateway:    outboxGateway,
 	}
 }
 
-func (puc *produtoEditarUseCase) Run(c context.Context, id int, nome string, categoria string, descricao string, preco float32) (*entities.Produto, error) {
+// Run altera os campos informados do produto; campos vazios mantêm o valor atual. Nas informações
+// dietéticas, uma lista ausente (nil) mantém a atual e uma lista vazia a apaga.
+func (puc *produtoEditarUseCase) Run(c context.Context, id int, nome string, categoria string, descricao string, preco entities.Money, tempoPreparoMinutos int, dieta entities.InformacoesDieteticas) (*entities.Produto, error) {
 
 	produto, err := puc.produtoGateway.BuscarProdutoPorId(c, id)
 
@@ -47,34 +57,73 @@ func (puc *produtoEditarUseCase) Run(c context.Context, id int, nome string, cat
 		descricao = produto.Descricao
 	}
 
-	if preco == 0 {
+	if preco.IsZero() {
 		preco = produto.Preco
 	}
 
-	produtoEditado, err := entities.ProdutoNew(nome, categoria, descricao, preco)
-	if err != nil {
-		return nil, fmt.Errorf("atualização de produto inválida: %w", err)
+	if tempoPreparoMinutos == 0 {
+		tempoPreparoMinutos = produto.TempoPreparoMinutos
 	}
 
-	produtoEditado.ID = id
+	if dieta.Alergenos == nil {
+		dieta.Alergenos = produto.Alergenos
+	}
+
+	if dieta.TagsDieta == nil {
+		dieta.TagsDieta = produto.TagsDieta
+	}
+
+	if dieta.Nutricao == nil {
+		dieta.Nutricao = produto.Nutricao
+	}
 
-	err = puc.produtoGateway.EditarProduto(c, produtoEditado)
+	var produtoEditado *entities.Produto
+	if produto.EhCombo() {
+		// A composição do combo não muda na edição; apenas nome, descrição e preço
+		if entities.CatProduto(categoria) != entities.Combo {
+			return nil, fmt.Errorf("atualização de produto inválida: um combo não pode mudar de categoria")
+		}
+		produtoEditado, err = entities.ComboNew(nome, descricao, preco, produto.Componentes)
+	} else {
+		categoriaProduto, errCategoria := categoriaCadastrada(c, puc.categoriaGateway, entities.CatProduto(categoria))
+		if errCategoria != nil {
+			return nil, errCategoria
+		}
+		produtoEditado, err = entities.ProdutoNew(nome, categoriaProduto, descricao, preco)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("não foi possível atualizar o produto: %w", err)
+		return nil, fmt.Errorf("atualização de produto inválida: %w", err)
 	}
 
-	// ✨ Publicar evento no SQS
-	payload := map[string]interface{}{
-		"id_produto": produtoEditado.ID,
-		"nome":       produtoEditado.Nome,
-		"categoria":  produtoEditado.Categoria,
-		"descricao":  produtoEditado.Descricao,
-		"preco":      produtoEditado.Preco,
+	if !produtoEditado.EhCombo() {
+		if err := produtoEditado.DefinirTempoPreparo(tempoPreparoMinutos); err != nil {
+			return nil, fmt.Errorf("atualização de produto inválida: %w", err)
+		}
 	}
 
-	err = puc.eventPublisher.Publish("produto_editado", payload)
+	if err := produtoEditado.DefinirInformacoesDieteticas(dieta); err != nil {
+		return nil, fmt.Errorf("atualização de produto inválida: %w", err)
+	}
+
+	produtoEditado.ID = id
+	produtoEditado.Esgotado = produto.Esgotado
+	produtoEditado.Estoque = produto.Estoque
+	produtoEditado.Imagens = produto.Imagens
+
+	err = puc.transacao.Executar(c, func(c context.Context) error {
+		if err := puc.produtoGateway.EditarProduto(c, produtoEditado); err != nil {
+			return fmt.Errorf("não foi possível atualizar o produto: %w", err)
+		}
+
+		if err := registrarVersao(c, puc.versaoGateway, *produtoEditado); err != nil {
+			return err
+		}
+
+		// ✨ Evento para o SQS, publicado pelo relay da outbox
+		return registrarEvento(c, puc.outboxGateway, entities.FilaProdutos, "produto_editado", eventoProdutoEditado(c, produtoEditado))
+	})
 	if err != nil {
-		fmt.Println("⚠️ Falha ao publicar evento do produto editado:", err)
+		return nil, err
 	}
 
 	return produtoEditado, nil
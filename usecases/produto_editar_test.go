@@ -8,76 +8,24 @@ import (
 	"testing"
 )
 
-// MockProdutoRepositoryEditar implements repository.ProdutoRepository for testing
-type MockProdutoRepositoryEditar struct {
-	Produtos []*entities.Produto
-}
-
-type MockEventPublisherEditar struct{}
-
-func (m *MockEventPublisherEditar) Publish(eventType string, payload interface{}) error {
-	// apenas retorna nil, simula sucesso
-	return nil
-}
-
-func (m *MockProdutoRepositoryEditar) AdicionarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryEditar) EditarProduto(ctx context.Context, produto *entities.Produto) error {
-	// Simula sucesso sempre - repositório real faria a edição
-	return nil
-}
-
-func (m *MockProdutoRepositoryEditar) RemoverProduto(ctx context.Context, id int) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryEditar) BuscarProdutoPorId(ctx context.Context, id int) (*entities.Produto, error) {
-	for _, produto := range m.Produtos {
-		if produto.ID == id {
-			return produto, nil
-		}
-	}
-	return nil, errors.New("produto não encontrado")
-}
-
-func (m *MockProdutoRepositoryEditar) ListarTodosOsProdutos(ctx context.Context) ([]*entities.Produto, error) {
-	return m.Produtos, nil
-}
-
-func (m *MockProdutoRepositoryEditar) ListarPorCategoria(ctx context.Context, categoria string) ([]*entities.Produto, error) {
-	var produtosFiltrados []*entities.Produto
-	for _, produto := range m.Produtos {
-		if string(produto.Categoria) == categoria {
-			produtosFiltrados = append(produtosFiltrados, produto)
-		}
-	}
-	return produtosFiltrados, nil
-}
-
 func TestProdutoEditar_Run_Sucesso(t *testing.T) {
 	// Given
 	produtoOriginal := &entities.Produto{
-		ID:        1,
 		Nome:      "Produto Original",
 		Categoria: entities.Lanche,
 		Descricao: "Descrição original",
-		Preco:     15.0,
-	}
-
-	mockRepo := &MockProdutoRepositoryEditar{
-		Produtos: []*entities.Produto{produtoOriginal},
+		Preco:     entities.Reais(15.0),
 	}
 
-	mockPublisher := &MockEventPublisherEditar{}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtoOriginal)
 
-	useCase := NewProdutoEditarUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx, 1, "Produto Editado", "Bebida", "Nova descrição", 20.0)
+	resultado, err := useCase.Run(ctx, produtoOriginal.ID, "Produto Editado", "Bebida", "Nova descrição", entities.Reais(20.0), 0, entities.InformacoesDieteticas{})
 
 	// Then
 	if err != nil {
@@ -96,25 +44,21 @@ func TestProdutoEditar_Run_Sucesso(t *testing.T) {
 		t.Errorf("Esperado categoria 'Bebida', recebido %s", resultado.Categoria)
 	}
 
-	if resultado.Preco != 20.0 {
-		t.Errorf("Esperado preço 20.0, recebido %f", resultado.Preco)
+	if resultado.Preco != entities.Reais(20.0) {
+		t.Errorf("Esperado preço 20.0, recebido %s", resultado.Preco)
 	}
 }
 
 func TestProdutoEditar_Run_ProdutoNaoEncontrado(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryEditar{
-		Produtos: []*entities.Produto{},
-	}
-
-	mockPublisher := &MockEventPublisherEditar{}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoEditarUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx, 999, "Produto Teste", "Lanche", "Descrição", 10.0)
+	resultado, err := useCase.Run(ctx, 999, "Produto Teste", "Lanche", "Descrição", entities.Reais(10.0), 0, entities.InformacoesDieteticas{})
 
 	// Then
 	if err == nil {
@@ -133,25 +77,21 @@ func TestProdutoEditar_Run_ProdutoNaoEncontrado(t *testing.T) {
 func TestProdutoEditar_Run_CamposVazios(t *testing.T) {
 	// Given - produto original manterá valores quando campos vazios
 	produtoOriginal := &entities.Produto{
-		ID:        1,
 		Nome:      "Produto Original",
 		Categoria: entities.Lanche,
 		Descricao: "Descrição original",
-		Preco:     15.0,
-	}
-
-	mockRepo := &MockProdutoRepositoryEditar{
-		Produtos: []*entities.Produto{produtoOriginal},
+		Preco:     entities.Reais(15.0),
 	}
 
-	mockPublisher := &MockEventPublisherEditar{}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtoOriginal)
 
-	useCase := NewProdutoEditarUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
 	// When - passando campos vazios (devem manter valores originais)
-	resultado, err := useCase.Run(ctx, 1, "", "", "", 0)
+	resultado, err := useCase.Run(ctx, produtoOriginal.ID, "", "", "", entities.Money{}, 0, entities.InformacoesDieteticas{})
 
 	// Then
 	if err != nil {
@@ -171,33 +111,57 @@ func TestProdutoEditar_Run_CamposVazios(t *testing.T) {
 		t.Errorf("Esperado categoria original 'Lanche', recebido %s", resultado.Categoria)
 	}
 
-	if resultado.Preco != 15.0 {
-		t.Errorf("Esperado preço original 15.0, recebido %f", resultado.Preco)
+	if resultado.Preco != entities.Reais(15.0) {
+		t.Errorf("Esperado preço original 15.0, recebido %s", resultado.Preco)
+	}
+}
+
+func TestProdutoEditar_Run_TempoPreparo(t *testing.T) {
+	produtoOriginal := &entities.Produto{
+		Nome:                "X-Tudo",
+		Categoria:           entities.Lanche,
+		Descricao:           "Completo",
+		Preco:               entities.Reais(28.5),
+		TempoPreparoMinutos: 15,
+	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtoOriginal)
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	resultado, err := useCase.Run(context.Background(), produtoOriginal.ID, "", "", "", entities.Money{}, 0, entities.InformacoesDieteticas{})
+	if err != nil || resultado.TempoPreparoMinutos != 15 {
+		t.Errorf("Esperado manter o tempo de preparo de 15 minutos, recebido %v (erro %v)", resultado, err)
+	}
+
+	resultado, err = useCase.Run(context.Background(), produtoOriginal.ID, "", "", "", entities.Money{}, 12, entities.InformacoesDieteticas{})
+	if err != nil || resultado.TempoPreparoMinutos != 12 {
+		t.Errorf("Esperado tempo de preparo de 12 minutos, recebido %v (erro %v)", resultado, err)
+	}
+
+	_, err = useCase.Run(context.Background(), produtoOriginal.ID, "", "", "", entities.Money{}, -5, entities.InformacoesDieteticas{})
+	if err == nil {
+		t.Error("Esperado erro para tempo de preparo negativo")
 	}
 }
 
 func TestProdutoEditar_Run_DadosInvalidos(t *testing.T) {
 	// Given
 	produtoOriginal := &entities.Produto{
-		ID:        1,
 		Nome:      "Produto Original",
 		Categoria: entities.Lanche,
 		Descricao: "Descrição original",
-		Preco:     15.0,
-	}
-
-	mockRepo := &MockProdutoRepositoryEditar{
-		Produtos: []*entities.Produto{produtoOriginal},
+		Preco:     entities.Reais(15.0),
 	}
 
-	mockPublisher := &MockEventPublisherEditar{}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtoOriginal)
 
-	useCase := NewProdutoEditarUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
 	// When - categoria inválida
-	resultado, err := useCase.Run(ctx, 1, "Produto Teste", "CategoriaInvalida", "Descrição", 10.0)
+	resultado, err := useCase.Run(ctx, produtoOriginal.ID, "Produto Teste", "CategoriaInvalida", "Descrição", entities.Reais(10.0), 0, entities.InformacoesDieteticas{})
 
 	// Then
 	if err == nil {
@@ -212,3 +176,101 @@ func TestProdutoEditar_Run_DadosInvalidos(t *testing.T) {
 		t.Errorf("Esperado erro sobre dados inválidos, recebido: %v", err)
 	}
 }
+
+func TestProdutoEditar_Run_Combo(t *testing.T) {
+	// Given
+	xSalada := &entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)}
+	combo := &entities.Produto{
+		Nome:      "Combo X-Salada",
+		Categoria: entities.Combo,
+		Preco:     entities.Reais(30.0),
+		Componentes: []entities.ComponenteCombo{
+			{Produto: xSalada, Quantidade: 1},
+			{Categoria: entities.Bebida, Quantidade: 1},
+		},
+	}
+
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(xSalada, combo)
+
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	ctx := context.Background()
+
+	// When - apenas o preço muda
+	resultado, err := useCase.Run(ctx, combo.ID, "", "", "", entities.Reais(28.0), 0, entities.InformacoesDieteticas{})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado sucesso ao editar combo, recebido: %v", err)
+	}
+
+	if resultado.Preco != entities.Reais(28.0) || len(resultado.Componentes) != 2 {
+		t.Errorf("Esperado combo com novo preço e mesmos componentes, recebido: %+v", resultado)
+	}
+
+	// When - tentativa de transformar o combo em lanche
+	_, err = useCase.Run(ctx, combo.ID, "", string(entities.Lanche), "", entities.Money{}, 0, entities.InformacoesDieteticas{})
+
+	// Then
+	if err == nil || !strings.Contains(err.Error(), "não pode mudar de categoria") {
+		t.Errorf("Esperado erro de mudança de categoria do combo, recebido: %v", err)
+	}
+}
+
+func TestProdutoEditar_Run_InformacoesDieteticas(t *testing.T) {
+	// Given
+	nutricao := &entities.InformacaoNutricional{PorcaoGramas: 120, Calorias: 280}
+	produtoOriginal := &entities.Produto{
+		Nome:      "Mousse de chocolate",
+		Categoria: entities.Sobremesa,
+		Descricao: "Chocolate cremoso ao leite",
+		Preco:     entities.Reais(12.5),
+		InformacoesDieteticas: entities.InformacoesDieteticas{
+			Alergenos: []entities.Alergeno{entities.AlergenoLactose, entities.AlergenoOvo},
+			TagsDieta: []entities.TagDieta{entities.TagVegetariano},
+			Nutricao:  nutricao,
+		},
+	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtoOriginal)
+	useCase := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	// When - listas ausentes mantêm os valores atuais
+	mantido, err := useCase.Run(context.Background(), produtoOriginal.ID, "", "", "", entities.Money{}, 0, entities.InformacoesDieteticas{})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if len(mantido.Alergenos) != 2 || !mantido.TemTagDieta(entities.TagVegetariano) || mantido.Nutricao == nil || *mantido.Nutricao != *nutricao {
+		t.Errorf("Esperado manter as informações dietéticas, recebido %+v", mantido.InformacoesDieteticas)
+	}
+
+	// When - lista vazia apaga as tags; alérgenos informados substituem os atuais
+	editado, err := useCase.Run(context.Background(), produtoOriginal.ID, "", "", "", entities.Money{}, 0, entities.InformacoesDieteticas{
+		Alergenos: []entities.Alergeno{entities.AlergenoLactose},
+		TagsDieta: []entities.TagDieta{},
+	})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if len(editado.Alergenos) != 1 || editado.Alergenos[0] != entities.AlergenoLactose {
+		t.Errorf("Esperado apenas lactose, recebido %v", editado.Alergenos)
+	}
+	if len(editado.TagsDieta) != 0 {
+		t.Errorf("Esperado tags apagadas, recebido %v", editado.TagsDieta)
+	}
+
+	// When - tag que contradiz os alérgenos mantidos
+	_, err = useCase.Run(context.Background(), produtoOriginal.ID, "", "", "", entities.Money{}, 0, entities.InformacoesDieteticas{
+		TagsDieta: []entities.TagDieta{entities.TagVegano},
+	})
+
+	// Then
+	if !errors.Is(err, entities.ErrTagIncompativel) {
+		t.Errorf("Esperado ErrTagIncompativel, recebido %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+type ProdutoHistoricoUseCase interface {
+	Run(ctx context.Context, id int) ([]entities.VersaoProduto, error)
+}
+
+type produtoHistoricoUseCase struct {
+	produtoGateway repository.ProdutoRepository
+	versaoGateway  repository.VersaoProdutoRepository
+}
+
+func NewProdutoHistoricoUseCase(produtoGateway repository.ProdutoRepository, versaoGateway repository.VersaoProdutoRepository) ProdutoHistoricoUseCase {
+	return &produtoHistoricoUseCase{
+		produtoGateway: produtoGateway,
+		versaoGateway:  versaoGateway,
+	}
+}
+
+// Run lista todas as versões do produto, da mais antiga para a mais recente
+func (phuc *produtoHistoricoUseCase) Run(c context.Context, id int) ([]entities.VersaoProduto, error) {
+	if _, err := phuc.produtoGateway.BuscarProdutoPorId(c, id); err != nil {
+		return nil, fmt.Errorf("produto não existe no banco de dados: %w", err)
+	}
+
+	versoes, err := phuc.versaoGateway.ListarVersoes(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível carregar o histórico do produto: %w", err)
+	}
+
+	return versoes, nil
+}
+
+type ProdutoVersaoEmUseCase interface {
+	Run(ctx context.Context, id int, em time.Time) (*entities.VersaoProduto, error)
+}
+
+type produtoVersaoEmUseCase struct {
+	produtoGateway repository.ProdutoRepository
+	versaoGateway  repository.VersaoProdutoRepository
+}
+
+func NewProdutoVersaoEmUseCase(produtoGateway repository.ProdutoRepository, versaoGateway repository.VersaoProdutoRepository) ProdutoVersaoEmUseCase {
+	return &produtoVersaoEmUseCase{
+		produtoGateway: produtoGateway,
+		versaoGateway:  versaoGateway,
+	}
+}
+
+// Run informa como o produto estava, e quanto custava, no instante informado
+func (pvuc *produtoVersaoEmUseCase) Run(c context.Context, id int, em time.Time) (*entities.VersaoProduto, error) {
+	if _, err := pvuc.produtoGateway.BuscarProdutoPorId(c, id); err != nil {
+		return nil, fmt.Errorf("produto não existe no banco de dados: %w", err)
+	}
+
+	versao, err := pvuc.versaoGateway.BuscarVersaoEm(c, id, em)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível consultar a versão do produto: %w", err)
+	}
+
+	return versao, nil
+}
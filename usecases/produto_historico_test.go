@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockVersaoProdutoRepository implements repository.VersaoProdutoRepository for testing
+type MockVersaoProdutoRepository struct {
+	Versoes []entities.VersaoProduto
+}
+
+func (m *MockVersaoProdutoRepository) RegistrarVersao(ctx context.Context, versao *entities.VersaoProduto) error {
+	versao.ID = len(m.Versoes) + 1
+	m.Versoes = append(m.Versoes, *versao)
+	return nil
+}
+
+func (m *MockVersaoProdutoRepository) ListarVersoes(ctx context.Context, produtoID int) ([]entities.VersaoProduto, error) {
+	versoes := []entities.VersaoProduto{}
+	for _, v := range m.Versoes {
+		if v.ProdutoID == produtoID {
+			versoes = append(versoes, v)
+		}
+	}
+	return versoes, nil
+}
+
+func (m *MockVersaoProdutoRepository) BuscarVersaoEm(ctx context.Context, produtoID int, em time.Time) (*entities.VersaoProduto, error) {
+	versoes, _ := m.ListarVersoes(ctx, produtoID)
+	return entities.VersaoVigente(versoes, em)
+}
+
+func TestProdutoHistorico_Run_RegistraInclusaoEEdicoes(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	versaoRepo := &MockVersaoProdutoRepository{}
+	ctx := ComAutor(context.Background(), "gerente")
+
+	incluir := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, versaoRepo, &MockTransacao{}, &MockOutboxRepository{})
+	produto, err := incluir.Run(ctx, "Hamburguer", "Lanche", "Delicioso hamburguer", entities.Reais(25.0), 0, entities.InformacoesDieteticas{})
+	if err != nil {
+		t.Fatalf("Não esperado erro na inclusão, recebido %v", err)
+	}
+
+	editar := NewProdutoEditarUseCase(banco.Produtos, banco.Categorias, versaoRepo, &MockTransacao{}, &MockOutboxRepository{})
+	if _, err := editar.Run(ComAutor(context.Background(), "caixa"), produto.ID, "", "", "", entities.Reais(27.0), 0, entities.InformacoesDieteticas{}); err != nil {
+		t.Fatalf("Não esperado erro na edição, recebido %v", err)
+	}
+
+	// When
+	versoes, err := NewProdutoHistoricoUseCase(banco.Produtos, versaoRepo).Run(context.Background(), produto.ID)
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if len(versoes) != 2 {
+		t.Fatalf("Esperado 2 versões, encontrado %d", len(versoes))
+	}
+	if versoes[0].Autor != "gerente" || versoes[0].Preco != entities.Reais(25.0) {
+		t.Errorf("Primeira versão inesperada: %+v", versoes[0])
+	}
+	if versoes[1].Autor != "caixa" || versoes[1].Preco != entities.Reais(27.0) {
+		t.Errorf("Segunda versão inesperada: %+v", versoes[1])
+	}
+}
+
+func TestProdutoHistorico_Run_AutorDesconhecido(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	versaoRepo := &MockVersaoProdutoRepository{}
+	incluir := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, versaoRepo, &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	if _, err := incluir.Run(context.Background(), "Hamburguer", "Lanche", "Delicioso hamburguer", entities.Reais(25.0), 0, entities.InformacoesDieteticas{}); err != nil {
+		t.Fatalf("Não esperado erro na inclusão, recebido %v", err)
+	}
+
+	// Then
+	if versaoRepo.Versoes[0].Autor != AutorDesconhecido {
+		t.Errorf("Esperado autor %q, recebido %q", AutorDesconhecido, versaoRepo.Versoes[0].Autor)
+	}
+}
+
+func TestProdutoHistorico_Run_ProdutoNaoEncontrado(t *testing.T) {
+	// Given
+	useCase := NewProdutoHistoricoUseCase(novoBancoTeste(t).Produtos, &MockVersaoProdutoRepository{})
+
+	// When
+	_, err := useCase.Run(context.Background(), 999)
+
+	// Then
+	if err == nil || !strings.Contains(err.Error(), "produto não encontrado") {
+		t.Errorf("Esperado erro de produto não encontrado, recebido %v", err)
+	}
+}
+
+func TestProdutoVersaoEm_Run(t *testing.T) {
+	// Given
+	inicio := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(27.0)})
+	versaoRepo := &MockVersaoProdutoRepository{Versoes: []entities.VersaoProduto{
+		{ID: 1, ProdutoID: produto.ID, Preco: entities.Reais(25.0), VigenteDesde: inicio},
+		{ID: 2, ProdutoID: produto.ID, Preco: entities.Reais(27.0), VigenteDesde: inicio.Add(24 * time.Hour)},
+	}}
+	useCase := NewProdutoVersaoEmUseCase(banco.Produtos, versaoRepo)
+
+	// When
+	versao, err := useCase.Run(context.Background(), produto.ID, inicio.Add(time.Hour))
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if versao.Preco != entities.Reais(25.0) {
+		t.Errorf("Esperado preço 25.0 no instante consultado, recebido %s", versao.Preco)
+	}
+
+	_, err = useCase.Run(context.Background(), produto.ID, inicio.Add(-time.Hour))
+	if !errors.Is(err, entities.ErrSemVersaoVigente) {
+		t.Errorf("Esperado ErrSemVersaoVigente antes da primeira versão, recebido %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"lanchonete/internal/interfaces/storage"
+	"time"
+)
+
+type ProdutoImagemEnviarUseCase interface {
+	Run(ctx context.Context, id int, conteudo []byte, contentType string) (*entities.Produto, error)
+}
+
+type produtoImagemEnviarUseCase struct {
+	produtoGateway repository.ProdutoRepository
+	imagemGateway  repository.ImagemProdutoRepository
+	imageStorage   storage.ImageStorage
+	transacao      repository.Transacao
+	outboxGateway  repository.OutboxRepository
+}
+
+func NewProdutoImagemEnviarUseCase(
+	produtoGateway repository.ProdutoRepository,
+	imagemGateway repository.ImagemProdutoRepository,
+	imageStorage storage.ImageStorage,
+	transacao repository.Transacao,
+	outboxGateway repository.OutboxRepository,
+) ProdutoImagemEnviarUseCase {
+	return &produtoImagemEnviarUseCase{
+		produtoGateway: produtoGateway,
+		imagemGateway:  imagemGateway,
+		imageStorage:   imageStorage,
+		transacao:      transacao,
+		outboxGateway:  outboxGateway,
+	}
+}
+
+// Run grava a imagem do produto e suas variantes reduzidas, substituindo a imagem anterior
+func (piuc *produtoImagemEnviarUseCase) Run(c context.Context, id int, conteudo []byte, contentType string) (*entities.Produto, error) {
+	produto, err := piuc.produtoGateway.BuscarProdutoPorId(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("produto não existe no banco de dados: %w", err)
+	}
+	if produto.Arquivado() {
+		return nil, entities.ErrProdutoArquivado
+	}
+
+	extensao, err := entities.ValidarImagem(contentType, len(conteudo))
+	if err != nil {
+		return nil, err
+	}
+
+	variantes, err := gerarVariantes(conteudo, extensao)
+	if err != nil {
+		return nil, err
+	}
+
+	enviadaEm := time.Now()
+	imagens := make([]entities.ImagemProduto, 0, len(variantes))
+	for _, v := range variantes {
+		chave := entities.ChaveImagem(produto.ID, v.variante, enviadaEm, extensao)
+		url, err := piuc.imageStorage.Save(c, chave, v.conteudo, contentType)
+		if err != nil {
+			apagarArquivosImagens(c, piuc.imageStorage, imagens)
+			return nil, fmt.Errorf("não foi possível armazenar a imagem: %w", err)
+		}
+		imagens = append(imagens, entities.ImagemProduto{
+			Variante: v.variante,
+			Chave:    chave,
+			URL:      url,
+			Largura:  v.largura,
+			Altura:   v.altura,
+		})
+	}
+
+	anteriores := produto.Imagens
+	produto.Imagens = imagens
+	err = piuc.transacao.Executar(c, func(c context.Context) error {
+		if err := piuc.imagemGateway.SalvarImagens(c, produto.ID, imagens); err != nil {
+			return fmt.Errorf("não foi possível gravar as imagens do produto: %w", err)
+		}
+
+		// ✨ Evento para o SQS, publicado pelo relay da outbox
+		return registrarEvento(c, piuc.outboxGateway, entities.FilaProdutos, "produto_editado", eventoProdutoEditado(c, produto))
+	})
+	if err != nil {
+		apagarArquivosImagens(c, piuc.imageStorage, imagens)
+		return nil, err
+	}
+
+	// Só depois de gravar as novas a imagem anterior deixa de ser referenciada
+	apagarArquivosImagens(c, piuc.imageStorage, anteriores)
+
+	return produto, nil
+}
@@ -0,0 +1,266 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"lanchonete/internal/domain/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockImageStorage implements storage.ImageStorage for testing
+type MockImageStorage struct {
+	Arquivos map[string][]byte
+	Apagados []string
+	ErrSave  error
+}
+
+func novoMockImageStorage() *MockImageStorage {
+	return &MockImageStorage{Arquivos: map[string][]byte{}}
+}
+
+func (m *MockImageStorage) Save(ctx context.Context, key string, content []byte, contentType string) (string, error) {
+	if m.ErrSave != nil {
+		return "", m.ErrSave
+	}
+	m.Arquivos[key] = content
+	return "http://imagens.local/" + key, nil
+}
+
+func (m *MockImageStorage) Delete(ctx context.Context, key string) error {
+	delete(m.Arquivos, key)
+	m.Apagados = append(m.Apagados, key)
+	return nil
+}
+
+// imagemTeste gera um arquivo de imagem sólida no formato pedido ("png" ou "jpg")
+func imagemTeste(t *testing.T, formato string, largura, altura int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	for y := 0; y < altura; y++ {
+		for x := 0; x < largura; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 80, B: 20, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if formato == "png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatalf("erro ao gerar imagem de teste: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// cabecalhoPNG monta só a assinatura e o cabeçalho de um PNG com as dimensões informadas, sem os pixels:
+// é o que basta para uma imagem declarar dimensões enormes em poucos bytes
+func cabecalhoPNG(largura, altura uint32) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, largura)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, altura)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0) // 8 bits por canal, RGBA
+
+	conteudo := []byte("\x89PNG\r\n\x1a\n")
+	conteudo = binary.BigEndian.AppendUint32(conteudo, uint32(len(ihdr)-4))
+	conteudo = append(conteudo, ihdr...)
+	return binary.BigEndian.AppendUint32(conteudo, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestProdutoImagemEnviar_Run_Sucesso(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	imageStorage := novoMockImageStorage()
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewProdutoImagemEnviarUseCase(banco.Produtos, banco.Imagens, imageStorage, &MockTransacao{}, mockOutbox)
+
+	// When
+	atualizado, err := useCase.Run(context.Background(), produto.ID, imagemTeste(t, "png", 1000, 500), "image/png")
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if len(atualizado.Imagens) != 3 {
+		t.Fatalf("Esperado 3 variantes, recebido %d", len(atualizado.Imagens))
+	}
+
+	tamanhos := map[entities.VarianteImagem][2]int{}
+	for _, imagem := range atualizado.Imagens {
+		tamanhos[imagem.Variante] = [2]int{imagem.Largura, imagem.Altura}
+		if !strings.HasSuffix(imagem.Chave, ".png") {
+			t.Errorf("Esperado arquivo PNG, recebido %s", imagem.Chave)
+		}
+		if _, ok := imageStorage.Arquivos[imagem.Chave]; !ok {
+			t.Errorf("Variante %s não foi armazenada", imagem.Variante)
+		}
+	}
+	if tamanhos[entities.ImagemOriginal] != [2]int{1000, 500} {
+		t.Errorf("Tamanho da original incorreto: %v", tamanhos[entities.ImagemOriginal])
+	}
+	if tamanhos[entities.ImagemMedia] != [2]int{480, 240} {
+		t.Errorf("Tamanho da média incorreto: %v", tamanhos[entities.ImagemMedia])
+	}
+	if tamanhos[entities.ImagemMiniatura] != [2]int{160, 80} {
+		t.Errorf("Tamanho da miniatura incorreto: %v", tamanhos[entities.ImagemMiniatura])
+	}
+
+	if gravadas := banco.buscarProduto(produto.ID).Imagens; len(gravadas) != 3 {
+		t.Errorf("Esperado 3 imagens gravadas, recebido %d", len(gravadas))
+	}
+	if len(mockOutbox.Tipos()) != 1 || mockOutbox.Tipos()[0] != "produto_editado" {
+		t.Fatalf("Esperado evento produto_editado, recebido %v", mockOutbox.Tipos())
+	}
+	urls, _ := mockOutbox.Payload(t, 0)["imagens"].(map[string]interface{})
+	if url, _ := urls[string(entities.ImagemMiniatura)].(string); !strings.HasPrefix(url, "http://imagens.local/produtos/1/miniatura-") {
+		t.Errorf("URL da miniatura incorreta no evento: %v", urls)
+	}
+}
+
+func TestProdutoImagemEnviar_Run_ImagemPequenaNaoAmplia(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6)})
+	useCase := NewProdutoImagemEnviarUseCase(banco.Produtos, banco.Imagens, novoMockImageStorage(), &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	atualizado, err := useCase.Run(context.Background(), produto.ID, imagemTeste(t, "jpg", 300, 300), "image/jpeg")
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	for _, imagem := range atualizado.Imagens {
+		switch imagem.Variante {
+		case entities.ImagemMedia:
+			if imagem.Largura != 300 {
+				t.Errorf("A média não deveria ampliar a imagem, largura %d", imagem.Largura)
+			}
+		case entities.ImagemMiniatura:
+			if imagem.Largura != 160 || imagem.Altura != 160 {
+				t.Errorf("Tamanho da miniatura incorreto: %dx%d", imagem.Largura, imagem.Altura)
+			}
+		}
+	}
+}
+
+func TestProdutoImagemEnviar_Run_SubstituiImagemAnterior(t *testing.T) {
+	// Given
+	anterior := []entities.ImagemProduto{
+		{Variante: entities.ImagemOriginal, Chave: "produtos/1/original-1.png"},
+		{Variante: entities.ImagemMiniatura, Chave: "produtos/1/miniatura-1.png"},
+	}
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5), Imagens: anterior})
+	imageStorage := novoMockImageStorage()
+	useCase := NewProdutoImagemEnviarUseCase(banco.Produtos, banco.Imagens, imageStorage, &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	_, err := useCase.Run(context.Background(), produto.ID, imagemTeste(t, "png", 200, 200), "image/png")
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if len(imageStorage.Apagados) != 2 || imageStorage.Apagados[0] != anterior[0].Chave || imageStorage.Apagados[1] != anterior[1].Chave {
+		t.Errorf("Esperado apagar os arquivos anteriores, apagados %v", imageStorage.Apagados)
+	}
+	if len(imageStorage.Arquivos) != 3 {
+		t.Errorf("Esperado 3 arquivos novos no armazenamento, encontrado %d", len(imageStorage.Arquivos))
+	}
+}
+
+func TestProdutoImagemEnviar_Run_Validacoes(t *testing.T) {
+	png := imagemTeste(t, "png", 10, 10)
+	lanche := entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)}
+	arquivado := lanche
+	arquivado.ArquivadoEm = &time.Time{}
+
+	testCases := []struct {
+		name        string
+		produto     entities.Produto
+		conteudo    []byte
+		contentType string
+		esperado    error
+	}{
+		{"formato não suportado", lanche, []byte("GIF89a"), "image/gif", entities.ErrFormatoImagem},
+		{"arquivo vazio", lanche, nil, "image/png", entities.ErrImagemVazia},
+		{"arquivo grande demais", lanche, make([]byte, entities.TamanhoMaximoImagem+1), "image/png", entities.ErrImagemGrande},
+		{"conteúdo que não é imagem", lanche, []byte("não sou um png"), "image/png", entities.ErrFormatoImagem},
+		{"dimensões grandes demais", lanche, cabecalhoPNG(50000, 50000), "image/png", entities.ErrDimensoesImagem},
+		{"pixels demais", lanche, cabecalhoPNG(6000, 6000), "image/png", entities.ErrDimensoesImagem},
+		{"produto arquivado", arquivado, png, "image/png", entities.ErrProdutoArquivado},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			banco := novoBancoTeste(t)
+			produto := banco.produto(tc.produto)
+			imageStorage := novoMockImageStorage()
+			mockOutbox := &MockOutboxRepository{}
+			useCase := NewProdutoImagemEnviarUseCase(banco.Produtos, banco.Imagens, imageStorage, &MockTransacao{}, mockOutbox)
+
+			// When
+			_, err := useCase.Run(context.Background(), produto.ID, tc.conteudo, tc.contentType)
+
+			// Then
+			if !errors.Is(err, tc.esperado) {
+				t.Errorf("Esperado %v, recebido %v", tc.esperado, err)
+			}
+			if len(imageStorage.Arquivos) != 0 || len(mockOutbox.Tipos()) != 0 {
+				t.Error("Nada deveria ser armazenado nem publicado")
+			}
+		})
+	}
+}
+
+func TestProdutoImagemEnviar_Run_ProdutoNaoEncontrado(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	useCase := NewProdutoImagemEnviarUseCase(banco.Produtos, banco.Imagens, novoMockImageStorage(), &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	_, err := useCase.Run(context.Background(), 99, imagemTeste(t, "png", 10, 10), "image/png")
+
+	// Then
+	if err == nil || !strings.Contains(err.Error(), "produto não encontrado") {
+		t.Errorf("Esperado erro de produto não encontrado, recebido %v", err)
+	}
+}
+
+func TestProdutoImagemEnviar_Run_FalhaAoGravarApagaArquivos(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	imagens := imagensComFalha{ImagemProdutoRepository: banco.Imagens, err: errors.New("banco indisponível")}
+	imageStorage := novoMockImageStorage()
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewProdutoImagemEnviarUseCase(banco.Produtos, imagens, imageStorage, &MockTransacao{}, mockOutbox)
+
+	// When
+	_, err := useCase.Run(context.Background(), produto.ID, imagemTeste(t, "png", 200, 200), "image/png")
+
+	// Then
+	if err == nil {
+		t.Fatal("Esperado erro, recebido nil")
+	}
+	if len(imageStorage.Arquivos) != 0 {
+		t.Errorf("Os arquivos enviados deveriam ser apagados, restaram %d", len(imageStorage.Arquivos))
+	}
+	if len(mockOutbox.Tipos()) != 0 {
+		t.Errorf("Nenhum evento deveria ser publicado, recebido %v", mockOutbox.Tipos())
+	}
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"lanchonete/internal/interfaces/storage"
+)
+
+type ProdutoImagemRemoverUseCase interface {
+	Run(ctx context.Context, id int) (*entities.Produto, error)
+}
+
+type produtoImagemRemoverUseCase struct {
+	produtoGateway repository.ProdutoRepository
+	imagemGateway  repository.ImagemProdutoRepository
+	imageStorage   storage.ImageStorage
+	transacao      repository.Transacao
+	outboxGateway  repository.OutboxRepository
+}
+
+func NewProdutoImagemRemoverUseCase(
+	produtoGateway repository.ProdutoRepository,
+	imagemGateway repository.ImagemProdutoRepository,
+	imageStorage storage.ImageStorage,
+	transacao repository.Transacao,
+	outboxGateway repository.OutboxRepository,
+) ProdutoImagemRemoverUseCase {
+	return &produtoImagemRemoverUseCase{
+		produtoGateway: produtoGateway,
+		imagemGateway:  imagemGateway,
+		imageStorage:   imageStorage,
+		transacao:      transacao,
+		outboxGateway:  outboxGateway,
+	}
+}
+
+// Run retira a imagem do produto e apaga todas as suas variantes do armazenamento
+func (piuc *produtoImagemRemoverUseCase) Run(c context.Context, id int) (*entities.Produto, error) {
+	produto, err := piuc.produtoGateway.BuscarProdutoPorId(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("produto não existe no banco de dados: %w", err)
+	}
+	if len(produto.Imagens) == 0 {
+		return nil, entities.ErrProdutoSemFoto
+	}
+
+	anteriores := produto.Imagens
+	produto.Imagens = nil
+	err = piuc.transacao.Executar(c, func(c context.Context) error {
+		if err := piuc.imagemGateway.RemoverImagens(c, produto.ID); err != nil {
+			return fmt.Errorf("não foi possível remover as imagens do produto: %w", err)
+		}
+
+		// ✨ Evento para o SQS, publicado pelo relay da outbox
+		return registrarEvento(c, piuc.outboxGateway, entities.FilaProdutos, "produto_editado", eventoProdutoEditado(c, produto))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Os arquivos só são apagados depois que o produto deixou de referenciá-los
+	apagarArquivosImagens(c, piuc.imageStorage, anteriores)
+
+	return produto, nil
+}
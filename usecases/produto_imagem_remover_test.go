@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"testing"
+)
+
+func TestProdutoImagemRemover_Run_Sucesso(t *testing.T) {
+	// Given
+	imagens := []entities.ImagemProduto{
+		{Variante: entities.ImagemOriginal, Chave: "produtos/1/original-1.png", URL: "http://imagens.local/produtos/1/original-1.png"},
+		{Variante: entities.ImagemMiniatura, Chave: "produtos/1/miniatura-1.png", URL: "http://imagens.local/produtos/1/miniatura-1.png"},
+	}
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5), Imagens: imagens})
+	imageStorage := novoMockImageStorage()
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewProdutoImagemRemoverUseCase(banco.Produtos, banco.Imagens, imageStorage, &MockTransacao{}, mockOutbox)
+
+	// When
+	atualizado, err := useCase.Run(context.Background(), produto.ID)
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if len(atualizado.Imagens) != 0 {
+		t.Errorf("Esperado produto sem imagens, recebido %v", atualizado.Imagens)
+	}
+	if gravadas := banco.buscarProduto(produto.ID).Imagens; len(gravadas) != 0 {
+		t.Errorf("Esperado remover as imagens gravadas, encontradas %v", gravadas)
+	}
+	if len(imageStorage.Apagados) != 2 {
+		t.Errorf("Esperado apagar 2 arquivos, apagados %v", imageStorage.Apagados)
+	}
+	if len(mockOutbox.Tipos()) != 1 || mockOutbox.Tipos()[0] != "produto_editado" {
+		t.Fatalf("Esperado evento produto_editado, recebido %v", mockOutbox.Tipos())
+	}
+	if urls, _ := mockOutbox.Payload(t, 0)["imagens"].(map[string]interface{}); len(urls) != 0 {
+		t.Errorf("Esperado evento sem imagens, recebido %v", urls)
+	}
+}
+
+func TestProdutoImagemRemover_Run_ProdutoSemFoto(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)})
+	useCase := NewProdutoImagemRemoverUseCase(banco.Produtos, banco.Imagens, novoMockImageStorage(), &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	_, err := useCase.Run(context.Background(), produto.ID)
+
+	// Then
+	if !errors.Is(err, entities.ErrProdutoSemFoto) {
+		t.Errorf("Esperado ErrProdutoSemFoto, recebido %v", err)
+	}
+}
@@ -5,50 +5,75 @@ import (
 	"fmt"
 	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
-	"lanchonete/internal/interfaces/publisher"
 )
 
 type ProdutoIncluirUseCase interface {
-	Run(ctx context.Context, nome, categoria, descricao string, preco float32) (*entities.Produto, error)
+	Run(ctx context.Context, nome, categoria, descricao string, preco entities.Money, tempoPreparoMinutos int, dieta entities.InformacoesDieteticas) (*entities.Produto, error)
 }
 
 type produtoIncluirUseCase struct {
-	produtoRepository repository.ProdutoRepository
-	eventPublisher    publisher.EventPublisher
+	produtoRepository   repository.ProdutoRepository
+	categoriaRepository repository.CategoriaRepository
+	versaoRepository    repository.VersaoProdutoRepository
+	transacao           repository.Transacao
+	outboxRepository    repository.OutboxRepository
 }
 
-func NewProdutoIncluirUseCase(produtoRepository repository.ProdutoRepository, publisher publisher.EventPublisher) ProdutoIncluirUseCase {
+func NewProdutoIncluirUseCase(produtoRepository repository.ProdutoRepository, categoriaRepository repository.CategoriaRepository, versaoRepository repository.VersaoProdutoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) ProdutoIncluirUseCase {
 	return &produtoIncluirUseCase{
-		produtoRepository: produtoRepository,
-		eventPublisher:    publisher,
+		produtoRepository:   produtoRepository,
+		categoriaRepository: categoriaRepository,
+		versaoRepository:    versaoRepository,
+		transacao:           transacao,
+		outboxRepository:    outboxRepository,
 	}
 }
 
-func (pd *produtoIncluirUseCase) Run(c context.Context, nome string, categoria string, descricao string, preco float32) (*entities.Produto, error) {
+func (pd *produtoIncluirUseCase) Run(c context.Context, nome string, categoria string, descricao string, preco entities.Money, tempoPreparoMinutos int, dieta entities.InformacoesDieteticas) (*entities.Produto, error) {
 
-	produto, err := entities.ProdutoNew(nome, categoria, descricao, preco)
+	categoriaProduto, err := categoriaCadastrada(c, pd.categoriaRepository, entities.CatProduto(categoria))
+	if err != nil {
+		return nil, err
+	}
+
+	produto, err := entities.ProdutoNew(nome, categoriaProduto, descricao, preco)
 
 	if err != nil {
 		return nil, fmt.Errorf("criação de produto inválida: %w", err)
 	}
 
-	err = pd.produtoRepository.AdicionarProduto(c, produto)
-	if err != nil {
-		return nil, fmt.Errorf("não foi possível criar produto: %w", err)
+	if err := produto.DefinirTempoPreparo(tempoPreparoMinutos); err != nil {
+		return nil, fmt.Errorf("criação de produto inválida: %w", err)
 	}
 
-	// ✨ Publicar evento no SQS
-	payload := map[string]interface{}{
-		"id_produto": produto.ID,
-		"nome":       produto.Nome,
-		"categoria":  produto.Categoria,
-		"descricao":  produto.Descricao,
-		"preco":      produto.Preco,
+	if err := produto.DefinirInformacoesDieteticas(dieta); err != nil {
+		return nil, fmt.Errorf("criação de produto inválida: %w", err)
 	}
 
-	err = pd.eventPublisher.Publish("produto_criado", payload)
+	err = pd.transacao.Executar(c, func(c context.Context) error {
+		if err := pd.produtoRepository.AdicionarProduto(c, produto); err != nil {
+			return fmt.Errorf("não foi possível criar produto: %w", err)
+		}
+
+		if err := registrarVersao(c, pd.versaoRepository, *produto); err != nil {
+			return err
+		}
+
+		// ✨ Evento para o SQS, publicado pelo relay da outbox
+		payload := map[string]interface{}{
+			"id_produto":    produto.ID,
+			"nome":          produto.Nome,
+			"categoria":     produto.Categoria,
+			"descricao":     produto.Descricao,
+			"preco":         produto.Preco,
+			"tempo_preparo": produto.TempoPreparoMinutos,
+			"alergenos":     produto.Alergenos,
+			"tags_dieta":    produto.TagsDieta,
+		}
+		return registrarEvento(c, pd.outboxRepository, entities.FilaProdutos, "produto_criado", payload)
+	})
 	if err != nil {
-		fmt.Println("⚠️ Falha ao publicar evento do produto:", err)
+		return nil, err
 	}
 
 	return produto, nil
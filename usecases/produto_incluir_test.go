@@ -2,73 +2,22 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"lanchonete/internal/domain/entities"
 	"testing"
 )
 
-// MockProdutoRepositoryIncluir implements repository.ProdutoRepository for testing
-type MockProdutoRepositoryIncluir struct {
-	Produtos []*entities.Produto
-}
-
-type MockEventPublisherProdutoIncluir struct{}
-
-func (m *MockEventPublisherProdutoIncluir) Publish(eventType string, payload interface{}) error {
-	// apenas retorna nil, simula sucesso
-	return nil
-}
-
-func (m *MockProdutoRepositoryIncluir) AdicionarProduto(ctx context.Context, produto *entities.Produto) error {
-	produto.ID = len(m.Produtos) + 1
-	m.Produtos = append(m.Produtos, produto)
-	return nil
-}
-
-func (m *MockProdutoRepositoryIncluir) EditarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryIncluir) RemoverProduto(ctx context.Context, id int) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryIncluir) BuscarProdutoPorId(ctx context.Context, id int) (*entities.Produto, error) {
-	for _, produto := range m.Produtos {
-		if produto.ID == id {
-			return produto, nil
-		}
-	}
-	return nil, nil
-}
-
-func (m *MockProdutoRepositoryIncluir) ListarTodosOsProdutos(ctx context.Context) ([]*entities.Produto, error) {
-	return m.Produtos, nil
-}
-
-func (m *MockProdutoRepositoryIncluir) ListarPorCategoria(ctx context.Context, categoria string) ([]*entities.Produto, error) {
-	var produtosFiltrados []*entities.Produto
-	for _, produto := range m.Produtos {
-		if string(produto.Categoria) == categoria {
-			produtosFiltrados = append(produtosFiltrados, produto)
-		}
-	}
-	return produtosFiltrados, nil
-}
-
 func TestProdutoIncluir_Run_Sucesso(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryIncluir{
-		Produtos: []*entities.Produto{},
-	}
+	banco := novoBancoTeste(t)
+	mockOutbox := &MockOutboxRepository{}
 
-	mockPublisher := &MockEventPublisherProdutoIncluir{}
-
-	useCase := NewProdutoIncluirUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, mockOutbox)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx, "Hamburguer", "Lanche", "Delicioso hamburguer", 25.0)
+	resultado, err := useCase.Run(ctx, "Hamburguer", "Lanche", "Delicioso hamburguer", entities.Reais(25.0), 0, entities.InformacoesDieteticas{})
 
 	// Then
 	if err != nil {
@@ -87,24 +36,25 @@ func TestProdutoIncluir_Run_Sucesso(t *testing.T) {
 		t.Errorf("Esperado categoria 'Lanche', recebido %s", resultado.Categoria)
 	}
 
-	if resultado.Preco != 25.0 {
-		t.Errorf("Esperado preço 25.0, recebido %f", resultado.Preco)
+	if resultado.Preco != entities.Reais(25.0) {
+		t.Errorf("Esperado preço 25.0, recebido %s", resultado.Preco)
+	}
+
+	if len(banco.produtos()) != 1 {
+		t.Errorf("Esperado 1 produto no repositório, encontrado %d", len(banco.produtos()))
 	}
 
-	if len(mockRepo.Produtos) != 1 {
-		t.Errorf("Esperado 1 produto no repositório, encontrado %d", len(mockRepo.Produtos))
+	// O evento fica na outbox para o relay publicar na fila de produtos
+	if len(mockOutbox.Eventos) != 1 || mockOutbox.Eventos[0].Tipo != "produto_criado" || mockOutbox.Eventos[0].Fila != entities.FilaProdutos {
+		t.Errorf("Esperado evento produto_criado na outbox, recebido %v", mockOutbox.Tipos())
 	}
 }
 
 func TestProdutoIncluir_Run_DadosInvalidos(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryIncluir{
-		Produtos: []*entities.Produto{},
-	}
+	banco := novoBancoTeste(t)
 
-	mockPublisher := &MockEventPublisherProdutoIncluir{}
-
-	useCase := NewProdutoIncluirUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
@@ -113,19 +63,19 @@ func TestProdutoIncluir_Run_DadosInvalidos(t *testing.T) {
 		nome      string
 		categoria string
 		descricao string
-		preco     float32
+		preco     entities.Money
 		descTest  string
 	}{
-		{"", "Lanche", "Descrição", 10.0, "nome vazio"},
-		{"Produto", "", "Descrição", 10.0, "categoria vazia"},
-		{"Produto", "Lanche", "Descrição", 0, "preço zero"},
-		{"Produto", "Lanche", "Descrição", -5.0, "preço negativo"},
-		{"Produto", "CategoriaInvalida", "Descrição", 10.0, "categoria inválida"},
+		{"", "Lanche", "Descrição", entities.Reais(10.0), "nome vazio"},
+		{"Produto", "", "Descrição", entities.Reais(10.0), "categoria vazia"},
+		{"Produto", "Lanche", "Descrição", entities.Reais(0), "preço zero"},
+		{"Produto", "Lanche", "Descrição", entities.Reais(-5.0), "preço negativo"},
+		{"Produto", "CategoriaInvalida", "Descrição", entities.Reais(10.0), "categoria inválida"},
 	}
 
 	for _, tc := range testCases {
 		// When
-		resultado, err := useCase.Run(ctx, tc.nome, tc.categoria, tc.descricao, tc.preco)
+		resultado, err := useCase.Run(ctx, tc.nome, tc.categoria, tc.descricao, tc.preco, 0, entities.InformacoesDieteticas{})
 
 		// Then
 		if err == nil {
@@ -140,13 +90,9 @@ func TestProdutoIncluir_Run_DadosInvalidos(t *testing.T) {
 
 func TestProdutoIncluir_Run_MultiplosProdutos(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryIncluir{
-		Produtos: []*entities.Produto{},
-	}
-
-	mockPublisher := &MockEventPublisherProdutoIncluir{}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoIncluirUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
@@ -154,17 +100,17 @@ func TestProdutoIncluir_Run_MultiplosProdutos(t *testing.T) {
 		nome      string
 		categoria string
 		descricao string
-		preco     float32
+		preco     entities.Money
 	}{
-		{"Hamburguer", "Lanche", "Delicioso hamburguer", 25.0},
-		{"Batata Frita", "Acompanhamento", "Batata crocante", 10.0},
-		{"Coca-Cola", "Bebida", "Refrigerante gelado", 7.5},
-		{"Sorvete", "Sobremesa", "Sorvete cremoso", 8.0},
+		{"Hamburguer", "Lanche", "Delicioso hamburguer", entities.Reais(25.0)},
+		{"Batata Frita", "Acompanhamento", "Batata crocante", entities.Reais(10.0)},
+		{"Coca-Cola", "Bebida", "Refrigerante gelado", entities.Reais(7.5)},
+		{"Sorvete", "Sobremesa", "Sorvete cremoso", entities.Reais(8.0)},
 	}
 
 	// When - criando múltiplos produtos
 	for i, p := range produtos {
-		resultado, err := useCase.Run(ctx, p.nome, p.categoria, p.descricao, p.preco)
+		resultado, err := useCase.Run(ctx, p.nome, p.categoria, p.descricao, p.preco, 0, entities.InformacoesDieteticas{})
 
 		// Then
 		if err != nil {
@@ -181,20 +127,16 @@ func TestProdutoIncluir_Run_MultiplosProdutos(t *testing.T) {
 	}
 
 	// Verificar se todos foram adicionados
-	if len(mockRepo.Produtos) != len(produtos) {
-		t.Errorf("Esperado %d produtos no repositório, encontrado %d", len(produtos), len(mockRepo.Produtos))
+	if len(banco.produtos()) != len(produtos) {
+		t.Errorf("Esperado %d produtos no repositório, encontrado %d", len(produtos), len(banco.produtos()))
 	}
 }
 
 func TestProdutoIncluir_Run_TodasCategorias(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryIncluir{
-		Produtos: []*entities.Produto{},
-	}
-
-	mockPublisher := &MockEventPublisherProdutoIncluir{}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoIncluirUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
 
 	ctx := context.Background()
 
@@ -202,7 +144,7 @@ func TestProdutoIncluir_Run_TodasCategorias(t *testing.T) {
 
 	// When - testando cada categoria válida
 	for _, categoria := range categorias {
-		resultado, err := useCase.Run(ctx, "Produto "+categoria, categoria, "Descrição", 10.0)
+		resultado, err := useCase.Run(ctx, "Produto "+categoria, categoria, "Descrição", entities.Reais(10.0), 0, entities.InformacoesDieteticas{})
 
 		// Then
 		if err != nil {
@@ -218,7 +160,65 @@ func TestProdutoIncluir_Run_TodasCategorias(t *testing.T) {
 		}
 	}
 
-	if len(mockRepo.Produtos) != len(categorias) {
-		t.Errorf("Esperado %d produtos criados, encontrado %d", len(categorias), len(mockRepo.Produtos))
+	if len(banco.produtos()) != len(categorias) {
+		t.Errorf("Esperado %d produtos criados, encontrado %d", len(categorias), len(banco.produtos()))
+	}
+}
+
+func TestProdutoIncluir_Run_CategoriaCadastrada(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	banco.categoria(entities.Categoria{Nome: "Café da manhã", Slug: "cafe-da-manha", Ativa: true})
+	banco.categoria(entities.Categoria{Nome: "Salgados", Slug: "salgados", Ativa: false})
+	useCase := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	produto, err := useCase.Run(context.Background(), "Pão na chapa", "Café da manhã", "Pão com manteiga", entities.Reais(7), 4, entities.InformacoesDieteticas{})
+	_, errInativa := useCase.Run(context.Background(), "Coxinha", "Salgados", "Coxinha de frango", entities.Reais(8), 0, entities.InformacoesDieteticas{})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil para categoria cadastrada, recebido %v", err)
+	}
+	if produto.Categoria != "Café da manhã" {
+		t.Errorf("Esperado categoria Café da manhã, recebido %s", produto.Categoria)
+	}
+	if !errors.Is(errInativa, entities.ErrCategoriaInativa) {
+		t.Errorf("Esperado ErrCategoriaInativa, recebido %v", errInativa)
+	}
+	if len(banco.produtos()) != 1 {
+		t.Errorf("Esperado 1 produto no repositório, encontrado %d", len(banco.produtos()))
+	}
+}
+
+func TestProdutoIncluir_Run_InformacoesDieteticas(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	useCase := NewProdutoIncluirUseCase(banco.Produtos, banco.Categorias, &MockVersaoProdutoRepository{}, &MockTransacao{}, &MockOutboxRepository{})
+	dieta := entities.InformacoesDieteticas{
+		TagsDieta: []entities.TagDieta{"vegano", "sem glúten"},
+		Nutricao:  &entities.InformacaoNutricional{PorcaoGramas: 150, Calorias: 320},
+	}
+
+	// When
+	produto, err := useCase.Run(context.Background(), "Batata rústica", "Acompanhamento", "Batata com alecrim", entities.Reais(16), 0, dieta)
+	_, errIncompativel := useCase.Run(context.Background(), "Milk-shake", "Bebida", "Milk-shake de morango", entities.Reais(14), 0,
+		entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoLactose}, TagsDieta: []entities.TagDieta{entities.TagVegano}})
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if !produto.TemTagDieta(entities.TagSemGluten) || !produto.TemTagDieta(entities.TagVegano) {
+		t.Errorf("Tags não gravadas: %v", produto.TagsDieta)
+	}
+	if produto.Nutricao == nil || produto.Nutricao.Calorias != 320 {
+		t.Errorf("Informação nutricional não gravada: %v", produto.Nutricao)
+	}
+	if !errors.Is(errIncompativel, entities.ErrTagIncompativel) {
+		t.Errorf("Esperado ErrTagIncompativel, recebido %v", errIncompativel)
+	}
+	if len(banco.produtos()) != 1 {
+		t.Errorf("Esperado 1 produto no repositório, encontrado %d", len(banco.produtos()))
 	}
 }
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+// eventoProdutoIndisponivel monta o aviso de que o produto saiu do cardápio, por decisão manual ou por falta de estoque
+func eventoProdutoIndisponivel(produto entities.Produto) map[string]interface{} {
+	motivo := "sem_estoque"
+	if produto.Esgotado {
+		motivo = "esgotado"
+	}
+
+	return map[string]interface{}{
+		"id_produto": produto.ID,
+		"nome":       produto.Nome,
+		"motivo":     motivo,
+		"estoque":    produto.Estoque,
+	}
+}
+
+// registrarProdutoIndisponivel grava o evento de produto indisponível na outbox, na transação da alteração
+func registrarProdutoIndisponivel(c context.Context, outboxRepository repository.OutboxRepository, produto entities.Produto) error {
+	return registrarEvento(c, outboxRepository, entities.FilaProdutos, "produto_indisponivel", eventoProdutoIndisponivel(produto))
+}
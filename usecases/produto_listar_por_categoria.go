@@ -7,23 +7,49 @@ import (
 	"lanchonete/internal/domain/repository"
 )
 
+// ProdutoListarPorCategoriaUseCase lista os produtos da categoria identificada pelo slug, ex.: "cafe-da-manha"
 type ProdutoListarPorCategoriaUseCase interface {
-	Run(ctx context.Context, categoria string) ([]*entities.Produto, error)
+	Run(ctx context.Context, slug string, incluirIndisponiveis bool) ([]*entities.Produto, error)
 }
 type produtoListarPorCategoriaUseCase struct {
-	produtoRepo repository.ProdutoRepository
+	produtoRepo   repository.ProdutoRepository
+	categoriaRepo repository.CategoriaRepository
 }
 
-func NewProdutoListarPorCategoriaUseCase(produtoRepo repository.ProdutoRepository) ProdutoListarPorCategoriaUseCase {
+func NewProdutoListarPorCategoriaUseCase(produtoRepo repository.ProdutoRepository, categoriaRepo repository.CategoriaRepository) ProdutoListarPorCategoriaUseCase {
 	return &produtoListarPorCategoriaUseCase{
-		produtoRepo: produtoRepo,
+		produtoRepo:   produtoRepo,
+		categoriaRepo: categoriaRepo,
 	}
 }
 
-func (pd *produtoListarPorCategoriaUseCase) Run(c context.Context, categoria string) ([]*entities.Produto, error) {
-	produtos, err := pd.produtoRepo.ListarPorCategoria(c, categoria)
+func (pd *produtoListarPorCategoriaUseCase) Run(c context.Context, slug string, incluirIndisponiveis bool) ([]*entities.Produto, error) {
+	categoria, err := pd.categoriaRepo.BuscarCategoriaPorSlug(c, slug)
 	if err != nil {
 		return nil, fmt.Errorf("não foi possível listar produtos: %w", err)
 	}
-	return produtos, nil
+	// Uma categoria inativa sai do cardápio com todos os seus produtos
+	if !categoria.Ativa && !incluirIndisponiveis {
+		return []*entities.Produto{}, nil
+	}
+
+	produtos, err := pd.produtoRepo.ListarPorCategoria(c, string(categoria.Nome))
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível listar produtos: %w", err)
+	}
+	if incluirIndisponiveis {
+		return produtos, nil
+	}
+	return apenasDisponiveis(produtos), nil
+}
+
+// apenasDisponiveis remove do cardápio os produtos esgotados ou sem estoque
+func apenasDisponiveis(produtos []*entities.Produto) []*entities.Produto {
+	disponiveis := make([]*entities.Produto, 0, len(produtos))
+	for _, produto := range produtos {
+		if produto.Disponivel(1) {
+			disponiveis = append(disponiveis, produto)
+		}
+	}
+	return disponiveis
 }
@@ -2,69 +2,29 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"lanchonete/internal/domain/entities"
 	"testing"
 )
 
-// MockProdutoRepositoryListarPorCategoria implements repository.ProdutoRepository for testing
-type MockProdutoRepositoryListarPorCategoria struct {
-	Produtos []*entities.Produto
-}
-
-func (m *MockProdutoRepositoryListarPorCategoria) AdicionarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryListarPorCategoria) EditarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryListarPorCategoria) RemoverProduto(ctx context.Context, id int) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryListarPorCategoria) BuscarProdutoPorId(ctx context.Context, id int) (*entities.Produto, error) {
-	for _, produto := range m.Produtos {
-		if produto.ID == id {
-			return produto, nil
-		}
-	}
-	return nil, nil
-}
-
-func (m *MockProdutoRepositoryListarPorCategoria) ListarTodosOsProdutos(ctx context.Context) ([]*entities.Produto, error) {
-	return m.Produtos, nil
-}
-
-func (m *MockProdutoRepositoryListarPorCategoria) ListarPorCategoria(ctx context.Context, categoria string) ([]*entities.Produto, error) {
-	var produtosFiltrados []*entities.Produto
-	for _, produto := range m.Produtos {
-		if string(produto.Categoria) == categoria {
-			produtosFiltrados = append(produtosFiltrados, produto)
-		}
-	}
-	return produtosFiltrados, nil
-}
-
 func TestProdutoListarPorCategoria_Run_Sucesso(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "Cheeseburger", Categoria: entities.Lanche, Descricao: "Lanche com queijo", Preco: 28.0},
-		{ID: 3, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: 10.0},
-		{ID: 4, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
+		{Nome: "Cheeseburger", Categoria: entities.Lanche, Descricao: "Lanche com queijo", Preco: entities.Reais(28.0)},
+		{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: entities.Reais(10.0)},
+		{Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
 	}
 
-	mockRepo := &MockProdutoRepositoryListarPorCategoria{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarPorCategoriaUseCase(mockRepo)
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
 
 	ctx := context.Background()
 
 	// When - buscando produtos da categoria Lanche
-	resultado, err := useCase.Run(ctx, "Lanche")
+	resultado, err := useCase.Run(ctx, "lanche", false)
 
 	// Then
 	if err != nil {
@@ -96,20 +56,19 @@ func TestProdutoListarPorCategoria_Run_Sucesso(t *testing.T) {
 func TestProdutoListarPorCategoria_Run_CategoriaVazia(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
+		{Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
 	}
 
-	mockRepo := &MockProdutoRepositoryListarPorCategoria{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarPorCategoriaUseCase(mockRepo)
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
 
 	ctx := context.Background()
 
 	// When - buscando categoria que não tem produtos
-	resultado, err := useCase.Run(ctx, "Sobremesa")
+	resultado, err := useCase.Run(ctx, "sobremesa", false)
 
 	// Then
 	if err != nil {
@@ -128,37 +87,37 @@ func TestProdutoListarPorCategoria_Run_CategoriaVazia(t *testing.T) {
 func TestProdutoListarPorCategoria_Run_TodasCategorias(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "X-Bacon", Categoria: entities.Lanche, Descricao: "Lanche com bacon", Preco: 30.0},
-		{ID: 3, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: 10.0},
-		{ID: 4, Nome: "Onion Rings", Categoria: entities.Acompanhamento, Descricao: "Anéis de cebola", Preco: 12.0},
-		{ID: 5, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
-		{ID: 6, Nome: "Suco", Categoria: entities.Bebida, Descricao: "Suco natural", Preco: 6.0},
-		{ID: 7, Nome: "Sorvete", Categoria: entities.Sobremesa, Descricao: "Sobremesa gelada", Preco: 8.0},
-		{ID: 8, Nome: "Pudim", Categoria: entities.Sobremesa, Descricao: "Sobremesa doce", Preco: 9.0},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
+		{Nome: "X-Bacon", Categoria: entities.Lanche, Descricao: "Lanche com bacon", Preco: entities.Reais(30.0)},
+		{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: entities.Reais(10.0)},
+		{Nome: "Onion Rings", Categoria: entities.Acompanhamento, Descricao: "Anéis de cebola", Preco: entities.Reais(12.0)},
+		{Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
+		{Nome: "Suco", Categoria: entities.Bebida, Descricao: "Suco natural", Preco: entities.Reais(6.0)},
+		{Nome: "Sorvete", Categoria: entities.Sobremesa, Descricao: "Sobremesa gelada", Preco: entities.Reais(8.0)},
+		{Nome: "Pudim", Categoria: entities.Sobremesa, Descricao: "Sobremesa doce", Preco: entities.Reais(9.0)},
 	}
 
-	mockRepo := &MockProdutoRepositoryListarPorCategoria{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarPorCategoriaUseCase(mockRepo)
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
 
 	ctx := context.Background()
 
 	testCases := []struct {
+		slug               string
 		categoria          string
 		quantidadeEsperada int
 	}{
-		{"Lanche", 2},
-		{"Acompanhamento", 2},
-		{"Bebida", 2},
-		{"Sobremesa", 2},
+		{"lanche", "Lanche", 2},
+		{"acompanhamento", "Acompanhamento", 2},
+		{"bebida", "Bebida", 2},
+		{"sobremesa", "Sobremesa", 2},
 	}
 
 	for _, tc := range testCases {
 		// When
-		resultado, err := useCase.Run(ctx, tc.categoria)
+		resultado, err := useCase.Run(ctx, tc.slug, false)
 
 		// Then
 		if err != nil {
@@ -181,46 +140,66 @@ func TestProdutoListarPorCategoria_Run_TodasCategorias(t *testing.T) {
 func TestProdutoListarPorCategoria_Run_CategoriaInvalida(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
 	}
 
-	mockRepo := &MockProdutoRepositoryListarPorCategoria{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarPorCategoriaUseCase(mockRepo)
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
 
 	ctx := context.Background()
 
-	// When - buscando categoria inválida
-	resultado, err := useCase.Run(ctx, "CategoriaInexistente")
+	// When - buscando categoria que não está cadastrada
+	resultado, err := useCase.Run(ctx, "categoria-inexistente", false)
 
 	// Then
-	if err != nil {
-		t.Errorf("Esperado nil, recebido %v", err)
+	if !errors.Is(err, entities.ErrCategoriaNaoEncontrada) {
+		t.Errorf("Esperado ErrCategoriaNaoEncontrada, recebido %v", err)
 	}
 
-	if resultado == nil {
-		resultado = []*entities.Produto{} // Tratar nil como lista vazia
+	if resultado != nil {
+		t.Errorf("Esperado nil para categoria inexistente, recebido %d produtos", len(resultado))
 	}
+}
 
-	if len(resultado) != 0 {
-		t.Errorf("Esperado 0 produtos para categoria inválida, recebido %d", len(resultado))
+func TestProdutoListarPorCategoria_Run_CategoriaInativa(t *testing.T) {
+	// Given
+	produtos := []*entities.Produto{
+		{Nome: "Pão na chapa", Categoria: "Café da manhã", Preco: entities.Reais(7.0)},
+	}
+	banco := novoBancoTeste(t)
+	banco.categoria(entities.Categoria{Nome: "Café da manhã", Slug: "cafe-da-manha", Ativa: false})
+	banco.gravarProdutos(produtos...)
+
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
+
+	// When
+	cardapio, err := useCase.Run(context.Background(), "cafe-da-manha", false)
+	gestao, errGestao := useCase.Run(context.Background(), "cafe-da-manha", true)
+
+	// Then
+	if err != nil || errGestao != nil {
+		t.Fatalf("Esperado nil, recebido %v / %v", err, errGestao)
+	}
+	if len(cardapio) != 0 {
+		t.Errorf("Esperado cardápio sem os produtos da categoria inativa, recebido %d", len(cardapio))
+	}
+	if len(gestao) != 1 {
+		t.Errorf("Esperado 1 produto ao incluir indisponíveis, recebido %d", len(gestao))
 	}
 }
 
 func TestProdutoListarPorCategoria_Run_ListaVaziaGeral(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryListarPorCategoria{
-		Produtos: []*entities.Produto{},
-	}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoListarPorCategoriaUseCase(mockRepo)
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx, "Lanche")
+	resultado, err := useCase.Run(ctx, "lanche", false)
 
 	// Then
 	if err != nil {
@@ -236,42 +215,38 @@ func TestProdutoListarPorCategoria_Run_ListaVaziaGeral(t *testing.T) {
 	}
 }
 
-func TestProdutoListarPorCategoria_Run_CaseSensitive(t *testing.T) {
+func TestProdutoListarPorCategoria_Run_SlugExato(t *testing.T) {
 	// Given
-	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-	}
-
-	mockRepo := &MockProdutoRepositoryListarPorCategoria{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.categoria(entities.Categoria{Nome: "Café da manhã", Slug: "cafe-da-manha", Ativa: true})
+	banco.gravarProdutos(&entities.Produto{Nome: "Pão na chapa", Categoria: "Café da manhã", Preco: entities.Reais(7.0)})
 
-	useCase := NewProdutoListarPorCategoriaUseCase(mockRepo)
+	useCase := NewProdutoListarPorCategoriaUseCase(banco.Produtos, banco.Categorias)
 
 	ctx := context.Background()
 
-	// Test cases com diferentes variações de case
+	// A categoria é identificada pelo slug, comparado sem diferenciar maiúsculas como no MySQL;
+	// o nome de exibição não é aceito na URL
 	testCases := []struct {
-		categoria          string
-		quantidadeEsperada int
+		slug       string
+		encontrada bool
 	}{
-		{"Lanche", 1}, // correto
-		{"lanche", 0}, // minúscula
-		{"LANCHE", 0}, // maiúscula
-		{"LaNcHe", 0}, // misto
+		{"cafe-da-manha", true},  // slug
+		{"CAFE-DA-MANHA", true},  // maiúscula
+		{"Café da manhã", false}, // nome
+		{"cafe", false},          // parte do slug
 	}
 
 	for _, tc := range testCases {
 		// When
-		resultado, err := useCase.Run(ctx, tc.categoria)
+		resultado, err := useCase.Run(ctx, tc.slug, false)
 
 		// Then
-		if err != nil {
-			t.Errorf("Não esperado erro para categoria %s, recebido %v", tc.categoria, err)
+		if tc.encontrada && (err != nil || len(resultado) != 1) {
+			t.Errorf("Para o slug '%s' esperado 1 produto, recebido %d (%v)", tc.slug, len(resultado), err)
 		}
-
-		if len(resultado) != tc.quantidadeEsperada {
-			t.Errorf("Para categoria '%s' esperado %d produtos, recebido %d", tc.categoria, tc.quantidadeEsperada, len(resultado))
+		if !tc.encontrada && !errors.Is(err, entities.ErrCategoriaNaoEncontrada) {
+			t.Errorf("Para o slug '%s' esperado ErrCategoriaNaoEncontrada, recebido %v", tc.slug, err)
 		}
 	}
 }
@@ -8,7 +8,7 @@ import (
 )
 
 type ProdutoListarTodosUseCase interface {
-	Run(ctx context.Context) ([]*entities.Produto, error)
+	Run(ctx context.Context, consulta entities.ConsultaProdutos) (*entities.Pagina[*entities.Produto], error)
 }
 
 type produtoListarTodosUseCase struct {
@@ -21,10 +21,15 @@ func NewProdutoListarTodosUseCase(produtoRepo repository.ProdutoRepository) Prod
 	}
 }
 
-func (pd *produtoListarTodosUseCase) Run(c context.Context) ([]*entities.Produto, error) {
-	produtos, err := pd.produtoRepo.ListarTodosOsProdutos(c)
+// Run lista uma página do cardápio; os filtros de disponibilidade e de dieta são aplicados pelo repositório
+func (pd *produtoListarTodosUseCase) Run(c context.Context, consulta entities.ConsultaProdutos) (*entities.Pagina[*entities.Produto], error) {
+	if err := consulta.Validar(); err != nil {
+		return nil, err
+	}
+
+	pagina, err := pd.produtoRepo.ConsultarProdutos(c, consulta)
 	if err != nil {
 		return nil, fmt.Errorf("não foi possível listar produtos: %w", err)
 	}
-	return produtos, nil
+	return pagina, nil
 }
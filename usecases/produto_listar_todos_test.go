@@ -2,69 +2,29 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"lanchonete/internal/domain/entities"
 	"testing"
 )
 
-// MockProdutoRepositoryListarTodos implements repository.ProdutoRepository for testing
-type MockProdutoRepositoryListarTodos struct {
-	Produtos []*entities.Produto
-}
-
-func (m *MockProdutoRepositoryListarTodos) AdicionarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryListarTodos) EditarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryListarTodos) RemoverProduto(ctx context.Context, id int) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryListarTodos) BuscarProdutoPorId(ctx context.Context, id int) (*entities.Produto, error) {
-	for _, produto := range m.Produtos {
-		if produto.ID == id {
-			return produto, nil
-		}
-	}
-	return nil, nil
-}
-
-func (m *MockProdutoRepositoryListarTodos) ListarTodosOsProdutos(ctx context.Context) ([]*entities.Produto, error) {
-	return m.Produtos, nil
-}
-
-func (m *MockProdutoRepositoryListarTodos) ListarPorCategoria(ctx context.Context, categoria string) ([]*entities.Produto, error) {
-	var produtosFiltrados []*entities.Produto
-	for _, produto := range m.Produtos {
-		if string(produto.Categoria) == categoria {
-			produtosFiltrados = append(produtosFiltrados, produto)
-		}
-	}
-	return produtosFiltrados, nil
-}
-
 func TestProdutoListarTodos_Run_Sucesso(t *testing.T) {
-	// Given
+	// Given - na ordem do nome, em que o cardápio é listado
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: 10.0},
-		{ID: 3, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
+		{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: entities.Reais(10.0)},
+		{Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
 	}
 
-	mockRepo := &MockProdutoRepositoryListarTodos{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarTodosUseCase(mockRepo)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx)
+	resultado, err := useCase.Run(ctx, entities.ConsultaProdutos{})
 
 	// Then
 	if err != nil {
@@ -75,33 +35,31 @@ func TestProdutoListarTodos_Run_Sucesso(t *testing.T) {
 		t.Fatal("Esperado lista de produtos, recebido nil")
 	}
 
-	if len(resultado) != len(produtos) {
-		t.Errorf("Esperado %d produtos, recebido %d", len(produtos), len(resultado))
+	if len(resultado.Itens) != len(produtos) {
+		t.Errorf("Esperado %d produtos, recebido %d", len(produtos), len(resultado.Itens))
 	}
 
 	// Verificar se todos os produtos estão presentes
 	for i, produtoEsperado := range produtos {
-		if resultado[i].ID != produtoEsperado.ID {
-			t.Errorf("Esperado ID %d na posição %d, recebido %d", produtoEsperado.ID, i, resultado[i].ID)
+		if resultado.Itens[i].ID != produtoEsperado.ID {
+			t.Errorf("Esperado ID %d na posição %d, recebido %d", produtoEsperado.ID, i, resultado.Itens[i].ID)
 		}
-		if resultado[i].Nome != produtoEsperado.Nome {
-			t.Errorf("Esperado nome %s na posição %d, recebido %s", produtoEsperado.Nome, i, resultado[i].Nome)
+		if resultado.Itens[i].Nome != produtoEsperado.Nome {
+			t.Errorf("Esperado nome %s na posição %d, recebido %s", produtoEsperado.Nome, i, resultado.Itens[i].Nome)
 		}
 	}
 }
 
 func TestProdutoListarTodos_Run_ListaVazia(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryListarTodos{
-		Produtos: []*entities.Produto{},
-	}
+	banco := novoBancoTeste(t)
 
-	useCase := NewProdutoListarTodosUseCase(mockRepo)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx)
+	resultado, err := useCase.Run(ctx, entities.ConsultaProdutos{})
 
 	// Then
 	if err != nil {
@@ -112,31 +70,29 @@ func TestProdutoListarTodos_Run_ListaVazia(t *testing.T) {
 		t.Fatal("Esperado lista vazia, recebido nil")
 	}
 
-	if len(resultado) != 0 {
-		t.Errorf("Esperado lista vazia, recebido %d produtos", len(resultado))
+	if len(resultado.Itens) != 0 {
+		t.Errorf("Esperado lista vazia, recebido %d produtos", len(resultado.Itens))
 	}
 }
 
 func TestProdutoListarTodos_Run_UmProduto(t *testing.T) {
 	// Given
 	produto := &entities.Produto{
-		ID:        1,
 		Nome:      "Produto Único",
 		Categoria: entities.Lanche,
 		Descricao: "Único produto",
-		Preco:     15.0,
+		Preco:     entities.Reais(15.0),
 	}
 
-	mockRepo := &MockProdutoRepositoryListarTodos{
-		Produtos: []*entities.Produto{produto},
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produto)
 
-	useCase := NewProdutoListarTodosUseCase(mockRepo)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx)
+	resultado, err := useCase.Run(ctx, entities.ConsultaProdutos{})
 
 	// Then
 	if err != nil {
@@ -147,51 +103,50 @@ func TestProdutoListarTodos_Run_UmProduto(t *testing.T) {
 		t.Fatal("Esperado lista com um produto, recebido nil")
 	}
 
-	if len(resultado) != 1 {
-		t.Errorf("Esperado 1 produto, recebido %d", len(resultado))
+	if len(resultado.Itens) != 1 {
+		t.Errorf("Esperado 1 produto, recebido %d", len(resultado.Itens))
 	}
 
-	if resultado[0].ID != 1 {
-		t.Errorf("Esperado ID 1, recebido %d", resultado[0].ID)
+	if resultado.Itens[0].ID != produto.ID {
+		t.Errorf("Esperado ID %d, recebido %d", produto.ID, resultado.Itens[0].ID)
 	}
 
-	if resultado[0].Nome != "Produto Único" {
-		t.Errorf("Esperado nome 'Produto Único', recebido %s", resultado[0].Nome)
+	if resultado.Itens[0].Nome != "Produto Único" {
+		t.Errorf("Esperado nome 'Produto Único', recebido %s", resultado.Itens[0].Nome)
 	}
 }
 
 func TestProdutoListarTodos_Run_TodasCategorias(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: 10.0},
-		{ID: 3, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
-		{ID: 4, Nome: "Sorvete", Categoria: entities.Sobremesa, Descricao: "Sobremesa gelada", Preco: 8.0},
+		{Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
+		{Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: entities.Reais(10.0)},
+		{Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
+		{Nome: "Sorvete", Categoria: entities.Sobremesa, Descricao: "Sobremesa gelada", Preco: entities.Reais(8.0)},
 	}
 
-	mockRepo := &MockProdutoRepositoryListarTodos{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarTodosUseCase(mockRepo)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx)
+	resultado, err := useCase.Run(ctx, entities.ConsultaProdutos{})
 
 	// Then
 	if err != nil {
 		t.Errorf("Esperado nil, recebido %v", err)
 	}
 
-	if len(resultado) != 4 {
-		t.Errorf("Esperado 4 produtos, recebido %d", len(resultado))
+	if len(resultado.Itens) != 4 {
+		t.Errorf("Esperado 4 produtos, recebido %d", len(resultado.Itens))
 	}
 
 	// Verificar se todas as categorias estão presentes
 	categorias := make(map[entities.CatProduto]bool)
-	for _, produto := range resultado {
+	for _, produto := range resultado.Itens {
 		categorias[produto.Categoria] = true
 	}
 
@@ -223,40 +178,135 @@ func TestProdutoListarTodos_Run_MuitosProdutos(t *testing.T) {
 		}
 
 		produto := &entities.Produto{
-			ID:        i,
-			Nome:      fmt.Sprintf("Produto %d", i),
+			Nome:      fmt.Sprintf("Produto %03d", i),
 			Categoria: categoria,
 			Descricao: fmt.Sprintf("Descrição do produto %d", i),
-			Preco:     float32(10 + i),
+			Preco:     entities.Reais(float64(10 + i)),
 		}
 		produtos = append(produtos, produto)
 	}
 
-	mockRepo := &MockProdutoRepositoryListarTodos{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	useCase := NewProdutoListarTodosUseCase(mockRepo)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
 
 	ctx := context.Background()
 
 	// When
-	resultado, err := useCase.Run(ctx)
+	resultado, err := useCase.Run(ctx, entities.ConsultaProdutos{Limite: entities.LimiteMaximo})
 
 	// Then
 	if err != nil {
 		t.Errorf("Esperado nil, recebido %v", err)
 	}
 
-	if len(resultado) != 100 {
-		t.Errorf("Esperado 100 produtos, recebido %d", len(resultado))
+	if len(resultado.Itens) != 100 {
+		t.Errorf("Esperado 100 produtos, recebido %d", len(resultado.Itens))
 	}
 
 	// Verificar ordem
-	for i, produto := range resultado {
+	for i, produto := range resultado.Itens {
 		expectedID := i + 1
 		if produto.ID != expectedID {
 			t.Errorf("Esperado ID %d na posição %d, recebido %d", expectedID, i, produto.ID)
 		}
 	}
 }
+
+func TestProdutoListarTodos_Run_FiltraIndisponiveis(t *testing.T) {
+	zero := 0
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(
+		&entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5)},
+		&entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6), Estoque: &zero},
+		&entities.Produto{Nome: "Mousse", Categoria: entities.Sobremesa, Preco: entities.Reais(12.5), Esgotado: true},
+	)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
+
+	cardapio, err := useCase.Run(context.Background(), entities.ConsultaProdutos{})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(cardapio.Itens) != 1 || cardapio.Itens[0].ID != 1 {
+		t.Errorf("Esperado apenas o X-Salada no cardápio, recebido %d produtos", len(cardapio.Itens))
+	}
+
+	todos, err := useCase.Run(context.Background(), entities.ConsultaProdutos{IncluirIndisponiveis: true})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(todos.Itens) != 3 {
+		t.Errorf("Esperado 3 produtos incluindo indisponíveis, recebido %d", len(todos.Itens))
+	}
+}
+
+func TestProdutoListarTodos_Run_FiltroDieta(t *testing.T) {
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(
+		&entities.Produto{Nome: "X-Salada", Categoria: entities.Lanche, Preco: entities.Reais(22.5),
+			InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoGluten, entities.AlergenoOvo}}},
+		&entities.Produto{Nome: "Coca-cola", Categoria: entities.Bebida, Preco: entities.Reais(6),
+			InformacoesDieteticas: entities.InformacoesDieteticas{TagsDieta: []entities.TagDieta{entities.TagSemGluten, entities.TagVegano}}},
+		&entities.Produto{Nome: "Mousse", Categoria: entities.Sobremesa, Preco: entities.Reais(12.5),
+			InformacoesDieteticas: entities.InformacoesDieteticas{Alergenos: []entities.Alergeno{entities.AlergenoLactose}, TagsDieta: []entities.TagDieta{entities.TagVegetariano}}},
+	)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
+
+	veganos, err := useCase.Run(context.Background(), entities.ConsultaProdutos{Dieta: entities.FiltroDieta{Tags: []entities.TagDieta{entities.TagVegano}}})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(veganos.Itens) != 1 || veganos.Itens[0].ID != 2 {
+		t.Errorf("Esperado apenas a Coca-cola, recebido %d produtos", len(veganos.Itens))
+	}
+
+	semLactose, err := useCase.Run(context.Background(), entities.ConsultaProdutos{Dieta: entities.FiltroDieta{SemAlergenos: []entities.Alergeno{entities.AlergenoLactose}}})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(semLactose.Itens) != 2 || semLactose.Itens[0].ID != 2 || semLactose.Itens[1].ID != 1 {
+		t.Errorf("Esperado Coca-cola e X-Salada, na ordem do nome, recebido %d produtos", len(semLactose.Itens))
+	}
+}
+
+func TestProdutoListarTodos_Run_Paginada(t *testing.T) {
+	var produtos []*entities.Produto
+	for i := 1; i <= 5; i++ {
+		produtos = append(produtos, &entities.Produto{Nome: fmt.Sprintf("Produto %d", i), Categoria: entities.Lanche, Preco: entities.Reais(10)})
+	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
+	useCase := NewProdutoListarTodosUseCase(banco.Produtos)
+
+	pagina, err := useCase.Run(context.Background(), entities.ConsultaProdutos{Limite: 2})
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if len(pagina.Itens) != 2 || pagina.Total != 5 {
+		t.Errorf("Esperado 2 de 5 produtos, recebido %d de %d", len(pagina.Itens), pagina.Total)
+	}
+
+	cursor, err := entities.CursorParse(pagina.ProximoCursor)
+	if err != nil || cursor == nil {
+		t.Fatalf("Esperado cursor da próxima página, recebido %q (%v)", pagina.ProximoCursor, err)
+	}
+	if cursor.Ordem != entities.OrdemProdutoNome || cursor.ID != 2 || cursor.Valor != "Produto 2" {
+		t.Errorf("Cursor não aponta para o último produto da página: %+v", cursor)
+	}
+}
+
+func TestProdutoListarTodos_Run_ConsultaInvalida(t *testing.T) {
+	useCase := NewProdutoListarTodosUseCase(novoBancoTeste(t).Produtos)
+	min, max := entities.Reais(20), entities.Reais(10)
+
+	_, err := useCase.Run(context.Background(), entities.ConsultaProdutos{PrecoMin: &min, PrecoMax: &max})
+	if !errors.Is(err, entities.ErrFaixaPreco) {
+		t.Errorf("Esperado ErrFaixaPreco, recebido %v", err)
+	}
+
+	_, err = useCase.Run(context.Background(), entities.ConsultaProdutos{Ordem: entities.Ordenacao{Campo: "estoque"}})
+	if !errors.Is(err, entities.ErrOrdemInvalida) {
+		t.Errorf("Esperado ErrOrdemInvalida, recebido %v", err)
+	}
+}
@@ -3,8 +3,9 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"lanchonete/internal/domain/entities"
 	"lanchonete/internal/domain/repository"
-	"lanchonete/internal/interfaces/publisher"
+	"time"
 )
 
 type ProdutoRemoverUseCase interface {
@@ -13,39 +14,46 @@ type ProdutoRemoverUseCase interface {
 
 type produtoRemoverUseCase struct {
 	produtoGateway repository.ProdutoRepository
-	eventPublisher publisher.EventPublisher
+	transacao      repository.Transacao
+	outboxGateway  repository.OutboxRepository
 }
 
 func NewProdutoRemoverUseCase(
 	produtoGateway repository.ProdutoRepository,
-	eventPublisher publisher.EventPublisher,
+	transacao repository.Transacao,
+	outboxGateway repository.OutboxRepository,
 ) ProdutoRemoverUseCase {
 	return &produtoRemoverUseCase{
 		produtoGateway: produtoGateway,
-		eventPublisher: eventPublisher,
+		transacao:      transacao,
+		outboxGateway:  outboxGateway,
 	}
 }
 
+// Run arquiva o produto: ele sai do cardápio, mas continua nos pedidos que já o contêm
 func (pruc *produtoRemoverUseCase) Run(c context.Context, id int) error {
-	_, err := pruc.produtoGateway.BuscarProdutoPorId(c, id)
+	produto, err := pruc.produtoGateway.BuscarProdutoPorId(c, id)
 	if err != nil {
 		return fmt.Errorf("produto não existe no banco de dados: %w", err)
 	}
 
-	err = pruc.produtoGateway.RemoverProduto(c, id)
-	if err != nil {
-		return fmt.Errorf("não foi possível remover o produto: %w", err)
-	}
-
-	// ✨ Publicar evento de remoção
-	payload := map[string]interface{}{
-		"id_produto": id,
-	}
-
-	err = pruc.eventPublisher.Publish("produto_removido", payload)
-	if err != nil {
-		fmt.Println("⚠️ Falha ao publicar evento de remoção do produto:", err)
+	if err := produto.Arquivar(time.Now()); err != nil {
+		return err
 	}
 
-	return nil
+	err = pruc.transacao.Executar(c, func(c context.Context) error {
+		if err := pruc.produtoGateway.ArquivarProduto(c, produto); err != nil {
+			return fmt.Errorf("não foi possível arquivar o produto: %w", err)
+		}
+
+		// ✨ Evento de arquivamento, publicado pelo relay da outbox
+		payload := map[string]interface{}{
+			"id_produto":   id,
+			"nome":         produto.Nome,
+			"arquivado_em": produto.ArquivadoEm,
+		}
+		return registrarEvento(c, pruc.outboxGateway, entities.FilaProdutos, "produto_arquivado", payload)
+	})
+	// As imagens ficam com o produto arquivado: ele sai do cardápio, mas pode ser restaurado
+	return err
 }
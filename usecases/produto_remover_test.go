@@ -8,59 +8,6 @@ import (
 	"testing"
 )
 
-// MockProdutoRepositoryRemover implements repository.ProdutoRepository for testing
-type MockProdutoRepositoryRemover struct {
-	Produtos []*entities.Produto
-}
-
-type MockEventPublisherRemover struct{}
-
-func (m *MockEventPublisherRemover) Publish(eventType string, payload interface{}) error {
-	// apenas retorna nil, simula sucesso
-	return nil
-}
-
-func (m *MockProdutoRepositoryRemover) AdicionarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryRemover) EditarProduto(ctx context.Context, produto *entities.Produto) error {
-	return nil
-}
-
-func (m *MockProdutoRepositoryRemover) RemoverProduto(ctx context.Context, id int) error {
-	for i, produto := range m.Produtos {
-		if produto.ID == id {
-			m.Produtos = append(m.Produtos[:i], m.Produtos[i+1:]...)
-			return nil
-		}
-	}
-	return errors.New("produto não encontrado")
-}
-
-func (m *MockProdutoRepositoryRemover) BuscarProdutoPorId(ctx context.Context, id int) (*entities.Produto, error) {
-	for _, produto := range m.Produtos {
-		if produto.ID == id {
-			return produto, nil
-		}
-	}
-	return nil, errors.New("produto não encontrado")
-}
-
-func (m *MockProdutoRepositoryRemover) ListarTodosOsProdutos(ctx context.Context) ([]*entities.Produto, error) {
-	return m.Produtos, nil
-}
-
-func (m *MockProdutoRepositoryRemover) ListarPorCategoria(ctx context.Context, categoria string) ([]*entities.Produto, error) {
-	var produtosFiltrados []*entities.Produto
-	for _, produto := range m.Produtos {
-		if string(produto.Categoria) == categoria {
-			produtosFiltrados = append(produtosFiltrados, produto)
-		}
-	}
-	return produtosFiltrados, nil
-}
-
 func TestProdutoRemover_Run_Sucesso(t *testing.T) {
 	// Given
 	produto := &entities.Produto{
@@ -68,16 +15,15 @@ func TestProdutoRemover_Run_Sucesso(t *testing.T) {
 		Nome:      "Produto Teste",
 		Categoria: entities.Lanche,
 		Descricao: "Descrição teste",
-		Preco:     10.0,
+		Preco:     entities.Reais(10.0),
 	}
 
-	mockRepo := &MockProdutoRepositoryRemover{
-		Produtos: []*entities.Produto{produto},
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produto)
 
-	mockPublisher := &MockEventPublisherRemover{}
+	mockOutbox := &MockOutboxRepository{}
 
-	useCase := NewProdutoRemoverUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
 
 	ctx := context.Background()
 
@@ -89,21 +35,49 @@ func TestProdutoRemover_Run_Sucesso(t *testing.T) {
 		t.Errorf("Esperado nil, recebido %v", err)
 	}
 
-	// Verificar se o produto foi removido
-	if len(mockRepo.Produtos) != 0 {
-		t.Errorf("Esperado 0 produtos após remoção, encontrado %d", len(mockRepo.Produtos))
+	// O produto é arquivado, não apagado
+	if !banco.buscarProduto(produto.ID).Arquivado() {
+		t.Error("Esperado produto arquivado")
+	}
+	if len(banco.produtos()) != 0 {
+		t.Errorf("Esperado 0 produtos no cardápio após arquivamento, encontrado %d", len(banco.produtos()))
+	}
+	if len(mockOutbox.Eventos) != 1 || mockOutbox.Eventos[0].Tipo != "produto_arquivado" {
+		t.Errorf("Esperado evento produto_arquivado, recebido %v", mockOutbox.Tipos())
 	}
 }
 
-func TestProdutoRemover_Run_ProdutoNaoEncontrado(t *testing.T) {
+func TestProdutoRemover_Run_JaArquivado(t *testing.T) {
 	// Given
-	mockRepo := &MockProdutoRepositoryRemover{
-		Produtos: []*entities.Produto{},
+	produto := &entities.Produto{ID: 1, Nome: "Produto Teste", Categoria: entities.Lanche, Preco: entities.Reais(10.0)}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produto)
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
+
+	if err := useCase.Run(context.Background(), 1); err != nil {
+		t.Fatalf("Não esperado erro no primeiro arquivamento, recebido %v", err)
 	}
 
-	mockPublisher := &MockEventPublisherRemover{}
+	// When
+	err := useCase.Run(context.Background(), 1)
 
-	useCase := NewProdutoRemoverUseCase(mockRepo, mockPublisher)
+	// Then
+	if !errors.Is(err, entities.ErrProdutoArquivado) {
+		t.Errorf("Esperado ErrProdutoArquivado, recebido %v", err)
+	}
+	if len(mockOutbox.Eventos) != 1 {
+		t.Errorf("Esperado apenas um evento publicado, recebido %v", mockOutbox.Tipos())
+	}
+}
+
+func TestProdutoRemover_Run_ProdutoNaoEncontrado(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+
+	mockOutbox := &MockOutboxRepository{}
+
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
 
 	ctx := context.Background()
 
@@ -127,15 +101,14 @@ func TestProdutoRemover_Run_IdInvalido(t *testing.T) {
 		Nome:      "Produto Teste",
 		Categoria: entities.Lanche,
 		Descricao: "Descrição teste",
-		Preco:     10.0,
+		Preco:     entities.Reais(10.0),
 	}
 
-	mockRepo := &MockProdutoRepositoryRemover{
-		Produtos: []*entities.Produto{produto},
-	}
-	mockPublisher := &MockEventPublisherRemover{}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produto)
+	mockOutbox := &MockOutboxRepository{}
 
-	useCase := NewProdutoRemoverUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
 
 	ctx := context.Background()
 
@@ -151,8 +124,8 @@ func TestProdutoRemover_Run_IdInvalido(t *testing.T) {
 			t.Errorf("Esperado erro para ID inválido %d, recebido nil", id)
 		}
 
-		// Produto original deve permanecer
-		if len(mockRepo.Produtos) != 1 {
+		// Produto original deve permanecer no cardápio
+		if len(banco.produtos()) != 1 {
 			t.Errorf("Produto original deve permanecer para ID inválido %d", id)
 		}
 	}
@@ -161,24 +134,23 @@ func TestProdutoRemover_Run_IdInvalido(t *testing.T) {
 func TestProdutoRemover_Run_MultiplosProdutos(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: 25.0},
-		{ID: 2, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: 10.0},
-		{ID: 3, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: 7.5},
+		{ID: 1, Nome: "Hamburguer", Categoria: entities.Lanche, Descricao: "Lanche delicioso", Preco: entities.Reais(25.0)},
+		{ID: 2, Nome: "Batata Frita", Categoria: entities.Acompanhamento, Descricao: "Acompanhamento crocante", Preco: entities.Reais(10.0)},
+		{ID: 3, Nome: "Coca-Cola", Categoria: entities.Bebida, Descricao: "Bebida refrescante", Preco: entities.Reais(7.5)},
 	}
 
-	mockRepo := &MockProdutoRepositoryRemover{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	mockPublisher := &MockEventPublisherRemover{}
+	mockOutbox := &MockOutboxRepository{}
 
-	useCase := NewProdutoRemoverUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
 
 	ctx := context.Background()
 
-	totalInicial := len(mockRepo.Produtos)
+	totalInicial := len(banco.produtos())
 
-	// When - removendo produto do meio
+	// When - arquivando produto do meio
 	err := useCase.Run(ctx, 2)
 
 	// Then
@@ -186,21 +158,21 @@ func TestProdutoRemover_Run_MultiplosProdutos(t *testing.T) {
 		t.Errorf("Não esperado erro, recebido %v", err)
 	}
 
-	if len(mockRepo.Produtos) != totalInicial-1 {
-		t.Errorf("Esperado %d produtos após remoção, encontrado %d", totalInicial-1, len(mockRepo.Produtos))
+	if len(banco.produtos()) != totalInicial-1 {
+		t.Errorf("Esperado %d produtos após arquivamento, encontrado %d", totalInicial-1, len(banco.produtos()))
 	}
 
-	// Verificar que os produtos corretos permanecem
-	for _, produto := range mockRepo.Produtos {
+	// Verificar que os produtos corretos permanecem no cardápio
+	for _, produto := range banco.produtos() {
 		if produto.ID == 2 {
-			t.Error("Produto com ID 2 deveria ter sido removido")
+			t.Error("Produto com ID 2 deveria ter sido arquivado")
 		}
 	}
 
-	// Verificar que produtos 1 e 3 ainda existem
+	// Verificar que produtos 1 e 3 ainda estão ativos
 	encontrouProduto1 := false
 	encontrouProduto3 := false
-	for _, produto := range mockRepo.Produtos {
+	for _, produto := range banco.produtos() {
 		if produto.ID == 1 {
 			encontrouProduto1 = true
 		}
@@ -220,22 +192,21 @@ func TestProdutoRemover_Run_MultiplosProdutos(t *testing.T) {
 func TestProdutoRemover_Run_RemocaoSequencial(t *testing.T) {
 	// Given
 	produtos := []*entities.Produto{
-		{ID: 1, Nome: "Produto 1", Categoria: entities.Lanche, Descricao: "Descrição 1", Preco: 10.0},
-		{ID: 2, Nome: "Produto 2", Categoria: entities.Bebida, Descricao: "Descrição 2", Preco: 15.0},
-		{ID: 3, Nome: "Produto 3", Categoria: entities.Sobremesa, Descricao: "Descrição 3", Preco: 20.0},
+		{ID: 1, Nome: "Produto 1", Categoria: entities.Lanche, Descricao: "Descrição 1", Preco: entities.Reais(10.0)},
+		{ID: 2, Nome: "Produto 2", Categoria: entities.Bebida, Descricao: "Descrição 2", Preco: entities.Reais(15.0)},
+		{ID: 3, Nome: "Produto 3", Categoria: entities.Sobremesa, Descricao: "Descrição 3", Preco: entities.Reais(20.0)},
 	}
 
-	mockRepo := &MockProdutoRepositoryRemover{
-		Produtos: produtos,
-	}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produtos...)
 
-	mockPublisher := &MockEventPublisherRemover{}
+	mockOutbox := &MockOutboxRepository{}
 
-	useCase := NewProdutoRemoverUseCase(mockRepo, mockPublisher)
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
 
 	ctx := context.Background()
 
-	// When - removendo produtos sequencialmente
+	// When - arquivando produtos sequencialmente
 	idsParaRemover := []int{1, 3, 2}
 
 	for i, id := range idsParaRemover {
@@ -243,17 +214,46 @@ func TestProdutoRemover_Run_RemocaoSequencial(t *testing.T) {
 
 		// Then
 		if err != nil {
-			t.Errorf("Não esperado erro na remoção %d, recebido %v", i+1, err)
+			t.Errorf("Não esperado erro no arquivamento %d, recebido %v", i+1, err)
 		}
 
 		expectedCount := len(produtos) - (i + 1)
-		if len(mockRepo.Produtos) != expectedCount {
-			t.Errorf("Esperado %d produtos após remoção %d, encontrado %d", expectedCount, i+1, len(mockRepo.Produtos))
+		if len(banco.produtos()) != expectedCount {
+			t.Errorf("Esperado %d produtos após arquivamento %d, encontrado %d", expectedCount, i+1, len(banco.produtos()))
 		}
 	}
 
-	// Verificar que todos foram removidos
-	if len(mockRepo.Produtos) != 0 {
-		t.Errorf("Esperado 0 produtos após remoção completa, encontrado %d", len(mockRepo.Produtos))
+	// Verificar que todos foram arquivados, sem apagar nenhum
+	if len(banco.produtos()) != 0 {
+		t.Errorf("Esperado 0 produtos no cardápio após arquivamento completo, encontrado %d", len(banco.produtos()))
+	}
+	for _, produto := range produtos {
+		if !banco.buscarProduto(produto.ID).Arquivado() {
+			t.Errorf("Esperado produto %d arquivado no repositório", produto.ID)
+		}
+	}
+}
+
+func TestProdutoRemover_Run_MantemImagens(t *testing.T) {
+	// Given
+	imagens := []entities.ImagemProduto{
+		{Variante: entities.ImagemOriginal, Chave: "produtos/1/original-1.jpg"},
+		{Variante: entities.ImagemMiniatura, Chave: "produtos/1/miniatura-1.jpg"},
+	}
+	produto := &entities.Produto{ID: 1, Nome: "Produto Teste", Categoria: entities.Lanche, Preco: entities.Reais(10.0), Imagens: imagens}
+	banco := novoBancoTeste(t)
+	banco.gravarProdutos(produto)
+	useCase := NewProdutoRemoverUseCase(banco.Produtos, &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	err := useCase.Run(context.Background(), 1)
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	// O produto arquivado pode ser restaurado, então volta ao cardápio com as mesmas imagens
+	if gravadas := banco.buscarProduto(produto.ID).Imagens; len(gravadas) != len(imagens) {
+		t.Errorf("Esperado manter as imagens do produto arquivado, encontradas %v", gravadas)
 	}
 }
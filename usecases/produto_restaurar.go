@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+)
+
+type ProdutoRestaurarUseCase interface {
+	Run(ctx context.Context, id int) (*entities.Produto, error)
+}
+
+type produtoRestaurarUseCase struct {
+	produtoGateway   repository.ProdutoRepository
+	transacao        repository.Transacao
+	outboxRepository repository.OutboxRepository
+}
+
+func NewProdutoRestaurarUseCase(produtoGateway repository.ProdutoRepository, transacao repository.Transacao, outboxRepository repository.OutboxRepository) ProdutoRestaurarUseCase {
+	return &produtoRestaurarUseCase{
+		produtoGateway:   produtoGateway,
+		transacao:        transacao,
+		outboxRepository: outboxRepository,
+	}
+}
+
+// Run devolve ao cardápio um produto arquivado
+func (pruc *produtoRestaurarUseCase) Run(c context.Context, id int) (*entities.Produto, error) {
+	produto, err := pruc.produtoGateway.BuscarProdutoPorId(c, id)
+	if err != nil {
+		return nil, fmt.Errorf("produto não existe no banco de dados: %w", err)
+	}
+
+	if err := produto.Restaurar(); err != nil {
+		return nil, err
+	}
+
+	err = pruc.transacao.Executar(c, func(c context.Context) error {
+		if err := pruc.produtoGateway.RestaurarProduto(c, produto); err != nil {
+			return fmt.Errorf("não foi possível restaurar o produto: %w", err)
+		}
+
+		// ✨ Evento de restauração, publicado pelo relay da outbox
+		payload := map[string]interface{}{
+			"id_produto": id,
+			"nome":       produto.Nome,
+		}
+		return registrarEvento(c, pruc.outboxRepository, entities.FilaProdutos, "produto_restaurado", payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return produto, nil
+}
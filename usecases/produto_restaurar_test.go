@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"lanchonete/internal/domain/entities"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProdutoRestaurar_Run_Sucesso(t *testing.T) {
+	// Given
+	arquivadoEm := time.Now()
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(25.0), ArquivadoEm: &arquivadoEm})
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewProdutoRestaurarUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
+
+	// When
+	restaurado, err := useCase.Run(context.Background(), produto.ID)
+
+	// Then
+	if err != nil {
+		t.Fatalf("Esperado nil, recebido %v", err)
+	}
+	if restaurado.Arquivado() {
+		t.Error("Esperado produto restaurado")
+	}
+	if len(banco.produtos()) != 1 {
+		t.Errorf("Esperado produto de volta ao cardápio, encontrado %d ativos", len(banco.produtos()))
+	}
+	if len(mockOutbox.Tipos()) != 1 || mockOutbox.Tipos()[0] != "produto_restaurado" {
+		t.Errorf("Esperado evento produto_restaurado, recebido %v", mockOutbox.Tipos())
+	}
+}
+
+func TestProdutoRestaurar_Run_NaoArquivado(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	produto := banco.produto(entities.Produto{Nome: "Hamburguer", Categoria: entities.Lanche, Preco: entities.Reais(25.0)})
+	mockOutbox := &MockOutboxRepository{}
+	useCase := NewProdutoRestaurarUseCase(banco.Produtos, &MockTransacao{}, mockOutbox)
+
+	// When
+	_, err := useCase.Run(context.Background(), produto.ID)
+
+	// Then
+	if !errors.Is(err, entities.ErrProdutoNaoArquivado) {
+		t.Errorf("Esperado ErrProdutoNaoArquivado, recebido %v", err)
+	}
+	if len(mockOutbox.Tipos()) != 0 {
+		t.Errorf("Nenhum evento deveria ser publicado, recebido %v", mockOutbox.Tipos())
+	}
+}
+
+func TestProdutoRestaurar_Run_ProdutoNaoEncontrado(t *testing.T) {
+	// Given
+	banco := novoBancoTeste(t)
+	useCase := NewProdutoRestaurarUseCase(banco.Produtos, &MockTransacao{}, &MockOutboxRepository{})
+
+	// When
+	_, err := useCase.Run(context.Background(), 999)
+
+	// Then
+	if err == nil || !strings.Contains(err.Error(), "produto não encontrado") {
+		t.Errorf("Esperado erro de produto não encontrado, recebido %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"lanchonete/internal/domain/entities"
+	"lanchonete/internal/domain/repository"
+	"time"
+)
+
+// AutorDesconhecido identifica alterações feitas sem informar quem as fez
+const AutorDesconhecido = "desconhecido"
+
+type chaveAutor struct{}
+
+// ComAutor associa ao contexto quem está alterando o catálogo, para o histórico de versões dos produtos
+func ComAutor(ctx context.Context, autor string) context.Context {
+	return context.WithValue(ctx, chaveAutor{}, autor)
+}
+
+// AutorDe informa quem está alterando o catálogo, ou AutorDesconhecido
+func AutorDe(ctx context.Context) string {
+	if autor, ok := ctx.Value(chaveAutor{}).(string); ok && autor != "" {
+		return autor
+	}
+	return AutorDesconhecido
+}
+
+// registrarVersao grava no histórico o estado atual do produto, vigente a partir de agora
+func registrarVersao(c context.Context, versaoGateway repository.VersaoProdutoRepository, produto entities.Produto) error {
+	versao, err := entities.VersaoProdutoNew(produto, AutorDe(c), time.Now())
+	if err != nil {
+		return fmt.Errorf("versão do produto inválida: %w", err)
+	}
+
+	if err := versaoGateway.RegistrarVersao(c, versao); err != nil {
+		return fmt.Errorf("não foi possível registrar o histórico do produto: %w", err)
+	}
+	return nil
+}